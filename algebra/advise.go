@@ -0,0 +1,109 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the ADVISE statement, which recommends the secondary
+indexes that would serve a statement, and whether its current plan
+is already optimal. ADVISE ALL gives the recommendations for every
+statement in system:completed_requests.
+*/
+type Advise struct {
+	statementBase
+
+	stmt Statement `json:"stmt"`
+	text string    `json:"text"`
+}
+
+/*
+Advise on a single statement.
+*/
+func NewAdvise(stmt Statement, text string) *Advise {
+	rv := &Advise{
+		stmt: stmt,
+		text: text,
+	}
+
+	rv.statementBase.stmt = rv
+	return rv
+}
+
+/*
+Advise on the statements of system:completed_requests.
+*/
+func NewAdviseAll() *Advise {
+	return NewAdvise(nil, "")
+}
+
+func (this *Advise) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitAdvise(this)
+}
+
+func (this *Advise) Signature() value.Value {
+	return value.NewValue(value.JSON.String())
+}
+
+func (this *Advise) Formalize() error {
+	if this.stmt == nil {
+		return nil
+	}
+	return this.stmt.Formalize()
+}
+
+func (this *Advise) MapExpressions(mapper expression.Mapper) error {
+	if this.stmt == nil {
+		return nil
+	}
+	return this.stmt.MapExpressions(mapper)
+}
+
+func (this *Advise) Expressions() expression.Expressions {
+	if this.stmt == nil {
+		return nil
+	}
+	return this.stmt.Expressions()
+}
+
+/*
+Advising on a statement requires the privileges of the statement,
+as EXPLAIN does, and advising on the completed requests requires
+reading them.
+*/
+func (this *Advise) Privileges() (*auth.Privileges, errors.Error) {
+	if this.stmt == nil {
+		return privilegesFromKeyspace("#system", "completed_requests")
+	}
+	return this.stmt.Privileges()
+}
+
+/*
+Returns the statement advised on, or nil for ADVISE ALL.
+*/
+func (this *Advise) Statement() Statement {
+	return this.stmt
+}
+
+/*
+Returns the text of the statement advised on.
+*/
+func (this *Advise) Text() string {
+	return this.text
+}
+
+func (this *Advise) Type() string {
+	return "ADVISE"
+}
@@ -0,0 +1,106 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+This represents the Aggregate function APPROX_COUNT_DISTINCT(expr).
+It returns an estimate of the count of the distinct non-NULL,
+non-MISSING values in the group. Unlike COUNT(DISTINCT expr), it
+does not keep the values, but a HyperLogLog sketch of fixed size,
+with a standard error of about 1%.
+*/
+type ApproxCountDistinct struct {
+	AggregateBase
+}
+
+func NewApproxCountDistinct(operand expression.Expression) Aggregate {
+	rv := &ApproxCountDistinct{
+		*NewAggregateBase("approx_count_distinct", operand),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *ApproxCountDistinct) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+/*
+It returns a value of type NUMBER.
+*/
+func (this *ApproxCountDistinct) Type() value.Type { return value.NUMBER }
+
+func (this *ApproxCountDistinct) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *ApproxCountDistinct) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewApproxCountDistinct(operands[0])
+	}
+}
+
+/*
+If no input to the APPROX_COUNT_DISTINCT function, then the
+default value returned is 0.
+*/
+func (this *ApproxCountDistinct) Default() value.Value { return value.ZERO_VALUE }
+
+/*
+Aggregates input data by adding the hash of the operand
+value to the sketch. NULL and MISSING values are ignored.
+*/
+func (this *ApproxCountDistinct) CumulateInitial(item, cumulative value.Value, context Context) (value.Value, error) {
+	item, e := this.Operand().Evaluate(item, context)
+	if e != nil {
+		return nil, e
+	}
+
+	if item.Type() <= value.NULL {
+		return cumulative, nil
+	}
+
+	return hllAdd(item, cumulative)
+}
+
+/*
+Aggregates intermediate results by merging their sketches.
+*/
+func (this *ApproxCountDistinct) CumulateIntermediate(part, cumulative value.Value, context Context) (value.Value, error) {
+	if part == value.ZERO_VALUE {
+		return cumulative, nil
+	} else if cumulative == value.ZERO_VALUE {
+		return part, nil
+	}
+
+	return cumulateHLLs(part, cumulative)
+}
+
+/*
+Compute the Final result, the estimate of the sketch.
+*/
+func (this *ApproxCountDistinct) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	if cumulative == value.ZERO_VALUE {
+		return cumulative, nil
+	}
+
+	hll, e := getHLL(cumulative)
+	if e != nil {
+		return nil, e
+	}
+
+	return value.NewValue(int64(hll.Estimate())), nil
+}
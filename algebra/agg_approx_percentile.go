@@ -0,0 +1,125 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+This represents the ordered-set Aggregate function
+APPROX_PERCENTILE(fraction) WITHIN GROUP (ORDER BY expr). It returns
+an estimate of PERCENTILE_CONT(fraction) over the number values in
+the group. It does not keep the values, but a t-digest of bounded
+size, which is most accurate for fractions near 0 and 1.
+*/
+type ApproxPercentile struct {
+	AggregateBase
+}
+
+func NewApproxPercentile(operand expression.Expression) Aggregate {
+	rv := &ApproxPercentile{
+		*NewAggregateBase("approx_percentile", operand),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *ApproxPercentile) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+/*
+It returns a value of type NUMBER.
+*/
+func (this *ApproxPercentile) Type() value.Type { return value.NUMBER }
+
+func (this *ApproxPercentile) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *ApproxPercentile) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewApproxPercentile(operands[0])
+	}
+}
+
+/*
+Sets the sort terms of the WITHIN GROUP clause.
+*/
+func (this *ApproxPercentile) SetWithinGroup(within SortTerms) {
+	this.within = within
+}
+
+func (this *ApproxPercentile) OrderedSet() bool { return true }
+
+/*
+If no input to the APPROX_PERCENTILE function, then the
+default value returned is a null.
+*/
+func (this *ApproxPercentile) Default() value.Value { return value.NULL_VALUE }
+
+/*
+Aggregates input data by adding the number values of the
+WITHIN GROUP clause to the t-digest. Other values are ignored.
+*/
+func (this *ApproxPercentile) CumulateInitial(item, cumulative value.Value, context Context) (value.Value, error) {
+	item, e := this.within[0].Expression().Evaluate(item, context)
+	if e != nil {
+		return nil, e
+	}
+
+	if item.Type() != value.NUMBER {
+		return cumulative, nil
+	}
+
+	return tdigestAdd(item, cumulative)
+}
+
+/*
+Aggregates intermediate results by merging their t-digests.
+*/
+func (this *ApproxPercentile) CumulateIntermediate(part, cumulative value.Value, context Context) (value.Value, error) {
+	if part == value.NULL_VALUE {
+		return cumulative, nil
+	} else if cumulative == value.NULL_VALUE {
+		return part, nil
+	}
+
+	return cumulateTDigests(part, cumulative)
+}
+
+/*
+Compute the Final result, the estimate of the t-digest for the
+fraction, counted from the end if the order is descending.
+*/
+func (this *ApproxPercentile) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	fraction, e := percentileFraction(this, context)
+	if e != nil {
+		return nil, e
+	}
+
+	if cumulative == value.NULL_VALUE {
+		return cumulative, nil
+	}
+
+	td, e := getTDigest(cumulative)
+	if e != nil {
+		return nil, e
+	}
+
+	if this.within[0].Descending() {
+		fraction = 1.0 - fraction
+	}
+
+	return value.NewValue(td.Quantile(fraction)), nil
+}
@@ -19,7 +19,8 @@ import (
 
 /*
 This represents the Aggregate function ARRAY_AGG(expr). It returns an
-array of the non-MISSING values in the group, including NULLs. Type
+array of the non-MISSING values in the group, including NULLs, in the
+order of its ORDER BY clause, if any, and sorted otherwise. Type
 ArrayAgg is a struct that inherits from AggregateBase.
 */
 type ArrayAgg struct {
@@ -71,6 +72,15 @@ func (this *ArrayAgg) Constructor() expression.FunctionConstructor {
 	}
 }
 
+/*
+Sets the sort terms of the ORDER BY clause.
+*/
+func (this *ArrayAgg) SetWithinGroup(within SortTerms) {
+	this.within = within
+}
+
+func (this *ArrayAgg) OrderedSet() bool { return false }
+
 /*
 If no input to the ARRAY_AGG function, then the default value
 returned is a null.
@@ -84,16 +94,25 @@ cumulatePart to compute the intermediate aggregate value
 and return it.
 */
 func (this *ArrayAgg) CumulateInitial(item, cumulative value.Value, context Context) (value.Value, error) {
-	item, e := this.Operand().Evaluate(item, context)
+	val, e := this.Operand().Evaluate(item, context)
 	if e != nil {
 		return nil, e
 	}
 
-	if item.Type() <= value.MISSING || item.Type() == value.BINARY {
+	if val.Type() <= value.MISSING || val.Type() == value.BINARY {
 		return cumulative, nil
 	}
 
-	return this.cumulatePart(value.NewValue([]interface{}{item}), cumulative, context)
+	if this.within != nil {
+		part, e := orderedPart(val, item, this.within, context)
+		if e != nil {
+			return nil, e
+		}
+
+		return this.cumulatePart(part, cumulative, context)
+	}
+
+	return this.cumulatePart(value.NewValue([]interface{}{val}), cumulative, context)
 }
 
 /*
@@ -111,6 +130,15 @@ func (this *ArrayAgg) ComputeFinal(cumulative value.Value, context Context) (val
 		return cumulative, nil
 	}
 
+	if this.within != nil {
+		values, e := orderedValues(this.Name(), cumulative, this.within)
+		if e != nil {
+			return nil, e
+		}
+
+		return value.NewValue(values), nil
+	}
+
 	sort.Sort(value.NewSorter(cumulative))
 	return cumulative, nil
 }
@@ -0,0 +1,56 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+This represents the Aggregate function MEDIAN(expr). It returns
+the median of the number values in the group, which is the same
+as PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY expr).
+*/
+type Median struct {
+	percentileBase
+}
+
+func NewMedian(operand expression.Expression) Aggregate {
+	rv := &Median{
+		*newPercentileBase("median", operand, true),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *Median) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+/*
+It returns a value of type NUMBER.
+*/
+func (this *Median) Type() value.Type { return value.NUMBER }
+
+func (this *Median) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *Median) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewMedian(operands[0])
+	}
+}
+
+func (this *Median) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	return this.percentileCont(cumulative, 0.5)
+}
@@ -0,0 +1,130 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"fmt"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+This represents the Aggregate function OBJECT_AGG(name_expr,
+value_expr). It returns an object with a field for each string
+name in the group, whose value is the non-MISSING value for that
+name. If a name occurs more than once in the group, one of its
+values is kept.
+*/
+type ObjectAgg struct {
+	AggregateBase
+}
+
+func NewObjectAgg(operands ...expression.Expression) Aggregate {
+	rv := &ObjectAgg{
+		*NewMultiAggregateBase("object_agg", operands...),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *ObjectAgg) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+/*
+It returns a value of type OBJECT.
+*/
+func (this *ObjectAgg) Type() value.Type { return value.OBJECT }
+
+func (this *ObjectAgg) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *ObjectAgg) MinArgs() int { return 2 }
+
+func (this *ObjectAgg) MaxArgs() int { return 2 }
+
+func (this *ObjectAgg) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewObjectAgg(operands...)
+	}
+}
+
+/*
+If no input to the OBJECT_AGG function, then the default value
+returned is a null.
+*/
+func (this *ObjectAgg) Default() value.Value { return value.NULL_VALUE }
+
+/*
+Aggregates input data by evaluating the name and value operands.
+Names other than strings and MISSING values are ignored.
+*/
+func (this *ObjectAgg) CumulateInitial(item, cumulative value.Value, context Context) (value.Value, error) {
+	name, e := this.Operand().Evaluate(item, context)
+	if e != nil {
+		return nil, e
+	}
+
+	if name.Type() != value.STRING {
+		return cumulative, nil
+	}
+
+	val, e := this.Operands()[1].Evaluate(item, context)
+	if e != nil {
+		return nil, e
+	}
+
+	if val.Type() == value.MISSING {
+		return cumulative, nil
+	}
+
+	part := value.NewValue(map[string]interface{}{name.Actual().(string): val})
+	return this.cumulatePart(part, cumulative, context)
+}
+
+/*
+Aggregates intermediate results and return them.
+*/
+func (this *ObjectAgg) CumulateIntermediate(part, cumulative value.Value, context Context) (value.Value, error) {
+	return this.cumulatePart(part, cumulative, context)
+}
+
+/*
+The cumulative object is the final result.
+*/
+func (this *ObjectAgg) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	return cumulative, nil
+}
+
+/*
+Add the fields of the partial object to the cumulative object.
+*/
+func (this *ObjectAgg) cumulatePart(part, cumulative value.Value, context Context) (value.Value, error) {
+	if part == value.NULL_VALUE {
+		return cumulative, nil
+	} else if cumulative == value.NULL_VALUE {
+		return part, nil
+	}
+
+	if part.Type() != value.OBJECT || cumulative.Type() != value.OBJECT {
+		return nil, fmt.Errorf("Invalid partial OBJECT_AGG %v, %v.", part.Actual(), cumulative.Actual())
+	}
+
+	for name, val := range part.Fields() {
+		if _, ok := cumulative.Field(name); !ok {
+			cumulative.SetField(name, val)
+		}
+	}
+
+	return cumulative, nil
+}
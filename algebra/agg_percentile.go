@@ -0,0 +1,282 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Base type for the MEDIAN and percentile aggregates. They collect
+the values in the group into an array, which is sorted when the
+final result is computed. The percentile aggregates take their
+values from the WITHIN GROUP clause, and MEDIAN from its operand.
+*/
+type percentileBase struct {
+	AggregateBase
+	numeric bool
+}
+
+func newPercentileBase(name string, operand expression.Expression, numeric bool) *percentileBase {
+	return &percentileBase{
+		*NewAggregateBase(name, operand),
+		numeric,
+	}
+}
+
+/*
+If no input to the function, then the default value
+returned is a null.
+*/
+func (this *percentileBase) Default() value.Value { return value.NULL_VALUE }
+
+/*
+Aggregates input data by evaluating the values to order. Only
+numbers are collected by continuous percentiles, and NULL and
+MISSING values are ignored.
+*/
+func (this *percentileBase) CumulateInitial(item, cumulative value.Value, context Context) (value.Value, error) {
+	input := this.Operand()
+	if this.within != nil {
+		input = this.within[0].Expression()
+	}
+
+	item, e := input.Evaluate(item, context)
+	if e != nil {
+		return nil, e
+	}
+
+	if item.Type() <= value.NULL || item.Type() == value.BINARY ||
+		(this.numeric && item.Type() != value.NUMBER) {
+		return cumulative, nil
+	}
+
+	return this.cumulatePart(value.NewValue([]interface{}{item}), cumulative, context)
+}
+
+/*
+Aggregates intermediate results and return them.
+*/
+func (this *percentileBase) CumulateIntermediate(part, cumulative value.Value, context Context) (value.Value, error) {
+	return this.cumulatePart(part, cumulative, context)
+}
+
+/*
+Append the partial array of values to the cumulative one.
+*/
+func (this *percentileBase) cumulatePart(part, cumulative value.Value, context Context) (value.Value, error) {
+	return cumulateArrays(this.Name(), part, cumulative)
+}
+
+/*
+Sort the collected values, in descending order if the WITHIN GROUP
+clause says so.
+*/
+func (this *percentileBase) sorted(cumulative value.Value) ([]interface{}, error) {
+	sort.Sort(value.NewSorter(cumulative))
+
+	values, ok := cumulative.Actual().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Invalid %s %v.", this.Name(), cumulative.Actual())
+	}
+
+	if this.within != nil && this.within[0].Descending() {
+		for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+
+	return values, nil
+}
+
+/*
+Evaluate the fraction operand of a percentile aggregate. It must
+be a number between 0 and 1.
+*/
+func percentileFraction(agg Aggregate, context Context) (float64, error) {
+	val, e := agg.Operand().Evaluate(value.NULL_VALUE, context)
+	if e != nil {
+		return 0.0, e
+	}
+
+	if val.Type() == value.NUMBER {
+		fraction := val.Actual().(float64)
+		if fraction >= 0.0 && fraction <= 1.0 {
+			return fraction, nil
+		}
+	}
+
+	return 0.0, fmt.Errorf("Invalid fraction %v for %s, it must be a number between 0 and 1.",
+		val, agg.Name())
+}
+
+/*
+The continuous percentile interpolates linearly between the two
+values around the fraction of the way through the sorted values.
+*/
+func (this *percentileBase) percentileCont(cumulative value.Value, fraction float64) (value.Value, error) {
+	if cumulative == value.NULL_VALUE {
+		return cumulative, nil
+	}
+
+	values, e := this.sorted(cumulative)
+	if e != nil || len(values) == 0 {
+		return value.NULL_VALUE, e
+	}
+
+	rn := fraction * float64(len(values)-1)
+	lo, hi := math.Floor(rn), math.Ceil(rn)
+	lov := value.NewValue(values[int(lo)]).Actual().(float64)
+	if lo == hi {
+		return value.NewValue(lov), nil
+	}
+
+	hiv := value.NewValue(values[int(hi)]).Actual().(float64)
+	return value.NewValue(lov + (rn-lo)*(hiv-lov)), nil
+}
+
+/*
+The discrete percentile is the first of the sorted values whose
+cumulative distribution is at least the fraction.
+*/
+func (this *percentileBase) percentileDisc(cumulative value.Value, fraction float64) (value.Value, error) {
+	if cumulative == value.NULL_VALUE {
+		return cumulative, nil
+	}
+
+	values, e := this.sorted(cumulative)
+	if e != nil || len(values) == 0 {
+		return value.NULL_VALUE, e
+	}
+
+	pos := int(math.Ceil(fraction*float64(len(values)))) - 1
+	if pos < 0 {
+		pos = 0
+	}
+
+	return value.NewValue(values[pos]), nil
+}
+
+/*
+This represents the ordered-set Aggregate function
+PERCENTILE_CONT(fraction) WITHIN GROUP (ORDER BY expr). It returns
+the value at the given fraction of the way through the sorted number
+values in the group, interpolating between adjacent values.
+*/
+type PercentileCont struct {
+	percentileBase
+}
+
+func NewPercentileCont(operand expression.Expression) Aggregate {
+	rv := &PercentileCont{
+		*newPercentileBase("percentile_cont", operand, true),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *PercentileCont) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+/*
+It returns a value of type NUMBER.
+*/
+func (this *PercentileCont) Type() value.Type { return value.NUMBER }
+
+func (this *PercentileCont) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *PercentileCont) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewPercentileCont(operands[0])
+	}
+}
+
+/*
+Sets the sort terms of the WITHIN GROUP clause.
+*/
+func (this *PercentileCont) SetWithinGroup(within SortTerms) {
+	this.within = within
+}
+
+func (this *PercentileCont) OrderedSet() bool { return true }
+
+func (this *PercentileCont) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	fraction, e := percentileFraction(this, context)
+	if e != nil {
+		return nil, e
+	}
+
+	return this.percentileCont(cumulative, fraction)
+}
+
+/*
+This represents the ordered-set Aggregate function
+PERCENTILE_DISC(fraction) WITHIN GROUP (ORDER BY expr). It returns
+the first of the sorted non-NULL values in the group whose cumulative
+distribution is at least the given fraction.
+*/
+type PercentileDisc struct {
+	percentileBase
+}
+
+func NewPercentileDisc(operand expression.Expression) Aggregate {
+	rv := &PercentileDisc{
+		*newPercentileBase("percentile_disc", operand, false),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *PercentileDisc) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+/*
+It returns a value of type JSON.
+*/
+func (this *PercentileDisc) Type() value.Type { return value.JSON }
+
+func (this *PercentileDisc) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *PercentileDisc) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewPercentileDisc(operands[0])
+	}
+}
+
+/*
+Sets the sort terms of the WITHIN GROUP clause.
+*/
+func (this *PercentileDisc) SetWithinGroup(within SortTerms) {
+	this.within = within
+}
+
+func (this *PercentileDisc) OrderedSet() bool { return true }
+
+func (this *PercentileDisc) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	fraction, e := percentileFraction(this, context)
+	if e != nil {
+		return nil, e
+	}
+
+	return this.percentileDisc(cumulative, fraction)
+}
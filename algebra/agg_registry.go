@@ -49,14 +49,57 @@ var _DISTINCT_AGGREGATES = map[string]Aggregate{
 /*
 Non Distinct Aggregate functions. The variable represents a
 map from string to Aggregate Function. Contains aggregate
-functions ARRAY_AGG, AVG, COUNT, MAX, MIN and SUM.
+functions ARRAY_AGG, AVG, COUNT, MAX, MIN and SUM, the
+statistical aggregates STDDEV, VARIANCE and MEDIAN, the
+ordered-set aggregates PERCENTILE_CONT and PERCENTILE_DISC,
+the approximate aggregates APPROX_COUNT_DISTINCT and
+APPROX_PERCENTILE, and the aggregates STRING_AGG, LISTAGG
+and OBJECT_AGG.
 */
 var _OTHER_AGGREGATES = map[string]Aggregate{
-	"array_agg": &ArrayAgg{},
-	"avg":       &Avg{},
-	"count":     &Count{},
-	"countn":    &Countn{},
-	"max":       &Max{},
-	"min":       &Min{},
-	"sum":       &Sum{},
+	"approx_count_distinct": &ApproxCountDistinct{},
+	"approx_percentile":     &ApproxPercentile{},
+	"array_agg":             &ArrayAgg{},
+	"avg":                   &Avg{},
+	"count":                 &Count{},
+	"countn":                &Countn{},
+	"listagg":               &ListAgg{},
+	"max":                   &Max{},
+	"median":                &Median{},
+	"min":                   &Min{},
+	"object_agg":            &ObjectAgg{},
+	"percentile_cont":       &PercentileCont{},
+	"percentile_disc":       &PercentileDisc{},
+	"stddev":                &Stddev{},
+	"stddev_pop":            &StddevPop{},
+	"stddev_samp":           &StddevSamp{},
+	"string_agg":            &StringAgg{},
+	"sum":                   &Sum{},
+	"var_pop":               &VarPop{},
+	"var_samp":              &VarSamp{},
+	"variance":              &Variance{},
+}
+
+/*
+This method is used to retrieve a ranking or navigation window
+function by the parser. Window aggregates are retrieved with
+GetAggregate().
+*/
+func GetWindowFunction(name string) (WindowOnlyFunction, bool) {
+	rv, ok := _WINDOW_FUNCTIONS[strings.ToLower(name)]
+	return rv, ok
+}
+
+/*
+Ranking and navigation window functions.
+*/
+var _WINDOW_FUNCTIONS = map[string]WindowOnlyFunction{
+	"dense_rank":  &DenseRank{},
+	"first_value": &FirstValue{},
+	"lag":         &Lag{},
+	"last_value":  &LastValue{},
+	"lead":        &Lead{},
+	"ntile":       &Ntile{},
+	"rank":        &Rank{},
+	"row_number":  &RowNumber{},
 }
@@ -0,0 +1,136 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"math"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+This represents the Aggregate function STDDEV(expr). It returns
+the sample standard deviation of all the number values in the
+group, and 0 if the group has a single number value.
+*/
+type Stddev struct {
+	varianceBase
+}
+
+func NewStddev(operand expression.Expression) Aggregate {
+	rv := &Stddev{
+		*newVarianceBase("stddev", operand),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *Stddev) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *Stddev) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *Stddev) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewStddev(operands[0])
+	}
+}
+
+func (this *Stddev) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	return stddev(this.variance(cumulative, _VARIANCE))
+}
+
+/*
+This represents the Aggregate function STDDEV_POP(expr). It returns
+the population standard deviation of all the number values in the
+group.
+*/
+type StddevPop struct {
+	varianceBase
+}
+
+func NewStddevPop(operand expression.Expression) Aggregate {
+	rv := &StddevPop{
+		*newVarianceBase("stddev_pop", operand),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *StddevPop) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *StddevPop) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *StddevPop) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewStddevPop(operands[0])
+	}
+}
+
+func (this *StddevPop) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	return stddev(this.variance(cumulative, _VARIANCE_POP))
+}
+
+/*
+This represents the Aggregate function STDDEV_SAMP(expr). It returns
+the sample standard deviation of all the number values in the
+group, and NULL if the group has a single number value.
+*/
+type StddevSamp struct {
+	varianceBase
+}
+
+func NewStddevSamp(operand expression.Expression) Aggregate {
+	rv := &StddevSamp{
+		*newVarianceBase("stddev_samp", operand),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *StddevSamp) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *StddevSamp) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *StddevSamp) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewStddevSamp(operands[0])
+	}
+}
+
+func (this *StddevSamp) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	return stddev(this.variance(cumulative, _VARIANCE_SAMP))
+}
+
+/*
+The standard deviation is the square root of the variance.
+*/
+func stddev(variance value.Value, e error) (value.Value, error) {
+	if e != nil || variance.Type() != value.NUMBER {
+		return variance, e
+	}
+
+	return value.NewValue(math.Sqrt(variance.Actual().(float64))), nil
+}
@@ -0,0 +1,197 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+This represents the Aggregate function STRING_AGG(expr [, separator]).
+It returns the concatenation of the string
+values in the group, with the separator between them, in the order
+of its ORDER BY or WITHIN GROUP clause, if any, and sorted otherwise.
+Values other than strings are ignored. The separator must be a
+constant string, and defaults to the empty string.
+*/
+type StringAgg struct {
+	AggregateBase
+}
+
+func NewStringAgg(operands ...expression.Expression) Aggregate {
+	rv := &StringAgg{
+		*NewMultiAggregateBase("string_agg", operands...),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *StringAgg) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+/*
+It returns a value of type STRING.
+*/
+func (this *StringAgg) Type() value.Type { return value.STRING }
+
+func (this *StringAgg) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *StringAgg) MinArgs() int { return 1 }
+
+func (this *StringAgg) MaxArgs() int { return 2 }
+
+func (this *StringAgg) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewStringAgg(operands...)
+	}
+}
+
+/*
+Sets the sort terms of the ORDER BY or WITHIN GROUP clause.
+*/
+func (this *StringAgg) SetWithinGroup(within SortTerms) {
+	this.within = within
+}
+
+func (this *StringAgg) OrderedSet() bool { return false }
+
+/*
+If no input to the STRING_AGG function, then the default value
+returned is a null.
+*/
+func (this *StringAgg) Default() value.Value { return value.NULL_VALUE }
+
+/*
+Aggregates input data by evaluating operands, collecting the
+string values.
+*/
+func (this *StringAgg) CumulateInitial(item, cumulative value.Value, context Context) (value.Value, error) {
+	val, e := this.Operand().Evaluate(item, context)
+	if e != nil {
+		return nil, e
+	}
+
+	if val.Type() != value.STRING {
+		return cumulative, nil
+	}
+
+	if this.within != nil {
+		part, e := orderedPart(val, item, this.within, context)
+		if e != nil {
+			return nil, e
+		}
+
+		return this.cumulatePart(part, cumulative, context)
+	}
+
+	return this.cumulatePart(value.NewValue([]interface{}{val}), cumulative, context)
+}
+
+/*
+Aggregates intermediate results and return them.
+*/
+func (this *StringAgg) CumulateIntermediate(part, cumulative value.Value, context Context) (value.Value, error) {
+	return this.cumulatePart(part, cumulative, context)
+}
+
+/*
+Compute the Final result, by sorting the collected strings and
+joining them with the separator.
+*/
+func (this *StringAgg) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	separator := ""
+	if operands := this.Operands(); len(operands) > 1 {
+		sep, e := operands[1].Evaluate(value.NULL_VALUE, context)
+		if e != nil {
+			return nil, e
+		}
+
+		if sep.Type() != value.STRING {
+			return nil, fmt.Errorf("Invalid separator %v for %s, it must be a constant string.",
+				sep, this.Name())
+		}
+
+		separator = sep.Actual().(string)
+	}
+
+	if cumulative == value.NULL_VALUE {
+		return cumulative, nil
+	}
+
+	var values []interface{}
+	if this.within != nil {
+		var e error
+		values, e = orderedValues(this.Name(), cumulative, this.within)
+		if e != nil {
+			return nil, e
+		}
+	} else {
+		sort.Sort(value.NewSorter(cumulative))
+		values, _ = cumulative.Actual().([]interface{})
+	}
+
+	strs := make([]string, len(values))
+	for i, v := range values {
+		str, ok := value.NewValue(v).Actual().(string)
+		if !ok {
+			return nil, fmt.Errorf("Invalid %s value %v.", this.Name(), v)
+		}
+
+		strs[i] = str
+	}
+
+	return value.NewValue(strings.Join(strs, separator)), nil
+}
+
+func (this *StringAgg) cumulatePart(part, cumulative value.Value, context Context) (value.Value, error) {
+	return cumulateArrays(this.Name(), part, cumulative)
+}
+
+/*
+This represents the Aggregate function LISTAGG(expr [, separator]),
+which is the same as STRING_AGG(expr [, separator]).
+*/
+type ListAgg struct {
+	StringAgg
+}
+
+func NewListAgg(operands ...expression.Expression) Aggregate {
+	rv := &ListAgg{
+		StringAgg{
+			*NewMultiAggregateBase("listagg", operands...),
+		},
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *ListAgg) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *ListAgg) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *ListAgg) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewListAgg(operands...)
+	}
+}
@@ -11,7 +11,10 @@ package algebra
 
 import (
 	"fmt"
+	"math"
+	"sort"
 
+	"github.com/couchbase/query/util"
 	"github.com/couchbase/query/value"
 )
 
@@ -99,3 +102,227 @@ func getSet(item value.Value) (*value.Set, error) {
 		return nil, fmt.Errorf("Invalid DISTINCT %v of type %T.", item, item)
 	}
 }
+
+/*
+Add the hash of the input item to the cumulative HyperLogLog
+sketch, creating the sketch if it does not exist yet.
+*/
+func hllAdd(item, cumulative value.Value) (value.AnnotatedValue, error) {
+	bytes, e := item.MarshalJSON()
+	if e != nil {
+		return nil, e
+	}
+
+	av, ok := cumulative.(value.AnnotatedValue)
+	if !ok {
+		av = value.NewAnnotatedValue(cumulative)
+	}
+
+	hll, e := getHLL(av)
+	if e != nil {
+		hll = util.NewHyperLogLog(util.HLL_DEFAULT_PRECISION)
+		av.SetAttachment("hll", hll)
+	}
+
+	hll.Add(util.SeaHashSum64(bytes))
+	return av, nil
+}
+
+/*
+Merge HyperLogLog sketches of intermediate results.
+*/
+func cumulateHLLs(part, cumulative value.Value) (value.AnnotatedValue, error) {
+	phll, e := getHLL(part)
+	if e != nil {
+		return nil, e
+	}
+
+	chll, e := getHLL(cumulative)
+	if e != nil {
+		return nil, e
+	}
+
+	e = chll.Merge(phll)
+	if e != nil {
+		return nil, e
+	}
+
+	return cumulative.(value.AnnotatedValue), nil
+}
+
+/*
+Retrieve the HyperLogLog sketch of annotated values.
+*/
+func getHLL(item value.Value) (*util.HyperLogLog, error) {
+	switch item := item.(type) {
+	case value.AnnotatedValue:
+		hll := item.GetAttachment("hll")
+		switch hll := hll.(type) {
+		case *util.HyperLogLog:
+			return hll, nil
+		default:
+			return nil, fmt.Errorf("Invalid HyperLogLog %v of type %T.", hll, hll)
+		}
+	default:
+		return nil, fmt.Errorf("Invalid HyperLogLog %v of type %T.", item, item)
+	}
+}
+
+/*
+Add the input number to the cumulative t-digest, creating the
+t-digest if it does not exist yet. Infinite numbers are ignored.
+*/
+func tdigestAdd(item, cumulative value.Value) (value.Value, error) {
+	x := item.Actual().(float64)
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return cumulative, nil
+	}
+
+	av, ok := cumulative.(value.AnnotatedValue)
+	if !ok {
+		av = value.NewAnnotatedValue(cumulative)
+	}
+
+	td, e := getTDigest(av)
+	if e != nil {
+		td = util.NewTDigest(util.TDIGEST_DEFAULT_COMPRESSION)
+		av.SetAttachment("tdigest", td)
+	}
+
+	td.Add(x)
+	return av, nil
+}
+
+/*
+Merge t-digests of intermediate results.
+*/
+func cumulateTDigests(part, cumulative value.Value) (value.AnnotatedValue, error) {
+	ptd, e := getTDigest(part)
+	if e != nil {
+		return nil, e
+	}
+
+	ctd, e := getTDigest(cumulative)
+	if e != nil {
+		return nil, e
+	}
+
+	ctd.Merge(ptd)
+	return cumulative.(value.AnnotatedValue), nil
+}
+
+/*
+Retrieve the t-digest of annotated values.
+*/
+func getTDigest(item value.Value) (*util.TDigest, error) {
+	switch item := item.(type) {
+	case value.AnnotatedValue:
+		td := item.GetAttachment("tdigest")
+		switch td := td.(type) {
+		case *util.TDigest:
+			return td, nil
+		default:
+			return nil, fmt.Errorf("Invalid t-digest %v of type %T.", td, td)
+		}
+	default:
+		return nil, fmt.Errorf("Invalid t-digest %v of type %T.", item, item)
+	}
+}
+
+/*
+Aggregate partial arrays of input values into the cumulative array,
+for aggregates that collect their input.
+*/
+func cumulateArrays(name string, part, cumulative value.Value) (value.Value, error) {
+	if part == value.NULL_VALUE {
+		return cumulative, nil
+	} else if cumulative == value.NULL_VALUE {
+		return part, nil
+	}
+
+	actual := part.Actual()
+	switch actual := actual.(type) {
+	case []interface{}:
+		array := cumulative.Actual()
+		switch array := array.(type) {
+		case []interface{}:
+			return value.NewValue(append(array, actual...)), nil
+		default:
+			return nil, fmt.Errorf("Invalid %s %v of type %T.", name, array, array)
+		}
+	default:
+		return nil, fmt.Errorf("Invalid partial %s %v of type %T.", name, actual, actual)
+	}
+}
+
+/*
+Wrap an input value of an ordered aggregate, together with the
+values of the sort terms of its WITHIN GROUP clause for the input
+item, into a partial array, so that the input can be sorted when
+the final result is computed.
+*/
+func orderedPart(val, item value.Value, within SortTerms, context Context) (value.Value, error) {
+	part := make([]interface{}, 1+len(within))
+	part[0] = val
+	for i, term := range within {
+		key, e := term.Expression().Evaluate(item, context)
+		if e != nil {
+			return nil, e
+		}
+
+		part[i+1] = key
+	}
+
+	return value.NewValue([]interface{}{part}), nil
+}
+
+/*
+Sort the input values wrapped by orderedPart() by the sort terms
+of the WITHIN GROUP clause, and unwrap them.
+*/
+func orderedValues(name string, cumulative value.Value, within SortTerms) ([]interface{}, error) {
+	parts, ok := cumulative.Actual().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Invalid %s %v.", name, cumulative.Actual())
+	}
+
+	wrapped := make([]value.Value, len(parts))
+	for i, part := range parts {
+		wrapped[i] = value.NewValue(part)
+		if wrapped[i].Type() != value.ARRAY {
+			return nil, fmt.Errorf("Invalid ordered %s %v.", name, part)
+		}
+	}
+
+	sort.SliceStable(wrapped, func(i, j int) bool {
+		for t, term := range within {
+			key1, _ := wrapped[i].Index(t + 1)
+			key2, _ := wrapped[j].Index(t + 1)
+
+			c := key1.Collate(key2)
+			if c == 0 {
+				continue
+			}
+
+			// NULL and MISSING values go where the term places them
+			null1 := key1.Type() <= value.NULL
+			null2 := key2.Type() <= value.NULL
+			if null1 != null2 {
+				return null1 == term.NullsFirst()
+			} else if term.Descending() {
+				return c > 0
+			} else {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+
+	rv := make([]interface{}, len(wrapped))
+	for i, w := range wrapped {
+		rv[i], _ = w.Index(0)
+	}
+
+	return rv, nil
+}
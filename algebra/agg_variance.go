@@ -0,0 +1,264 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Variance computations. The standard deviation and variance
+aggregates keep the count, the mean, and the sum of squared
+differences from the mean (m2) of the number values in the
+group. Partial results are combined using the parallel algorithm
+of Chan et al., so that they can be computed in parallel streams.
+*/
+const (
+	_VARIANCE      = iota // Sample variance, 0 for a single value
+	_VARIANCE_POP         // Population variance
+	_VARIANCE_SAMP        // Sample variance, NULL for a single value
+)
+
+/*
+Base type for the standard deviation and variance aggregates.
+*/
+type varianceBase struct {
+	AggregateBase
+}
+
+func newVarianceBase(name string, operand expression.Expression) *varianceBase {
+	return &varianceBase{
+		*NewAggregateBase(name, operand),
+	}
+}
+
+/*
+It returns a value of type NUMBER.
+*/
+func (this *varianceBase) Type() value.Type { return value.NUMBER }
+
+/*
+If no input to the function, then the default value
+returned is a null.
+*/
+func (this *varianceBase) Default() value.Value { return value.NULL_VALUE }
+
+/*
+Aggregates input data by evaluating operands. Values other
+than numbers are ignored.
+*/
+func (this *varianceBase) CumulateInitial(item, cumulative value.Value, context Context) (value.Value, error) {
+	item, e := this.Operand().Evaluate(item, context)
+	if e != nil {
+		return nil, e
+	}
+
+	if item.Type() != value.NUMBER {
+		return cumulative, nil
+	}
+
+	part := value.NewValue(map[string]interface{}{
+		"count": value.ONE_VALUE,
+		"mean":  item,
+		"m2":    value.ZERO_VALUE,
+	})
+	return this.cumulatePart(part, cumulative, context)
+}
+
+/*
+Aggregates intermediate results and return them.
+*/
+func (this *varianceBase) CumulateIntermediate(part, cumulative value.Value, context Context) (value.Value, error) {
+	return this.cumulatePart(part, cumulative, context)
+}
+
+/*
+Combine the count, mean and m2 of two partial results.
+*/
+func (this *varianceBase) cumulatePart(part, cumulative value.Value, context Context) (value.Value, error) {
+	if part == value.NULL_VALUE {
+		return cumulative, nil
+	} else if cumulative == value.NULL_VALUE {
+		return part, nil
+	}
+
+	pcount, pmean, pm2, e := this.moments(part)
+	if e != nil {
+		return nil, e
+	}
+
+	ccount, cmean, cm2, e := this.moments(cumulative)
+	if e != nil {
+		return nil, e
+	}
+
+	count := ccount + pcount
+	delta := pmean - cmean
+	cumulative.SetField("count", value.NewValue(count))
+	cumulative.SetField("mean", value.NewValue(cmean+delta*pcount/count))
+	cumulative.SetField("m2", value.NewValue(cm2+pm2+delta*delta*ccount*pcount/count))
+	return cumulative, nil
+}
+
+/*
+Compute the variance of the group. The sample variance divides
+m2 by count - 1, and the population variance by count.
+*/
+func (this *varianceBase) variance(cumulative value.Value, kind int) (value.Value, error) {
+	if cumulative == value.NULL_VALUE {
+		return cumulative, nil
+	}
+
+	count, _, m2, e := this.moments(cumulative)
+	if e != nil {
+		return nil, e
+	}
+
+	// Rounding errors must not make the variance negative
+	m2 = math.Max(m2, 0.0)
+
+	switch {
+	case count <= 0.0:
+		return value.NULL_VALUE, nil
+	case kind == _VARIANCE_POP:
+		return value.NewValue(m2 / count), nil
+	case count > 1.0:
+		return value.NewValue(m2 / (count - 1.0)), nil
+	case kind == _VARIANCE:
+		return value.ZERO_VALUE, nil
+	default:
+		return value.NULL_VALUE, nil
+	}
+}
+
+func (this *varianceBase) moments(val value.Value) (count, mean, m2 float64, e error) {
+	c, _ := val.Field("count")
+	m, _ := val.Field("mean")
+	s, _ := val.Field("m2")
+
+	if c.Type() != value.NUMBER || m.Type() != value.NUMBER || s.Type() != value.NUMBER {
+		e = fmt.Errorf("Missing or invalid count, mean or m2 in %s: %v, %v, %v.",
+			this.Name(), c.Actual(), m.Actual(), s.Actual())
+		return
+	}
+
+	return c.Actual().(float64), m.Actual().(float64), s.Actual().(float64), nil
+}
+
+/*
+This represents the Aggregate function VARIANCE(expr). It returns
+the sample variance of all the number values in the group, and 0
+if the group has a single number value.
+*/
+type Variance struct {
+	varianceBase
+}
+
+func NewVariance(operand expression.Expression) Aggregate {
+	rv := &Variance{
+		*newVarianceBase("variance", operand),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *Variance) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *Variance) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *Variance) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewVariance(operands[0])
+	}
+}
+
+func (this *Variance) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	return this.variance(cumulative, _VARIANCE)
+}
+
+/*
+This represents the Aggregate function VAR_POP(expr). It returns
+the population variance of all the number values in the group.
+*/
+type VarPop struct {
+	varianceBase
+}
+
+func NewVarPop(operand expression.Expression) Aggregate {
+	rv := &VarPop{
+		*newVarianceBase("var_pop", operand),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *VarPop) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *VarPop) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *VarPop) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewVarPop(operands[0])
+	}
+}
+
+func (this *VarPop) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	return this.variance(cumulative, _VARIANCE_POP)
+}
+
+/*
+This represents the Aggregate function VAR_SAMP(expr). It returns
+the sample variance of all the number values in the group, and
+NULL if the group has a single number value.
+*/
+type VarSamp struct {
+	varianceBase
+}
+
+func NewVarSamp(operand expression.Expression) Aggregate {
+	rv := &VarSamp{
+		*newVarianceBase("var_samp", operand),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *VarSamp) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *VarSamp) Evaluate(item value.Value, context expression.Context) (result value.Value, e error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *VarSamp) Constructor() expression.FunctionConstructor {
+	return func(operands ...expression.Expression) expression.Function {
+		return NewVarSamp(operands[0])
+	}
+}
+
+func (this *VarSamp) ComputeFinal(cumulative value.Value, context Context) (value.Value, error) {
+	return this.variance(cumulative, _VARIANCE_SAMP)
+}
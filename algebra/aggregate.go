@@ -37,6 +37,19 @@ stream. CumulateIntermediate() must be chainable, to provide cascading
 aggregation.
 
 If no input data is received, the Default() value is returned.
+
+An aggregate may have a FILTER clause, in which case only the input
+items that satisfy its condition are aggregated.
+
+Ordered aggregates, such as ARRAY_AGG() and STRING_AGG(), may order
+their input with an ORDER BY or WITHIN GROUP clause. Ordered-set
+aggregates, such as PERCENTILE_CONT(), take the values they aggregate
+from the single sort term of their WITHIN GROUP clause, which they
+require.
+
+An aggregate followed by an OVER clause is a window aggregate. It is
+not computed by the GROUP operators, but over the window frame of
+each row, after grouping.
 */
 type Aggregate interface {
 	/*
@@ -63,16 +76,68 @@ type Aggregate interface {
 	   Performs final post-processing, if any.
 	*/
 	ComputeFinal(cumulative value.Value, context Context) (value.Value, error)
+
+	/*
+	   Returns the sort terms of the WITHIN GROUP clause, or nil.
+	*/
+	WithinGroup() SortTerms
+
+	/*
+	   Returns the condition of the FILTER clause, or nil.
+	*/
+	Filter() expression.Expression
+
+	/*
+	   Sets the condition of the FILTER clause.
+	*/
+	SetFilter(filter expression.Expression)
+
+	/*
+	   Returns the OVER clause, or nil.
+	*/
+	WindowTerm() *WindowTerm
+
+	/*
+	   Sets the OVER clause.
+	*/
+	SetWindowTerm(wTerm *WindowTerm)
+}
+
+/*
+The OrderedAggregate interface represents aggregate functions
+whose input can be ordered by a WITHIN GROUP clause.
+*/
+type OrderedAggregate interface {
+	Aggregate
+
+	/*
+	   Sets the sort terms of the WITHIN GROUP clause.
+	*/
+	SetWithinGroup(within SortTerms)
+
+	/*
+	   True for ordered-set aggregates, which require a WITHIN
+	   GROUP clause with a single sort term, and a constant
+	   argument.
+	*/
+	OrderedSet() bool
 }
 
 /*
 Base class for Aggregate functions. It inherits from
 expressions UnaryFunctionBase, and has field text
-which represents the function name.
+which represents the function name, field within
+which holds the sort terms of the WITHIN GROUP clause,
+field filter which holds the condition of the FILTER
+clause, and field wTerm which holds the OVER clause of
+window aggregates.
 */
 type AggregateBase struct {
 	expression.UnaryFunctionBase
-	text string
+	text   string
+	within SortTerms
+	filter expression.Expression
+	wTerm  *WindowTerm
 }
 
 /*
@@ -84,6 +149,9 @@ func NewAggregateBase(name string, operand expression.Expression) *AggregateBase
 	return &AggregateBase{
 		*expression.NewUnaryFunctionBase(name, operand),
 		"",
+		nil,
+		nil,
+		nil,
 	}
 }
 
@@ -145,7 +213,9 @@ Aggregates are same Return true otherwise Return false.
 func (this *AggregateBase) EquivalentTo(other expression.Expression) bool {
 	otherAggregate, ok := other.(Aggregate)
 	return ok && !otherAggregate.Distinct() && this.Name() == otherAggregate.Name() &&
-		expression.Equivalents(this.Children(), otherAggregate.Children())
+		expression.Equivalents(this.Children(), otherAggregate.Children()) &&
+		this.within.String() == otherAggregate.WithinGroup().String() &&
+		windowTermsEquivalent(this.wTerm, otherAggregate.WindowTerm())
 }
 
 /*
@@ -156,14 +226,34 @@ func (this *AggregateBase) SubsetOf(other expression.Expression) bool {
 }
 
 /*
-Return the operands of the Aggregate function.
+Return the operands of the Aggregate function, followed by the
+expressions of the WITHIN GROUP clause, the condition of the
+FILTER clause and the expressions of the OVER clause, if any.
 */
 func (this *AggregateBase) Children() expression.Expressions {
-	if this.Operands()[0] == nil {
-		return nil
-	} else {
-		return this.Operands()
+	var children expression.Expressions
+	if this.Operands()[0] != nil {
+		children = this.Operands()
+	}
+
+	if this.within == nil && this.filter == nil && this.wTerm == nil {
+		return children
 	}
+
+	children = children[0:len(children):len(children)]
+	if this.within != nil {
+		children = append(children, this.within.Expressions()...)
+	}
+
+	if this.filter != nil {
+		children = append(children, this.filter)
+	}
+
+	if this.wTerm != nil {
+		children = append(children, this.wTerm.Expressions()...)
+	}
+
+	return children
 }
 
 /*
@@ -172,25 +262,180 @@ a mapper and maps the involved expressions to an expression.
 If there is an error during the mapping, an error is returned.
 */
 func (this *AggregateBase) MapChildren(mapper expression.Mapper) error {
-	children := this.Children()
+	operands := this.Operands()
 
-	for i, c := range children {
-		expr, err := mapper.Map(c)
+	for i, op := range operands {
+		if op == nil {
+			continue
+		}
+
+		expr, err := mapper.Map(op)
 		if err != nil {
 			return err
 		}
 
-		children[i] = expr
+		operands[i] = expr
+	}
+
+	if this.within != nil {
+		err := this.within.MapExpressions(mapper)
+		if err != nil {
+			return err
+		}
+	}
+
+	if this.filter != nil {
+		expr, err := mapper.Map(this.filter)
+		if err != nil {
+			return err
+		}
+
+		this.filter = expr
+	}
+
+	if this.wTerm != nil {
+		return this.wTerm.MapExpressions(mapper)
 	}
 
 	return nil
 }
 
+/*
+Copy the aggregate, including its WITHIN GROUP, FILTER and OVER
+clauses.
+*/
+func (this *AggregateBase) Copy() expression.Expression {
+	rv := this.UnaryFunctionBase.Copy()
+	if this.within != nil {
+		within := make(SortTerms, len(this.within))
+		for i, term := range this.within {
+			within[i] = NewSortTerm(term.Expression().Copy(), term.Descending(), term.NullsPos())
+		}
+
+		rv.(OrderedAggregate).SetWithinGroup(within)
+	}
+
+	if this.filter != nil {
+		rv.(Aggregate).SetFilter(this.filter.Copy())
+	}
+
+	if this.wTerm != nil {
+		rv.(Aggregate).SetWindowTerm(this.wTerm.Copy())
+	}
+
+	return rv
+}
+
+/*
+Group aggregates always survive grouping. Window aggregates are
+computed after grouping, so their operands and OVER clause must
+themselves survive grouping.
+*/
 func (this *AggregateBase) SurvivesGrouping(groupKeys expression.Expressions,
 	allowed *value.ScopeValue) (bool, expression.Expression) {
+	if this.wTerm == nil {
+		return true, nil
+	}
+
+	for _, child := range this.Children() {
+		ok, expr := child.SurvivesGrouping(groupKeys, allowed)
+		if !ok {
+			return ok, expr
+		}
+	}
+
 	return true, nil
 }
 
+/*
+Returns the sort terms of the WITHIN GROUP clause, or nil.
+*/
+func (this *AggregateBase) WithinGroup() SortTerms {
+	return this.within
+}
+
+/*
+Returns the condition of the FILTER clause, or nil.
+*/
+func (this *AggregateBase) Filter() expression.Expression {
+	return this.filter
+}
+
+/*
+Sets the condition of the FILTER clause.
+*/
+func (this *AggregateBase) SetFilter(filter expression.Expression) {
+	this.filter = filter
+}
+
+/*
+Returns the OVER clause, or nil.
+*/
+func (this *AggregateBase) WindowTerm() *WindowTerm {
+	return this.wTerm
+}
+
+/*
+Sets the OVER clause.
+*/
+func (this *AggregateBase) SetWindowTerm(wTerm *WindowTerm) {
+	this.wTerm = wTerm
+}
+
+/*
+Returns the WITHIN GROUP, FILTER and OVER clauses as a N1QL
+string, for the Stringer.
+*/
+func (this *AggregateBase) Suffix() string {
+	s := ""
+	if this.within != nil {
+		s += " within group (order by " + this.within.String() + ")"
+	}
+
+	if this.filter != nil {
+		s += " filter (where " + this.filter.String() + ")"
+	}
+
+	if this.wTerm != nil {
+		s += this.wTerm.String()
+	}
+
+	return s
+}
+
+/*
+Aggregates an input item, unless the aggregate has a FILTER clause
+that the item does not satisfy.
+*/
+func CumulateInitial(agg Aggregate, item, cumulative value.Value, context Context) (value.Value, error) {
+	if filter := agg.Filter(); filter != nil {
+		val, err := filter.Evaluate(item, context)
+		if err != nil {
+			return nil, err
+		}
+
+		if !val.Truth() {
+			return cumulative, nil
+		}
+	}
+
+	return agg.CumulateInitial(item, cumulative, context)
+}
+
+/*
+This method creates an AggregateBase for aggregate functions
+with several operands, such as STRING_AGG(expr, separator).
+*/
+func NewMultiAggregateBase(name string, operands ...expression.Expression) *AggregateBase {
+	return &AggregateBase{
+		expression.UnaryFunctionBase{FunctionBase: *expression.NewFunctionBase(name, operands...)},
+		"",
+		nil,
+		nil,
+		nil,
+	}
+}
+
 /*
 Base class for queries that have the DISTINCT keyword for aggregate
 functions. Type DistinctAggregateBase is a struct that inherits
@@ -220,5 +465,6 @@ func (this *DistinctAggregateBase) Distinct() bool { return true }
 func (this *DistinctAggregateBase) EquivalentTo(other expression.Expression) bool {
 	otherAggregate, ok := other.(Aggregate)
 	return ok && otherAggregate.Distinct() && this.Name() == otherAggregate.Name() &&
-		expression.Equivalents(this.Children(), otherAggregate.Children())
+		expression.Equivalents(this.Children(), otherAggregate.Children()) &&
+		windowTermsEquivalent(this.wTerm, otherAggregate.WindowTerm())
 }
@@ -27,12 +27,14 @@ and returning represents the returning clause.
 type Delete struct {
 	statementBase
 
-	keyspace  *KeyspaceRef          `json:"keyspace"`
-	keys      expression.Expression `json:"keys"`
-	indexes   IndexRefs             `json:"indexes"`
-	where     expression.Expression `json:"where"`
-	limit     expression.Expression `json:"limit"`
-	returning *Projection           `json:"returning"`
+	with       expression.Bindings   `json:"with"`
+	keyspace   *KeyspaceRef          `json:"keyspace"`
+	keys       expression.Expression `json:"keys"`
+	indexes    IndexRefs             `json:"indexes"`
+	where      expression.Expression `json:"where"`
+	limit      expression.Expression `json:"limit"`
+	returning  *Projection           `json:"returning"`
+	optimHints *OptimHints           `json:"optimizer_hints"`
 }
 
 /*
@@ -86,6 +88,13 @@ func (this *Delete) Type() string {
 Applies mapper to all the expressions in the delete statement.
 */
 func (this *Delete) MapExpressions(mapper expression.Mapper) (err error) {
+	if this.with != nil {
+		err = this.with.MapExpressions(mapper)
+		if err != nil {
+			return
+		}
+	}
+
 	if this.keys != nil {
 		this.keys, err = mapper.Map(this.keys)
 		if err != nil {
@@ -120,6 +129,10 @@ Returns all contained Expressions.
 func (this *Delete) Expressions() expression.Expressions {
 	exprs := make(expression.Expressions, 0, 8)
 
+	if this.with != nil {
+		exprs = append(exprs, this.with.Expressions()...)
+	}
+
 	if this.keys != nil {
 		exprs = append(exprs, this.keys)
 	}
@@ -176,12 +189,18 @@ Fully qualify identifiers for each of the constituent clauses
 in the delete statement.
 */
 func (this *Delete) Formalize() (err error) {
+	empty, err := formalizeWiths(this.with)
+	if err != nil {
+		return err
+	}
+
 	f, err := this.keyspace.Formalize()
 	if err != nil {
 		return err
 	}
 
-	empty := expression.NewFormalizer("", nil)
+	f.SetWiths(this.with)
+
 	if this.keys != nil {
 		_, err = this.keys.Accept(empty)
 		if err != nil {
@@ -210,6 +229,20 @@ func (this *Delete) Formalize() (err error) {
 	return
 }
 
+/*
+Returns the WITH clause of the delete statement.
+*/
+func (this *Delete) With() expression.Bindings {
+	return this.with
+}
+
+/*
+Sets the WITH clause of the delete statement.
+*/
+func (this *Delete) SetWith(with expression.Bindings) {
+	this.with = with
+}
+
 /*
 Returns the keyspace-ref for the delete statement.
 */
@@ -255,3 +288,17 @@ delete statement.
 func (this *Delete) Returning() *Projection {
 	return this.returning
 }
+
+/*
+Returns the optimizer hints of the delete statement, or nil.
+*/
+func (this *Delete) OptimHints() *OptimHints {
+	return this.optimHints
+}
+
+/*
+Sets the optimizer hints of the delete statement.
+*/
+func (this *Delete) SetOptimHints(optimHints *OptimHints) {
+	this.optimHints = optimHints
+}
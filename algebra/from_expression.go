@@ -103,9 +103,16 @@ Qualify all identifiers for the parent expression. Checks for
 duplicate aliases.
 */
 func (this *ExpressionTerm) Formalize(parent *expression.Formalizer) (f *expression.Formalizer, err error) {
+	var with expression.Expression
 	if this.keyspaceTerm != nil {
 		_, ok := parent.Aliases().Field(this.keyspaceTerm.Keyspace())
 		this.isKeyspace = !ok
+
+		// A keyspace without namespace may refer to the WITH clause
+		if this.isKeyspace && this.keyspaceTerm.Namespace() == "" {
+			with = parent.With(this.keyspaceTerm.Keyspace())
+			this.isKeyspace = with == nil
+		}
 	}
 
 	if this.isKeyspace {
@@ -131,7 +138,30 @@ func (this *ExpressionTerm) Formalize(parent *expression.Formalizer) (f *express
 		return nil, err
 	}
 
+	if with != nil {
+		// The WITH subquery is already formalized, and shared by all
+		// references so that it is evaluated once per request
+		this.fromExpr = with
+		this.as = alias
+		f = expression.NewFormalizer(alias, parent)
+
+		// Within a recursive WITH term, the alias refers to the
+		// working set, which is a correlated reference
+		if _, ok := with.(*expression.Identifier); ok {
+			this.fromExpr, err = f.Map(with)
+			if err != nil {
+				return
+			}
+
+			this.correlated = true
+		}
+
+		f.SetAlias(this.as)
+		return
+	}
+
 	f = expression.NewFormalizer("", parent)
+
 	this.fromExpr, err = f.Map(this.fromExpr)
 	if err != nil {
 		return
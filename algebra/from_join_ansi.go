@@ -22,19 +22,33 @@ Represents the ANSI JOIN clause. AnsiJoins create new input objects by
 combining two or more source objects.  They can be chained.
 */
 type AnsiJoin struct {
-	left     FromTerm
-	right    SimpleFromTerm
-	outer    bool
-	onclause expression.Expression
+	left       FromTerm
+	right      SimpleFromTerm
+	outer      bool
+	rightOuter bool
+	onclause   expression.Expression
 }
 
 func NewAnsiJoin(left FromTerm, outer bool, right SimpleFromTerm, onclause expression.Expression) *AnsiJoin {
-	return &AnsiJoin{left, right, outer, onclause}
+	return &AnsiJoin{left, right, outer, false, onclause}
 }
 
+/*
+A RIGHT OUTER JOIN of two simple terms is turned into a LEFT OUTER
+JOIN, by swapping them.
+*/
 func NewAnsiRightJoin(left SimpleFromTerm, right SimpleFromTerm, onclause expression.Expression) *AnsiJoin {
 	TransferJoinHint(left, right)
-	return &AnsiJoin{right, left, true, onclause}
+	return &AnsiJoin{right, left, true, false, onclause}
+}
+
+/*
+A RIGHT OUTER JOIN whose left source is itself a join keeps the rows
+of the right source that have no match. If outer is also set, it is a
+FULL OUTER JOIN, which keeps the unmatched rows of both sources.
+*/
+func NewAnsiRightOuterJoin(left FromTerm, outer bool, right SimpleFromTerm, onclause expression.Expression) *AnsiJoin {
+	return &AnsiJoin{left, right, outer, true, onclause}
 }
 
 func TransferJoinHint(left SimpleFromTerm, right SimpleFromTerm) {
@@ -110,7 +124,11 @@ func (this *AnsiJoin) Privileges() (*auth.Privileges, errors.Error) {
 func (this *AnsiJoin) String() string {
 	s := this.left.String()
 
-	if this.outer {
+	if this.outer && this.rightOuter {
+		s += " full outer join "
+	} else if this.rightOuter {
+		s += " right outer join "
+	} else if this.outer {
 		s += " left outer join "
 	} else {
 		s += " join "
@@ -182,6 +200,14 @@ func (this *AnsiJoin) Outer() bool {
 	return this.outer
 }
 
+/*
+Returns boolean value based on if the unmatched rows of
+the right source are kept, as in a RIGHT or FULL OUTER JOIN.
+*/
+func (this *AnsiJoin) RightOuter() bool {
+	return this.rightOuter
+}
+
 /*
 Returns ON-clause of ANSI JOIN
 */
@@ -196,6 +222,13 @@ func (this *AnsiJoin) SetOuter(outer bool) {
 	this.outer = outer
 }
 
+/*
+Set right outer
+*/
+func (this *AnsiJoin) SetRightOuter(rightOuter bool) {
+	this.rightOuter = rightOuter
+}
+
 /*
 Set ON-clause
 */
@@ -211,6 +244,9 @@ func (this *AnsiJoin) MarshalJSON() ([]byte, error) {
 	r["left"] = this.left
 	r["right"] = this.right
 	r["outer"] = this.outer
+	if this.rightOuter {
+		r["right_outer"] = this.rightOuter
+	}
 	r["onclause"] = this.onclause
 	return json.Marshal(r)
 }
@@ -50,11 +50,12 @@ type KeyspaceTerm struct {
 	joinKeys  expression.Expression
 	joinHint  JoinHint
 	property  uint32
+	sample    *Sample
 }
 
 func NewKeyspaceTerm(namespace, keyspace string, as string,
 	keys expression.Expression, indexes IndexRefs) *KeyspaceTerm {
-	return &KeyspaceTerm{namespace, keyspace, as, keys, indexes, nil, JOIN_HINT_NONE, 0}
+	return &KeyspaceTerm{namespace, keyspace, as, keys, indexes, nil, JOIN_HINT_NONE, 0, nil}
 }
 
 func (this *KeyspaceTerm) Accept(visitor NodeVisitor) (interface{}, error) {
@@ -78,6 +79,10 @@ func (this *KeyspaceTerm) MapExpressions(mapper expression.Mapper) (err error) {
 		}
 	}
 
+	if this.sample != nil {
+		err = this.sample.MapExpressions(mapper)
+	}
+
 	return
 }
 
@@ -92,6 +97,10 @@ func (this *KeyspaceTerm) Expressions() expression.Expressions {
 		exprs = append(exprs, this.keys)
 	}
 
+	if this.sample != nil {
+		exprs = append(exprs, this.sample.Expressions()...)
+	}
+
 	return exprs
 }
 
@@ -118,7 +127,7 @@ func privilegesFromKeyspace(namespace, keyspace string) (*auth.Privileges, error
 		switch keyspace {
 		case "user_info", "applicable_roles":
 			privs.Add(fullKeyspace, auth.PRIV_SECURITY_READ)
-		case "keyspaces", "indexes", "my_user_info":
+		case "keyspaces", "indexes", "my_user_info", "statistics":
 			// Do nothing. These tables handle security internally, by
 			// filtering the results.
 		case "datastores", "namespaces", "dual":
@@ -168,6 +177,10 @@ func (this *KeyspaceTerm) String() string {
 		s += " use nl"
 	}
 
+	if this.sample != nil {
+		s += this.sample.String()
+	}
+
 	return s
 }
 
@@ -208,6 +221,15 @@ func (this *KeyspaceTerm) Formalize(parent *expression.Formalizer) (f *expressio
 		}
 	}
 
+	if this.sample != nil {
+		for _, expr := range this.sample.Expressions() {
+			_, err = expr.Accept(f)
+			if err != nil {
+				return
+			}
+		}
+	}
+
 	_, ok := parent.Allowed().Field(keyspace)
 	if ok {
 		if this.IsAnsiJoin() {
@@ -324,6 +346,13 @@ func (this *KeyspaceTerm) PreferNL() bool {
 	return this.joinHint == USE_NL
 }
 
+/*
+Returns the SAMPLE clause, or nil.
+*/
+func (this *KeyspaceTerm) Sample() *Sample {
+	return this.sample
+}
+
 /*
 Returns the property.
 */
@@ -398,6 +427,13 @@ func (this *KeyspaceTerm) SetJoinHint(joinHint JoinHint) {
 	this.joinHint = joinHint
 }
 
+/*
+Set the SAMPLE clause
+*/
+func (this *KeyspaceTerm) SetSample(sample *Sample) {
+	this.sample = sample
+}
+
 /*
 Set property
 */
@@ -476,5 +512,8 @@ func (this *KeyspaceTerm) MarshalJSON() ([]byte, error) {
 	}
 	r["namespace"] = this.namespace
 	r["keyspace"] = this.keyspace
+	if this.sample != nil {
+		r["sample"] = this.sample
+	}
 	return json.Marshal(r)
 }
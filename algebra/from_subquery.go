@@ -89,7 +89,7 @@ func (this *SubqueryTerm) Formalize(parent *expression.Formalizer) (f *expressio
 			return
 		}
 	} else {
-		err = this.subquery.Formalize()
+		err = this.subquery.FormalizeSubquery(newWithsFormalizer(parent.Withs()))
 		if err != nil {
 			return
 		}
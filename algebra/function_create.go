@@ -0,0 +1,179 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the CREATE FUNCTION statement. The body is an
+expression over the parameters.
+*/
+type CreateFunction struct {
+	statementBase
+
+	name       string                `json:"name"`
+	parameters []string              `json:"parameters"`
+	body       expression.Expression `json:"body"`
+}
+
+/*
+The function NewCreateFunction returns a pointer to the
+CreateFunction struct with the input argument values as fields.
+*/
+func NewCreateFunction(name string, parameters []string, body expression.Expression) *CreateFunction {
+	rv := &CreateFunction{
+		name:       strings.ToLower(name),
+		parameters: parameters,
+		body:       body,
+	}
+
+	rv.stmt = rv
+	return rv
+}
+
+/*
+It calls the VisitCreateFunction method by passing
+in the receiver and returns the interface. It is a
+visitor pattern.
+*/
+func (this *CreateFunction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCreateFunction(this)
+}
+
+/*
+Returns nil.
+*/
+func (this *CreateFunction) Signature() value.Value {
+	return nil
+}
+
+/*
+Checks that the name is not taken by a builtin function, and that
+the body only references the parameters. Aggregates and subqueries
+are not allowed in the body.
+*/
+func (this *CreateFunction) Formalize() error {
+	_, isAggregate := GetAggregate(this.name, false)
+	_, isWindow := GetWindowFunction(this.name)
+	if isAggregate || isWindow || expression.IsBuiltinFunction(this.name) {
+		return fmt.Errorf("Function %s is a builtin function.", this.name)
+	}
+
+	f := expression.NewFormalizer("", nil)
+	for _, param := range this.parameters {
+		if _, ok := f.Allowed().Field(param); ok {
+			return fmt.Errorf("Duplicate parameter %s in function %s.", param, this.name)
+		}
+
+		f.SetAllowedAlias(param, false)
+	}
+
+	err := checkFunctionBody(this.body, this.name)
+	if err != nil {
+		return err
+	}
+
+	this.body, err = f.Map(this.body)
+	return err
+}
+
+func checkFunctionBody(expr expression.Expression, name string) error {
+	switch expr.(type) {
+	case Aggregate:
+		return fmt.Errorf("Aggregates are not allowed in function %s.", name)
+	case *Subquery:
+		return fmt.Errorf("Subqueries are not allowed in function %s.", name)
+	}
+
+	for _, child := range expr.Children() {
+		if child == nil {
+			continue
+		}
+
+		err := checkFunctionBody(child, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+This method maps the body of the function.
+*/
+func (this *CreateFunction) MapExpressions(mapper expression.Mapper) (err error) {
+	this.body, err = mapper.Map(this.body)
+	return
+}
+
+/*
+Return expr from the statement.
+*/
+func (this *CreateFunction) Expressions() expression.Expressions {
+	return expression.Expressions{this.body}
+}
+
+/*
+Returns all required privileges. The creator must also hold the
+privileges the body requires, e.g. for external access.
+*/
+func (this *CreateFunction) Privileges() (*auth.Privileges, errors.Error) {
+	privs := auth.NewPrivileges()
+	privs.Add("", auth.PRIV_QUERY_MANAGE_FUNCTIONS)
+	privs.AddAll(this.body.Privileges())
+	return privs, nil
+}
+
+/*
+Returns the name of the function.
+*/
+func (this *CreateFunction) Name() string {
+	return this.name
+}
+
+/*
+Returns the names of the parameters.
+*/
+func (this *CreateFunction) Parameters() []string {
+	return this.parameters
+}
+
+/*
+Returns the body of the function.
+*/
+func (this *CreateFunction) Body() expression.Expression {
+	return this.body
+}
+
+/*
+Marshals input receiver into byte array.
+*/
+func (this *CreateFunction) MarshalJSON() ([]byte, error) {
+	r := map[string]interface{}{"type": "createFunction"}
+	r["name"] = this.name
+	r["parameters"] = this.parameters
+	r["body"] = expression.NewStringer().Visit(this.body)
+
+	return json.Marshal(r)
+}
+
+func (this *CreateFunction) Type() string {
+	return "CREATE_FUNCTION"
+}
@@ -0,0 +1,110 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the EXECUTE FUNCTION statement, which returns the result
+of calling a user defined function.
+*/
+type ExecuteFunction struct {
+	statementBase
+
+	call expression.Expression `json:"call"`
+}
+
+/*
+The function NewExecuteFunction returns a pointer to the
+ExecuteFunction struct with the input argument values as fields.
+*/
+func NewExecuteFunction(call expression.Expression) *ExecuteFunction {
+	rv := &ExecuteFunction{
+		call: call,
+	}
+
+	rv.stmt = rv
+	return rv
+}
+
+/*
+It calls the VisitExecuteFunction method by passing
+in the receiver and returns the interface. It is a
+visitor pattern.
+*/
+func (this *ExecuteFunction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitExecuteFunction(this)
+}
+
+/*
+The result of a function can be any JSON value.
+*/
+func (this *ExecuteFunction) Signature() value.Value {
+	return value.NewValue(value.JSON.String())
+}
+
+/*
+The arguments cannot reference any identifiers.
+*/
+func (this *ExecuteFunction) Formalize() (err error) {
+	f := expression.NewFormalizer("", nil)
+	this.call, err = f.Map(this.call)
+	return
+}
+
+/*
+This method maps the function call.
+*/
+func (this *ExecuteFunction) MapExpressions(mapper expression.Mapper) (err error) {
+	this.call, err = mapper.Map(this.call)
+	return
+}
+
+/*
+Return expr from the statement.
+*/
+func (this *ExecuteFunction) Expressions() expression.Expressions {
+	return expression.Expressions{this.call}
+}
+
+/*
+Returns all required privileges.
+*/
+func (this *ExecuteFunction) Privileges() (*auth.Privileges, errors.Error) {
+	return this.call.Privileges(), nil
+}
+
+/*
+Returns the function call.
+*/
+func (this *ExecuteFunction) Call() expression.Expression {
+	return this.call
+}
+
+/*
+Marshals input receiver into byte array.
+*/
+func (this *ExecuteFunction) MarshalJSON() ([]byte, error) {
+	r := map[string]interface{}{"type": "executeFunction"}
+	r["call"] = expression.NewStringer().Visit(this.call)
+
+	return json.Marshal(r)
+}
+
+func (this *ExecuteFunction) Type() string {
+	return "EXECUTE_FUNCTION"
+}
@@ -10,6 +10,8 @@
 package algebra
 
 import (
+	"sort"
+
 	"github.com/couchbase/query/expression"
 )
 
@@ -24,6 +26,8 @@ BY clauses. Having specifies a condition.
 */
 type Group struct {
 	by      expression.Expressions `json:by`
+	terms   GroupTerms             `json:"terms"`
+	sets    [][]int                `json:"sets"`
 	letting expression.Bindings    `json:"letting"`
 	having  expression.Expression  `json:"having"`
 }
@@ -41,6 +45,86 @@ func NewGroup(by expression.Expressions, letting expression.Bindings, having exp
 	}
 }
 
+/*
+The function NewGroupTerms returns a pointer to a Group whose
+terms may include ROLLUP, CUBE and GROUPING SETS. The distinct
+expressions of the terms become the group by expressions, and
+the grouping sets are the cross product of the sets of each
+term.
+*/
+func NewGroupTerms(terms GroupTerms, letting expression.Bindings, having expression.Expression) *Group {
+	rv := &Group{
+		by:      make(expression.Expressions, 0, len(terms)),
+		letting: letting,
+		having:  having,
+	}
+
+	grouping := false
+	for _, term := range terms {
+		if term.kind != GROUP_EXPR {
+			grouping = true
+		}
+
+		for _, set := range term.sets {
+			for _, expr := range set {
+				if rv.keyPos(expr) < 0 {
+					rv.by = append(rv.by, expr)
+				}
+			}
+		}
+	}
+
+	if !grouping {
+		return rv
+	}
+
+	rv.terms = terms
+	rv.sets = [][]int{[]int{}}
+	for _, term := range terms {
+		termSets := term.groupingSets(rv)
+		sets := make([][]int, 0, len(rv.sets)*len(termSets))
+		for _, set := range rv.sets {
+			for _, termSet := range termSets {
+				sets = append(sets, unionGroupingSets(set, termSet))
+			}
+		}
+		rv.sets = sets
+	}
+
+	return rv
+}
+
+func (this *Group) keyPos(expr expression.Expression) int {
+	for i, b := range this.by {
+		if b.EquivalentTo(expr) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unionGroupingSets(set1, set2 []int) []int {
+	rv := make([]int, 0, len(set1)+len(set2))
+	rv = append(rv, set1...)
+	for _, pos := range set2 {
+		found := false
+		for _, p := range rv {
+			if p == pos {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			rv = append(rv, pos)
+		}
+	}
+
+	sort.Ints(rv)
+	return rv
+}
+
 /*
 This method qualifies identifiers for all the constituent clauses,
 namely the by, letting and having expressions by mapping them.
@@ -57,6 +141,13 @@ func (this *Group) Formalize(f *expression.Formalizer) error {
 		}
 	}
 
+	for _, term := range this.terms {
+		err = term.MapExpressions(f)
+		if err != nil {
+			return err
+		}
+	}
+
 	if this.letting != nil {
 		err = f.PushBindings(this.letting, false)
 		if err != nil {
@@ -86,6 +177,21 @@ func (this *Group) MapExpressions(mapper expression.Mapper) (err error) {
 		}
 	}
 
+	for _, term := range this.terms {
+		err = term.MapExpressions(mapper)
+		if err != nil {
+			return
+		}
+	}
+
+	return this.MapGrouped(mapper)
+}
+
+/*
+This method maps the letting and having expressions, which
+are evaluated on the groups rather than on their input.
+*/
+func (this *Group) MapGrouped(mapper expression.Mapper) (err error) {
 	if this.letting != nil {
 		err = this.letting.MapExpressions(mapper)
 		if err != nil {
@@ -127,7 +233,17 @@ func (this *Group) Expressions() expression.Expressions {
 func (this *Group) String() string {
 	s := ""
 
-	if this.by != nil {
+	if this.terms != nil {
+		s += " group by "
+
+		for i, term := range this.terms {
+			if i > 0 {
+				s += ", "
+			}
+
+			s += term.String()
+		}
+	} else if this.by != nil {
 		s += " group by "
 
 		for i, b := range this.by {
@@ -157,6 +273,23 @@ func (this *Group) By() expression.Expressions {
 	return this.by
 }
 
+/*
+Returns the GROUP BY terms, or nil if there is no ROLLUP, CUBE
+or GROUPING SETS.
+*/
+func (this *Group) Terms() GroupTerms {
+	return this.terms
+}
+
+/*
+Returns the grouping sets, each a sorted list of positions in
+the group by expressions, or nil if there is no ROLLUP, CUBE or
+GROUPING SETS.
+*/
+func (this *Group) GroupingSets() [][]int {
+	return this.sets
+}
+
 /*
 Returns the letting expression bindings.
 */
@@ -170,3 +303,144 @@ Returns the having condition expression.
 func (this *Group) Having() expression.Expression {
 	return this.having
 }
+
+type GroupTerms []*GroupTerm
+
+const (
+	GROUP_EXPR = iota
+	GROUP_ROLLUP
+	GROUP_CUBE
+	GROUP_SETS
+)
+
+/*
+A term of the GROUP BY clause: an expression, a ROLLUP or CUBE of
+expressions, or GROUPING SETS. All but GROUPING SETS hold a single
+list of expressions.
+*/
+type GroupTerm struct {
+	kind int
+	sets []expression.Expressions
+}
+
+func NewGroupTerm(expr expression.Expression) *GroupTerm {
+	return &GroupTerm{
+		kind: GROUP_EXPR,
+		sets: []expression.Expressions{expression.Expressions{expr}},
+	}
+}
+
+func NewRollup(exprs expression.Expressions) *GroupTerm {
+	return &GroupTerm{
+		kind: GROUP_ROLLUP,
+		sets: []expression.Expressions{exprs},
+	}
+}
+
+func NewCube(exprs expression.Expressions) *GroupTerm {
+	return &GroupTerm{
+		kind: GROUP_CUBE,
+		sets: []expression.Expressions{exprs},
+	}
+}
+
+func NewGroupingSets(sets []expression.Expressions) *GroupTerm {
+	return &GroupTerm{
+		kind: GROUP_SETS,
+		sets: sets,
+	}
+}
+
+func (this *GroupTerm) Kind() int {
+	return this.kind
+}
+
+func (this *GroupTerm) MapExpressions(mapper expression.Mapper) error {
+	for _, set := range this.sets {
+		err := set.MapExpressions(mapper)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+The grouping sets of the term, as positions in the group by
+expressions. ROLLUP(a, b) yields (a, b), (a) and (); CUBE(a, b)
+yields (a, b), (a), (b) and ().
+*/
+func (this *GroupTerm) groupingSets(group *Group) [][]int {
+	positions := make([][]int, len(this.sets))
+	for i, set := range this.sets {
+		positions[i] = make([]int, len(set))
+		for j, expr := range set {
+			positions[i][j] = group.keyPos(expr)
+		}
+	}
+
+	switch this.kind {
+	case GROUP_ROLLUP:
+		keys := positions[0]
+		rv := make([][]int, 0, len(keys)+1)
+		for n := len(keys); n >= 0; n-- {
+			rv = append(rv, unionGroupingSets(nil, keys[:n]))
+		}
+		return rv
+	case GROUP_CUBE:
+		keys := positions[0]
+		rv := make([][]int, 0, 1<<uint(len(keys)))
+		for mask := 1<<uint(len(keys)) - 1; mask >= 0; mask-- {
+			set := make([]int, 0, len(keys))
+			for i, pos := range keys {
+				if mask&(1<<uint(len(keys)-1-i)) != 0 {
+					set = append(set, pos)
+				}
+			}
+			rv = append(rv, unionGroupingSets(nil, set))
+		}
+		return rv
+	default:
+		for i, set := range positions {
+			positions[i] = unionGroupingSets(nil, set)
+		}
+		return positions
+	}
+}
+
+/*
+   Representation as a N1QL string.
+*/
+func (this *GroupTerm) String() string {
+	switch this.kind {
+	case GROUP_ROLLUP:
+		return "rollup(" + stringExpressions(this.sets[0]) + ")"
+	case GROUP_CUBE:
+		return "cube(" + stringExpressions(this.sets[0]) + ")"
+	case GROUP_SETS:
+		s := "grouping sets("
+		for i, set := range this.sets {
+			if i > 0 {
+				s += ", "
+			}
+
+			s += "(" + stringExpressions(set) + ")"
+		}
+		return s + ")"
+	default:
+		return this.sets[0][0].String()
+	}
+}
+
+func stringExpressions(exprs expression.Expressions) string {
+	s := ""
+	for i, expr := range exprs {
+		if i > 0 {
+			s += ", "
+		}
+
+		s += expr.String()
+	}
+	return s
+}
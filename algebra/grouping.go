@@ -0,0 +1,96 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"fmt"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+This represents the function GROUPING(). It tells super-aggregate
+rows produced by ROLLUP, CUBE and GROUPING SETS apart: it returns 1
+if its GROUP BY key is rolled up in the current group, and 0
+otherwise. With several keys, it returns a bit mask, with the first
+key as the most significant bit.
+
+The group operators record the rolled up keys in the "grouping"
+attachment of each group, by the text of the key.
+*/
+type Grouping struct {
+	expression.FunctionBase
+}
+
+func NewGrouping(operands ...expression.Expression) expression.Function {
+	rv := &Grouping{
+		*expression.NewFunctionBase("grouping", operands...),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *Grouping) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *Grouping) Type() value.Type { return value.NUMBER }
+
+func (this *Grouping) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	av, ok := item.(value.AnnotatedValue)
+	if !ok || av.GetAttachment("aggregates") == nil {
+		return nil, fmt.Errorf("GROUPING() can only be used on groups.")
+	}
+
+	grouping, _ := av.GetAttachment("grouping").(map[string]value.Value)
+	rv := 0
+	for _, op := range this.Operands() {
+		rv <<= 1
+
+		text := op.String()
+		if cover, ok := op.(*expression.Cover); ok {
+			text = cover.Text()
+		}
+
+		if rolledUp, ok := grouping[text]; ok && rolledUp.Truth() {
+			rv |= 1
+		}
+	}
+
+	return value.NewValue(rv), nil
+}
+
+/*
+Not constant.
+*/
+func (this *Grouping) Value() value.Value {
+	return nil
+}
+
+/*
+Not static.
+*/
+func (this *Grouping) Static() expression.Expression {
+	return nil
+}
+
+func (this *Grouping) Indexable() bool {
+	return false
+}
+
+func (this *Grouping) MinArgs() int { return 1 }
+
+func (this *Grouping) MaxArgs() int { return 32 }
+
+func (this *Grouping) Constructor() expression.FunctionConstructor {
+	return NewGrouping
+}
@@ -30,6 +30,7 @@ represents the returning clause.
 type Insert struct {
 	statementBase
 
+	with      expression.Bindings   `json:"with"`
 	keyspace  *KeyspaceRef          `json:"keyspace"`
 	key       expression.Expression `json:"key"`
 	value     expression.Expression `json:"value"`
@@ -110,6 +111,13 @@ func (this *Insert) Type() string {
 Applies mapper to all the expressions in the insert statement.
 */
 func (this *Insert) MapExpressions(mapper expression.Mapper) (err error) {
+	if this.with != nil {
+		err = this.with.MapExpressions(mapper)
+		if err != nil {
+			return
+		}
+	}
+
 	if this.key != nil {
 		this.key, err = mapper.Map(this.key)
 		if err != nil {
@@ -151,6 +159,10 @@ Returns all contained Expressions.
 func (this *Insert) Expressions() expression.Expressions {
 	exprs := make(expression.Expressions, 0, 16)
 
+	if this.with != nil {
+		exprs = append(exprs, this.with.Expressions()...)
+	}
+
 	if this.key != nil {
 		exprs = append(exprs, this.key)
 	}
@@ -212,8 +224,13 @@ Fully qualify identifiers for each of the constituent clauses
 in the insert statement.
 */
 func (this *Insert) Formalize() (err error) {
+	_, err = formalizeWiths(this.with)
+	if err != nil {
+		return
+	}
+
 	if this.values != nil {
-		f := expression.NewFormalizer("", nil)
+		f := newWithsFormalizer(this.with)
 		err = this.values.MapExpressions(f)
 		if err != nil {
 			return
@@ -221,7 +238,7 @@ func (this *Insert) Formalize() (err error) {
 	}
 
 	if this.query != nil {
-		err = this.query.Formalize()
+		err = this.query.FormalizeSubquery(newWithsFormalizer(this.with))
 		if err != nil {
 			return
 		}
@@ -232,6 +249,8 @@ func (this *Insert) Formalize() (err error) {
 		return err
 	}
 
+	f.SetWiths(this.with)
+
 	if this.returning != nil {
 		_, err = this.returning.Formalize(f)
 	}
@@ -239,6 +258,20 @@ func (this *Insert) Formalize() (err error) {
 	return
 }
 
+/*
+Returns the WITH clause of the insert statement.
+*/
+func (this *Insert) With() expression.Bindings {
+	return this.with
+}
+
+/*
+Sets the WITH clause of the insert statement.
+*/
+func (this *Insert) SetWith(with expression.Bindings) {
+	this.with = with
+}
+
 /*
 Returns the keyspace-ref for the insert statement.
 */
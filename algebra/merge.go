@@ -31,14 +31,15 @@ clause.
 type Merge struct {
 	statementBase
 
-	keyspace  *KeyspaceRef          `json:"keyspace"`
-	indexes   IndexRefs             `json:"indexes"`
-	source    *MergeSource          `json:"source"`
-	on        expression.Expression `json:"on"`
-	isOnKey   bool                  `json:"is_on_key"`
-	actions   *MergeActions         `json:"actions"`
-	limit     expression.Expression `json:"limit"`
-	returning *Projection           `json:"returning"`
+	keyspace   *KeyspaceRef          `json:"keyspace"`
+	indexes    IndexRefs             `json:"indexes"`
+	source     *MergeSource          `json:"source"`
+	on         expression.Expression `json:"on"`
+	isOnKey    bool                  `json:"is_on_key"`
+	actions    *MergeActions         `json:"actions"`
+	limit      expression.Expression `json:"limit"`
+	returning  *Projection           `json:"returning"`
+	optimHints *OptimHints           `json:"optimizer_hints"`
 }
 
 /*
@@ -306,6 +307,20 @@ func (this *Merge) Returning() *Projection {
 	return this.returning
 }
 
+/*
+Returns the optimizer hints of the merge statement, or nil.
+*/
+func (this *Merge) OptimHints() *OptimHints {
+	return this.optimHints
+}
+
+/*
+Sets the optimizer hints of the merge statement.
+*/
+func (this *Merge) SetOptimHints(optimHints *OptimHints) {
+	this.optimHints = optimHints
+}
+
 func (this *Merge) Type() string {
 	return "MERGE"
 }
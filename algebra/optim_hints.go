@@ -0,0 +1,257 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"strconv"
+	"strings"
+)
+
+type OptimHintType int
+
+const (
+	OPTIM_HINT_INDEX OptimHintType = iota
+	OPTIM_HINT_NO_INDEX
+	OPTIM_HINT_USE_HASH
+	OPTIM_HINT_USE_NL
+	OPTIM_HINT_ORDERED
+	OPTIM_HINT_PARALLEL
+	OPTIM_HINT_INVALID
+)
+
+type OptimHintState int
+
+const (
+	OPTIM_HINT_UNKNOWN OptimHintState = iota
+	OPTIM_HINT_FOLLOWED
+	OPTIM_HINT_NOT_FOLLOWED
+)
+
+/*
+Represents a single optimizer hint, such as INDEX(o ix_cid) or
+PARALLEL(4). Hints are written in a comment beginning /*+ directly
+after SELECT, UPDATE, DELETE or MERGE, and apply to that query block.
+A hint is advice: when the planner cannot follow it, the statement is
+planned as if it were not given, and the reason is kept for EXPLAIN.
+*/
+type OptimHint struct {
+	hintType OptimHintType
+	keyspace string
+	indexes  []string
+	joinHint JoinHint
+	parallel int
+	text     string
+	state    OptimHintState
+	reason   string
+}
+
+/*
+INDEX(alias [index ...]) prefers the named indexes of the keyspace,
+or any secondary index when none is named, to a primary scan.
+*/
+func NewIndexHint(keyspace string, indexes []string) *OptimHint {
+	return &OptimHint{hintType: OPTIM_HINT_INDEX, keyspace: keyspace, indexes: indexes}
+}
+
+/*
+NO_INDEX(alias [index ...]) avoids the named indexes of the keyspace,
+or all its secondary indexes when none is named.
+*/
+func NewNoIndexHint(keyspace string, indexes []string) *OptimHint {
+	return &OptimHint{hintType: OPTIM_HINT_NO_INDEX, keyspace: keyspace, indexes: indexes}
+}
+
+/*
+USE_HASH(alias[/BUILD|/PROBE]) joins the keyspace with a hash join,
+and USE_NL(alias) with a nested-loop join, as the USE HASH and USE NL
+join hints of the FROM clause do.
+*/
+func NewJoinOptimHint(keyspace string, joinHint JoinHint) *OptimHint {
+	hintType := OPTIM_HINT_USE_HASH
+	if joinHint == USE_NL {
+		hintType = OPTIM_HINT_USE_NL
+	}
+	return &OptimHint{hintType: hintType, keyspace: keyspace, joinHint: joinHint}
+}
+
+/*
+ORDERED joins the keyspaces in the order of the FROM clause.
+*/
+func NewOrderedHint() *OptimHint {
+	return &OptimHint{hintType: OPTIM_HINT_ORDERED}
+}
+
+/*
+PARALLEL(n) runs the query block with up to n parallel streams.
+*/
+func NewParallelHint(parallel int) *OptimHint {
+	return &OptimHint{hintType: OPTIM_HINT_PARALLEL, parallel: parallel}
+}
+
+/*
+A hint that cannot be parsed is kept as it was written, with the
+error, and is otherwise ignored.
+*/
+func NewInvalidHint(text, err string) *OptimHint {
+	return &OptimHint{hintType: OPTIM_HINT_INVALID, text: text, reason: err}
+}
+
+func (this *OptimHint) Type() OptimHintType {
+	return this.hintType
+}
+
+/*
+Returns the alias of the keyspace the hint applies to, or the empty
+string for ORDERED and PARALLEL.
+*/
+func (this *OptimHint) Keyspace() string {
+	return this.keyspace
+}
+
+func (this *OptimHint) Indexes() []string {
+	return this.indexes
+}
+
+func (this *OptimHint) JoinHint() JoinHint {
+	return this.joinHint
+}
+
+func (this *OptimHint) Parallel() int {
+	return this.parallel
+}
+
+func (this *OptimHint) State() OptimHintState {
+	return this.state
+}
+
+/*
+Returns why the hint was not followed, or why it is invalid.
+*/
+func (this *OptimHint) Reason() string {
+	return this.reason
+}
+
+func (this *OptimHint) SetFollowed() {
+	if this.hintType != OPTIM_HINT_INVALID {
+		this.state = OPTIM_HINT_FOLLOWED
+		this.reason = ""
+	}
+}
+
+func (this *OptimHint) SetNotFollowed(reason string) {
+	if this.hintType != OPTIM_HINT_INVALID {
+		this.state = OPTIM_HINT_NOT_FOLLOWED
+		this.reason = reason
+	}
+}
+
+/*
+Forgets whether the hint was followed, before the statement is
+planned again.
+*/
+func (this *OptimHint) Reset() {
+	if this.hintType != OPTIM_HINT_INVALID {
+		this.state = OPTIM_HINT_UNKNOWN
+		this.reason = ""
+	}
+}
+
+/*
+Representation as a N1QL hint.
+*/
+func (this *OptimHint) String() string {
+	switch this.hintType {
+	case OPTIM_HINT_INDEX, OPTIM_HINT_NO_INDEX:
+		s := "INDEX("
+		if this.hintType == OPTIM_HINT_NO_INDEX {
+			s = "NO_INDEX("
+		}
+		s += hintIdentifier(this.keyspace)
+		for _, index := range this.indexes {
+			s += " " + hintIdentifier(index)
+		}
+		return s + ")"
+	case OPTIM_HINT_USE_HASH:
+		s := "USE_HASH(" + hintIdentifier(this.keyspace)
+		if this.joinHint == USE_HASH_PROBE {
+			s += "/PROBE"
+		} else {
+			s += "/BUILD"
+		}
+		return s + ")"
+	case OPTIM_HINT_USE_NL:
+		return "USE_NL(" + hintIdentifier(this.keyspace) + ")"
+	case OPTIM_HINT_ORDERED:
+		return "ORDERED"
+	case OPTIM_HINT_PARALLEL:
+		return "PARALLEL(" + strconv.Itoa(this.parallel) + ")"
+	default:
+		return this.text
+	}
+}
+
+func hintIdentifier(name string) string {
+	for _, c := range name {
+		if !(c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+			return "`" + name + "`"
+		}
+	}
+	return name
+}
+
+/*
+Represents the optimizer hints of a query block.
+*/
+type OptimHints struct {
+	hints []*OptimHint
+}
+
+func NewOptimHints(hints []*OptimHint) *OptimHints {
+	return &OptimHints{hints}
+}
+
+func (this *OptimHints) Hints() []*OptimHint {
+	return this.hints
+}
+
+/*
+Returns the hints of the given type, and for keyspace hints, of the
+given keyspace alias.
+*/
+func (this *OptimHints) Find(hintType OptimHintType, keyspace string) []*OptimHint {
+	if this == nil {
+		return nil
+	}
+
+	var rv []*OptimHint
+	for _, hint := range this.hints {
+		if hint.hintType == hintType && hint.keyspace == keyspace {
+			rv = append(rv, hint)
+		}
+	}
+	return rv
+}
+
+func (this *OptimHints) Reset() {
+	for _, hint := range this.hints {
+		hint.Reset()
+	}
+}
+
+/*
+Representation as a N1QL hint comment.
+*/
+func (this *OptimHints) String() string {
+	s := make([]string, len(this.hints))
+	for i, hint := range this.hints {
+		s[i] = hint.String()
+	}
+	return "/*+ " + strings.Join(s, " ") + " */"
+}
@@ -61,6 +61,16 @@ func (this *Order) Terms() SortTerms {
 	return this.terms
 }
 
+/*
+Position of NULL and MISSING values in the sort order. By default,
+they sort first in ascending order and last in descending order.
+*/
+const (
+	NULLS_DEFAULT = iota
+	NULLS_FIRST
+	NULLS_LAST
+)
+
 /*
 It represents multiple orderby terms.
 Type SortTerms is a slice of SortTerm.
@@ -69,22 +79,25 @@ type SortTerms []*SortTerm
 
 /*
 Represents the ordering term in an order by clause. Type
-SortTerm is a struct containing the expression and a bool
-value that decides the sort order (ASC or DESC).
+SortTerm is a struct containing the expression, a bool
+value that decides the sort order (ASC or DESC), and the
+position of NULL and MISSING values (NULLS FIRST or LAST).
 */
 type SortTerm struct {
 	expr       expression.Expression `json:"expr"`
 	descending bool                  `json:"desc"`
+	nullsPos   int                   `json:"nulls_pos"`
 }
 
 /*
 The function NewSortTerm returns a pointer to the SortTerm
 struct that has its fields set to the input arguments.
 */
-func NewSortTerm(expr expression.Expression, descending bool) *SortTerm {
+func NewSortTerm(expr expression.Expression, descending bool, nullsPos int) *SortTerm {
 	return &SortTerm{
 		expr:       expr,
 		descending: descending,
+		nullsPos:   nullsPos,
 	}
 }
 
@@ -98,6 +111,13 @@ func (this *SortTerm) String() string {
 		s += " desc"
 	}
 
+	switch this.nullsPos {
+	case NULLS_FIRST:
+		s += " nulls first"
+	case NULLS_LAST:
+		s += " nulls last"
+	}
+
 	return s
 }
 
@@ -116,6 +136,29 @@ func (this *SortTerm) Descending() bool {
 	return this.descending
 }
 
+/*
+Return the position of NULL and MISSING values as given
+in the order by clause.
+*/
+func (this *SortTerm) NullsPos() int {
+	return this.nullsPos
+}
+
+/*
+Return bool value representing whether NULL and MISSING
+values sort before all other values.
+*/
+func (this *SortTerm) NullsFirst() bool {
+	switch this.nullsPos {
+	case NULLS_FIRST:
+		return true
+	case NULLS_LAST:
+		return false
+	default:
+		return !this.descending
+	}
+}
+
 /*
 Map Expressions for all sort terms in the receiver.
 */
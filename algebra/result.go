@@ -91,6 +91,27 @@ func (this *Projection) Signature() value.Value {
 	return rv
 }
 
+/*
+Returns the names of the result fields in the order of the result
+terms. Raw projections and projections with a star term return nil,
+as the names of their fields are not known until execution.
+*/
+func (this *Projection) Columns() []string {
+	if this.raw {
+		return nil
+	}
+
+	rv := make([]string, 0, len(this.terms))
+	for _, term := range this.terms {
+		if term.star {
+			return nil
+		}
+		rv = append(rv, term.alias)
+	}
+
+	return rv
+}
+
 /*
 This method maps the result expressions.
 */
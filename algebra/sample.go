@@ -0,0 +1,111 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/expression"
+)
+
+/*
+Represents the SAMPLE clause of a keyspace term. SAMPLE n PERCENT
+reads each document of the keyspace with a probability of n percent,
+and SAMPLE n ROWS reads n documents of the keyspace chosen at random.
+The sample is taken before the WHERE clause is applied. With a SEED,
+the same documents are sampled each time the statement is run.
+SAMPLE is only allowed on the FROM terms of a query; the targets of
+UPDATE and DELETE cannot be sampled, so that which documents are
+mutated never depends on a random draw.
+*/
+type Sample struct {
+	size expression.Expression
+	rows bool
+	seed expression.Expression
+}
+
+func NewSample(size expression.Expression, rows bool, seed expression.Expression) *Sample {
+	return &Sample{size, rows, seed}
+}
+
+/*
+Returns the sample size, a percentage or a number of rows.
+*/
+func (this *Sample) Size() expression.Expression {
+	return this.size
+}
+
+/*
+Returns whether the sample size is a number of rows.
+*/
+func (this *Sample) Rows() bool {
+	return this.rows
+}
+
+/*
+Returns the seed expression, or nil when the sample is not
+repeatable.
+*/
+func (this *Sample) Seed() expression.Expression {
+	return this.seed
+}
+
+/*
+Maps the size and seed expressions.
+*/
+func (this *Sample) MapExpressions(mapper expression.Mapper) (err error) {
+	this.size, err = mapper.Map(this.size)
+	if err != nil {
+		return
+	}
+
+	if this.seed != nil {
+		this.seed, err = mapper.Map(this.seed)
+	}
+	return
+}
+
+/*
+Returns all contained Expressions.
+*/
+func (this *Sample) Expressions() expression.Expressions {
+	exprs := make(expression.Expressions, 0, 2)
+	exprs = append(exprs, this.size)
+	if this.seed != nil {
+		exprs = append(exprs, this.seed)
+	}
+	return exprs
+}
+
+/*
+Representation as a N1QL string.
+*/
+func (this *Sample) String() string {
+	s := " sample " + this.size.String()
+	if this.rows {
+		s += " rows"
+	} else {
+		s += " percent"
+	}
+
+	if this.seed != nil {
+		s += " seed " + this.seed.String()
+	}
+	return s
+}
+
+func (this *Sample) MarshalJSON() ([]byte, error) {
+	r := map[string]interface{}{"size": expression.NewStringer().Visit(this.size)}
+	r["rows"] = this.rows
+	if this.seed != nil {
+		r["seed"] = expression.NewStringer().Visit(this.seed)
+	}
+	return json.Marshal(r)
+}
@@ -29,6 +29,7 @@ clause.
 type Select struct {
 	statementBase
 
+	with       expression.Bindings   `json:"with"`
 	subresult  Subresult             `json:"subresult"`
 	order      *Order                `json:"order"`
 	offset     expression.Expression `json:"offset"`
@@ -66,6 +67,14 @@ func (this *Select) Signature() value.Value {
 	return this.subresult.Signature()
 }
 
+/*
+This method returns the names of the result fields of this
+statement, in projection order.
+*/
+func (this *Select) Columns() []string {
+	return this.subresult.Columns()
+}
+
 /*
 It's a select
 */
@@ -78,14 +87,26 @@ This method calls FormalizeSubquery to qualify all the children
 of the query, and returns an error if any.
 */
 func (this *Select) Formalize() (err error) {
-	return this.FormalizeSubquery(expression.NewFormalizer("", nil))
+	f, err := formalizeWiths(this.with)
+	if err != nil {
+		return err
+	}
+
+	return this.FormalizeSubquery(f)
 }
 
 /*
-This method maps all the constituent clauses, namely the subresult,
-order, limit and offset within a Select statement.
+This method maps all the constituent clauses, namely the with,
+subresult, order, limit and offset within a Select statement.
 */
 func (this *Select) MapExpressions(mapper expression.Mapper) (err error) {
+	if this.with != nil {
+		err = this.with.MapExpressions(mapper)
+		if err != nil {
+			return
+		}
+	}
+
 	err = this.subresult.MapExpressions(mapper)
 	if err != nil {
 		return
@@ -115,6 +136,10 @@ func (this *Select) MapExpressions(mapper expression.Mapper) (err error) {
 func (this *Select) Expressions() expression.Expressions {
 	exprs := this.subresult.Expressions()
 
+	if this.with != nil {
+		exprs = append(exprs, this.with.Expressions()...)
+	}
+
 	if this.order != nil {
 		exprs = append(exprs, this.order.Expressions()...)
 	}
@@ -139,6 +164,12 @@ func (this *Select) Privileges() (*auth.Privileges, errors.Error) {
 		return nil, err
 	}
 
+	withPrivs, err := withsPrivileges(this.with)
+	if err != nil {
+		return nil, err
+	}
+	privs.AddAll(withPrivs)
+
 	exprs := make(expression.Expressions, 0, 16)
 
 	if this.order != nil {
@@ -170,7 +201,7 @@ func (this *Select) Privileges() (*auth.Privileges, errors.Error) {
    Representation as a N1QL string.
 */
 func (this *Select) String() string {
-	s := this.subresult.String()
+	s := stringWiths(this.with) + this.subresult.String()
 
 	if this.order != nil {
 		s += " " + this.order.String()
@@ -250,6 +281,20 @@ func (this *Select) FormalizeSubquery(parent *expression.Formalizer) error {
 	return err
 }
 
+/*
+Returns the WITH clause of the select statement.
+*/
+func (this *Select) With() expression.Bindings {
+	return this.with
+}
+
+/*
+Sets the WITH clause of the select statement.
+*/
+func (this *Select) SetWith(with expression.Bindings) {
+	this.with = with
+}
+
 /*
 Return the subresult of the select statement.
 */
@@ -309,6 +354,12 @@ type Subresult interface {
 	*/
 	Signature() value.Value
 
+	/*
+	   The names of the result fields, in projection order, or nil
+	   if they are not known until execution.
+	*/
+	Columns() []string
+
 	/*
 	   Fully qualify all identifiers in this statement.
 	*/
@@ -29,6 +29,7 @@ type Subselect struct {
 	where      expression.Expression `json:"where"`
 	group      *Group                `json:"group"`
 	projection *Projection           `json:"projection"`
+	optimHints *OptimHints           `json:"optimizer_hints"`
 	correlated bool                  `json:"correlated"`
 }
 
@@ -37,7 +38,7 @@ Constructor.
 */
 func NewSubselect(from FromTerm, let expression.Bindings, where expression.Expression,
 	group *Group, projection *Projection) *Subselect {
-	return &Subselect{from, let, where, group, projection, false}
+	return &Subselect{from, let, where, group, projection, nil, false}
 }
 
 /*
@@ -55,6 +56,14 @@ func (this *Subselect) Signature() value.Value {
 	return this.projection.Signature()
 }
 
+/*
+This method returns the names of the result fields, in the order
+of the projection.
+*/
+func (this *Subselect) Columns() []string {
+	return this.projection.Columns()
+}
+
 /*
 This method qualifies identifiers for all the contituent
 clauses namely the from, let, where, group and projection
@@ -230,7 +239,11 @@ func (this *Subselect) Privileges() (*auth.Privileges, errors.Error) {
    Representation as a N1QL string.
 */
 func (this *Subselect) String() string {
-	s := "select " + this.projection.String()
+	s := "select "
+	if this.optimHints != nil {
+		s += this.optimHints.String() + " "
+	}
+	s += this.projection.String()
 
 	if this.from != nil {
 		s += " from " + this.from.String()
@@ -299,6 +312,17 @@ func (this *Subselect) Projection() *Projection {
 	return this.projection
 }
 
+/*
+Returns the optimizer hints of the subselect, or nil.
+*/
+func (this *Subselect) OptimHints() *OptimHints {
+	return this.optimHints
+}
+
+func (this *Subselect) SetOptimHints(optimHints *OptimHints) {
+	this.optimHints = optimHints
+}
+
 /*
    Representation as a N1QL string.
 */
@@ -46,6 +46,13 @@ func (this *SelectTerm) Signature() value.Value {
 	return this.query.Signature()
 }
 
+/*
+This method returns the names of the result fields of the query.
+*/
+func (this *SelectTerm) Columns() []string {
+	return this.query.Columns()
+}
+
 /*
  */
 func (this *SelectTerm) Formalize(parent *expression.Formalizer) (f *expression.Formalizer, err error) {
@@ -33,6 +33,13 @@ func (this *setOp) Signature() value.Value {
 	return this.first.Signature()
 }
 
+/*
+Returns the result fields of the first subresult.
+*/
+func (this *setOp) Columns() []string {
+	return this.first.Columns()
+}
+
 /*
 Returns true if either of the subresults are correlated.
 */
@@ -166,6 +173,31 @@ func (this *unionSubresult) Signature() value.Value {
 	return rv
 }
 
+/*
+Returns the result fields of the first subresult, followed by
+those of the second that the first does not have.
+*/
+func (this *unionSubresult) Columns() []string {
+	first := this.first.Columns()
+	second := this.second.Columns()
+	if first == nil || second == nil {
+		return nil
+	}
+
+	rv := append(make([]string, 0, len(first)+len(second)), first...)
+outer:
+	for _, s := range second {
+		for _, f := range first {
+			if s == f {
+				continue outer
+			}
+		}
+		rv = append(rv, s)
+	}
+
+	return rv
+}
+
 /*
 New JSON string value.
 */
@@ -0,0 +1,270 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"fmt"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the body of a WITH RECURSIVE term, i.e. an anchor and a
+recursive member combined by UNION or UNION ALL. The recursive member
+references the WITH alias, which denotes the rows produced by the
+previous iteration. Iteration stops when an iteration produces no new
+rows, or when the maximum number of levels or documents given in the
+OPTIONS clause is reached. Without a levels option, a recursion that
+goes on past a default maximum number of levels fails.
+
+UNION discards rows that have already been produced. The CYCLE clause
+discards rows whose cycle expressions have already been seen, so
+that cyclic data terminates with UNION ALL.
+*/
+type RecursiveUnion struct {
+	unionSubresult
+	alias     string
+	distinct  bool
+	cycle     expression.Expressions
+	options   value.Value
+	levels    int64
+	documents int64
+	recursive bool
+}
+
+/*
+The function NewRecursiveUnion returns a pointer to the
+RecursiveUnion struct. distinct is true for UNION, and false for
+UNION ALL.
+*/
+func NewRecursiveUnion(alias string, first, second Subresult, distinct bool,
+	cycle expression.Expressions, options value.Value) *RecursiveUnion {
+	return &RecursiveUnion{
+		unionSubresult: unionSubresult{
+			setOp{
+				first:  first,
+				second: second,
+			},
+		},
+		alias:     alias,
+		distinct:  distinct,
+		cycle:     cycle,
+		options:   options,
+		levels:    -1,
+		documents: -1,
+	}
+}
+
+/*
+Visitor pattern.
+*/
+func (this *RecursiveUnion) Accept(visitor NodeVisitor) (interface{}, error) {
+	return visitor.VisitRecursiveUnion(this)
+}
+
+/*
+Only the anchor can be correlated. The recursive member references
+the working set, which is local to this term.
+*/
+func (this *RecursiveUnion) IsCorrelated() bool {
+	return this.first.IsCorrelated()
+}
+
+/*
+Qualify identifiers of the anchor and the recursive member. Within
+the recursive member, the WITH alias refers to the working set.
+*/
+func (this *RecursiveUnion) Formalize(parent *expression.Formalizer) (*expression.Formalizer, error) {
+	err := this.setOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = this.first.Formalize(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	withs := parent.Withs()
+	withs = append(withs[0:len(withs):len(withs)],
+		expression.NewSimpleBinding(this.alias, expression.NewIdentifier(this.WorkingSet())))
+
+	sf := expression.NewFormalizer("", parent)
+	sf.SetAllowedAlias(this.WorkingSet(), false)
+	sf.SetWiths(withs)
+
+	_, err = this.second.Formalize(sf)
+	if err != nil {
+		return nil, err
+	}
+
+	this.recursive = referencesIdentifier(this.second.Expressions(), this.WorkingSet())
+
+	terms := this.ResultTerms()
+	f := expression.NewFormalizer("", parent)
+	for _, term := range terms {
+		f.SetAllowedAlias(term.Alias(), true)
+	}
+
+	return f, nil
+}
+
+func (this *RecursiveUnion) setOptions() error {
+	if this.options == nil {
+		return nil
+	}
+
+	for name, val := range this.options.Fields() {
+		var limit *int64
+		switch name {
+		case "levels":
+			limit = &this.levels
+		case "documents":
+			limit = &this.documents
+		default:
+			return fmt.Errorf("Invalid option %s for recursive WITH %s.", name, this.alias)
+		}
+
+		v := value.NewValue(val)
+		n, ok := v.Actual().(float64)
+		if !ok || !value.IsInt(n) || n < 0 {
+			return fmt.Errorf("Invalid value %v for option %s of recursive WITH %s.", v, name, this.alias)
+		}
+
+		*limit = int64(n)
+	}
+
+	return nil
+}
+
+/*
+Returns true if any of the expressions, including those of nested
+subqueries, references the given identifier.
+*/
+func referencesIdentifier(exprs expression.Expressions, ident string) bool {
+	for _, expr := range exprs {
+		switch expr := expr.(type) {
+		case nil:
+			continue
+		case *expression.Identifier:
+			if expr.Identifier() == ident {
+				return true
+			}
+		case *Subquery:
+			if referencesIdentifier(expr.Select().Expressions(), ident) {
+				return true
+			}
+			continue
+		}
+
+		if referencesIdentifier(expr.Children(), ident) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Returns all contained Expressions.
+*/
+func (this *RecursiveUnion) Expressions() expression.Expressions {
+	return append(this.setOp.Expressions(), this.cycle...)
+}
+
+/*
+Representation as a N1QL string.
+*/
+func (this *RecursiveUnion) String() string {
+	if this.distinct {
+		return this.first.String() + " union " + this.second.String()
+	}
+
+	return this.first.String() + " union all " + this.second.String()
+}
+
+/*
+Representation of the CYCLE and OPTIONS clauses as a N1QL string.
+*/
+func (this *RecursiveUnion) ClausesString() string {
+	s := ""
+
+	if len(this.cycle) > 0 {
+		s += " cycle "
+		for i, expr := range this.cycle {
+			if i > 0 {
+				s += ", "
+			}
+
+			s += expr.String()
+		}
+
+		s += " restrict"
+	}
+
+	if this.options != nil {
+		s += " options " + this.options.String()
+	}
+
+	return s
+}
+
+/*
+Returns the WITH alias.
+*/
+func (this *RecursiveUnion) Alias() string {
+	return this.alias
+}
+
+/*
+Returns the variable holding the rows of the previous iteration.
+*/
+func (this *RecursiveUnion) WorkingSet() string {
+	return "#" + this.alias
+}
+
+/*
+Returns true for UNION, false for UNION ALL.
+*/
+func (this *RecursiveUnion) Distinct() bool {
+	return this.distinct
+}
+
+/*
+Returns the expressions of the CYCLE clause.
+*/
+func (this *RecursiveUnion) Cycle() expression.Expressions {
+	return this.cycle
+}
+
+/*
+Returns the maximum number of iterations of the recursive member,
+or -1 if not given. Without the option, recursion past a default
+maximum is an error.
+*/
+func (this *RecursiveUnion) Levels() int64 {
+	return this.levels
+}
+
+/*
+Returns the maximum number of documents produced, or -1 if
+unlimited.
+*/
+func (this *RecursiveUnion) Documents() int64 {
+	return this.documents
+}
+
+/*
+Returns true if the recursive member references the WITH alias.
+*/
+func (this *RecursiveUnion) IsRecursive() bool {
+	return this.recursive
+}
@@ -0,0 +1,95 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the COMMIT WORK statement, which applies the
+mutations of the current transaction and ends it.
+*/
+type CommitTransaction struct {
+	statementBase
+}
+
+/*
+The function NewCommitTransaction returns a pointer to the
+CommitTransaction struct.
+*/
+func NewCommitTransaction() *CommitTransaction {
+	rv := &CommitTransaction{}
+
+	rv.stmt = rv
+	return rv
+}
+
+/*
+It calls the VisitCommitTransaction method by passing in the
+receiver and returns the interface. It is a visitor
+pattern.
+*/
+func (this *CommitTransaction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCommitTransaction(this)
+}
+
+/*
+Returns nil.
+*/
+func (this *CommitTransaction) Signature() value.Value {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *CommitTransaction) Formalize() error {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *CommitTransaction) MapExpressions(mapper expression.Mapper) error {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *CommitTransaction) Expressions() expression.Expressions {
+	return nil
+}
+
+/*
+No privileges are required, those of the statements run in the
+transaction are checked when they run.
+*/
+func (this *CommitTransaction) Privileges() (*auth.Privileges, errors.Error) {
+	return auth.NewPrivileges(), nil
+}
+
+/*
+Marshals input receiver into byte array.
+*/
+func (this *CommitTransaction) MarshalJSON() ([]byte, error) {
+	r := map[string]interface{}{"type": "commitTransaction"}
+	return json.Marshal(r)
+}
+
+func (this *CommitTransaction) Type() string {
+	return "COMMIT"
+}
@@ -0,0 +1,111 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the ROLLBACK WORK statement, which discards the
+mutations of the current transaction, or only those made after a
+savepoint when it has a TO SAVEPOINT clause.
+*/
+type RollbackTransaction struct {
+	statementBase
+
+	savepoint string `json:"savepoint"`
+}
+
+/*
+The function NewRollbackTransaction returns a pointer to the
+RollbackTransaction struct, with the savepoint to roll back to,
+or an empty string to roll back the whole transaction.
+*/
+func NewRollbackTransaction(savepoint string) *RollbackTransaction {
+	rv := &RollbackTransaction{
+		savepoint: savepoint,
+	}
+
+	rv.stmt = rv
+	return rv
+}
+
+/*
+It calls the VisitRollbackTransaction method by passing in the
+receiver and returns the interface. It is a visitor
+pattern.
+*/
+func (this *RollbackTransaction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitRollbackTransaction(this)
+}
+
+/*
+Returns nil.
+*/
+func (this *RollbackTransaction) Signature() value.Value {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *RollbackTransaction) Formalize() error {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *RollbackTransaction) MapExpressions(mapper expression.Mapper) error {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *RollbackTransaction) Expressions() expression.Expressions {
+	return nil
+}
+
+/*
+No privileges are required, those of the statements run in the
+transaction are checked when they run.
+*/
+func (this *RollbackTransaction) Privileges() (*auth.Privileges, errors.Error) {
+	return auth.NewPrivileges(), nil
+}
+
+/*
+Returns the savepoint to roll back to, or an empty string.
+*/
+func (this *RollbackTransaction) Savepoint() string {
+	return this.savepoint
+}
+
+/*
+Marshals input receiver into byte array.
+*/
+func (this *RollbackTransaction) MarshalJSON() ([]byte, error) {
+	r := map[string]interface{}{"type": "rollbackTransaction"}
+	if this.savepoint != "" {
+		r["savepoint"] = this.savepoint
+	}
+	return json.Marshal(r)
+}
+
+func (this *RollbackTransaction) Type() string {
+	return "ROLLBACK"
+}
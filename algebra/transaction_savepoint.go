@@ -0,0 +1,107 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the SAVEPOINT statement, which marks a point of the
+current transaction to which it can be rolled back.
+*/
+type Savepoint struct {
+	statementBase
+
+	savepoint string `json:"savepoint"`
+}
+
+/*
+The function NewSavepoint returns a pointer to the Savepoint
+struct with the input savepoint name as a field.
+*/
+func NewSavepoint(savepoint string) *Savepoint {
+	rv := &Savepoint{
+		savepoint: savepoint,
+	}
+
+	rv.stmt = rv
+	return rv
+}
+
+/*
+It calls the VisitSavepoint method by passing in the
+receiver and returns the interface. It is a visitor
+pattern.
+*/
+func (this *Savepoint) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitSavepoint(this)
+}
+
+/*
+Returns nil.
+*/
+func (this *Savepoint) Signature() value.Value {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *Savepoint) Formalize() error {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *Savepoint) MapExpressions(mapper expression.Mapper) error {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *Savepoint) Expressions() expression.Expressions {
+	return nil
+}
+
+/*
+No privileges are required, those of the statements run in the
+transaction are checked when they run.
+*/
+func (this *Savepoint) Privileges() (*auth.Privileges, errors.Error) {
+	return auth.NewPrivileges(), nil
+}
+
+/*
+Returns the name of the savepoint.
+*/
+func (this *Savepoint) Savepoint() string {
+	return this.savepoint
+}
+
+/*
+Marshals input receiver into byte array.
+*/
+func (this *Savepoint) MarshalJSON() ([]byte, error) {
+	r := map[string]interface{}{"type": "savepoint"}
+	r["savepoint"] = this.savepoint
+	return json.Marshal(r)
+}
+
+func (this *Savepoint) Type() string {
+	return "SAVEPOINT"
+}
@@ -0,0 +1,109 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the TRUNCATE statement, which removes all the documents
+from a keyspace at once, without mutating them one by one.
+*/
+type Truncate struct {
+	statementBase
+
+	keyspace *KeyspaceRef `json:"keyspace"`
+}
+
+/*
+The function NewTruncate returns a pointer to the Truncate
+struct with the input keyspace as a field.
+*/
+func NewTruncate(keyspace *KeyspaceRef) *Truncate {
+	rv := &Truncate{
+		keyspace: keyspace,
+	}
+
+	rv.stmt = rv
+	return rv
+}
+
+/*
+It calls the VisitTruncate method by passing in the
+receiver and returns the interface. It is a visitor
+pattern.
+*/
+func (this *Truncate) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitTruncate(this)
+}
+
+/*
+Returns nil.
+*/
+func (this *Truncate) Signature() value.Value {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *Truncate) Formalize() error {
+	return nil
+}
+
+/*
+Returns nil.
+*/
+func (this *Truncate) MapExpressions(mapper expression.Mapper) error {
+	return nil
+}
+
+/*
+Returns all contained Expressions.
+*/
+func (this *Truncate) Expressions() expression.Expressions {
+	return nil
+}
+
+/*
+Returns all required privileges.
+*/
+func (this *Truncate) Privileges() (*auth.Privileges, errors.Error) {
+	privs := auth.NewPrivileges()
+	fullName := this.keyspace.FullName()
+	privs.Add(fullName, auth.PRIV_QUERY_TRUNCATE)
+	return privs, nil
+}
+
+/*
+Return the keyspace.
+*/
+func (this *Truncate) Keyspace() *KeyspaceRef {
+	return this.keyspace
+}
+
+/*
+Marshals input receiver into byte array.
+*/
+func (this *Truncate) MarshalJSON() ([]byte, error) {
+	r := map[string]interface{}{"type": "truncate"}
+	r["keyspaceRef"] = this.keyspace
+	return json.Marshal(r)
+}
+
+func (this *Truncate) Type() string {
+	return "TRUNCATE"
+}
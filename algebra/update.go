@@ -22,14 +22,16 @@ Represents the UPDATE statement.
 type Update struct {
 	statementBase
 
-	keyspace  *KeyspaceRef          `json:"keyspace"`
-	keys      expression.Expression `json:"keys"`
-	indexes   IndexRefs             `json:"indexes"`
-	set       *Set                  `json:"set"`
-	unset     *Unset                `json:"unset"`
-	where     expression.Expression `json:"where"`
-	limit     expression.Expression `json:"limit"`
-	returning *Projection           `json:"returning"`
+	with       expression.Bindings   `json:"with"`
+	keyspace   *KeyspaceRef          `json:"keyspace"`
+	keys       expression.Expression `json:"keys"`
+	indexes    IndexRefs             `json:"indexes"`
+	set        *Set                  `json:"set"`
+	unset      *Unset                `json:"unset"`
+	where      expression.Expression `json:"where"`
+	limit      expression.Expression `json:"limit"`
+	returning  *Projection           `json:"returning"`
+	optimHints *OptimHints           `json:"optimizer_hints"`
 }
 
 func NewUpdate(keyspace *KeyspaceRef, keys expression.Expression, indexes IndexRefs,
@@ -79,6 +81,13 @@ func (this *Update) Type() string {
 Applies mapper to all the expressions in the UPDATE statement.
 */
 func (this *Update) MapExpressions(mapper expression.Mapper) (err error) {
+	if this.with != nil {
+		err = this.with.MapExpressions(mapper)
+		if err != nil {
+			return
+		}
+	}
+
 	if this.keys != nil {
 		this.keys, err = mapper.Map(this.keys)
 		if err != nil {
@@ -127,6 +136,10 @@ Returns all contained Expressions.
 func (this *Update) Expressions() expression.Expressions {
 	exprs := make(expression.Expressions, 0, 16)
 
+	if this.with != nil {
+		exprs = append(exprs, this.with.Expressions()...)
+	}
+
 	if this.keys != nil {
 		exprs = append(exprs, this.keys)
 	}
@@ -184,12 +197,17 @@ Fully qualify identifiers for each of the constituent clauses
 in the UPDATE statement.
 */
 func (this *Update) Formalize() (err error) {
+	empty, err := formalizeWiths(this.with)
+	if err != nil {
+		return err
+	}
+
 	f, err := this.keyspace.Formalize()
 	if err != nil {
 		return err
 	}
 
-	empty := expression.NewFormalizer("", nil)
+	f.SetWiths(this.with)
 
 	if this.keys != nil {
 		_, err = this.keys.Accept(empty)
@@ -233,6 +251,20 @@ func (this *Update) Formalize() (err error) {
 	return
 }
 
+/*
+Returns the WITH clause of the UPDATE statement.
+*/
+func (this *Update) With() expression.Bindings {
+	return this.with
+}
+
+/*
+Sets the WITH clause of the UPDATE statement.
+*/
+func (this *Update) SetWith(with expression.Bindings) {
+	this.with = with
+}
+
 /*
 Returns the keyspace-ref for the UPDATE statement.
 */
@@ -294,3 +326,17 @@ UPDATE statement.
 func (this *Update) Returning() *Projection {
 	return this.returning
 }
+
+/*
+Returns the optimizer hints of the UPDATE statement, or nil.
+*/
+func (this *Update) OptimHints() *OptimHints {
+	return this.optimHints
+}
+
+/*
+Sets the optimizer hints of the UPDATE statement.
+*/
+func (this *Update) SetOptimHints(optimHints *OptimHints) {
+	this.optimHints = optimHints
+}
@@ -0,0 +1,101 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Represents the UPDATE STATISTICS statement, also written ANALYZE
+KEYSPACE, which samples the documents of a keyspace, and stores the
+histograms and distinct counts of the values of the terms for use by
+the optimizer.
+*/
+type UpdateStatistics struct {
+	statementBase
+
+	keyspace *KeyspaceRef           `json:"keyspace"`
+	terms    expression.Expressions `json:"terms"`
+}
+
+func NewUpdateStatistics(keyspace *KeyspaceRef, terms expression.Expressions) *UpdateStatistics {
+	rv := &UpdateStatistics{
+		keyspace: keyspace,
+		terms:    terms,
+	}
+
+	rv.stmt = rv
+	return rv
+}
+
+func (this *UpdateStatistics) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitUpdateStatistics(this)
+}
+
+func (this *UpdateStatistics) Signature() value.Value {
+	return nil
+}
+
+/*
+The terms are formalized as index keys are, without the keyspace,
+so that they match the keys of the indexes.
+*/
+func (this *UpdateStatistics) Formalize() error {
+	f := expression.NewKeyspaceFormalizer(this.keyspace.Keyspace(), nil)
+	return this.MapExpressions(f)
+}
+
+func (this *UpdateStatistics) MapExpressions(mapper expression.Mapper) error {
+	return this.terms.MapExpressions(mapper)
+}
+
+func (this *UpdateStatistics) Expressions() expression.Expressions {
+	return this.terms
+}
+
+/*
+Returns all required privileges.
+*/
+func (this *UpdateStatistics) Privileges() (*auth.Privileges, errors.Error) {
+	privs := auth.NewPrivileges()
+	fullName := this.keyspace.FullName()
+	privs.Add(fullName, auth.PRIV_QUERY_SELECT)
+	privs.Add(fullName, auth.PRIV_QUERY_CREATE_INDEX)
+
+	for _, expr := range this.terms {
+		privs.AddAll(expr.Privileges())
+	}
+	return privs, nil
+}
+
+func (this *UpdateStatistics) Keyspace() *KeyspaceRef {
+	return this.keyspace
+}
+
+func (this *UpdateStatistics) Terms() expression.Expressions {
+	return this.terms
+}
+
+func (this *UpdateStatistics) MarshalJSON() ([]byte, error) {
+	r := map[string]interface{}{"type": "updateStatistics"}
+	r["keyspaceRef"] = this.keyspace
+	r["terms"] = this.terms
+	return json.Marshal(r)
+}
+
+func (this *UpdateStatistics) Type() string {
+	return "UPDATE_STATISTICS"
+}
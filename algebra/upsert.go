@@ -30,6 +30,7 @@ represents the returning clause. (Update and insert).
 type Upsert struct {
 	statementBase
 
+	with      expression.Bindings   `json:"with"`
 	keyspace  *KeyspaceRef          `json:"keyspace"`
 	key       expression.Expression `json:"key"`
 	value     expression.Expression `json:"value"`
@@ -103,6 +104,13 @@ func (this *Upsert) Signature() value.Value {
 Applies mapper to all the expressions in the upsert statement.
 */
 func (this *Upsert) MapExpressions(mapper expression.Mapper) (err error) {
+	if this.with != nil {
+		err = this.with.MapExpressions(mapper)
+		if err != nil {
+			return
+		}
+	}
+
 	if this.key != nil {
 		this.key, err = mapper.Map(this.key)
 		if err != nil {
@@ -144,6 +152,10 @@ Returns all contained Expressions.
 func (this *Upsert) Expressions() expression.Expressions {
 	exprs := make(expression.Expressions, 0, 16)
 
+	if this.with != nil {
+		exprs = append(exprs, this.with.Expressions()...)
+	}
+
 	if this.key != nil {
 		exprs = append(exprs, this.key)
 	}
@@ -206,8 +218,13 @@ Fully qualify identifiers for each of the constituent clauses
 in the upsert statement.
 */
 func (this *Upsert) Formalize() (err error) {
+	_, err = formalizeWiths(this.with)
+	if err != nil {
+		return
+	}
+
 	if this.values != nil {
-		f := expression.NewFormalizer("", nil)
+		f := newWithsFormalizer(this.with)
 		err = this.values.MapExpressions(f)
 		if err != nil {
 			return
@@ -215,7 +232,7 @@ func (this *Upsert) Formalize() (err error) {
 	}
 
 	if this.query != nil {
-		err = this.query.Formalize()
+		err = this.query.FormalizeSubquery(newWithsFormalizer(this.with))
 		if err != nil {
 			return
 		}
@@ -226,6 +243,8 @@ func (this *Upsert) Formalize() (err error) {
 		return err
 	}
 
+	f.SetWiths(this.with)
+
 	if this.returning != nil {
 		_, err = this.returning.Formalize(f)
 	}
@@ -233,6 +252,20 @@ func (this *Upsert) Formalize() (err error) {
 	return
 }
 
+/*
+Returns the WITH clause of the upsert statement.
+*/
+func (this *Upsert) With() expression.Bindings {
+	return this.with
+}
+
+/*
+Sets the WITH clause of the upsert statement.
+*/
+func (this *Upsert) SetWith(with expression.Bindings) {
+	this.with = with
+}
+
 /*
 Returns the keyspace-ref for the upsert statement.
 */
@@ -18,24 +18,27 @@ type Visitor interface {
 	/*
 	   Visitor for DML statements. N1QL provides several data
 	   modification statements such as INSERT, UPSERT, DELETE,
-	   UPDATE and MERGE.
+	   UPDATE, MERGE and TRUNCATE.
 	*/
 	VisitInsert(stmt *Insert) (interface{}, error)
 	VisitUpsert(stmt *Upsert) (interface{}, error)
 	VisitDelete(stmt *Delete) (interface{}, error)
 	VisitUpdate(stmt *Update) (interface{}, error)
 	VisitMerge(stmt *Merge) (interface{}, error)
+	VisitTruncate(stmt *Truncate) (interface{}, error)
 
 	/*
 	   Visitor for DDL statements. N1QL provides index
 	   statements CREATE PRIMARY INDEX, CREATE INDEX, DROP
-	   INDEX and ALTER INDEX as Data definition statements.
+	   INDEX and ALTER INDEX as Data definition statements, and
+	   UPDATE STATISTICS for the optimizer.
 	*/
 	VisitCreatePrimaryIndex(stmt *CreatePrimaryIndex) (interface{}, error)
 	VisitCreateIndex(stmt *CreateIndex) (interface{}, error)
 	VisitDropIndex(stmt *DropIndex) (interface{}, error)
 	VisitAlterIndex(stmt *AlterIndex) (interface{}, error)
 	VisitBuildIndexes(stmt *BuildIndexes) (interface{}, error)
+	VisitUpdateStatistics(stmt *UpdateStatistics) (interface{}, error)
 
 	/*
 	   Visitor for ROLES statements.
@@ -43,11 +46,31 @@ type Visitor interface {
 	VisitGrantRole(stmt *GrantRole) (interface{}, error)
 	VisitRevokeRole(stmt *RevokeRole) (interface{}, error)
 
+	/*
+	   Visitor for FUNCTION statements.
+	*/
+	VisitCreateFunction(stmt *CreateFunction) (interface{}, error)
+	VisitDropFunction(stmt *DropFunction) (interface{}, error)
+	VisitExecuteFunction(stmt *ExecuteFunction) (interface{}, error)
+
+	/*
+	   Visitor for TRANSACTION statements.
+	*/
+	VisitStartTransaction(stmt *StartTransaction) (interface{}, error)
+	VisitCommitTransaction(stmt *CommitTransaction) (interface{}, error)
+	VisitRollbackTransaction(stmt *RollbackTransaction) (interface{}, error)
+	VisitSavepoint(stmt *Savepoint) (interface{}, error)
+
 	/*
 	   Visitor for EXPLAIN statements.
 	*/
 	VisitExplain(stmt *Explain) (interface{}, error)
 
+	/*
+	   Visitor for ADVISE statements.
+	*/
+	VisitAdvise(stmt *Advise) (interface{}, error)
+
 	/*
 	   Visitor for PREPARED statements.
 	*/
@@ -79,6 +102,7 @@ type NodeVisitor interface {
 	VisitUnnest(node *Unnest) (interface{}, error)
 	VisitUnion(node *Union) (interface{}, error)
 	VisitUnionAll(node *UnionAll) (interface{}, error)
+	VisitRecursiveUnion(node *RecursiveUnion) (interface{}, error)
 	VisitIntersect(node *Intersect) (interface{}, error)
 	VisitIntersectAll(node *IntersectAll) (interface{}, error)
 	VisitExcept(node *Except) (interface{}, error)
@@ -0,0 +1,323 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"bytes"
+
+	"github.com/couchbase/query/expression"
+)
+
+/*
+Window frame units. ROWS frames are delimited by physical row
+offsets, RANGE frames by logical offsets on the ORDER BY key.
+*/
+const (
+	WINDOW_FRAME_ROWS = iota
+	WINDOW_FRAME_RANGE
+)
+
+/*
+Window frame extent types, in frame order.
+*/
+const (
+	WINDOW_UNBOUNDED_PRECEDING = iota
+	WINDOW_VALUE_PRECEDING
+	WINDOW_CURRENT_ROW
+	WINDOW_VALUE_FOLLOWING
+	WINDOW_UNBOUNDED_FOLLOWING
+)
+
+/*
+Represents the OVER clause of a window function:
+
+OVER ([PARTITION BY exprs] [ORDER BY sort_terms] [frame])
+*/
+type WindowTerm struct {
+	partitionBy expression.Expressions
+	orderBy     *Order
+	frame       *WindowFrame
+}
+
+func NewWindowTerm(partitionBy expression.Expressions, orderBy *Order, frame *WindowFrame) *WindowTerm {
+	return &WindowTerm{
+		partitionBy: partitionBy,
+		orderBy:     orderBy,
+		frame:       frame,
+	}
+}
+
+/*
+Returns the PARTITION BY expressions.
+*/
+func (this *WindowTerm) PartitionBy() expression.Expressions {
+	return this.partitionBy
+}
+
+/*
+Returns the ORDER BY clause, or nil.
+*/
+func (this *WindowTerm) OrderBy() *Order {
+	return this.orderBy
+}
+
+/*
+Returns the frame clause, or nil.
+*/
+func (this *WindowTerm) Frame() *WindowFrame {
+	return this.frame
+}
+
+/*
+Returns the sort terms required to bring the input into window
+order: the PARTITION BY expressions, followed by the ORDER BY terms.
+*/
+func (this *WindowTerm) SortTerms() SortTerms {
+	terms := make(SortTerms, 0, len(this.partitionBy)+len(this.orderTerms()))
+	for _, expr := range this.partitionBy {
+		terms = append(terms, NewSortTerm(expr, false, NULLS_DEFAULT))
+	}
+
+	return append(terms, this.orderTerms()...)
+}
+
+func (this *WindowTerm) orderTerms() SortTerms {
+	if this.orderBy == nil {
+		return nil
+	}
+
+	return this.orderBy.Terms()
+}
+
+/*
+Returns all contained Expressions.
+*/
+func (this *WindowTerm) Expressions() expression.Expressions {
+	exprs := make(expression.Expressions, 0, len(this.partitionBy)+len(this.orderTerms())+2)
+	exprs = append(exprs, this.partitionBy...)
+
+	if this.orderBy != nil {
+		exprs = append(exprs, this.orderBy.Expressions()...)
+	}
+
+	if this.frame != nil {
+		exprs = append(exprs, this.frame.Expressions()...)
+	}
+
+	return exprs
+}
+
+/*
+Map all contained Expressions.
+*/
+func (this *WindowTerm) MapExpressions(mapper expression.Mapper) (err error) {
+	if this.partitionBy != nil {
+		err = this.partitionBy.MapExpressions(mapper)
+		if err != nil {
+			return
+		}
+	}
+
+	if this.orderBy != nil {
+		err = this.orderBy.MapExpressions(mapper)
+		if err != nil {
+			return
+		}
+	}
+
+	if this.frame != nil {
+		err = this.frame.MapExpressions(mapper)
+	}
+
+	return
+}
+
+/*
+Deep copy.
+*/
+func (this *WindowTerm) Copy() *WindowTerm {
+	var partitionBy expression.Expressions
+	if this.partitionBy != nil {
+		partitionBy = this.partitionBy.Copy()
+	}
+
+	var orderBy *Order
+	if this.orderBy != nil {
+		terms := make(SortTerms, len(this.orderBy.Terms()))
+		for i, term := range this.orderBy.Terms() {
+			terms[i] = NewSortTerm(term.Expression().Copy(), term.Descending(), term.NullsPos())
+		}
+		orderBy = NewOrder(terms)
+	}
+
+	var frame *WindowFrame
+	if this.frame != nil {
+		frame = this.frame.Copy()
+	}
+
+	return NewWindowTerm(partitionBy, orderBy, frame)
+}
+
+/*
+Representation as a N1QL string.
+*/
+func (this *WindowTerm) String() string {
+	var buf bytes.Buffer
+	buf.WriteString(" over (")
+	sep := ""
+
+	if len(this.partitionBy) > 0 {
+		buf.WriteString("partition by ")
+		for i, expr := range this.partitionBy {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(expr.String())
+		}
+		sep = " "
+	}
+
+	if this.orderBy != nil {
+		buf.WriteString(sep)
+		buf.WriteString("order by ")
+		buf.WriteString(this.orderBy.Terms().String())
+		sep = " "
+	}
+
+	if this.frame != nil {
+		buf.WriteString(sep)
+		buf.WriteString(this.frame.String())
+	}
+
+	buf.WriteString(")")
+	return buf.String()
+}
+
+/*
+Represents the frame clause of a window term:
+
+{ROWS | RANGE} BETWEEN start AND end
+*/
+type WindowFrame struct {
+	units int
+	start *WindowFrameExtent
+	end   *WindowFrameExtent
+}
+
+func NewWindowFrame(units int, start, end *WindowFrameExtent) *WindowFrame {
+	return &WindowFrame{
+		units: units,
+		start: start,
+		end:   end,
+	}
+}
+
+/*
+Returns WINDOW_FRAME_ROWS or WINDOW_FRAME_RANGE.
+*/
+func (this *WindowFrame) Units() int {
+	return this.units
+}
+
+func (this *WindowFrame) Start() *WindowFrameExtent {
+	return this.start
+}
+
+func (this *WindowFrame) End() *WindowFrameExtent {
+	return this.end
+}
+
+/*
+Returns the offset expressions of the extents.
+*/
+func (this *WindowFrame) Expressions() expression.Expressions {
+	exprs := make(expression.Expressions, 0, 2)
+	for _, extent := range []*WindowFrameExtent{this.start, this.end} {
+		if extent.valueExpr != nil {
+			exprs = append(exprs, extent.valueExpr)
+		}
+	}
+
+	return exprs
+}
+
+func (this *WindowFrame) MapExpressions(mapper expression.Mapper) (err error) {
+	for _, extent := range []*WindowFrameExtent{this.start, this.end} {
+		if extent.valueExpr != nil {
+			extent.valueExpr, err = mapper.Map(extent.valueExpr)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (this *WindowFrame) Copy() *WindowFrame {
+	return NewWindowFrame(this.units, this.start.Copy(), this.end.Copy())
+}
+
+func (this *WindowFrame) String() string {
+	s := "rows"
+	if this.units == WINDOW_FRAME_RANGE {
+		s = "range"
+	}
+
+	return s + " between " + this.start.String() + " and " + this.end.String()
+}
+
+/*
+Represents one bound of a window frame. valueExpr is the offset of
+WINDOW_VALUE_PRECEDING and WINDOW_VALUE_FOLLOWING extents.
+*/
+type WindowFrameExtent struct {
+	extentType int
+	valueExpr  expression.Expression
+}
+
+func NewWindowFrameExtent(valueExpr expression.Expression, extentType int) *WindowFrameExtent {
+	return &WindowFrameExtent{
+		extentType: extentType,
+		valueExpr:  valueExpr,
+	}
+}
+
+func (this *WindowFrameExtent) Type() int {
+	return this.extentType
+}
+
+func (this *WindowFrameExtent) ValueExpression() expression.Expression {
+	return this.valueExpr
+}
+
+func (this *WindowFrameExtent) Copy() *WindowFrameExtent {
+	var valueExpr expression.Expression
+	if this.valueExpr != nil {
+		valueExpr = this.valueExpr.Copy()
+	}
+
+	return NewWindowFrameExtent(valueExpr, this.extentType)
+}
+
+func (this *WindowFrameExtent) String() string {
+	switch this.extentType {
+	case WINDOW_UNBOUNDED_PRECEDING:
+		return "unbounded preceding"
+	case WINDOW_VALUE_PRECEDING:
+		return this.valueExpr.String() + " preceding"
+	case WINDOW_VALUE_FOLLOWING:
+		return this.valueExpr.String() + " following"
+	case WINDOW_UNBOUNDED_FOLLOWING:
+		return "unbounded following"
+	default:
+		return "current row"
+	}
+}
@@ -0,0 +1,258 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"fmt"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+type WindowFunctions []WindowFunction
+
+/*
+The WindowFunction interface represents functions that are computed
+over a window of rows defined by an OVER clause. These are the
+ranking and navigation functions such as ROW_NUMBER() and LAG(), and
+aggregates followed by an OVER clause.
+
+Window functions are computed after grouping, on input sorted by the
+PARTITION BY and ORDER BY terms of their OVER clause. The results are
+stored in the "aggregates" attachment of each row, like those of
+group aggregates.
+*/
+type WindowFunction interface {
+	expression.Function
+
+	/*
+	   Returns the OVER clause, or nil.
+	*/
+	WindowTerm() *WindowTerm
+
+	/*
+	   Sets the OVER clause.
+	*/
+	SetWindowTerm(wTerm *WindowTerm)
+}
+
+/*
+Ranking and navigation functions. Unlike aggregates, these are only
+valid with an OVER clause.
+*/
+type WindowOnlyFunction interface {
+	WindowFunction
+
+	/*
+	   True if the function is computed over the window frame, false
+	   if it is computed over the whole partition.
+	*/
+	UsesFrame() bool
+
+	/*
+	   True if the OVER clause must have an ORDER BY.
+	*/
+	RequiresOrder() bool
+
+	/*
+	   Computes the function for the current row of the window.
+	*/
+	EvaluateWindow(state *WindowState, context Context) (value.Value, error)
+}
+
+/*
+The position of the current row within its window partition. Rows
+holds the partition, sorted in window order. Peers are the rows
+sharing the ORDER BY values of the current row; PeerGroup numbers
+the distinct ORDER BY values from 0. The frame spans Rows[FrameStart:
+FrameEnd].
+*/
+type WindowState struct {
+	Rows       value.AnnotatedValues
+	Current    int
+	PeerStart  int
+	PeerEnd    int
+	PeerGroup  int
+	FrameStart int
+	FrameEnd   int
+}
+
+/*
+Base class for ranking and navigation functions.
+*/
+type WindowFunctionBase struct {
+	expression.FunctionBase
+	wTerm *WindowTerm
+}
+
+func NewWindowFunctionBase(name string, operands ...expression.Expression) *WindowFunctionBase {
+	return &WindowFunctionBase{
+		FunctionBase: *expression.NewFunctionBase(name, operands...),
+	}
+}
+
+/*
+Retrieve the result from the aggregates attachment, where it was
+stored by the WindowAggregate operator.
+*/
+func (this *WindowFunctionBase) evaluate(fn WindowFunction, item value.Value,
+	context expression.Context) (result value.Value, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("Error evaluating window function: %v.", r)
+		}
+	}()
+
+	av := item.(value.AnnotatedValue)
+	aggregates := av.GetAttachment("aggregates")
+	if aggregates != nil {
+		aggs := aggregates.(map[string]value.Value)
+		result = aggs[fn.String()]
+	}
+
+	if result == nil {
+		err = fmt.Errorf("Window function %s not found.", fn.String())
+	}
+
+	return
+}
+
+/*
+Not constant.
+*/
+func (this *WindowFunctionBase) Value() value.Value {
+	return nil
+}
+
+/*
+Not static.
+*/
+func (this *WindowFunctionBase) Static() expression.Expression {
+	return nil
+}
+
+/*
+Not indexable.
+*/
+func (this *WindowFunctionBase) Indexable() bool {
+	return false
+}
+
+func (this *WindowFunctionBase) EquivalentTo(other expression.Expression) bool {
+	otherFunction, ok := other.(WindowFunction)
+	return ok && this.Name() == otherFunction.Name() &&
+		expression.Equivalents(this.Children(), otherFunction.Children()) &&
+		windowTermsEquivalent(this.wTerm, otherFunction.WindowTerm())
+}
+
+/*
+Return the operands, followed by the expressions of the OVER clause.
+*/
+func (this *WindowFunctionBase) Children() expression.Expressions {
+	operands := this.Operands()
+	if this.wTerm == nil {
+		return operands
+	}
+
+	return append(operands[0:len(operands):len(operands)], this.wTerm.Expressions()...)
+}
+
+func (this *WindowFunctionBase) MapChildren(mapper expression.Mapper) error {
+	err := this.FunctionBase.MapChildren(mapper)
+	if err != nil {
+		return err
+	}
+
+	if this.wTerm != nil {
+		return this.wTerm.MapExpressions(mapper)
+	}
+
+	return nil
+}
+
+/*
+Copy the function, including its OVER clause.
+*/
+func (this *WindowFunctionBase) Copy() expression.Expression {
+	rv := this.FunctionBase.Copy()
+	if this.wTerm != nil {
+		rv.(WindowFunction).SetWindowTerm(this.wTerm.Copy())
+	}
+
+	return rv
+}
+
+func (this *WindowFunctionBase) WindowTerm() *WindowTerm {
+	return this.wTerm
+}
+
+func (this *WindowFunctionBase) SetWindowTerm(wTerm *WindowTerm) {
+	this.wTerm = wTerm
+}
+
+/*
+Returns the OVER clause as a N1QL string, for the Stringer.
+*/
+func (this *WindowFunctionBase) Suffix() string {
+	if this.wTerm == nil {
+		return ""
+	}
+
+	return this.wTerm.String()
+}
+
+/*
+By default, computed over the whole partition.
+*/
+func (this *WindowFunctionBase) UsesFrame() bool {
+	return false
+}
+
+/*
+By default, the OVER clause must have an ORDER BY.
+*/
+func (this *WindowFunctionBase) RequiresOrder() bool {
+	return true
+}
+
+func windowTermsEquivalent(wTerm1, wTerm2 *WindowTerm) bool {
+	if wTerm1 == nil || wTerm2 == nil {
+		return wTerm1 == wTerm2
+	}
+
+	return wTerm1.String() == wTerm2.String()
+}
+
+/*
+Evaluate an offset argument against the current row. The offset must
+be a non-negative integer.
+*/
+func windowOffset(name string, expr expression.Expression, state *WindowState,
+	context Context) (int, error) {
+	val, err := expr.Evaluate(state.Rows[state.Current], context)
+	if err != nil {
+		return 0, err
+	}
+
+	actual := val.ActualForIndex()
+	switch actual := actual.(type) {
+	case int64:
+		if actual >= 0 {
+			return int(actual), nil
+		}
+	case float64:
+		if value.IsInt(actual) && actual >= 0 {
+			return int(actual), nil
+		}
+	}
+
+	return 0, fmt.Errorf("Invalid offset %v for window function %s.", val, name)
+}
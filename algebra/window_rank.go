@@ -0,0 +1,226 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"fmt"
+
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+///////////////////////////////////////////////////
+//
+// RowNumber
+//
+///////////////////////////////////////////////////
+
+/*
+This represents the window function ROW_NUMBER(). It returns the
+1-based position of the current row within its partition.
+*/
+type RowNumber struct {
+	WindowFunctionBase
+}
+
+func NewRowNumber(operands ...expression.Expression) expression.Function {
+	rv := &RowNumber{
+		*NewWindowFunctionBase("row_number"),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *RowNumber) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *RowNumber) Type() value.Type { return value.NUMBER }
+
+func (this *RowNumber) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *RowNumber) Constructor() expression.FunctionConstructor {
+	return NewRowNumber
+}
+
+func (this *RowNumber) MinArgs() int { return 0 }
+
+func (this *RowNumber) MaxArgs() int { return 0 }
+
+/*
+ROW_NUMBER() does not require an ORDER BY; rows are then numbered in
+an arbitrary order.
+*/
+func (this *RowNumber) RequiresOrder() bool { return false }
+
+func (this *RowNumber) EvaluateWindow(state *WindowState, context Context) (value.Value, error) {
+	return value.NewValue(int64(state.Current + 1)), nil
+}
+
+///////////////////////////////////////////////////
+//
+// Rank
+//
+///////////////////////////////////////////////////
+
+/*
+This represents the window function RANK(). Peers receive the same
+rank, and gaps are left after peer groups.
+*/
+type Rank struct {
+	WindowFunctionBase
+}
+
+func NewRank(operands ...expression.Expression) expression.Function {
+	rv := &Rank{
+		*NewWindowFunctionBase("rank"),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *Rank) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *Rank) Type() value.Type { return value.NUMBER }
+
+func (this *Rank) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *Rank) Constructor() expression.FunctionConstructor {
+	return NewRank
+}
+
+func (this *Rank) MinArgs() int { return 0 }
+
+func (this *Rank) MaxArgs() int { return 0 }
+
+func (this *Rank) EvaluateWindow(state *WindowState, context Context) (value.Value, error) {
+	return value.NewValue(int64(state.PeerStart + 1)), nil
+}
+
+///////////////////////////////////////////////////
+//
+// DenseRank
+//
+///////////////////////////////////////////////////
+
+/*
+This represents the window function DENSE_RANK(). Peers receive the
+same rank, and no gaps are left after peer groups.
+*/
+type DenseRank struct {
+	WindowFunctionBase
+}
+
+func NewDenseRank(operands ...expression.Expression) expression.Function {
+	rv := &DenseRank{
+		*NewWindowFunctionBase("dense_rank"),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *DenseRank) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *DenseRank) Type() value.Type { return value.NUMBER }
+
+func (this *DenseRank) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *DenseRank) Constructor() expression.FunctionConstructor {
+	return NewDenseRank
+}
+
+func (this *DenseRank) MinArgs() int { return 0 }
+
+func (this *DenseRank) MaxArgs() int { return 0 }
+
+func (this *DenseRank) EvaluateWindow(state *WindowState, context Context) (value.Value, error) {
+	return value.NewValue(int64(state.PeerGroup + 1)), nil
+}
+
+///////////////////////////////////////////////////
+//
+// Ntile
+//
+///////////////////////////////////////////////////
+
+/*
+This represents the window function NTILE(n). It divides the
+partition into n buckets of as equal size as possible, and returns
+the 1-based bucket number of the current row. Larger buckets come
+first.
+*/
+type Ntile struct {
+	WindowFunctionBase
+}
+
+func NewNtile(operands ...expression.Expression) expression.Function {
+	rv := &Ntile{
+		*NewWindowFunctionBase("ntile", operands...),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *Ntile) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *Ntile) Type() value.Type { return value.NUMBER }
+
+func (this *Ntile) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *Ntile) Constructor() expression.FunctionConstructor {
+	return NewNtile
+}
+
+func (this *Ntile) MinArgs() int { return 1 }
+
+func (this *Ntile) MaxArgs() int { return 1 }
+
+func (this *Ntile) EvaluateWindow(state *WindowState, context Context) (value.Value, error) {
+	n, err := windowOffset(this.Name(), this.Operands()[0], state, context)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		return nil, fmt.Errorf("Invalid number of buckets 0 for window function %s.", this.Name())
+	}
+
+	size := len(state.Rows)
+	small := size / n
+	large := small + 1
+	numLarge := size % n
+
+	var bucket int
+	if state.Current < numLarge*large {
+		bucket = state.Current / large
+	} else {
+		bucket = numLarge + (state.Current-numLarge*large)/small
+	}
+
+	return value.NewValue(int64(bucket + 1)), nil
+}
@@ -0,0 +1,245 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+///////////////////////////////////////////////////
+//
+// Lag
+//
+///////////////////////////////////////////////////
+
+/*
+This represents the window function LAG(expr [, offset [, default]]).
+It returns expr evaluated on the row offset rows before the current
+row in its partition, or default if there is no such row. offset
+defaults to 1, and default to NULL.
+*/
+type Lag struct {
+	WindowFunctionBase
+}
+
+func NewLag(operands ...expression.Expression) expression.Function {
+	rv := &Lag{
+		*NewWindowFunctionBase("lag", operands...),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *Lag) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *Lag) Type() value.Type { return value.JSON }
+
+func (this *Lag) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *Lag) Constructor() expression.FunctionConstructor {
+	return NewLag
+}
+
+func (this *Lag) MinArgs() int { return 1 }
+
+func (this *Lag) MaxArgs() int { return 3 }
+
+func (this *Lag) EvaluateWindow(state *WindowState, context Context) (value.Value, error) {
+	return evaluateOffsetRow(this, -1, state, context)
+}
+
+///////////////////////////////////////////////////
+//
+// Lead
+//
+///////////////////////////////////////////////////
+
+/*
+This represents the window function LEAD(expr [, offset [, default]]).
+It returns expr evaluated on the row offset rows after the current
+row in its partition, or default if there is no such row. offset
+defaults to 1, and default to NULL.
+*/
+type Lead struct {
+	WindowFunctionBase
+}
+
+func NewLead(operands ...expression.Expression) expression.Function {
+	rv := &Lead{
+		*NewWindowFunctionBase("lead", operands...),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *Lead) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *Lead) Type() value.Type { return value.JSON }
+
+func (this *Lead) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *Lead) Constructor() expression.FunctionConstructor {
+	return NewLead
+}
+
+func (this *Lead) MinArgs() int { return 1 }
+
+func (this *Lead) MaxArgs() int { return 3 }
+
+func (this *Lead) EvaluateWindow(state *WindowState, context Context) (value.Value, error) {
+	return evaluateOffsetRow(this, 1, state, context)
+}
+
+/*
+Shared by LAG() and LEAD(); direction is -1 or 1.
+*/
+func evaluateOffsetRow(fn WindowFunction, direction int, state *WindowState,
+	context Context) (value.Value, error) {
+	operands := fn.Operands()
+
+	offset := 1
+	if len(operands) > 1 {
+		var err error
+		offset, err = windowOffset(fn.Name(), operands[1], state, context)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pos := state.Current + direction*offset
+	if pos >= 0 && pos < len(state.Rows) {
+		return operands[0].Evaluate(state.Rows[pos], context)
+	}
+
+	if len(operands) > 2 {
+		return operands[2].Evaluate(state.Rows[state.Current], context)
+	}
+
+	return value.NULL_VALUE, nil
+}
+
+///////////////////////////////////////////////////
+//
+// FirstValue
+//
+///////////////////////////////////////////////////
+
+/*
+This represents the window function FIRST_VALUE(expr). It returns
+expr evaluated on the first row of the window frame, or NULL if the
+frame is empty.
+*/
+type FirstValue struct {
+	WindowFunctionBase
+}
+
+func NewFirstValue(operands ...expression.Expression) expression.Function {
+	rv := &FirstValue{
+		*NewWindowFunctionBase("first_value", operands...),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *FirstValue) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *FirstValue) Type() value.Type { return value.JSON }
+
+func (this *FirstValue) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *FirstValue) Constructor() expression.FunctionConstructor {
+	return NewFirstValue
+}
+
+func (this *FirstValue) MinArgs() int { return 1 }
+
+func (this *FirstValue) MaxArgs() int { return 1 }
+
+func (this *FirstValue) UsesFrame() bool { return true }
+
+func (this *FirstValue) RequiresOrder() bool { return false }
+
+func (this *FirstValue) EvaluateWindow(state *WindowState, context Context) (value.Value, error) {
+	if state.FrameStart >= state.FrameEnd {
+		return value.NULL_VALUE, nil
+	}
+
+	return this.Operands()[0].Evaluate(state.Rows[state.FrameStart], context)
+}
+
+///////////////////////////////////////////////////
+//
+// LastValue
+//
+///////////////////////////////////////////////////
+
+/*
+This represents the window function LAST_VALUE(expr). It returns
+expr evaluated on the last row of the window frame, or NULL if the
+frame is empty.
+*/
+type LastValue struct {
+	WindowFunctionBase
+}
+
+func NewLastValue(operands ...expression.Expression) expression.Function {
+	rv := &LastValue{
+		*NewWindowFunctionBase("last_value", operands...),
+	}
+
+	rv.SetExpr(rv)
+	return rv
+}
+
+func (this *LastValue) Accept(visitor expression.Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *LastValue) Type() value.Type { return value.JSON }
+
+func (this *LastValue) Evaluate(item value.Value, context expression.Context) (value.Value, error) {
+	return this.evaluate(this, item, context)
+}
+
+func (this *LastValue) Constructor() expression.FunctionConstructor {
+	return NewLastValue
+}
+
+func (this *LastValue) MinArgs() int { return 1 }
+
+func (this *LastValue) MaxArgs() int { return 1 }
+
+func (this *LastValue) UsesFrame() bool { return true }
+
+func (this *LastValue) RequiresOrder() bool { return false }
+
+func (this *LastValue) EvaluateWindow(state *WindowState, context Context) (value.Value, error) {
+	if state.FrameStart >= state.FrameEnd {
+		return value.NULL_VALUE, nil
+	}
+
+	return this.Operands()[0].Evaluate(state.Rows[state.FrameEnd-1], context)
+}
@@ -0,0 +1,119 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package algebra
+
+import (
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+)
+
+/*
+The WITH clause of a statement binds aliases to non-correlated
+subqueries (common table expressions). A WITH alias can be referenced
+like a keyspace in the FROM clause of the statement and of its
+subqueries. All references share the same Subquery, whose results are
+cached by the execution context, so that each WITH subquery is
+evaluated at most once per request.
+*/
+
+/*
+Qualify all identifiers of the WITH clause. Each WITH subquery can
+reference the aliases that precede it. Returns a formalizer for the
+body of the statement.
+*/
+func formalizeWiths(withs expression.Bindings) (*expression.Formalizer, error) {
+	for i, b := range withs {
+		for _, prev := range withs[0:i] {
+			if prev.Variable() == b.Variable() {
+				return nil, errors.NewDuplicateAliasError("WITH clause", b.Variable(),
+					"semantics.with.duplicate_alias")
+			}
+		}
+
+		f := newWithsFormalizer(withs[0:i])
+		expr, err := f.Map(b.Expression())
+		if err != nil {
+			return nil, err
+		}
+
+		b.SetExpression(expr)
+	}
+
+	return newWithsFormalizer(withs), nil
+}
+
+/*
+Returns an empty formalizer that can reference the given WITH
+aliases.
+*/
+func newWithsFormalizer(withs expression.Bindings) *expression.Formalizer {
+	f := expression.NewFormalizer("", nil)
+	f.SetWiths(withs)
+	return f
+}
+
+/*
+Returns the privileges required by the WITH subqueries.
+*/
+func withsPrivileges(withs expression.Bindings) (*auth.Privileges, errors.Error) {
+	if len(withs) == 0 {
+		return auth.NewPrivileges(), nil
+	}
+
+	return subqueryPrivileges(withs.Expressions())
+}
+
+/*
+Representation as a N1QL string.
+*/
+func stringWiths(withs expression.Bindings) string {
+	if len(withs) == 0 {
+		return ""
+	}
+
+	s := "with "
+	for _, b := range withs {
+		if recursiveWith(b) != nil {
+			s += "recursive "
+			break
+		}
+	}
+
+	for i, b := range withs {
+		if i > 0 {
+			s += ", "
+		}
+
+		s += "`"
+		s += b.Variable()
+		s += "` as "
+		s += b.Expression().String()
+
+		if ru := recursiveWith(b); ru != nil {
+			s += ru.ClausesString()
+		}
+	}
+
+	return s + " "
+}
+
+/*
+Returns the body of a WITH RECURSIVE term, or nil if the binding is
+not a WITH RECURSIVE term.
+*/
+func recursiveWith(b *expression.Binding) *RecursiveUnion {
+	if sq, ok := b.Expression().(*Subquery); ok {
+		ru, _ := sq.Select().Subresult().(*RecursiveUnion)
+		return ru
+	}
+
+	return nil
+}
@@ -23,15 +23,16 @@ import (
 
 func opIsUnimplemented(namespace, bucket string, requested auth.Privilege) bool {
 	if namespace == "#system" {
-		// For system monitoring tables INSERT and UPDATE are not supported.
+		// For system monitoring tables INSERT, UPDATE and TRUNCATE are not supported.
 		if bucket == "prepareds" || bucket == "completed_requests" || bucket == "active_requests" {
-			if requested == auth.PRIV_QUERY_UPDATE || requested == auth.PRIV_QUERY_INSERT {
+			if requested == auth.PRIV_QUERY_UPDATE || requested == auth.PRIV_QUERY_INSERT || requested == auth.PRIV_QUERY_TRUNCATE {
 				return true
 			}
 			return false
 		}
-		// For other system buckets, INSERT/UPDATE/DELETE are not supported.
-		if requested == auth.PRIV_QUERY_UPDATE || requested == auth.PRIV_QUERY_INSERT || requested == auth.PRIV_QUERY_DELETE {
+		// For other system buckets, INSERT/UPDATE/DELETE/TRUNCATE are not supported.
+		if requested == auth.PRIV_QUERY_UPDATE || requested == auth.PRIV_QUERY_INSERT || requested == auth.PRIV_QUERY_DELETE ||
+			requested == auth.PRIV_QUERY_TRUNCATE {
 			return true
 		}
 		return false
@@ -72,6 +73,12 @@ func privilegeString(namespace, bucket string, requested auth.Privilege) (string
 		permission = fmt.Sprintf("cluster.bucket[%s].n1ql.index!list", bucket)
 	case auth.PRIV_QUERY_EXTERNAL_ACCESS:
 		permission = "cluster.n1ql.curl!execute"
+	case auth.PRIV_QUERY_MANAGE_FUNCTIONS:
+		permission = "cluster.n1ql.udf!manage"
+	case auth.PRIV_QUERY_EXECUTE_FUNCTIONS:
+		permission = "cluster.n1ql.udf!execute"
+	case auth.PRIV_QUERY_TRUNCATE:
+		permission = fmt.Sprintf("cluster.bucket[%s]!flush", bucket)
 	default:
 		return "", fmt.Errorf("Invalid Privileges")
 	}
@@ -353,6 +360,15 @@ func messageForDeniedPrivilege(pair auth.PrivilegePair) string {
 	case auth.PRIV_QUERY_EXTERNAL_ACCESS:
 		privilege = "queries using the CURL() function"
 		role = "query_external_access"
+	case auth.PRIV_QUERY_MANAGE_FUNCTIONS:
+		privilege = "queries creating or dropping functions"
+		role = "query_manage_functions"
+	case auth.PRIV_QUERY_EXECUTE_FUNCTIONS:
+		privilege = "queries using user defined functions"
+		role = "query_execute_functions"
+	case auth.PRIV_QUERY_TRUNCATE:
+		privilege = fmt.Sprintf("TRUNCATE queries on the %s bucket", keyspace)
+		role = fmt.Sprintf("bucket_admin on %s", keyspace)
 	default:
 		privilege = "this type of query"
 		role = "admin"
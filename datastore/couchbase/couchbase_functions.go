@@ -0,0 +1,50 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package couchbase
+
+import (
+	"strings"
+
+	"github.com/couchbase/cbauth/metakv"
+	"github.com/couchbase/query/errors"
+)
+
+// The definitions of user defined functions are kept in metakv, one
+// entry per function, so that all the query nodes share them
+const _FUNCTIONS_META_DIR = "/query/functions/"
+
+func (s *store) FunctionDefinitions() (map[string]string, errors.Error) {
+	entries, err := metakv.ListAllChildren(_FUNCTIONS_META_DIR)
+	if err != nil {
+		return nil, errors.NewCbMetaKVError(err, "")
+	}
+
+	definitions := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		definitions[strings.TrimPrefix(entry.Path, _FUNCTIONS_META_DIR)] = string(entry.Value)
+	}
+	return definitions, nil
+}
+
+func (s *store) SetFunctionDefinition(name, definition string) errors.Error {
+	err := metakv.Set(_FUNCTIONS_META_DIR+name, []byte(definition), nil)
+	if err != nil {
+		return errors.NewCbMetaKVError(err, name)
+	}
+	return nil
+}
+
+func (s *store) DeleteFunctionDefinition(name string) errors.Error {
+	err := metakv.Delete(_FUNCTIONS_META_DIR+name, nil)
+	if err != nil {
+		return errors.NewCbMetaKVError(err, name)
+	}
+	return nil
+}
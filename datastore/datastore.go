@@ -117,6 +117,16 @@ type Keyspace interface {
 	Release() // Release any resources held by this object
 }
 
+/*
+TruncateKeyspace is implemented by keyspaces that can remove all their
+key-value entries at once, for the TRUNCATE statement.
+*/
+type TruncateKeyspace interface {
+	Keyspace
+
+	Truncate(context QueryContext) errors.Error // Remove all key-value entries from this keyspace
+}
+
 // Globally accessible Datastore instance
 var _DATASTORE Datastore
 var _SYSTEMSTORE Datastore
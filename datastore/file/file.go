@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -42,6 +43,8 @@ type store struct {
 	namespaceNames []string
 
 	users map[string]*datastore.User
+
+	functionsLock sync.Mutex // serializes updates of the function definitions
 }
 
 func (s *store) Id() string {
@@ -157,6 +160,11 @@ func NewDatastore(path string) (s datastore.Datastore, e errors.Error) {
 
 	fs := &store{path: path, users: make(map[string]*datastore.User, 4)}
 
+	e = fs.recoverCommits()
+	if e != nil {
+		return
+	}
+
 	e = fs.loadNamespaces()
 	if e != nil {
 		return
@@ -289,8 +297,10 @@ func (p *namespace) loadKeyspaces() (e errors.Error) {
 type keyspace struct {
 	namespace *namespace
 	name      string
-	fi        datastore.Indexer
+	fi        *fileIndexer
 	fileLock  sync.Mutex
+	statsLock sync.RWMutex
+	stats     []*datastore.ExpressionStatistics // cached optimizer statistics
 }
 
 func (b *keyspace) NamespaceId() string {
@@ -314,7 +324,39 @@ func (b *keyspace) Count(context datastore.QueryContext) (int64, errors.Error) {
 	if er != nil {
 		return 0, errors.NewFileDatastoreError(er, "")
 	}
-	return int64(len(dirEntries)), nil
+
+	var count int64
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (b *keyspace) GetRandomEntry() (string, value.Value, errors.Error) {
+	dirEntries, er := ioutil.ReadDir(b.path())
+	if er != nil {
+		return "", nil, errors.NewFileDatastoreError(er, "")
+	}
+
+	files := make([]os.FileInfo, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			files = append(files, dirEntry)
+		}
+	}
+
+	if len(files) == 0 {
+		return "", nil, nil
+	}
+
+	key := documentPathToId(files[rand.Intn(len(files))].Name())
+	item, err := b.fetchOne(key)
+	if err != nil {
+		return "", nil, err
+	}
+	return key, item, nil
 }
 
 func (b *keyspace) Indexer(name datastore.IndexType) (datastore.Indexer, errors.Error) {
@@ -405,7 +447,7 @@ func (b *keyspace) performOp(op int, kvPairs []value.Pair) ([]value.Pair, errors
 		var err error
 
 		key := kv.Name
-		value, _ := json.Marshal(kv.Value.Actual())
+		bytes, _ := json.Marshal(kv.Value.Actual())
 		filename := filepath.Join(b.path(), key+".json")
 
 		switch op {
@@ -417,7 +459,7 @@ func (b *keyspace) performOp(op int, kvPairs []value.Pair) ([]value.Pair, errors
 			} else {
 				// create and write the file
 				if file, err = os.Create(filename); err == nil {
-					_, err = file.Write(value)
+					_, err = file.Write(bytes)
 					file.Close()
 				}
 			}
@@ -426,7 +468,7 @@ func (b *keyspace) performOp(op int, kvPairs []value.Pair) ([]value.Pair, errors
 			if _, err = os.Stat(filename); err == nil {
 				// open and write the file
 				if file, err = os.OpenFile(filename, os.O_TRUNC|os.O_RDWR, 0666); err == nil {
-					_, err = file.Write(value)
+					_, err = file.Write(bytes)
 					file.Close()
 				}
 			}
@@ -434,7 +476,7 @@ func (b *keyspace) performOp(op int, kvPairs []value.Pair) ([]value.Pair, errors
 		case UPSERT:
 			// open the file for writing, if doesn't exist then create
 			if file, err = os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0666); err == nil {
-				_, err = file.Write(value)
+				_, err = file.Write(bytes)
 				file.Close()
 			}
 		}
@@ -443,6 +485,11 @@ func (b *keyspace) performOp(op int, kvPairs []value.Pair) ([]value.Pair, errors
 			returnErr = errors.NewFileDMLError(returnErr, opToString(op)+" Failed "+err.Error())
 		} else {
 			insertedKeys = append(insertedKeys, kv)
+
+			doc := value.NewAnnotatedValue(value.NewValue(bytes))
+			doc.SetAttachment("meta", map[string]interface{}{"id": key})
+			doc.SetId(key)
+			b.fi.updateIndexes(key, doc)
 		}
 	}
 
@@ -474,6 +521,7 @@ func (b *keyspace) Delete(deletes []string, context datastore.QueryContext) ([]s
 			}
 		} else {
 			deleted = append(deleted, key)
+			b.fi.updateIndexes(key, nil)
 		}
 	}
 
@@ -485,6 +533,39 @@ func (b *keyspace) Delete(deletes []string, context datastore.QueryContext) ([]s
 	return deleted, nil
 }
 
+func (b *keyspace) Truncate(context datastore.QueryContext) errors.Error {
+	b.fileLock.Lock()
+	defer b.fileLock.Unlock()
+
+	dirEntries, er := ioutil.ReadDir(b.path())
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	var fileError []string
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), ".json") {
+			continue
+		}
+
+		key := strings.TrimSuffix(dirEntry.Name(), ".json")
+		if err := os.Remove(filepath.Join(b.path(), dirEntry.Name())); err != nil {
+			if !os.IsNotExist(err) {
+				fileError = append(fileError, err.Error())
+			}
+		} else {
+			b.fi.updateIndexes(key, nil)
+		}
+	}
+
+	if len(fileError) > 0 {
+		errLine := fmt.Sprintf("Truncate failed on some keys %v", fileError)
+		return errors.NewFileDatastoreError(nil, errLine)
+	}
+
+	return nil
+}
+
 func (b *keyspace) Release() {
 }
 
@@ -510,16 +591,22 @@ func newKeyspace(p *namespace, dir string) (b *keyspace, e errors.Error) {
 	b.fi = newFileIndexer(b)
 	b.fi.CreatePrimaryIndex("", "#primary", nil)
 
+	e = b.fi.loadIndexes()
+	if e != nil {
+		return nil, e
+	}
+
 	return
 }
 
 type fileIndexer struct {
+	sync.RWMutex
 	keyspace *keyspace
 	indexes  map[string]datastore.Index
 	primary  datastore.PrimaryIndex
 }
 
-func newFileIndexer(keyspace *keyspace) datastore.Indexer {
+func newFileIndexer(keyspace *keyspace) *fileIndexer {
 
 	return &fileIndexer{
 		keyspace: keyspace,
@@ -536,6 +623,9 @@ func (fi *fileIndexer) Name() datastore.IndexType {
 }
 
 func (fi *fileIndexer) IndexIds() ([]string, errors.Error) {
+	fi.RLock()
+	defer fi.RUnlock()
+
 	rv := make([]string, 0, len(fi.indexes))
 	for name, _ := range fi.indexes {
 		rv = append(rv, name)
@@ -544,6 +634,9 @@ func (fi *fileIndexer) IndexIds() ([]string, errors.Error) {
 }
 
 func (fi *fileIndexer) IndexNames() ([]string, errors.Error) {
+	fi.RLock()
+	defer fi.RUnlock()
+
 	rv := make([]string, 0, len(fi.indexes))
 	for name, _ := range fi.indexes {
 		rv = append(rv, name)
@@ -556,6 +649,9 @@ func (fi *fileIndexer) IndexById(id string) (datastore.Index, errors.Error) {
 }
 
 func (fi *fileIndexer) IndexByName(name string) (datastore.Index, errors.Error) {
+	fi.RLock()
+	defer fi.RUnlock()
+
 	index, ok := fi.indexes[name]
 	if !ok {
 		return nil, errors.NewFileIdxNotFound(nil, name)
@@ -568,7 +664,14 @@ func (fi *fileIndexer) PrimaryIndexes() ([]datastore.PrimaryIndex, errors.Error)
 }
 
 func (fi *fileIndexer) Indexes() ([]datastore.Index, errors.Error) {
-	return []datastore.Index{fi.primary}, nil
+	fi.RLock()
+	defer fi.RUnlock()
+
+	rv := make([]datastore.Index, 0, len(fi.indexes))
+	for _, index := range fi.indexes {
+		rv = append(rv, index)
+	}
+	return rv, nil
 }
 
 func (fi *fileIndexer) CreatePrimaryIndex(requestId, name string, with value.Value) (
@@ -585,13 +688,120 @@ func (fi *fileIndexer) CreatePrimaryIndex(requestId, name string, with value.Val
 	return fi.primary, nil
 }
 
-func (b *fileIndexer) CreateIndex(requestId, name string, seekKey, rangeKey expression.Expressions,
+func (fi *fileIndexer) CreateIndex(requestId, name string, seekKey, rangeKey expression.Expressions,
 	where expression.Expression, with value.Value) (datastore.Index, errors.Error) {
-	return nil, errors.NewFileNotSupported(nil, "CREATE INDEX is not supported for file-based datastore.")
+	rangeKey2 := make(datastore.IndexKeys, len(rangeKey))
+	for i, key := range rangeKey {
+		rangeKey2[i] = &datastore.IndexKey{Expr: key}
+	}
+
+	return fi.CreateIndex2(requestId, name, seekKey, rangeKey2, where, with)
+}
+
+// CreateIndex2 creates a secondary index, and builds it unless
+// WITH {"defer_build": true} is given.
+func (fi *fileIndexer) CreateIndex2(requestId, name string, seekKey expression.Expressions,
+	rangeKey datastore.IndexKeys, where expression.Expression, with value.Value) (
+	datastore.Index, errors.Error) {
+	if len(seekKey) > 0 {
+		return nil, errors.NewFileNotSupported(nil, "Seek keys are not supported for file-based datastore.")
+	}
+
+	deferred := false
+	if with != nil {
+		if defer_build, ok := with.Field("defer_build"); ok {
+			deferred = defer_build.Truth()
+		}
+	}
+
+	si := newSecondaryIndex(fi, name, rangeKey, where, deferred)
+	if !deferred {
+		e := si.build()
+		if e != nil {
+			return nil, e
+		}
+	}
+
+	fi.Lock()
+	defer fi.Unlock()
+
+	if _, ok := fi.indexes[name]; ok {
+		return nil, errors.NewIndexAlreadyExistsError(name)
+	}
+
+	e := fi.saveIndex(si)
+	if e != nil {
+		return nil, e
+	}
+
+	fi.indexes[name] = si
+	return si, nil
+}
+
+func (fi *fileIndexer) BuildIndexes(requestId string, names ...string) errors.Error {
+	indexes := make([]*secondaryIndex, 0, len(names))
+	for _, name := range names {
+		index, e := fi.IndexByName(name)
+		if e != nil {
+			return e
+		}
+
+		si, ok := index.(*secondaryIndex)
+		if !ok {
+			return errors.NewFileNotSupported(nil, "BUILD INDEX is not supported for primary index "+name)
+		}
+		indexes = append(indexes, si)
+	}
+
+	for _, si := range indexes {
+		state, _, _ := si.State()
+		if state != datastore.DEFERRED {
+			continue
+		}
+
+		e := si.build()
+		if e == nil {
+			e = fi.saveIndex(si)
+		}
+		if e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (fi *fileIndexer) dropIndex(si *secondaryIndex) errors.Error {
+	fi.Lock()
+	defer fi.Unlock()
+
+	if fi.indexes[si.name] != si {
+		return errors.NewFileIdxNotFound(nil, si.name)
+	}
+
+	er := os.Remove(fi.indexPath(si.name))
+	if er != nil && !os.IsNotExist(er) {
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	// leave no trace once the last index is gone
+	os.Remove(filepath.Join(fi.keyspace.path(), _INDEX_DIR))
+
+	delete(fi.indexes, si.name)
+	return nil
 }
 
-func (b *fileIndexer) BuildIndexes(requestId string, names ...string) errors.Error {
-	return errors.NewFileNotSupported(nil, "BUILD INDEXES is not supported for file-based datastore.")
+// updateIndexes replaces the entries of a document in the secondary
+// indexes; a nil document removes them
+func (fi *fileIndexer) updateIndexes(id string, doc value.AnnotatedValue) {
+	fi.RLock()
+	defer fi.RUnlock()
+
+	for _, index := range fi.indexes {
+		if si, ok := index.(*secondaryIndex); ok {
+			si.update(id, doc)
+		}
+	}
 }
 
 func (b *fileIndexer) Refresh() errors.Error {
@@ -656,7 +866,7 @@ func (pi *primaryIndex) State() (state datastore.IndexState, msg string, err err
 
 func (pi *primaryIndex) Statistics(requestId string, span *datastore.Span) (
 	datastore.Statistics, errors.Error) {
-	return nil, nil
+	return datastore.IndexStatistics(pi.keyspace, _META_ID, span)
 }
 
 func (pi *primaryIndex) Drop(requestId string) errors.Error {
@@ -671,12 +881,14 @@ func (pi *primaryIndex) Scan(requestId string, span *datastore.Span, distinct bo
 	// can just enforce that directly
 	low, high := "", ""
 
-	// Ensure that lower bound is a string, if any
+	// Ensure that lower bound is a string, if any. A null lower
+	// bound, as in covering primary scans, sorts before all keys
 	if len(span.Range.Low) > 0 {
 		a := span.Range.Low[0].Actual()
 		switch a := a.(type) {
 		case string:
 			low = a
+		case nil:
 		default:
 			conn.Error(errors.NewFileDatastoreError(nil, fmt.Sprintf("Invalid lower bound %v of type %T.", a, a)))
 			return
@@ -743,13 +955,15 @@ func (pi *primaryIndex) ScanEntries(requestId string, limit int64, cons datastor
 		return
 	}
 
-	for i, dirEntry := range dirEntries {
-		if limit > 0 && int64(i) > limit {
+	var n int64 = 0
+	for _, dirEntry := range dirEntries {
+		if limit > 0 && n >= limit {
 			break
 		}
 		if !dirEntry.IsDir() {
 			entry := datastore.IndexEntry{PrimaryKey: documentPathToId(dirEntry.Name())}
 			conn.EntryChannel() <- &entry
+			n++
 		}
 	}
 }
@@ -0,0 +1,120 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package file
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/couchbase/query/errors"
+)
+
+// The definitions of user defined functions are kept in this file in
+// the store directory, which is not a namespace
+const _FUNCTIONS_FILE = ".functions.json"
+const _FUNCTIONS_TEMP = ".functions.tmp-"
+
+func (s *store) FunctionDefinitions() (map[string]string, errors.Error) {
+	s.functionsLock.Lock()
+	defer s.functionsLock.Unlock()
+
+	definitions, er := s.readFunctions()
+	if er != nil {
+		return nil, errors.NewFileDatastoreError(er, "")
+	}
+	return definitions, nil
+}
+
+func (s *store) SetFunctionDefinition(name, definition string) errors.Error {
+	s.functionsLock.Lock()
+	defer s.functionsLock.Unlock()
+
+	definitions, er := s.readFunctions()
+	if er == nil {
+		definitions[name] = definition
+		er = s.writeFunctions(definitions)
+	}
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+	return nil
+}
+
+func (s *store) DeleteFunctionDefinition(name string) errors.Error {
+	s.functionsLock.Lock()
+	defer s.functionsLock.Unlock()
+
+	definitions, er := s.readFunctions()
+	if er == nil {
+		delete(definitions, name)
+		er = s.writeFunctions(definitions)
+	}
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+	return nil
+}
+
+func (s *store) readFunctions() (map[string]string, error) {
+	definitions := make(map[string]string)
+	bytes, er := ioutil.ReadFile(filepath.Join(s.path, _FUNCTIONS_FILE))
+	if os.IsNotExist(er) {
+		return definitions, nil
+	} else if er != nil {
+		return nil, er
+	}
+
+	er = json.Unmarshal(bytes, &definitions)
+	if er != nil {
+		return nil, er
+	}
+	return definitions, nil
+}
+
+// The file is written under a temporary name and renamed, so that it
+// is either complete or absent. It is removed once the last function
+// is dropped.
+func (s *store) writeFunctions(definitions map[string]string) error {
+	path := filepath.Join(s.path, _FUNCTIONS_FILE)
+	if len(definitions) == 0 {
+		er := os.Remove(path)
+		if os.IsNotExist(er) {
+			return nil
+		}
+		return er
+	}
+
+	bytes, er := json.MarshalIndent(definitions, "", "    ")
+	if er != nil {
+		return er
+	}
+
+	file, er := ioutil.TempFile(s.path, _FUNCTIONS_TEMP)
+	if er != nil {
+		return er
+	}
+	_, er = file.Write(bytes)
+	if er == nil {
+		er = file.Sync()
+	}
+	if cer := file.Close(); er == nil {
+		er = cer
+	}
+
+	if er == nil {
+		er = os.Rename(file.Name(), path)
+	}
+	if er != nil {
+		os.Remove(file.Name())
+	}
+	return er
+}
@@ -0,0 +1,690 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/expression/parser"
+	"github.com/couchbase/query/logging"
+	"github.com/couchbase/query/timestamp"
+	"github.com/couchbase/query/value"
+)
+
+// Secondary index definitions are kept in this subdirectory of the
+// keyspace directory, one file per index. Index entries are kept in
+// memory, and are rebuilt from the documents when the datastore is
+// opened.
+const _INDEX_DIR = ".indexes"
+
+// secondaryIndex is an in-memory index over the documents of a
+// keyspace, sorted on the index keys and then the document key.
+type secondaryIndex struct {
+	sync.RWMutex
+	name      string
+	keyspace  *keyspace
+	indexer   *fileIndexer
+	rangeKey  datastore.IndexKeys
+	condition expression.Expression
+	state     datastore.IndexState
+	entries   []*indexEntry
+	docs      map[string][]*indexEntry // entries of each document
+}
+
+type indexEntry struct {
+	key value.Values
+	id  string
+}
+
+// indexDefinition is the persisted form of a secondary index.
+type indexDefinition struct {
+	Name      string               `json:"name"`
+	Keys      []indexKeyDefinition `json:"keys"`
+	Condition string               `json:"condition,omitempty"`
+	Deferred  bool                 `json:"deferred,omitempty"`
+}
+
+type indexKeyDefinition struct {
+	Expr string `json:"expr"`
+	Desc bool   `json:"desc,omitempty"`
+}
+
+func newSecondaryIndex(indexer *fileIndexer, name string, rangeKey datastore.IndexKeys,
+	condition expression.Expression, deferred bool) *secondaryIndex {
+	rv := &secondaryIndex{
+		name:      name,
+		keyspace:  indexer.keyspace,
+		indexer:   indexer,
+		rangeKey:  rangeKey,
+		condition: condition,
+		state:     datastore.ONLINE,
+		docs:      make(map[string][]*indexEntry),
+	}
+
+	if deferred {
+		rv.state = datastore.DEFERRED
+	}
+
+	return rv
+}
+
+func (si *secondaryIndex) KeyspaceId() string {
+	return si.keyspace.Id()
+}
+
+func (si *secondaryIndex) Id() string {
+	return si.Name()
+}
+
+func (si *secondaryIndex) Name() string {
+	return si.name
+}
+
+func (si *secondaryIndex) Type() datastore.IndexType {
+	return datastore.DEFAULT
+}
+
+func (si *secondaryIndex) Indexer() datastore.Indexer {
+	return si.indexer
+}
+
+func (si *secondaryIndex) SeekKey() expression.Expressions {
+	return nil
+}
+
+func (si *secondaryIndex) RangeKey() expression.Expressions {
+	rv := make(expression.Expressions, len(si.rangeKey))
+	for i, key := range si.rangeKey {
+		rv[i] = key.Expr
+	}
+	return rv
+}
+
+func (si *secondaryIndex) RangeKey2() datastore.IndexKeys {
+	return si.rangeKey
+}
+
+func (si *secondaryIndex) Condition() expression.Expression {
+	return si.condition
+}
+
+func (si *secondaryIndex) IsPrimary() bool {
+	return false
+}
+
+func (si *secondaryIndex) State() (state datastore.IndexState, msg string, err errors.Error) {
+	si.RLock()
+	defer si.RUnlock()
+	return si.state, "", nil
+}
+
+func (si *secondaryIndex) Statistics(requestId string, span *datastore.Span) (
+	datastore.Statistics, errors.Error) {
+	return datastore.IndexStatistics(si.keyspace, si.rangeKey[0].Expr, span)
+}
+
+func (si *secondaryIndex) Drop(requestId string) errors.Error {
+	return si.indexer.dropIndex(si)
+}
+
+// Scan is the index API 1 scan. The bounds of the span are compared
+// with the leading keys of each entry, so only the leading key bounds
+// the range of entries scanned.
+func (si *secondaryIndex) Scan(requestId string, span *datastore.Span, distinct bool, limit int64,
+	cons datastore.ScanConsistency, vector timestamp.Vector, conn *datastore.IndexConnection) {
+	defer close(conn.EntryChannel())
+
+	var ranges datastore.Ranges2
+	if len(span.Seek) > 0 {
+		ranges = make(datastore.Ranges2, len(span.Seek))
+		for i, v := range span.Seek {
+			ranges[i] = &datastore.Range2{Low: v, High: v, Inclusion: datastore.BOTH}
+		}
+	} else {
+		rng := &datastore.Range2{Inclusion: datastore.BOTH}
+		if len(span.Range.Low) > 0 {
+			rng.Low = span.Range.Low[0]
+		}
+		if len(span.Range.High) > 0 {
+			rng.High = span.Range.High[0]
+		}
+		ranges = datastore.Ranges2{rng}
+	}
+
+	scan := &indexScan{
+		ranges: []datastore.Ranges2{ranges},
+		matches: func(key value.Values) bool {
+			return datastore.MatchSpan(key, span)
+		},
+		limit: limit,
+	}
+
+	for _, entry := range si.scanEntries(scan) {
+		if !sendEntry(conn, entry) {
+			return
+		}
+	}
+}
+
+// Scan2 returns the entries matching any of the spans, in index order
+// or its reverse. Entries are only returned once, even if spans
+// overlap.
+func (si *secondaryIndex) Scan2(requestId string, spans datastore.Spans2, reverse, distinctAfterProjection,
+	ordered bool, projection *datastore.IndexProjection, offset, limit int64,
+	cons datastore.ScanConsistency, vector timestamp.Vector, conn *datastore.IndexConnection) {
+	defer close(conn.EntryChannel())
+
+	scan := &indexScan{
+		ranges: make([]datastore.Ranges2, len(spans)),
+		matches: func(key value.Values) bool {
+			for _, span := range spans {
+				if datastore.MatchSpan2(key, span) {
+					return true
+				}
+			}
+			return false
+		},
+		reverse:    reverse,
+		offset:     offset,
+		limit:      limit,
+		projection: projection,
+		distinct:   distinctAfterProjection && si.dropsKeys(projection),
+	}
+	for i, span := range spans {
+		scan.ranges[i] = span.Ranges
+	}
+
+	for _, entry := range si.scanEntries(scan) {
+		if !sendEntry(conn, entry) {
+			return
+		}
+	}
+}
+
+// indexScan is a scan of the entries within the ranges of any of its
+// spans. The ranges bound the entries scanned, and the entries within
+// them are then matched against the spans.
+type indexScan struct {
+	ranges     []datastore.Ranges2
+	matches    func(key value.Values) bool
+	reverse    bool
+	offset     int64
+	limit      int64
+	projection *datastore.IndexProjection
+	distinct   bool // remove entries whose projected key and id repeat
+}
+
+// scanEntries returns the matching entries, projected. Entries are
+// copied out of the index, so that it is not locked while they are
+// being sent.
+func (si *secondaryIndex) scanEntries(scan *indexScan) []*datastore.IndexEntry {
+	si.RLock()
+	defer si.RUnlock()
+
+	var seen map[string]bool
+	if scan.distinct {
+		seen = make(map[string]bool)
+	}
+
+	var rv []*datastore.IndexEntry
+	offset := scan.offset
+	positions := si.positions(scan.ranges)
+	for j := range positions {
+		pos := positions[j]
+		if scan.reverse {
+			pos = positions[len(positions)-j-1]
+		}
+
+		for i := pos.start; i < pos.end; i++ {
+			if scan.limit > 0 && int64(len(rv)) >= scan.limit {
+				return rv
+			}
+
+			entry := si.entries[i]
+			if scan.reverse {
+				entry = si.entries[pos.end-(i-pos.start)-1]
+			}
+
+			if !scan.matches(entry.key) {
+				continue
+			}
+
+			projected := project(entry, scan.projection)
+			if seen != nil {
+				distinctKey := distinctEntryKey(projected)
+				if seen[distinctKey] {
+					continue
+				}
+				seen[distinctKey] = true
+			}
+
+			if offset > 0 {
+				offset--
+				continue
+			}
+
+			rv = append(rv, projected)
+		}
+	}
+
+	return rv
+}
+
+// entryPositions is the range of positions of the entries from start
+// up to, but excluding, end
+type entryPositions struct {
+	start int
+	end   int
+}
+
+// positions returns the positions of the entries within the ranges of
+// any of the spans, as sorted, disjoint position ranges
+func (si *secondaryIndex) positions(spans []datastore.Ranges2) []entryPositions {
+	rv := make([]entryPositions, 0, len(spans))
+	for _, ranges := range spans {
+		pos := si.rangePositions(ranges)
+		if pos.start < pos.end {
+			rv = append(rv, pos)
+		}
+	}
+
+	sort.Slice(rv, func(i, j int) bool {
+		return rv[i].start < rv[j].start
+	})
+
+	merged := rv[:0]
+	for _, pos := range rv {
+		last := len(merged) - 1
+		if last >= 0 && pos.start <= merged[last].end {
+			if pos.end > merged[last].end {
+				merged[last].end = pos.end
+			}
+		} else {
+			merged = append(merged, pos)
+		}
+	}
+
+	return merged
+}
+
+// rangePositions finds the positions of the entries within the ranges
+// of a span. The leading keys that the ranges bound to a single value,
+// and the key after them, bound the entries; later keys do not.
+func (si *secondaryIndex) rangePositions(ranges datastore.Ranges2) entryPositions {
+	var first, last value.Values
+	firstPast, lastPast := false, true
+
+	for i, rng := range ranges {
+		if i >= len(si.rangeKey) {
+			break
+		}
+
+		if rng.Low != nil && rng.High != nil && rng.Inclusion == datastore.BOTH &&
+			rng.Low.Collate(rng.High) == 0 {
+			first = append(first, rng.Low)
+			last = append(last, rng.Low)
+			continue
+		}
+
+		// descending keys are scanned from the high bound
+		low, high := rng.Low, rng.High
+		lowIncl, highIncl := rng.Inclusion&datastore.LOW != 0, rng.Inclusion&datastore.HIGH != 0
+		if si.rangeKey[i].Desc {
+			low, high = high, low
+			lowIncl, highIncl = highIncl, lowIncl
+		}
+
+		if low != nil {
+			first = append(first, low)
+			firstPast = !lowIncl
+		}
+		if high != nil {
+			last = append(last, high)
+			lastPast = highIncl
+		}
+		break
+	}
+
+	pos := entryPositions{start: 0, end: len(si.entries)}
+	if len(first) > 0 {
+		pos.start = si.searchPrefix(first, firstPast)
+	}
+	if len(last) > 0 {
+		pos.end = si.searchPrefix(last, lastPast)
+	}
+	return pos
+}
+
+// searchPrefix returns the position of the first entry whose leading
+// keys are not before the prefix, or are after it when past is set
+func (si *secondaryIndex) searchPrefix(prefix value.Values, past bool) int {
+	return sort.Search(len(si.entries), func(i int) bool {
+		key := si.entries[i].key
+		if len(key) > len(prefix) {
+			key = key[:len(prefix)]
+		}
+
+		c := datastore.CompareIndexKeys(key, prefix, si.rangeKey)
+		return c > 0 || (c == 0 && !past)
+	})
+}
+
+// dropsKeys is true if the projection leaves out some of the index keys
+func (si *secondaryIndex) dropsKeys(projection *datastore.IndexProjection) bool {
+	if projection == nil {
+		return false
+	}
+
+	projected := make(map[int]bool, len(projection.EntryKeys))
+	for _, pos := range projection.EntryKeys {
+		projected[pos] = true
+	}
+	for i := range si.rangeKey {
+		if !projected[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func project(entry *indexEntry, projection *datastore.IndexProjection) *datastore.IndexEntry {
+	key := entry.key
+	if projection != nil {
+		key = make(value.Values, 0, len(projection.EntryKeys))
+		for _, pos := range projection.EntryKeys {
+			if pos >= 0 && pos < len(entry.key) {
+				key = append(key, entry.key[pos])
+			}
+		}
+	}
+
+	return &datastore.IndexEntry{EntryKey: key, PrimaryKey: entry.id}
+}
+
+// distinctEntryKey identifies a projected entry. MISSING keys are told
+// apart from NULL keys by their string form.
+func distinctEntryKey(entry *datastore.IndexEntry) string {
+	keys := make([]string, len(entry.EntryKey))
+	for i, key := range entry.EntryKey {
+		keys[i] = key.String()
+	}
+	return entry.PrimaryKey + "\x00" + strings.Join(keys, ",")
+}
+
+func sendEntry(conn *datastore.IndexConnection, entry *datastore.IndexEntry) bool {
+	select {
+	case <-conn.StopChannel():
+		return false
+	default:
+	}
+
+	select {
+	case conn.EntryChannel() <- entry:
+		return true
+	case <-conn.StopChannel():
+		return false
+	}
+}
+
+// compare orders entries by their keys, honoring descending keys, and
+// then by document key
+func (si *secondaryIndex) compare(a, b *indexEntry) int {
+	if c := datastore.CompareIndexKeys(a.key, b.key, si.rangeKey); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.id < b.id:
+		return -1
+	case a.id > b.id:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// evaluate returns the keys the document is indexed under
+func (si *secondaryIndex) evaluate(doc value.AnnotatedValue) ([]value.Values, error) {
+	return datastore.EvaluateIndexKeys(si.rangeKey, si.condition, doc)
+}
+
+// update replaces the entries of a document; a nil document removes
+// them
+func (si *secondaryIndex) update(id string, doc value.AnnotatedValue) {
+	var keys []value.Values
+	if doc != nil {
+		var err error
+		keys, err = si.evaluate(doc)
+		if err != nil {
+			logging.Errorf("Unable to index document %v in index %v: %v", id, si.name, err)
+			keys = nil
+		}
+	}
+
+	si.Lock()
+	defer si.Unlock()
+
+	if si.state != datastore.ONLINE {
+		return
+	}
+
+	for _, entry := range si.docs[id] {
+		for i := si.search(entry); i < len(si.entries) && si.compare(si.entries[i], entry) == 0; i++ {
+			if si.entries[i] == entry {
+				si.entries = append(si.entries[:i], si.entries[i+1:]...)
+				break
+			}
+		}
+	}
+	delete(si.docs, id)
+
+	if len(keys) == 0 {
+		return
+	}
+
+	entries := make([]*indexEntry, len(keys))
+	for j, key := range keys {
+		entry := &indexEntry{key: key, id: id}
+		i := si.search(entry)
+		si.entries = append(si.entries, nil)
+		copy(si.entries[i+1:], si.entries[i:])
+		si.entries[i] = entry
+		entries[j] = entry
+	}
+	si.docs[id] = entries
+}
+
+// search returns the position of the first entry not before entry
+func (si *secondaryIndex) search(entry *indexEntry) int {
+	return sort.Search(len(si.entries), func(i int) bool {
+		return si.compare(si.entries[i], entry) >= 0
+	})
+}
+
+// build indexes all the documents in the keyspace
+func (si *secondaryIndex) build() errors.Error {
+	dirEntries, er := ioutil.ReadDir(si.keyspace.path())
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	var entries []*indexEntry
+	docs := make(map[string][]*indexEntry, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+
+		id := documentPathToId(dirEntry.Name())
+		doc, e := si.keyspace.fetchOne(id)
+		if e != nil {
+			return e
+		}
+
+		keys, err := si.evaluate(doc)
+		if err != nil {
+			return errors.NewFileDatastoreError(err, fmt.Sprintf("indexing document %v in index %v", id, si.name))
+		}
+
+		for _, key := range keys {
+			entry := &indexEntry{key: key, id: id}
+			entries = append(entries, entry)
+			docs[id] = append(docs[id], entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return si.compare(entries[i], entries[j]) < 0
+	})
+
+	si.Lock()
+	defer si.Unlock()
+
+	si.entries = entries
+	si.docs = docs
+	si.state = datastore.ONLINE
+	return nil
+}
+
+func (si *secondaryIndex) definition() *indexDefinition {
+	si.RLock()
+	defer si.RUnlock()
+
+	rv := &indexDefinition{
+		Name:     si.name,
+		Keys:     make([]indexKeyDefinition, len(si.rangeKey)),
+		Deferred: si.state == datastore.DEFERRED,
+	}
+
+	for i, key := range si.rangeKey {
+		rv.Keys[i] = indexKeyDefinition{Expr: expressionText(key.Expr), Desc: key.Desc}
+	}
+
+	if si.condition != nil {
+		rv.Condition = expressionText(si.condition)
+	}
+
+	return rv
+}
+
+// expressionText returns text that parses back into the expression.
+// Array index keys are written without the surrounding parentheses of
+// their string form, which the expression parser does not accept.
+func expressionText(expr expression.Expression) string {
+	if all, ok := expr.(*expression.All); ok {
+		if all.Distinct() {
+			return "distinct " + expression.NewStringer().Visit(all.Array())
+		}
+		return "all " + expression.NewStringer().Visit(all.Array())
+	}
+	return expression.NewStringer().Visit(expr)
+}
+
+func (fi *fileIndexer) indexPath(name string) string {
+	return filepath.Join(fi.keyspace.path(), _INDEX_DIR, name+".json")
+}
+
+func (fi *fileIndexer) saveIndex(si *secondaryIndex) errors.Error {
+	bytes, er := json.MarshalIndent(si.definition(), "", "    ")
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	er = os.MkdirAll(filepath.Join(fi.keyspace.path(), _INDEX_DIR), 0755)
+	if er == nil {
+		er = ioutil.WriteFile(fi.indexPath(si.name), bytes, 0666)
+	}
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+	return nil
+}
+
+// loadIndexes recreates the secondary indexes persisted in the
+// keyspace directory. Definitions that cannot be parsed are skipped.
+func (fi *fileIndexer) loadIndexes() errors.Error {
+	dirEntries, er := ioutil.ReadDir(filepath.Join(fi.keyspace.path(), _INDEX_DIR))
+	if er != nil {
+		if os.IsNotExist(er) {
+			return nil
+		}
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(fi.keyspace.path(), _INDEX_DIR, dirEntry.Name())
+		si, err := fi.loadIndex(path)
+		if err != nil {
+			logging.Errorf("Unable to load index definition %v: %v", path, err)
+			continue
+		}
+
+		if si.state != datastore.DEFERRED {
+			e := si.build()
+			if e != nil {
+				return e
+			}
+		}
+
+		fi.indexes[si.name] = si
+	}
+
+	return nil
+}
+
+func (fi *fileIndexer) loadIndex(path string) (*secondaryIndex, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var def indexDefinition
+	err = json.Unmarshal(bytes, &def)
+	if err != nil {
+		return nil, err
+	}
+
+	if def.Name == "" || len(def.Keys) == 0 {
+		return nil, fmt.Errorf("missing index name or keys")
+	}
+
+	rangeKey := make(datastore.IndexKeys, len(def.Keys))
+	for i, key := range def.Keys {
+		expr, err := parser.Parse(key.Expr)
+		if err != nil {
+			return nil, err
+		}
+		rangeKey[i] = &datastore.IndexKey{Expr: expr, Desc: key.Desc}
+	}
+
+	var condition expression.Expression
+	if def.Condition != "" {
+		condition, err = parser.Parse(def.Condition)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newSecondaryIndex(fi, def.Name, rangeKey, condition, def.Deferred), nil
+}
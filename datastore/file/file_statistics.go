@@ -0,0 +1,111 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package file
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+)
+
+// Optimizer statistics are kept in this subdirectory of the keyspace
+// directory, so that they are not taken for a document.
+const _STATISTICS_DIR = ".statistics"
+const _STATISTICS_FILE = "statistics.json"
+
+// Statistics of the document keys back the statistics of the primary index
+var _META_ID = expression.NewField(expression.NewMeta(), expression.NewFieldName("id", false))
+
+func (b *keyspace) statisticsPath() string {
+	return filepath.Join(b.path(), _STATISTICS_DIR, _STATISTICS_FILE)
+}
+
+// UpdateStatistics replaces the statistics of the analyzed expressions,
+// and keeps those of the other expressions.
+func (b *keyspace) UpdateStatistics(stats []*datastore.ExpressionStatistics) errors.Error {
+	b.statsLock.Lock()
+	defer b.statsLock.Unlock()
+
+	current, err := b.loadStatistics()
+	if err != nil {
+		return err
+	}
+
+	rv := make([]*datastore.ExpressionStatistics, 0, len(current)+len(stats))
+	for _, s := range current {
+		replaced := false
+		for _, ns := range stats {
+			if ns.Expression == s.Expression {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			rv = append(rv, s)
+		}
+	}
+	rv = append(rv, stats...)
+
+	bytes, er := json.MarshalIndent(rv, "", "    ")
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	er = os.MkdirAll(filepath.Join(b.path(), _STATISTICS_DIR), 0755)
+	if er == nil {
+		er = ioutil.WriteFile(b.statisticsPath(), bytes, 0666)
+	}
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	b.stats = nil
+	return nil
+}
+
+func (b *keyspace) KeyspaceStatistics() ([]*datastore.ExpressionStatistics, errors.Error) {
+	b.statsLock.RLock()
+	stats := b.stats
+	b.statsLock.RUnlock()
+	if stats != nil {
+		return stats, nil
+	}
+
+	b.statsLock.Lock()
+	defer b.statsLock.Unlock()
+	return b.loadStatistics()
+}
+
+// loadStatistics reads the persisted statistics, unless they are cached.
+// The caller holds the statistics lock.
+func (b *keyspace) loadStatistics() ([]*datastore.ExpressionStatistics, errors.Error) {
+	if b.stats != nil {
+		return b.stats, nil
+	}
+
+	stats := []*datastore.ExpressionStatistics{}
+	bytes, er := ioutil.ReadFile(b.statisticsPath())
+	if er == nil {
+		er = json.Unmarshal(bytes, &stats)
+	} else if os.IsNotExist(er) {
+		er = nil
+	}
+	if er != nil {
+		return nil, errors.NewFileDatastoreError(er, "")
+	}
+
+	b.stats = stats
+	return stats, nil
+}
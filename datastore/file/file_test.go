@@ -11,11 +11,15 @@ package file
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/couchbase/query/datastore"
 	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
 	"github.com/couchbase/query/value"
 )
 
@@ -183,6 +187,376 @@ func TestFile(t *testing.T) {
 
 }
 
+func TestSecondaryIndex(t *testing.T) {
+	dir, er := ioutil.TempDir("", "filestore")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "default", "orders")
+	os.MkdirAll(path, 0755)
+	for _, doc := range []struct{ key, value string }{
+		{"o1", `{"total": 10, "items": ["a", "b"]}`},
+		{"o2", `{"total": 30, "items": ["b"]}`},
+		{"o3", `{"total": 20}`},
+		{"o4", `{"items": ["c"]}`},
+	} {
+		ioutil.WriteFile(filepath.Join(path, doc.key+".json"), []byte(doc.value), 0666)
+	}
+
+	keyspace := openKeyspace(t, dir)
+	indexer, _ := keyspace.Indexer(datastore.DEFAULT)
+	indexer2 := indexer.(datastore.Indexer2)
+
+	total := expression.NewIdentifier("total")
+	items := expression.NewAll(expression.NewIdentifier("items"), true)
+	_, err := indexer2.CreateIndex2("", "ix_total", nil,
+		datastore.IndexKeys{&datastore.IndexKey{Expr: total, Desc: true}}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+
+	_, err = indexer2.CreateIndex2("", "ix_items", nil, datastore.IndexKeys{&datastore.IndexKey{Expr: items}}, nil,
+		value.NewValue(map[string]interface{}{"defer_build": true}))
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+
+	index, _ := indexer.IndexByName("ix_items")
+	if state, _, _ := index.State(); state != datastore.DEFERRED {
+		t.Errorf("expected deferred index, got %v", state)
+	}
+
+	err = indexer.BuildIndexes("", "ix_items")
+	if err != nil {
+		t.Errorf("failed to build index: %v", err)
+	}
+
+	// documents without the leading key are not indexed
+	verifyScan(t, indexer, "ix_total", nil, []string{"o2", "o3", "o1"})
+	verifyScan(t, indexer, "ix_items", nil, []string{"o1", "o1", "o2", "o4"})
+	verifyScan(t, indexer, "ix_items", value.NewValue("b"), []string{"o1", "o2"})
+
+	keyspace.Upsert([]value.Pair{value.Pair{Name: "o3", Value: value.NewValue(map[string]interface{}{"total": 40})}})
+	keyspace.Insert([]value.Pair{value.Pair{Name: "o5", Value: value.NewValue(map[string]interface{}{"items": []interface{}{"b"}})}})
+	keyspace.Delete([]string{"o1"}, datastore.NULL_QUERY_CONTEXT)
+	verifyScan(t, indexer, "ix_total", nil, []string{"o3", "o2"})
+	verifyScan(t, indexer, "ix_items", value.NewValue("b"), []string{"o2", "o5"})
+
+	// index definitions survive reopening the datastore
+	keyspace = openKeyspace(t, dir)
+	indexer, _ = keyspace.Indexer(datastore.DEFAULT)
+	verifyScan(t, indexer, "ix_total", nil, []string{"o3", "o2"})
+	verifyScan(t, indexer, "ix_items", value.NewValue("c"), []string{"o4"})
+
+	index, _ = indexer.IndexByName("ix_total")
+	err = index.Drop("")
+	if err != nil {
+		t.Errorf("failed to drop index: %v", err)
+	}
+
+	keyspace = openKeyspace(t, dir)
+	indexer, _ = keyspace.Indexer(datastore.DEFAULT)
+	if _, err = indexer.IndexByName("ix_total"); err == nil {
+		t.Errorf("expected dropped index to be gone")
+	}
+
+	count, _ := keyspace.Count(datastore.NULL_QUERY_CONTEXT)
+	if count != 4 {
+		t.Errorf("expected 4 documents, got %v", count)
+	}
+}
+
+func TestIndexRanges(t *testing.T) {
+	dir, er := ioutil.TempDir("", "filestore")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "default", "orders")
+	os.MkdirAll(path, 0755)
+	for _, doc := range []struct{ key, value string }{
+		{"o1", `{"total": 10, "items": ["a", "b"]}`},
+		{"o2", `{"total": 20, "items": ["b"]}`},
+		{"o3", `{"total": 30, "items": ["a", "c"]}`},
+		{"o4", `{"total": 40}`},
+		{"o5", `{"total": 50, "items": ["c"]}`},
+	} {
+		ioutil.WriteFile(filepath.Join(path, doc.key+".json"), []byte(doc.value), 0666)
+	}
+
+	keyspace := openKeyspace(t, dir)
+	indexer, _ := keyspace.Indexer(datastore.DEFAULT)
+	indexer2 := indexer.(datastore.Indexer2)
+
+	total := expression.NewIdentifier("total")
+	items := expression.NewAll(expression.NewIdentifier("items"), true)
+	ixTotal, err := indexer2.CreateIndex2("", "ix_total", nil,
+		datastore.IndexKeys{&datastore.IndexKey{Expr: total, Desc: true}}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+	ixItems, err := indexer2.CreateIndex2("", "ix_items_total", nil,
+		datastore.IndexKeys{&datastore.IndexKey{Expr: items}, &datastore.IndexKey{Expr: total}}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+
+	between := func(low, high interface{}, inclusion datastore.Inclusion) *datastore.Span2 {
+		rng := &datastore.Range2{Inclusion: inclusion}
+		if low != nil {
+			rng.Low = value.NewValue(low)
+		}
+		if high != nil {
+			rng.High = value.NewValue(high)
+		}
+		return &datastore.Span2{Ranges: datastore.Ranges2{rng}}
+	}
+
+	// descending keys are scanned from the high bound
+	verifyScan2(t, ixTotal, datastore.Spans2{between(20, 40, datastore.HIGH)}, false, nil, 0, 0, "[o4 o3]")
+	verifyScan2(t, ixTotal, datastore.Spans2{between(20, 40, datastore.LOW)}, true, nil, 0, 0, "[o2 o3]")
+	verifyScan2(t, ixTotal, datastore.Spans2{between(nil, 30, datastore.BOTH)}, false, nil, 0, 0, "[o3 o2 o1]")
+	verifyScan2(t, ixTotal, datastore.Spans2{between(45, nil, datastore.NEITHER)}, false, nil, 0, 0, "[o5]")
+
+	// overlapping spans return each entry once, in order
+	spans := datastore.Spans2{between(35, 50, datastore.BOTH), between(10, 20, datastore.BOTH),
+		between(15, 40, datastore.BOTH)}
+	verifyScan2(t, ixTotal, spans, false, nil, 0, 0, "[o5 o4 o3 o2 o1]")
+	verifyScan2(t, ixTotal, spans, true, nil, 1, 3, "[o2 o3 o4]")
+	verifyScan2(t, ixTotal, datastore.Spans2{between(60, 70, datastore.BOTH)}, false, nil, 0, 0, "[]")
+
+	// leading keys bound to one value, and the next key, bound the scan
+	span := &datastore.Span2{Ranges: datastore.Ranges2{
+		&datastore.Range2{Low: value.NewValue("a"), High: value.NewValue("a"), Inclusion: datastore.BOTH},
+		&datastore.Range2{Low: value.NewValue(20), Inclusion: datastore.LOW},
+	}}
+	verifyScan2(t, ixItems, datastore.Spans2{span}, false, nil, 0, 0, "[o3]")
+
+	// entries that only differ in keys the projection drops are returned once
+	projection := &datastore.IndexProjection{EntryKeys: []int{1}}
+	verifyScan2(t, ixItems, datastore.Spans2{between(nil, nil, datastore.BOTH)}, false, projection, 0, 0,
+		"[o1 o3 o2 o5]")
+	verifyScan2(t, ixItems, datastore.Spans2{between(nil, nil, datastore.BOTH)}, false, projection, 1, 2,
+		"[o3 o2]")
+
+	// index API 1 spans are bounded by the leading key
+	conn := datastore.NewIndexConnection(&testingContext{t})
+	go ixItems.Scan("", &datastore.Span{Range: datastore.Range{
+		Low:       value.Values{value.NewValue("a"), value.NewValue(20)},
+		High:      value.Values{value.NewValue("b")},
+		Inclusion: datastore.BOTH,
+	}}, false, math.MaxInt64, datastore.UNBOUNDED, nil, conn)
+	verifyEntries(t, "ix_items_total", conn, "[o3 o1 o2]")
+}
+
+func verifyScan2(t *testing.T, index datastore.Index, spans datastore.Spans2, reverse bool,
+	projection *datastore.IndexProjection, offset, limit int64, expected string) {
+	conn := datastore.NewIndexConnection(&testingContext{t})
+	go index.(datastore.Index2).Scan2("", spans, reverse, true, true, projection, offset, limit,
+		datastore.UNBOUNDED, nil, conn)
+	verifyEntries(t, index.Name(), conn, expected)
+}
+
+func verifyEntries(t *testing.T, name string, conn *datastore.IndexConnection, expected string) {
+	keys := []string{}
+	for entry := range conn.EntryChannel() {
+		keys = append(keys, entry.PrimaryKey)
+	}
+
+	if fmt.Sprint(keys) != expected {
+		t.Errorf("expected %v to scan %v, got %v", name, expected, keys)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	dir, er := ioutil.TempDir("", "filestore")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "default", "orders")
+	os.MkdirAll(path, 0755)
+	for _, key := range []string{"o1", "o2", "o3"} {
+		ioutil.WriteFile(filepath.Join(path, key+".json"), []byte(`{"total": 10}`), 0666)
+	}
+
+	keyspace := openKeyspace(t, dir)
+	indexer, _ := keyspace.Indexer(datastore.DEFAULT)
+	_, err := indexer.(datastore.Indexer2).CreateIndex2("", "ix_total", nil,
+		datastore.IndexKeys{&datastore.IndexKey{Expr: expression.NewIdentifier("total")}}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+
+	err = keyspace.(datastore.TruncateKeyspace).Truncate(datastore.NULL_QUERY_CONTEXT)
+	if err != nil {
+		t.Fatalf("failed to truncate keyspace: %v", err)
+	}
+
+	count, _ := keyspace.Count(datastore.NULL_QUERY_CONTEXT)
+	if count != 0 {
+		t.Errorf("expected no documents, got %v", count)
+	}
+
+	// indexes are kept, but emptied
+	verifyScan(t, indexer, "ix_total", nil, nil)
+
+	keyspace.Insert([]value.Pair{value.Pair{Name: "o4", Value: value.NewValue(map[string]interface{}{"total": 20})}})
+	verifyScan(t, indexer, "ix_total", nil, []string{"o4"})
+}
+
+func TestRandomEntry(t *testing.T) {
+	dir, er := ioutil.TempDir("", "filestore")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "default", "orders")
+	os.MkdirAll(path, 0755)
+	keyspace := openKeyspace(t, dir)
+	provider := keyspace.(datastore.RandomEntryProvider)
+
+	key, _, err := provider.GetRandomEntry()
+	if err != nil || key != "" {
+		t.Errorf("expected no entry, got %v %v", key, err)
+	}
+
+	for _, key := range []string{"o1", "o2", "o3"} {
+		ioutil.WriteFile(filepath.Join(path, key+".json"), []byte(`{"total": 10}`), 0666)
+	}
+
+	for i := 0; i < 10; i++ {
+		key, val, err := provider.GetRandomEntry()
+		if err != nil {
+			t.Fatalf("failed to get random entry: %v", err)
+		}
+		if key != "o1" && key != "o2" && key != "o3" {
+			t.Errorf("unexpected key %v", key)
+		}
+		if total, _ := val.Field("total"); total.Actual() != 10.0 {
+			t.Errorf("expected total 10 for key %v, got %v", key, total.Actual())
+		}
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	dir, er := ioutil.TempDir("", "filestore")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+	defer os.RemoveAll(dir)
+
+	// 10 documents of each total from 0 to 9
+	path := filepath.Join(dir, "default", "orders")
+	os.MkdirAll(path, 0755)
+	vals := make(value.Values, 100)
+	for i := range vals {
+		ioutil.WriteFile(filepath.Join(path, fmt.Sprintf("o%d.json", i)), []byte(fmt.Sprintf(`{"total": %d}`, i%10)), 0666)
+		vals[i] = value.NewValue(i % 10)
+	}
+
+	keyspace := openKeyspace(t, dir)
+	total := expression.NewIdentifier("total")
+	stats := datastore.NewExpressionStatistics(expression.NewStringer().Visit(total), vals, 100, 100)
+	if stats.Count != 100 || stats.DistinctCount != 10 || len(stats.Histogram) != 10 {
+		t.Errorf("unexpected statistics %v %v %v", stats.Count, stats.DistinctCount, len(stats.Histogram))
+	}
+
+	err := keyspace.(datastore.StatisticsKeyspace).UpdateStatistics([]*datastore.ExpressionStatistics{stats})
+	if err != nil {
+		t.Fatalf("failed to update statistics: %v", err)
+	}
+
+	// statistics survive reopening the datastore, and are not documents
+	keyspace = openKeyspace(t, dir)
+	if count, _ := keyspace.Count(datastore.NULL_QUERY_CONTEXT); count != 100 {
+		t.Errorf("expected 100 documents, got %v", count)
+	}
+
+	indexer, _ := keyspace.Indexer(datastore.DEFAULT)
+	index, err := indexer.CreateIndex("", "ix_total", nil, expression.Expressions{total}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+
+	for _, c := range []struct {
+		low, high int
+		inclusion datastore.Inclusion
+		expected  int64
+	}{
+		{3, 3, datastore.BOTH, 10},
+		{0, 4, datastore.BOTH, 50},
+		{0, 4, datastore.HIGH, 40},
+		{9, 20, datastore.BOTH, 10},
+		{10, 20, datastore.BOTH, 0},
+	} {
+		span := &datastore.Span{Range: datastore.Range{
+			Low:       value.Values{value.NewValue(c.low)},
+			High:      value.Values{value.NewValue(c.high)},
+			Inclusion: c.inclusion,
+		}}
+		s, err := index.Statistics("", span)
+		if err != nil || s == nil {
+			t.Fatalf("failed to get statistics: %v", err)
+		}
+		if count, _ := s.Count(); count != c.expected {
+			t.Errorf("expected %v entries from %v to %v, got %v", c.expected, c.low, c.high, count)
+		}
+	}
+
+	// no statistics for expressions that were not analyzed
+	index, _ = indexer.CreateIndex("", "ix_id", nil, expression.Expressions{expression.NewIdentifier("id")}, nil, nil)
+	if s, _ := index.Statistics("", &datastore.Span{}); s != nil {
+		t.Errorf("expected no statistics")
+	}
+}
+
+func openKeyspace(t *testing.T, dir string) datastore.Keyspace {
+	store, err := NewDatastore(dir)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	namespace, _ := store.NamespaceByName("default")
+	keyspace, err := namespace.KeyspaceByName("orders")
+	if err != nil {
+		t.Fatalf("failed to get keyspace: %v", err)
+	}
+	return keyspace
+}
+
+func verifyScan(t *testing.T, indexer datastore.Indexer, name string, equal value.Value, expected []string) {
+	index, err := indexer.IndexByName(name)
+	if err != nil {
+		t.Errorf("failed to get index %v: %v", name, err)
+		return
+	}
+
+	span := &datastore.Span2{Ranges: datastore.Ranges2{&datastore.Range2{Inclusion: datastore.BOTH}}}
+	if equal != nil {
+		span.Ranges[0].Low = equal
+		span.Ranges[0].High = equal
+	}
+
+	conn := datastore.NewIndexConnection(&testingContext{t})
+	go index.(datastore.Index2).Scan2("", datastore.Spans2{span}, false, false, true, nil, 0, math.MaxInt64,
+		datastore.UNBOUNDED, nil, conn)
+
+	var keys []string
+	for entry := range conn.EntryChannel() {
+		keys = append(keys, entry.PrimaryKey)
+	}
+
+	if fmt.Sprint(keys) != fmt.Sprint(expected) {
+		t.Errorf("expected %v to scan %v, got %v", name, expected, keys)
+	}
+}
+
 type testingContext struct {
 	t *testing.T
 }
@@ -206,3 +580,93 @@ func (this *testingContext) Warning(wrn errors.Error) {
 func (this *testingContext) Fatal(fatal errors.Error) {
 	this.t.Logf("scan fatal: %v", fatal)
 }
+
+func TestCommitRecovery(t *testing.T) {
+	dir, er := ioutil.TempDir("", "filestore")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+	defer os.RemoveAll(dir)
+
+	// a commit that stopped after renaming o1, before renaming o2 and deleting o3
+	path := filepath.Join(dir, "default", "orders")
+	staging := filepath.Join(path, _STAGING_DIR+"tx1-0")
+	os.MkdirAll(staging, 0755)
+	for _, key := range []string{"o1", "o2", "o3"} {
+		ioutil.WriteFile(filepath.Join(path, key+".json"), []byte(`{"total": 10}`), 0666)
+	}
+	ioutil.WriteFile(filepath.Join(staging, "o2.json"), []byte(`{"total": 20}`), 0666)
+
+	record := &commitRecord{
+		Dirs: []string{staging},
+		Renames: []commitRename{
+			commitRename{Staged: filepath.Join(staging, "o1.json"), Target: filepath.Join(path, "o1.json")},
+			commitRename{Staged: filepath.Join(staging, "o2.json"), Target: filepath.Join(path, "o2.json")},
+			commitRename{Target: filepath.Join(path, "o3.json")},
+		},
+	}
+	s := &store{path: dir}
+	if _, er = s.writeCommitRecord("tx1", record); er != nil {
+		t.Fatalf("failed to write commit record: %v", er)
+	}
+
+	keyspace := openKeyspace(t, dir)
+	count, _ := keyspace.Count(datastore.NULL_QUERY_CONTEXT)
+	if count != 2 {
+		t.Errorf("expected 2 documents, got %v", count)
+	}
+
+	bytes, _ := ioutil.ReadFile(filepath.Join(path, "o2.json"))
+	if string(bytes) != `{"total": 20}` {
+		t.Errorf("expected o2 to be committed, got %s", bytes)
+	}
+
+	if _, er = os.Stat(staging); !os.IsNotExist(er) {
+		t.Errorf("expected staging directory to be removed")
+	}
+	if records, _ := filepath.Glob(filepath.Join(dir, _COMMIT_RECORD+"*")); len(records) != 0 {
+		t.Errorf("expected commit record to be removed, got %v", records)
+	}
+}
+
+func TestCommitUndo(t *testing.T) {
+	dir, er := ioutil.TempDir("", "filestore")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+	defer os.RemoveAll(dir)
+
+	ioutil.WriteFile(filepath.Join(dir, "o1.json"), []byte(`{"total": 10}`), 0666)
+	ioutil.WriteFile(filepath.Join(dir, "o1.staged"), []byte(`{"total": 11}`), 0666)
+	ioutil.WriteFile(filepath.Join(dir, "o2.staged"), []byte(`{"total": 20}`), 0666)
+
+	renames := []commitRename{
+		commitRename{Staged: filepath.Join(dir, "o1.staged"), Target: filepath.Join(dir, "o1.json")},
+		commitRename{Staged: filepath.Join(dir, "o2.staged"), Target: filepath.Join(dir, "o2.json")},
+	}
+	for i, _ := range renames {
+		if er = applyRename(&renames[i], renames[i].Staged+_BACKUP_SUFFIX); er != nil {
+			t.Fatalf("failed to apply rename: %v", er)
+		}
+	}
+	for i := len(renames) - 1; i >= 0; i-- {
+		if er = undoRename(&renames[i], renames[i].Staged+_BACKUP_SUFFIX); er != nil {
+			t.Fatalf("failed to undo rename: %v", er)
+		}
+	}
+
+	bytes, _ := ioutil.ReadFile(filepath.Join(dir, "o1.json"))
+	if string(bytes) != `{"total": 10}` {
+		t.Errorf("expected o1 to be restored, got %s", bytes)
+	}
+	if _, er = os.Stat(filepath.Join(dir, "o2.json")); !os.IsNotExist(er) {
+		t.Errorf("expected o2 to be removed")
+	}
+
+	// the staged documents remain, so the commit can still be completed
+	for _, rename := range renames {
+		if _, er = os.Stat(rename.Staged); er != nil {
+			t.Errorf("expected %v to remain staged", rename.Staged)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package file
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/value"
+)
+
+// Transactions stage their documents in a directory of this prefix in
+// each keyspace they mutate, which scans skip like any other directory
+const _STAGING_DIR = ".staging-"
+
+// Once staged, a transaction is committed by writing a record of this
+// prefix in the store directory, which is not a namespace. The record is
+// first written under the temporary prefix.
+const _COMMIT_RECORD = ".commit-"
+const _COMMIT_TEMP = ".commit.tmp-"
+
+// The documents replaced by a commit are kept in the staging directory
+// under this suffix until the commit completes
+const _BACKUP_SUFFIX = ".orig"
+
+type commitRecord struct {
+	Dirs    []string       `json:"dirs"`
+	Renames []commitRename `json:"renames"`
+}
+
+// a document to rename over its target, or to delete if there is none
+type commitRename struct {
+	Staged string `json:"staged,omitempty"`
+	Target string `json:"target"`
+}
+
+/*
+DocumentVersions returns a digest of the contents of each existing
+document, which changes whenever the document is rewritten.
+*/
+func (s *store) DocumentVersions(ks datastore.Keyspace, keys []string) (map[string]string, errors.Error) {
+	fks, ok := ks.(*keyspace)
+	if !ok || fks.namespace.store != s {
+		return nil, errors.NewFileNotSupported(nil, "Transaction on keyspace "+ks.Name())
+	}
+
+	rv := make(map[string]string, len(keys))
+	for _, key := range keys {
+		version, er := documentVersion(filepath.Join(fks.path(), key+".json"))
+		if er != nil {
+			return nil, errors.NewFileDatastoreError(er, "")
+		}
+		if version != "" {
+			rv[key] = version
+		}
+	}
+	return rv, nil
+}
+
+// documentVersion is empty for a document that does not exist
+func documentVersion(filename string) (string, error) {
+	bytes, er := ioutil.ReadFile(filename)
+	if os.IsNotExist(er) {
+		return "", nil
+	} else if er != nil {
+		return "", er
+	}
+
+	digest := sha256.Sum256(bytes)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+/*
+CommitTransaction applies the mutations of a transaction. The keyspaces
+are locked while the documents the transaction read are checked to be
+unchanged, the mutations are checked against the documents on disk,
+and the new documents are written to staging directories. Then a
+commit record is written, and the staged documents are renamed over the
+keyspace documents. If a rename fails, those already done are undone;
+if the store stops first, the commit is completed when it is reopened.
+Either way, a commit is never left half applied.
+*/
+func (s *store) CommitTransaction(txid string, mutations []*datastore.Mutation,
+	reads []*datastore.Read) errors.Error {
+	keyspaces := make(map[string]*keyspace, 1)
+	for _, m := range mutations {
+		ks, ok := m.Keyspace.(*keyspace)
+		if !ok || ks.namespace.store != s {
+			return errors.NewFileNotSupported(nil, "Transaction on keyspace "+m.Keyspace.Name())
+		}
+		keyspaces[ks.path()] = ks
+	}
+	for _, r := range reads {
+		ks, ok := r.Keyspace.(*keyspace)
+		if !ok || ks.namespace.store != s {
+			return errors.NewFileNotSupported(nil, "Transaction on keyspace "+r.Keyspace.Name())
+		}
+		keyspaces[ks.path()] = ks
+	}
+
+	// Lock the keyspaces in a fixed order, so that concurrent commits
+	// cannot deadlock
+	paths := make([]string, 0, len(keyspaces))
+	for path, _ := range keyspaces {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		keyspaces[path].fileLock.Lock()
+		defer keyspaces[path].fileLock.Unlock()
+	}
+
+	// Documents written since the transaction read them would be
+	// overwritten, or were decided upon from stale values
+	for _, r := range reads {
+		ks := r.Keyspace.(*keyspace)
+		version, er := documentVersion(filepath.Join(ks.path(), r.Key+".json"))
+		if er != nil {
+			return errors.NewFileDatastoreError(er, "")
+		}
+		if version != r.Version {
+			return errors.NewTransactionConflictError(r.Key, ks.Name())
+		}
+	}
+
+	for _, m := range mutations {
+		ks := m.Keyspace.(*keyspace)
+		_, er := os.Stat(filepath.Join(ks.path(), m.Key+".json"))
+		switch {
+		case er != nil && !os.IsNotExist(er):
+			return errors.NewFileDatastoreError(er, "")
+		case m.Op == datastore.MUTATE_INSERT && er == nil:
+			return errors.NewFileKeyExists(nil, "Key (File) "+m.Key+" in keyspace "+ks.Name())
+		case m.Op == datastore.MUTATE_UPDATE && er != nil:
+			return errors.NewFileDMLError(nil, "Key (File) "+m.Key+" in keyspace "+ks.Name()+" does not exist")
+		}
+	}
+
+	staging := make(map[string]string, len(keyspaces))
+	defer func() {
+		for _, dir := range staging {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	for path, _ := range keyspaces {
+		dir, er := ioutil.TempDir(path, _STAGING_DIR+txid+"-")
+		if er != nil {
+			return errors.NewFileDatastoreError(er, "")
+		}
+		staging[path] = dir
+	}
+
+	docs := make([][]byte, len(mutations))
+	for i, m := range mutations {
+		if m.Op == datastore.MUTATE_DELETE {
+			continue
+		}
+
+		bytes, er := json.Marshal(m.Value.Actual())
+		if er == nil {
+			docs[i] = bytes
+			er = ioutil.WriteFile(s.stagedPath(staging, m), bytes, 0666)
+		}
+		if er != nil {
+			return errors.NewFileDMLError(er, "Staging key "+m.Key+" failed")
+		}
+	}
+
+	record := &commitRecord{Renames: make([]commitRename, len(mutations))}
+	for _, dir := range staging {
+		record.Dirs = append(record.Dirs, dir)
+	}
+	for i, m := range mutations {
+		record.Renames[i].Target = filepath.Join(m.Keyspace.(*keyspace).path(), m.Key+".json")
+		if m.Op != datastore.MUTATE_DELETE {
+			record.Renames[i].Staged = s.stagedPath(staging, m)
+		}
+	}
+
+	recordPath, er := s.writeCommitRecord(txid, record)
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	// The mutations are known to apply, make them visible
+	for i, m := range mutations {
+		er = applyRename(&record.Renames[i], s.stagedPath(staging, m)+_BACKUP_SUFFIX)
+		if er == nil {
+			continue
+		}
+
+		rv := errors.NewFileDMLError(er, "Commit of key "+m.Key+" failed")
+		for j := i; j >= 0; j-- {
+			if undoRename(&record.Renames[j], s.stagedPath(staging, mutations[j])+_BACKUP_SUFFIX) != nil {
+
+				// leave the commit to be completed when the store is reopened
+				staging = nil
+				return rv
+			}
+		}
+		os.Remove(recordPath)
+		return rv
+	}
+
+	for i, m := range mutations {
+		var doc value.AnnotatedValue
+		if m.Op != datastore.MUTATE_DELETE {
+			doc = value.NewAnnotatedValue(value.NewValue(docs[i]))
+			doc.SetAttachment("meta", map[string]interface{}{"id": m.Key})
+			doc.SetId(m.Key)
+		}
+		m.Keyspace.(*keyspace).fi.updateIndexes(m.Key, doc)
+	}
+
+	os.Remove(recordPath)
+	return nil
+}
+
+// The record is written under a temporary name and renamed, so that it
+// is either complete or absent
+func (s *store) writeCommitRecord(txid string, record *commitRecord) (string, error) {
+	bytes, er := json.Marshal(record)
+	if er != nil {
+		return "", er
+	}
+
+	file, er := ioutil.TempFile(s.path, _COMMIT_TEMP)
+	if er != nil {
+		return "", er
+	}
+	_, er = file.Write(bytes)
+	if er == nil {
+		er = file.Sync()
+	}
+	if cer := file.Close(); er == nil {
+		er = cer
+	}
+
+	path := filepath.Join(s.path, _COMMIT_RECORD+txid)
+	if er == nil {
+		er = os.Rename(file.Name(), path)
+	}
+	if er != nil {
+		os.Remove(file.Name())
+		return "", er
+	}
+	return path, nil
+}
+
+// Move the target to the backup, if it exists, and the staged document
+// to the target
+func applyRename(rename *commitRename, backup string) error {
+	er := os.Rename(rename.Target, backup)
+	if er != nil && !os.IsNotExist(er) {
+		return er
+	}
+
+	if rename.Staged == "" {
+		return nil
+	}
+	return os.Rename(rename.Staged, rename.Target)
+}
+
+// Move the target back to the staged document, if it was applied, and
+// the backup to the target, if there is one
+func undoRename(rename *commitRename, backup string) error {
+	if rename.Staged != "" {
+		if _, er := os.Stat(rename.Staged); os.IsNotExist(er) {
+			er = os.Rename(rename.Target, rename.Staged)
+			if er != nil && !os.IsNotExist(er) {
+				return er
+			}
+		}
+	}
+
+	er := os.Rename(backup, rename.Target)
+	if er != nil && !os.IsNotExist(er) {
+		return er
+	}
+	return nil
+}
+
+/*
+Complete the commits whose records were left behind by a store that
+stopped while committing. The renames are repeated where the staged
+documents remain, and the deletes are repeated, so that completing a
+commit more than once does no harm.
+*/
+func (s *store) recoverCommits() errors.Error {
+	paths, er := filepath.Glob(filepath.Join(s.path, _COMMIT_RECORD+"*"))
+	if er != nil {
+		return errors.NewFileDatastoreError(er, "")
+	}
+
+	// records that were not renamed were never committed
+	temps, _ := filepath.Glob(filepath.Join(s.path, _COMMIT_TEMP+"*"))
+	for _, temp := range temps {
+		os.Remove(temp)
+	}
+
+	for _, path := range paths {
+		var record commitRecord
+		bytes, er := ioutil.ReadFile(path)
+		if er == nil {
+			er = json.Unmarshal(bytes, &record)
+		}
+		if er != nil {
+			return errors.NewFileDatastoreError(er, "")
+		}
+
+		for _, rename := range record.Renames {
+			if rename.Staged == "" {
+				er = os.Remove(rename.Target)
+			} else if _, er = os.Stat(rename.Staged); er == nil {
+				er = os.Rename(rename.Staged, rename.Target)
+			}
+			if er != nil && !os.IsNotExist(er) {
+				return errors.NewFileDatastoreError(er, "")
+			}
+		}
+
+		for _, dir := range record.Dirs {
+			os.RemoveAll(dir)
+		}
+		os.Remove(path)
+	}
+
+	return nil
+}
+
+func (s *store) stagedPath(staging map[string]string, m *datastore.Mutation) string {
+	ks := m.Keyspace.(*keyspace)
+	return filepath.Join(staging[ks.path()], m.Key+".json")
+}
@@ -0,0 +1,28 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package datastore
+
+import (
+	"github.com/couchbase/query/errors"
+)
+
+/*
+FunctionsDatastore is implemented by datastores that persist the
+catalog of user defined functions, so that functions survive restarts
+and are shared by the query nodes using the datastore. A definition is
+the CREATE FUNCTION statement of a function.
+*/
+type FunctionsDatastore interface {
+	Datastore
+
+	FunctionDefinitions() (map[string]string, errors.Error) // Definitions by function name
+	SetFunctionDefinition(name, definition string) errors.Error
+	DeleteFunctionDefinition(name string) errors.Error
+}
@@ -0,0 +1,191 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package datastore
+
+import (
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Returns the range keys of an index, which are ascending for indexes
+that predate index API 2.
+*/
+func IndexRangeKeys(index Index) IndexKeys {
+	if index2, ok := index.(Index2); ok {
+		return index2.RangeKey2()
+	}
+
+	rangeKey := index.RangeKey()
+	rv := make(IndexKeys, len(rangeKey))
+	for i, expr := range rangeKey {
+		rv[i] = &IndexKey{Expr: expr}
+	}
+	return rv
+}
+
+/*
+Returns the keys a document is indexed under. Documents whose leading
+key is MISSING, or that do not satisfy the index condition, are not
+indexed. An array key produces a set of keys per element of the array.
+*/
+func EvaluateIndexKeys(rangeKey IndexKeys, condition expression.Expression,
+	doc value.AnnotatedValue) ([]value.Values, error) {
+	context := expression.NewIndexContext()
+
+	if condition != nil {
+		cond, err := condition.Evaluate(doc, context)
+		if err != nil {
+			return nil, err
+		}
+
+		if !cond.Truth() {
+			return nil, nil
+		}
+	}
+
+	keys := []value.Values{make(value.Values, len(rangeKey))}
+	for i, indexKey := range rangeKey {
+		val, vals, err := indexKey.Expr.EvaluateForIndex(doc, context)
+		if err != nil {
+			return nil, err
+		}
+
+		if vals == nil {
+			if i == 0 && val.Type() == value.MISSING {
+				return nil, nil
+			}
+
+			for _, key := range keys {
+				key[i] = val
+			}
+			continue
+		}
+
+		if _, distinct := indexKey.Expr.IsArrayIndexKey(); distinct {
+			vals = distinctValues(vals)
+		}
+
+		expanded := make([]value.Values, 0, len(keys)*len(vals))
+		for _, key := range keys {
+			for _, v := range vals {
+				if i == 0 && v.Type() == value.MISSING {
+					continue
+				}
+
+				k := make(value.Values, len(key))
+				copy(k, key)
+				k[i] = v
+				expanded = append(expanded, k)
+			}
+		}
+		keys = expanded
+	}
+
+	return keys, nil
+}
+
+func distinctValues(vals value.Values) value.Values {
+	rv := make(value.Values, 0, len(vals))
+outer:
+	for _, v := range vals {
+		for _, r := range rv {
+			if v.Collate(r) == 0 {
+				continue outer
+			}
+		}
+		rv = append(rv, v)
+	}
+	return rv
+}
+
+/*
+Compares two index keys in index order, honoring descending keys.
+*/
+func CompareIndexKeys(a, b value.Values, rangeKey IndexKeys) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		c := a[i].Collate(b[i])
+		if c != 0 {
+			if i < len(rangeKey) && rangeKey[i].Desc {
+				return -c
+			}
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+/*
+Whether an index key is within an index API 1 span. The bounds of the
+span are compared with the leading keys.
+*/
+func MatchSpan(key value.Values, span *Span) bool {
+	if len(span.Seek) > 0 {
+		return compareBound(key, span.Seek) == 0
+	}
+
+	if len(span.Range.Low) > 0 {
+		c := compareBound(key, span.Range.Low)
+		if c < 0 || (c == 0 && span.Range.Inclusion&LOW == 0) {
+			return false
+		}
+	}
+
+	if len(span.Range.High) > 0 {
+		c := compareBound(key, span.Range.High)
+		if c > 0 || (c == 0 && span.Range.Inclusion&HIGH == 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Whether an index key is within an index API 2 span.
+*/
+func MatchSpan2(key value.Values, span *Span2) bool {
+	for i, rng := range span.Ranges {
+		if i >= len(key) {
+			break
+		}
+
+		if rng.Low != nil {
+			c := key[i].Collate(rng.Low)
+			if c < 0 || (c == 0 && rng.Inclusion&LOW == 0) {
+				return false
+			}
+		}
+
+		if rng.High != nil {
+			c := key[i].Collate(rng.High)
+			if c > 0 || (c == 0 && rng.Inclusion&HIGH == 0) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// compareBound compares the leading keys of an entry with a bound
+func compareBound(key, bound value.Values) int {
+	for i, b := range bound {
+		if i >= len(key) {
+			return -1
+		}
+
+		c := key[i].Collate(b)
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
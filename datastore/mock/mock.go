@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/couchbase/query/auth"
 	"github.com/couchbase/query/datastore"
@@ -33,6 +34,9 @@ import (
 	"github.com/couchbase/query/value"
 )
 
+// Statistics of the document keys back the statistics of the primary index
+var _META_ID = expression.NewField(expression.NewMeta(), expression.NewFieldName("id", false))
+
 const (
 	DEFAULT_NUM_NAMESPACES = 1
 	DEFAULT_NUM_KEYSPACES  = 1
@@ -185,6 +189,8 @@ type keyspace struct {
 	name      string
 	nitems    int
 	mi        datastore.Indexer
+	statsLock sync.RWMutex
+	stats     []*datastore.ExpressionStatistics
 }
 
 func (b *keyspace) NamespaceId() string {
@@ -283,6 +289,40 @@ func (b *keyspace) Delete(deletes []string, context datastore.QueryContext) ([]s
 	return nil, errors.NewOtherNotImplementedError(nil, "for Mock datastore")
 }
 
+func (b *keyspace) Truncate(context datastore.QueryContext) errors.Error {
+	b.nitems = 0
+	return nil
+}
+
+// UpdateStatistics replaces the statistics of the analyzed expressions,
+// and keeps those of the other expressions.
+func (b *keyspace) UpdateStatistics(stats []*datastore.ExpressionStatistics) errors.Error {
+	b.statsLock.Lock()
+	defer b.statsLock.Unlock()
+
+	rv := make([]*datastore.ExpressionStatistics, 0, len(b.stats)+len(stats))
+	for _, s := range b.stats {
+		replaced := false
+		for _, ns := range stats {
+			if ns.Expression == s.Expression {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			rv = append(rv, s)
+		}
+	}
+	b.stats = append(rv, stats...)
+	return nil
+}
+
+func (b *keyspace) KeyspaceStatistics() ([]*datastore.ExpressionStatistics, errors.Error) {
+	b.statsLock.RLock()
+	defer b.statsLock.RUnlock()
+	return b.stats, nil
+}
+
 func (b *keyspace) Release() {
 }
 
@@ -481,7 +521,7 @@ func (pi *primaryIndex) State() (state datastore.IndexState, msg string, err err
 
 func (pi *primaryIndex) Statistics(requestId string, span *datastore.Span) (
 	datastore.Statistics, errors.Error) {
-	return nil, nil
+	return datastore.IndexStatistics(pi.keyspace, _META_ID, span)
 }
 
 func (pi *primaryIndex) Drop(requestId string) errors.Error {
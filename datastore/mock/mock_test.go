@@ -145,6 +145,15 @@ func TestMock(t *testing.T) {
 		t.Fatalf("expected not-an-item")
 	}
 
+	err = b.(datastore.TruncateKeyspace).Truncate(datastore.NULL_QUERY_CONTEXT)
+	if err != nil {
+		t.Fatalf("expected truncate to succeed")
+	}
+
+	c, err = b.Count(datastore.NULL_QUERY_CONTEXT)
+	if err != nil || c != 0 {
+		t.Fatalf("expected no items after truncate")
+	}
 }
 
 func TestMockIndex(t *testing.T) {
@@ -0,0 +1,258 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package datastore
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+// Number of bins of the histograms built by UPDATE STATISTICS
+const HISTOGRAM_BINS = 20
+
+/*
+StatisticsKeyspace is implemented by keyspaces that can store the
+optimizer statistics collected by UPDATE STATISTICS. The stored
+statistics back the index statistics of datastores whose indexes
+cannot provide their own.
+*/
+type StatisticsKeyspace interface {
+	Keyspace
+
+	UpdateStatistics(stats []*ExpressionStatistics) errors.Error // Replace the statistics of the expressions
+	KeyspaceStatistics() ([]*ExpressionStatistics, errors.Error) // Statistics of all the analyzed expressions
+}
+
+/*
+ExpressionStatistics are the statistics of the values of an expression
+over the documents of a keyspace, estimated from a sample. The first
+bin of the histogram holds the values from Min to its Max, and every
+following bin the values above the Max of the previous bin.
+*/
+type ExpressionStatistics struct {
+	Expression    string          `json:"expression"`
+	Count         int64           `json:"count"`
+	DistinctCount int64           `json:"distinct_count"`
+	Min           interface{}     `json:"min"`
+	Histogram     []*HistogramBin `json:"histogram"`
+	SampleSize    int64           `json:"sample_size"`
+	Updated       string          `json:"updated"`
+}
+
+type HistogramBin struct {
+	Max           interface{} `json:"max"`
+	Count         int64       `json:"count"`
+	DistinctCount int64       `json:"distinct_count"`
+}
+
+/*
+Build the statistics of an expression from its values in a sample of
+docs documents, drawn from a keyspace of total documents. MISSING values
+are not counted. Bins are equi-depth, but values are never split across
+bins. The number of distinct values in the keyspace is estimated with
+the Duj1 estimator of Haas et al.
+*/
+func NewExpressionStatistics(expr string, vals value.Values, docs, total int64) *ExpressionStatistics {
+	rv := &ExpressionStatistics{
+		Expression: expr,
+		SampleSize: docs,
+		Histogram:  []*HistogramBin{},
+		Updated:    time.Now().Format(time.RFC3339),
+	}
+
+	n := len(vals)
+	if n == 0 || docs <= 0 {
+		return rv
+	}
+
+	sort.Slice(vals, func(i, j int) bool { return vals[i].Collate(vals[j]) < 0 })
+
+	// Scale of the sample to the keyspace
+	scale := 1.0
+	if total > docs {
+		scale = float64(total) / float64(docs)
+	}
+	count := float64(n) * scale
+
+	depth := (n + HISTOGRAM_BINS - 1) / HISTOGRAM_BINS
+	distinct, singles := 0, 0
+	start, binStart, binDistinct := 0, 0, 0
+	bins := make([]int, 0, HISTOGRAM_BINS)
+	for i := 1; i <= n; i++ {
+		if i < n && vals[i].Collate(vals[start]) == 0 {
+			continue
+		}
+
+		// vals[start:i] are equal
+		distinct++
+		binDistinct++
+		if i-start == 1 {
+			singles++
+		}
+		start = i
+
+		if i-binStart >= depth || i == n {
+			rv.Histogram = append(rv.Histogram, &HistogramBin{
+				Max:   vals[i-1],
+				Count: int64(math.Ceil(float64(i-binStart) * scale)),
+			})
+			bins = append(bins, binDistinct)
+			binStart, binDistinct = i, 0
+		}
+	}
+
+	d := float64(distinct)
+	if scale > 1.0 {
+		fn := float64(n)
+		f1 := float64(singles)
+		d = fn * d / (fn - f1 + f1*fn/count)
+	}
+
+	rv.Min = vals[0]
+	rv.Count = int64(math.Ceil(count))
+	rv.DistinctCount = int64(math.Ceil(d))
+	for i, bin := range rv.Histogram {
+		bin.DistinctCount = int64(math.Ceil(float64(bins[i]) * d / float64(distinct)))
+		if bin.DistinctCount > bin.Count {
+			bin.DistinctCount = bin.Count
+		}
+	}
+
+	return rv
+}
+
+/*
+Estimate the number of values in the range of the leading key of a
+span. Bins wholly within the range, and bins of a single value within
+the range, are counted in full. A range of one value within a bin counts
+the average number of each value of the bin, and any other range half
+of the bin.
+*/
+func (this *ExpressionStatistics) SpanStatistics(span *Span) Statistics {
+	var low, high value.Value
+	lowIncl, highIncl := true, true
+	if len(span.Seek) > 0 {
+		low, high = span.Seek[0], span.Seek[0]
+	} else {
+		if len(span.Range.Low) > 0 {
+			low = span.Range.Low[0]
+			lowIncl = len(span.Range.Low) > 1 || span.Range.Inclusion&LOW != 0
+		}
+		if len(span.Range.High) > 0 {
+			high = span.Range.High[0]
+			highIncl = len(span.Range.High) > 1 || span.Range.Inclusion&HIGH != 0
+		}
+	}
+	equal := low != nil && high != nil && lowIncl && highIncl && low.Collate(high) == 0
+
+	count, distinct := 0.0, 0.0
+	min := value.NewValue(this.Min)
+	for i, bin := range this.Histogram {
+		max := value.NewValue(bin.Max)
+
+		// bins below the range
+		if low != nil {
+			c := max.Collate(low)
+			if c < 0 || (c == 0 && !lowIncl) {
+				min = max
+				continue
+			}
+		}
+
+		// bins above the range; only the first bin includes its lower bound
+		if high != nil {
+			c := min.Collate(high)
+			if c > 0 || (c == 0 && (i > 0 || !highIncl)) {
+				break
+			}
+		}
+
+		// the only value of a bin of a single value is its Max
+		maxWithin := high == nil || max.Collate(high) < 0 || (highIncl && max.Collate(high) == 0)
+		within := maxWithin && (bin.DistinctCount <= 1 || low == nil ||
+			(i > 0 && min.Collate(low) >= 0) ||
+			(i == 0 && (min.Collate(low) > 0 || (lowIncl && min.Collate(low) == 0))))
+
+		switch {
+		case within:
+			count += float64(bin.Count)
+			distinct += float64(bin.DistinctCount)
+		case equal:
+			if bin.DistinctCount > 0 {
+				count += float64(bin.Count) / float64(bin.DistinctCount)
+				distinct++
+			}
+		default:
+			count += float64(bin.Count) / 2.0
+			distinct += float64(bin.DistinctCount) / 2.0
+		}
+		min = max
+	}
+
+	return &spanStatistics{
+		count:    int64(math.Ceil(count)),
+		distinct: int64(math.Ceil(distinct)),
+	}
+}
+
+/*
+Return the statistics of a span of an index, from the stored statistics
+of its leading key. Returns nil when the keyspace does not store
+statistics, or the leading key has not been analyzed.
+*/
+func IndexStatistics(keyspace Keyspace, key expression.Expression, span *Span) (Statistics, errors.Error) {
+	sk, ok := keyspace.(StatisticsKeyspace)
+	if !ok || key == nil || span == nil {
+		return nil, nil
+	}
+
+	stats, err := sk.KeyspaceStatistics()
+	if err != nil {
+		return nil, err
+	}
+
+	expr := expression.NewStringer().Visit(key)
+	for _, s := range stats {
+		if s.Expression == expr {
+			return s.SpanStatistics(span), nil
+		}
+	}
+	return nil, nil
+}
+
+type spanStatistics struct {
+	count    int64
+	distinct int64
+}
+
+func (this *spanStatistics) Count() (int64, errors.Error) {
+	return this.count, nil
+}
+
+func (this *spanStatistics) Min() (value.Values, errors.Error) {
+	return nil, nil
+}
+
+func (this *spanStatistics) Max() (value.Values, errors.Error) {
+	return nil, nil
+}
+
+func (this *spanStatistics) DistinctCount() (int64, errors.Error) {
+	return this.distinct, nil
+}
+
+func (this *spanStatistics) Bins() ([]Statistics, errors.Error) {
+	return nil, nil
+}
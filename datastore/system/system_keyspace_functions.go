@@ -0,0 +1,237 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package system
+
+import (
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/functions"
+	"github.com/couchbase/query/timestamp"
+	"github.com/couchbase/query/value"
+)
+
+type functionsKeyspace struct {
+	keyspaceBase
+	name    string
+	indexer datastore.Indexer
+}
+
+func (b *functionsKeyspace) Release() {
+}
+
+func (b *functionsKeyspace) NamespaceId() string {
+	return b.namespace.Id()
+}
+
+func (b *functionsKeyspace) Id() string {
+	return b.Name()
+}
+
+func (b *functionsKeyspace) Name() string {
+	return b.name
+}
+
+func (b *functionsKeyspace) Count(context datastore.QueryContext) (int64, errors.Error) {
+	if err := functions.Load(); err != nil {
+		return 0, err
+	}
+	return int64(functions.CountFunctions()), nil
+}
+
+func (b *functionsKeyspace) Indexer(name datastore.IndexType) (datastore.Indexer, errors.Error) {
+	return b.indexer, nil
+}
+
+func (b *functionsKeyspace) Indexers() ([]datastore.Indexer, errors.Error) {
+	return []datastore.Indexer{b.indexer}, nil
+}
+
+func (b *functionsKeyspace) Fetch(keys []string, keysMap map[string]value.AnnotatedValue,
+	context datastore.QueryContext, subPaths []string) (errs []errors.Error) {
+	if err := functions.Load(); err != nil {
+		return []errors.Error{err}
+	}
+
+	for _, k := range keys {
+		item, e := b.fetchOne(k)
+
+		if e != nil {
+			if errs == nil {
+				errs = make([]errors.Error, 0, 1)
+			}
+			errs = append(errs, e)
+			continue
+		}
+
+		if item != nil {
+			item.SetAttachment("meta", map[string]interface{}{
+				"id": k,
+			})
+			item.SetId(k)
+		}
+
+		keysMap[k] = item
+	}
+
+	return
+}
+
+func (b *functionsKeyspace) fetchOne(key string) (value.AnnotatedValue, errors.Error) {
+	function := functions.GetFunction(key)
+	if function != nil {
+		parameters := make([]interface{}, len(function.Parameters()))
+		for i, param := range function.Parameters() {
+			parameters[i] = param
+		}
+
+		doc := value.NewAnnotatedValue(map[string]interface{}{
+			"name":       function.Name(),
+			"parameters": parameters,
+			"body":       function.Body().String(),
+			"definition": function.Text(),
+		})
+		return doc, nil
+	}
+	return nil, errors.NewSystemDatastoreError(nil, "Key Not Found "+key)
+}
+
+func (b *functionsKeyspace) Insert(inserts []value.Pair) ([]value.Pair, errors.Error) {
+	return nil, errors.NewSystemNotImplementedError(nil, "")
+}
+
+func (b *functionsKeyspace) Update(updates []value.Pair) ([]value.Pair, errors.Error) {
+	return nil, errors.NewSystemNotImplementedError(nil, "")
+}
+
+func (b *functionsKeyspace) Upsert(upserts []value.Pair) ([]value.Pair, errors.Error) {
+	return nil, errors.NewSystemNotImplementedError(nil, "")
+}
+
+func (b *functionsKeyspace) Delete(deletes []string, context datastore.QueryContext) ([]string, errors.Error) {
+	return nil, errors.NewSystemNotImplementedError(nil, "")
+}
+
+func newFunctionsKeyspace(p *namespace) (*functionsKeyspace, errors.Error) {
+	b := new(functionsKeyspace)
+	setKeyspaceBase(&b.keyspaceBase, p)
+	b.name = KEYSPACE_NAME_FUNCTIONS
+
+	primary := &functionsIndex{name: "#primary", keyspace: b}
+	b.indexer = newSystemIndexer(b, primary)
+	setIndexBase(&primary.indexBase, b.indexer)
+
+	return b, nil
+}
+
+type functionsIndex struct {
+	indexBase
+	name     string
+	keyspace *functionsKeyspace
+}
+
+func (pi *functionsIndex) KeyspaceId() string {
+	return pi.keyspace.Id()
+}
+
+func (pi *functionsIndex) Id() string {
+	return pi.Name()
+}
+
+func (pi *functionsIndex) Name() string {
+	return pi.name
+}
+
+func (pi *functionsIndex) Type() datastore.IndexType {
+	return datastore.SYSTEM
+}
+
+func (pi *functionsIndex) SeekKey() expression.Expressions {
+	return nil
+}
+
+func (pi *functionsIndex) RangeKey() expression.Expressions {
+	return nil
+}
+
+func (pi *functionsIndex) Condition() expression.Expression {
+	return nil
+}
+
+func (pi *functionsIndex) IsPrimary() bool {
+	return true
+}
+
+func (pi *functionsIndex) State() (state datastore.IndexState, msg string, err errors.Error) {
+	return datastore.ONLINE, "", nil
+}
+
+func (pi *functionsIndex) Statistics(requestId string, span *datastore.Span) (
+	datastore.Statistics, errors.Error) {
+	return nil, nil
+}
+
+func (pi *functionsIndex) Drop(requestId string) errors.Error {
+	return errors.NewSystemIdxNoDropError(nil, "")
+}
+
+func (pi *functionsIndex) Scan(requestId string, span *datastore.Span, distinct bool, limit int64,
+	cons datastore.ScanConsistency, vector timestamp.Vector, conn *datastore.IndexConnection) {
+	if span == nil || len(span.Seek) == 0 {
+		pi.ScanEntries(requestId, limit, cons, vector, conn)
+	} else {
+		defer close(conn.EntryChannel())
+
+		spanEvaluator, err := compileSpan(span)
+		if err == nil {
+			err = functions.Load()
+		}
+		if err != nil {
+			conn.Error(err)
+		} else {
+			var numProduced int64 = 0
+
+		loop:
+			for _, name := range functions.NameFunctions() {
+				if spanEvaluator.evaluate(name) {
+					entry := datastore.IndexEntry{PrimaryKey: name}
+					if !sendSystemKey(conn, &entry) {
+						return
+					}
+					numProduced++
+					if limit > 0 && numProduced >= limit {
+						break loop
+					}
+				}
+			}
+		}
+	}
+}
+
+func (pi *functionsIndex) ScanEntries(requestId string, limit int64, cons datastore.ScanConsistency,
+	vector timestamp.Vector, conn *datastore.IndexConnection) {
+	defer close(conn.EntryChannel())
+
+	if err := functions.Load(); err != nil {
+		conn.Error(err)
+		return
+	}
+
+	for i, name := range functions.NameFunctions() {
+		if limit > 0 && int64(i) >= limit {
+			break
+		}
+
+		entry := datastore.IndexEntry{PrimaryKey: name}
+		if !sendSystemKey(conn, &entry) {
+			return
+		}
+	}
+}
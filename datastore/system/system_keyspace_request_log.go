@@ -135,6 +135,9 @@ func (b *requestLogKeyspace) Fetch(keys []string, keysMap map[string]value.Annot
 				if entry.PhaseOperators != nil {
 					item.SetField("phaseOperators", entry.PhaseOperators)
 				}
+				if entry.UsedMemory > 0 {
+					item.SetField("usedMemory", int64(entry.UsedMemory))
+				}
 				if entry.PositionalArgs != nil {
 					item.SetField("positionalArgs", entry.PositionalArgs)
 				}
@@ -0,0 +1,292 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package system
+
+import (
+	"strings"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/logging"
+	"github.com/couchbase/query/timestamp"
+	"github.com/couchbase/query/value"
+)
+
+// system:statistics lists the optimizer statistics stored by UPDATE STATISTICS,
+// one document per analyzed expression, keyed by namespace/keyspace/expression.
+type statisticsKeyspace struct {
+	keyspaceBase
+	name    string
+	indexer datastore.Indexer
+}
+
+func (b *statisticsKeyspace) Release() {
+}
+
+func (b *statisticsKeyspace) NamespaceId() string {
+	return b.namespace.Id()
+}
+
+func (b *statisticsKeyspace) Id() string {
+	return b.Name()
+}
+
+func (b *statisticsKeyspace) Name() string {
+	return b.name
+}
+
+func (b *statisticsKeyspace) Count(context datastore.QueryContext) (int64, errors.Error) {
+	count := int64(0)
+	err := b.forEach(func(namespaceId, keyspaceId string, stats []*datastore.ExpressionStatistics) bool {
+		if !canRead(context, namespaceId, keyspaceId) {
+			if len(stats) > 0 {
+				context.Warning(errors.NewSystemFilteredRowsWarning("system:statistics"))
+			}
+		} else {
+			count += int64(len(stats))
+		}
+		return true
+	})
+	if err != nil {
+		return 0, errors.NewSystemDatastoreError(err, "")
+	}
+	return count, nil
+}
+
+// forEach calls f with the statistics of every keyspace that stores them,
+// until f returns false.
+func (b *statisticsKeyspace) forEach(f func(string, string, []*datastore.ExpressionStatistics) bool) errors.Error {
+	actualStore := b.namespace.store.actualStore
+	namespaceIds, err := actualStore.NamespaceIds()
+	if err != nil {
+		return err
+	}
+
+	for _, namespaceId := range namespaceIds {
+		namespace, err := actualStore.NamespaceById(namespaceId)
+		if err != nil {
+			return err
+		}
+
+		keyspaceIds, err := namespace.KeyspaceIds()
+		if err != nil {
+			return err
+		}
+
+		for _, keyspaceId := range keyspaceIds {
+			keyspace, err := namespace.KeyspaceById(keyspaceId)
+			if err != nil {
+				return err
+			}
+
+			sk, ok := keyspace.(datastore.StatisticsKeyspace)
+			if !ok {
+				continue
+			}
+
+			stats, err := sk.KeyspaceStatistics()
+			if err != nil {
+				return err
+			}
+
+			if !f(namespaceId, keyspaceId, stats) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *statisticsKeyspace) Indexer(name datastore.IndexType) (datastore.Indexer, errors.Error) {
+	return b.indexer, nil
+}
+
+func (b *statisticsKeyspace) Indexers() ([]datastore.Indexer, errors.Error) {
+	return []datastore.Indexer{b.indexer}, nil
+}
+
+func (b *statisticsKeyspace) Fetch(keys []string, keysMap map[string]value.AnnotatedValue,
+	context datastore.QueryContext, subPaths []string) (errs []errors.Error) {
+
+	for _, key := range keys {
+		ids := strings.SplitN(key, "/", 3)
+		if len(ids) < 3 {
+			errs = append(errs, errors.NewSystemMalformedKeyError(key, "system:statistics"))
+			continue
+		}
+
+		if !canRead(context, ids[0], ids[1]) {
+			context.Warning(errors.NewSystemFilteredRowsWarning("system:statistics"))
+			continue
+		}
+
+		err := b.fetchOne(key, keysMap, ids[0], ids[1], ids[2])
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return
+}
+
+func (b *statisticsKeyspace) fetchOne(key string, keysMap map[string]value.AnnotatedValue,
+	namespaceId, keyspaceId, expr string) errors.Error {
+
+	namespace, err := b.namespace.store.actualStore.NamespaceById(namespaceId)
+	if err != nil {
+		return err
+	}
+
+	keyspace, err := namespace.KeyspaceById(keyspaceId)
+	if err != nil {
+		return err
+	}
+
+	sk, ok := keyspace.(datastore.StatisticsKeyspace)
+	if !ok {
+		return nil
+	}
+
+	stats, err := sk.KeyspaceStatistics()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range stats {
+		if s.Expression != expr {
+			continue
+		}
+
+		doc := value.NewAnnotatedValue(datastoreObjectToJSONSafe(s))
+		doc.SetField("namespace_id", namespaceId)
+		doc.SetField("keyspace_id", keyspaceId)
+		doc.SetAttachment("meta", map[string]interface{}{
+			"id": key,
+		})
+		doc.SetId(key)
+
+		keysMap[key] = doc
+		break
+	}
+
+	return nil
+}
+
+func (b *statisticsKeyspace) Insert(inserts []value.Pair) ([]value.Pair, errors.Error) {
+	return nil, errors.NewSystemNotImplementedError(nil, "")
+}
+
+func (b *statisticsKeyspace) Update(updates []value.Pair) ([]value.Pair, errors.Error) {
+	return nil, errors.NewSystemNotImplementedError(nil, "")
+}
+
+func (b *statisticsKeyspace) Upsert(upserts []value.Pair) ([]value.Pair, errors.Error) {
+	return nil, errors.NewSystemNotImplementedError(nil, "")
+}
+
+func (b *statisticsKeyspace) Delete(deletes []string, context datastore.QueryContext) ([]string, errors.Error) {
+	return nil, errors.NewSystemNotImplementedError(nil, "")
+}
+
+func newStatisticsKeyspace(p *namespace) (*statisticsKeyspace, errors.Error) {
+	b := new(statisticsKeyspace)
+	setKeyspaceBase(&b.keyspaceBase, p)
+	b.name = KEYSPACE_NAME_STATISTICS
+
+	primary := &statisticsIndex{name: "#primary", keyspace: b}
+	b.indexer = newSystemIndexer(b, primary)
+	setIndexBase(&primary.indexBase, b.indexer)
+
+	return b, nil
+}
+
+type statisticsIndex struct {
+	indexBase
+	name     string
+	keyspace *statisticsKeyspace
+}
+
+func (pi *statisticsIndex) KeyspaceId() string {
+	return pi.keyspace.Id()
+}
+
+func (pi *statisticsIndex) Id() string {
+	return pi.Name()
+}
+
+func (pi *statisticsIndex) Name() string {
+	return pi.name
+}
+
+func (pi *statisticsIndex) Type() datastore.IndexType {
+	return datastore.SYSTEM
+}
+
+func (pi *statisticsIndex) SeekKey() expression.Expressions {
+	return nil
+}
+
+func (pi *statisticsIndex) RangeKey() expression.Expressions {
+	return nil
+}
+
+func (pi *statisticsIndex) Condition() expression.Expression {
+	return nil
+}
+
+func (pi *statisticsIndex) IsPrimary() bool {
+	return true
+}
+
+func (pi *statisticsIndex) State() (state datastore.IndexState, msg string, err errors.Error) {
+	return datastore.ONLINE, "", nil
+}
+
+func (pi *statisticsIndex) Statistics(requestId string, span *datastore.Span) (
+	datastore.Statistics, errors.Error) {
+	return nil, nil
+}
+
+func (pi *statisticsIndex) Drop(requestId string) errors.Error {
+	return errors.NewSystemIdxNoDropError(nil, "")
+}
+
+func (pi *statisticsIndex) Scan(requestId string, span *datastore.Span, distinct bool, limit int64,
+	cons datastore.ScanConsistency, vector timestamp.Vector, conn *datastore.IndexConnection) {
+	pi.ScanEntries(requestId, limit, cons, vector, conn)
+}
+
+func (pi *statisticsIndex) ScanEntries(requestId string, limit int64, cons datastore.ScanConsistency,
+	vector timestamp.Vector, conn *datastore.IndexConnection) {
+	defer close(conn.EntryChannel())
+
+	var numProduced int64
+	err := pi.keyspace.forEach(func(namespaceId, keyspaceId string, stats []*datastore.ExpressionStatistics) bool {
+		for _, s := range stats {
+			if limit > 0 && numProduced >= limit {
+				return false
+			}
+
+			entry := datastore.IndexEntry{PrimaryKey: namespaceId + "/" + keyspaceId + "/" + s.Expression}
+			if !sendSystemKey(conn, &entry) {
+				return false
+			}
+			numProduced++
+		}
+		return true
+	})
+
+	if err != nil {
+		logging.Errorf("Scanning statistics failed %v", err)
+		conn.Warning(errors.NewSystemDatastoreError(err, ""))
+	}
+}
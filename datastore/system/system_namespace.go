@@ -154,5 +154,17 @@ func (p *namespace) loadKeyspaces() (e errors.Error) {
 	}
 	p.keyspaces[applicableRoles.Name()] = applicableRoles
 
+	functions, e := newFunctionsKeyspace(p)
+	if e != nil {
+		return e
+	}
+	p.keyspaces[functions.Name()] = functions
+
+	statistics, e := newStatisticsKeyspace(p)
+	if e != nil {
+		return e
+	}
+	p.keyspaces[statistics.Name()] = statistics
+
 	return nil
 }
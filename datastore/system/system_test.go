@@ -227,6 +227,40 @@ func TestSystem(t *testing.T) {
 		t.Fatalf("Found unexpected key in keyspaces keyspace")
 	}
 
+	// Statistics stored on a keyspace are listed in the statistics keyspace
+	ns, _ := m.NamespaceByName("p0")
+	ks, _ := ns.KeyspaceByName("b1")
+	stats := datastore.NewExpressionStatistics("`id`", value.Values{value.NewValue(1), value.NewValue(2)}, 2, 2)
+	err = ks.(datastore.StatisticsKeyspace).UpdateStatistics([]*datastore.ExpressionStatistics{stats})
+	if err != nil {
+		t.Fatalf("failed to update statistics: %v", err)
+	}
+
+	sb, err := p.KeyspaceByName("statistics")
+	if err != nil {
+		t.Fatalf("failed to get keyspace by name %v", err)
+	}
+
+	sb_c, err := sb.Count(datastore.NULL_QUERY_CONTEXT)
+	if err != nil || sb_c != 1 {
+		t.Fatalf("failed to get expected statistics keyspace count %v %v", sb_c, err)
+	}
+
+	key = "p0/b1/`id`"
+	sb_e, err := doPrimaryIndexScan(t, sb)
+	if !sb_e[key] {
+		t.Fatalf("failed to get expected key from index scan: %v", key)
+	}
+
+	vals = make(map[string]value.AnnotatedValue, 1)
+	errs = sb.Fetch([]string{key}, vals, datastore.NULL_QUERY_CONTEXT, nil)
+	if errs != nil || vals[key] == nil {
+		t.Fatalf("failed to fetch expected key from statistics keyspace %v", errs)
+	}
+
+	if count, ok := vals[key].Field("distinct_count"); !ok || count.Actual() != float64(2) {
+		t.Fatalf("unexpected distinct count in statistics %v", vals[key])
+	}
 }
 
 type testingContext struct {
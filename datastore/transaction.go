@@ -0,0 +1,60 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package datastore
+
+import (
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/value"
+)
+
+type MutateOp int
+
+const (
+	MUTATE_INSERT MutateOp = iota // The key must not exist
+	MUTATE_UPDATE                 // The key must exist
+	MUTATE_UPSERT                 // The key may or may not exist
+	MUTATE_DELETE                 // The key is removed if it exists
+)
+
+/*
+Mutation is a document change made in a transaction, and applied
+when the transaction commits.
+*/
+type Mutation struct {
+	Op       MutateOp
+	Keyspace Keyspace
+	Key      string
+	Value    value.Value // nil for deletes
+}
+
+/*
+Read is the version of a document when a transaction first read or
+mutated it. An empty version means the document did not exist.
+*/
+type Read struct {
+	Keyspace Keyspace
+	Key      string
+	Version  string
+}
+
+/*
+TransactionDatastore is implemented by datastores that can apply the
+mutations of a transaction atomically: either all the mutations are
+applied, or none is. Transactions buffer their mutations until COMMIT,
+so a datastore only has to implement the final step. A version of a
+document changes whenever the document is written, so that a commit
+can tell whether documents changed after the transaction read them.
+*/
+type TransactionDatastore interface {
+	Datastore
+
+	DocumentVersions(keyspace Keyspace, keys []string) (map[string]string, errors.Error) // Versions of the existing documents
+	CommitTransaction(txid string, mutations []*Mutation, reads []*Read) errors.Error   // Apply all the mutations, or none if a read is stale
+}
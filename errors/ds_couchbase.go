@@ -118,3 +118,8 @@ func NewAuditStreamHandlerFailed(e error) Error {
 	return &err{level: EXCEPTION, ICode: 12019, IKey: "datastore.couchbase.audit_stream_failed event id", ICause: e,
 		InternalMsg: "Audit stream handler failed", InternalCaller: CallerN(1)}
 }
+
+func NewCbMetaKVError(e error, msg string) Error {
+	return &err{level: EXCEPTION, ICode: 12020, IKey: "datastore.couchbase.metakv_error", ICause: e,
+		InternalMsg: "Error accessing metakv " + msg, InternalCaller: CallerN(1)}
+}
@@ -216,3 +216,48 @@ func NewMergeMultiInsertError(key string) Error {
 		InternalMsg:    fmt.Sprintf("Multiple INSERT of the same document (document key '%s') in a MERGE statement", key),
 		InternalCaller: CallerN(1)}
 }
+
+func NewFunctionExistsError(name string) Error {
+	return &err{level: EXCEPTION, ICode: 5340, IKey: "execution.function_exists",
+		InternalMsg: fmt.Sprintf("Function %s already exists.", name), InternalCaller: CallerN(1)}
+}
+
+func NewNoSuchFunctionError(name string) Error {
+	return &err{level: EXCEPTION, ICode: 5350, IKey: "execution.no_such_function",
+		InternalMsg: fmt.Sprintf("Function %s does not exist.", name), InternalCaller: CallerN(1)}
+}
+
+func NewRecursiveFunctionError(name string) Error {
+	return &err{level: EXCEPTION, ICode: 5360, IKey: "execution.recursive_function",
+		InternalMsg: fmt.Sprintf("Function %s cannot call itself, directly or indirectly.", name), InternalCaller: CallerN(1)}
+}
+
+func NewFunctionArgumentsError(name string, expected, actual int) Error {
+	return &err{level: EXCEPTION, ICode: 5370, IKey: "execution.function_arguments",
+		InternalMsg:    fmt.Sprintf("Function %s expects %d arguments, %d given.", name, expected, actual),
+		InternalCaller: CallerN(1)}
+}
+
+func NewSpillError(e error, what string) Error {
+	return &err{level: EXCEPTION, ICode: 5380, IKey: "execution.spill_error", ICause: e,
+		InternalMsg:    fmt.Sprintf("Error spilling %s to disk", what),
+		InternalCaller: CallerN(1)}
+}
+
+func NewMemoryQuotaExceededError(quota int64) Error {
+	return &err{level: EXCEPTION, ICode: 5390, IKey: "execution.memory_quota_exceeded",
+		InternalMsg:    fmt.Sprintf("Request has exceeded its memory quota of %d bytes.", quota),
+		InternalCaller: CallerN(1)}
+}
+
+func NewRecursionLevelsExceededError(alias string, levels int64) Error {
+	return &err{level: EXCEPTION, ICode: 5400, IKey: "execution.recursion_levels_exceeded",
+		InternalMsg: fmt.Sprintf("Recursive WITH %s exceeded the default maximum of %d levels. "+
+			"Use OPTIONS {\"levels\": n} to allow more.", alias, levels),
+		InternalCaller: CallerN(1)}
+}
+
+func NewFunctionStorageError(e error, name string) Error {
+	return &err{level: EXCEPTION, ICode: 5410, IKey: "execution.function_storage", ICause: e,
+		InternalMsg: fmt.Sprintf("Error storing the definition of function %s.", name), InternalCaller: CallerN(1)}
+}
@@ -137,3 +137,40 @@ func NewPartitionIndexNotSupportedError() Error {
 	return &err{level: EXCEPTION, ICode: PARTITION_INDEX_NOT_SUPPORTED, IKey: "plan.partition_index_not_supported",
 		InternalMsg: fmt.Sprintf("PARTITION index is not supported by indexer."), InternalCaller: CallerN(1)}
 }
+
+const TRUNCATE_NOT_SUPPORTED = 4350
+
+func NewTruncateNotSupportedError(keyspace string) Error {
+	return &err{level: EXCEPTION, ICode: TRUNCATE_NOT_SUPPORTED, IKey: "plan.truncate_not_supported",
+		InternalMsg: fmt.Sprintf("TRUNCATE is not supported by keyspace %s.", keyspace), InternalCaller: CallerN(1)}
+}
+
+const RIGHT_OUTER_JOIN_CORRELATED = 4360
+
+func NewRightOuterJoinCorrelatedError(alias string) Error {
+	return &err{level: EXCEPTION, ICode: RIGHT_OUTER_JOIN_CORRELATED, IKey: "plan.ansi_join.right_outer_correlated",
+		InternalMsg:    fmt.Sprintf("RIGHT or FULL OUTER JOIN term %s cannot depend on the left side of the join.", alias),
+		InternalCaller: CallerN(1)}
+}
+
+const SAMPLE_NESTED_LOOP = 4370
+
+func NewSampleNestedLoopError(alias string) Error {
+	return &err{level: EXCEPTION, ICode: SAMPLE_NESTED_LOOP, IKey: "plan.sample.nested_loop",
+		InternalMsg:    fmt.Sprintf("SAMPLE on %s is only allowed on the first FROM term, or with a hash join.", alias),
+		InternalCaller: CallerN(1)}
+}
+
+const UPDATE_STATISTICS_NOT_SUPPORTED = 4380
+
+func NewUpdateStatisticsNotSupportedError(keyspace string) Error {
+	return &err{level: EXCEPTION, ICode: UPDATE_STATISTICS_NOT_SUPPORTED, IKey: "plan.update_statistics_not_supported",
+		InternalMsg: fmt.Sprintf("UPDATE STATISTICS is not supported by keyspace %s.", keyspace), InternalCaller: CallerN(1)}
+}
+
+const ADVISE_NOT_SUPPORTED = 4390
+
+func NewAdviseNotSupportedError(stmtType string) Error {
+	return &err{level: EXCEPTION, ICode: ADVISE_NOT_SUPPORTED, IKey: "plan.advise_not_supported",
+		InternalMsg: fmt.Sprintf("ADVISE is not supported for %s statements.", stmtType), InternalCaller: CallerN(1)}
+}
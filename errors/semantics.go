@@ -114,6 +114,30 @@ func NewAnsiKeyspaceOnlyError(op string, alias string, iKey string) Error {
 		InternalCaller: CallerN(1)}
 }
 
+const SAMPLE_KEYSPACE_ONLY = 3220
+
+func NewSampleKeyspaceOnlyError(alias string) Error {
+	return &err{level: EXCEPTION, ICode: SAMPLE_KEYSPACE_ONLY, IKey: "semantics.sample.keyspace_only",
+		InternalMsg:    fmt.Sprintf("SAMPLE (on %s) must be done on a keyspace.", alias),
+		InternalCaller: CallerN(1)}
+}
+
+const SAMPLE_NOT_CONSTANT = 3230
+
+func NewSampleNotConstantError(alias string) Error {
+	return &err{level: EXCEPTION, ICode: SAMPLE_NOT_CONSTANT, IKey: "semantics.sample.not_constant",
+		InternalMsg:    fmt.Sprintf("SAMPLE size and SEED (on %s) must be constants or parameters.", alias),
+		InternalCaller: CallerN(1)}
+}
+
+const JOIN_NEST_NO_SAMPLE = 3240
+
+func NewJoinNestNoSampleError(op string, alias string, iKey string) Error {
+	return &err{level: EXCEPTION, ICode: JOIN_NEST_NO_SAMPLE, IKey: iKey,
+		InternalMsg:    fmt.Sprintf("%s on %s cannot have SAMPLE.", op, alias),
+		InternalCaller: CallerN(1)}
+}
+
 /* ---- BEGIN MOVED error numbers ----
    The following error numbers (in the 4000 range) originally reside in plan.go (before the introduction of the semantics package)
    although they are semantic errors. They are moved from plan.go to semantics.go but their original error numbers are kept.
@@ -0,0 +1,82 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package errors
+
+import (
+	"fmt"
+)
+
+// Transaction error codes
+
+func NewTransactionNotSupportedError(datastore string) Error {
+	return &err{level: EXCEPTION, ICode: 17000, IKey: "transaction.not_supported",
+		InternalMsg:    fmt.Sprintf("Transactions are not supported by datastore %s.", datastore),
+		InternalCaller: CallerN(1)}
+}
+
+func NewNoSuchTransactionError(txid string) Error {
+	return &err{level: EXCEPTION, ICode: 17001, IKey: "transaction.not_found",
+		InternalMsg:    fmt.Sprintf("Transaction %s does not exist, or has ended or expired.", txid),
+		InternalCaller: CallerN(1)}
+}
+
+func NewNoActiveTransactionError(stmt string) Error {
+	return &err{level: EXCEPTION, ICode: 17002, IKey: "transaction.no_active",
+		InternalMsg:    fmt.Sprintf("%s requires an active transaction, passed in the txid request parameter.", stmt),
+		InternalCaller: CallerN(1)}
+}
+
+func NewTransactionInProgressError(txid string) Error {
+	return &err{level: EXCEPTION, ICode: 17003, IKey: "transaction.in_progress",
+		InternalMsg:    fmt.Sprintf("Transaction %s is already in progress.", txid),
+		InternalCaller: CallerN(1)}
+}
+
+func NewNoSuchSavepointError(name string) Error {
+	return &err{level: EXCEPTION, ICode: 17004, IKey: "transaction.savepoint_not_found",
+		InternalMsg:    fmt.Sprintf("Savepoint %s does not exist in the transaction.", name),
+		InternalCaller: CallerN(1)}
+}
+
+func NewTransactionKeyExistsError(key, keyspace string) Error {
+	return &err{level: EXCEPTION, ICode: 17005, IKey: "transaction.key_exists",
+		InternalMsg:    fmt.Sprintf("Duplicate key %s in keyspace %s.", key, keyspace),
+		InternalCaller: CallerN(1)}
+}
+
+func NewTransactionKeyNotFoundError(key, keyspace string) Error {
+	return &err{level: EXCEPTION, ICode: 17006, IKey: "transaction.key_not_found",
+		InternalMsg:    fmt.Sprintf("Key %s does not exist in keyspace %s.", key, keyspace),
+		InternalCaller: CallerN(1)}
+}
+
+func NewTransactionCommitError(e error, txid string) Error {
+	return &err{level: EXCEPTION, ICode: 17007, IKey: "transaction.commit_failed", ICause: e,
+		InternalMsg:    fmt.Sprintf("Commit of transaction %s failed, and it was rolled back.", txid),
+		InternalCaller: CallerN(1)}
+}
+
+func NewTransactionStatementError(stmt string) Error {
+	return &err{level: EXCEPTION, ICode: 17008, IKey: "transaction.statement_not_supported",
+		InternalMsg:    fmt.Sprintf("%s is not supported in a transaction.", stmt),
+		InternalCaller: CallerN(1)}
+}
+
+func NewTransactionConflictError(key, keyspace string) Error {
+	return &err{level: EXCEPTION, ICode: 17010, IKey: "transaction.conflict",
+		InternalMsg:    fmt.Sprintf("Key %s in keyspace %s was changed after the transaction read it.", key, keyspace),
+		InternalCaller: CallerN(1)}
+}
+
+func NewTransactionOwnerError(txid string) Error {
+	return &err{level: EXCEPTION, ICode: 17009, IKey: "transaction.owner",
+		InternalMsg:    fmt.Sprintf("Transaction %s was started by another user.", txid),
+		InternalCaller: CallerN(1)}
+}
@@ -0,0 +1,237 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/planner"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Return the index advice made for a statement as it was planned.
+*/
+type Advise struct {
+	base
+	plan *plan.Advise
+}
+
+func NewAdvise(plan *plan.Advise, context *Context) *Advise {
+	rv := &Advise{
+		plan: plan,
+	}
+
+	newRedirectBase(&rv.base)
+	rv.output = rv
+	return rv
+}
+
+func (this *Advise) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitAdvise(this)
+}
+
+func (this *Advise) Copy() Operator {
+	rv := &Advise{plan: this.plan}
+	this.base.copy(&rv.base)
+	return rv
+}
+
+func (this *Advise) RunOnce(context *Context, parent value.Value) {
+	this.once.Do(func() {
+		defer context.Recover() // Recover from any panic
+		active := this.active()
+		defer this.close(context)
+		this.switchPhase(_EXECTIME)
+		defer this.switchPhase(_NOTIME)
+		defer this.notify() // Notify that I have stopped
+		if !active {
+			return
+		}
+
+		this.sendItem(value.NewAnnotatedValue(this.plan.Advice()))
+	})
+}
+
+func (this *Advise) MarshalJSON() ([]byte, error) {
+	r := this.plan.MarshalBase(func(r map[string]interface{}) {
+		this.marshalTimes(r)
+	})
+	return json.Marshal(r)
+}
+
+func (this *Advise) Done() {
+	this.baseDone()
+	this.plan = nil
+}
+
+/*
+Advise on the statements of the completed requests, and return the
+recommended indexes with the statements each would serve. Statements
+that cannot be advised on are skipped.
+*/
+type AdviseAll struct {
+	base
+	plan       *plan.AdviseAll
+	statements map[string]bool
+	queries    int
+	optimal    int
+	indexes    *adviseMerger
+	covering   *adviseMerger
+}
+
+func NewAdviseAll(plan *plan.AdviseAll, context *Context) *AdviseAll {
+	rv := &AdviseAll{
+		plan: plan,
+	}
+
+	newBase(&rv.base, context)
+	rv.output = rv
+	return rv
+}
+
+func (this *AdviseAll) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitAdviseAll(this)
+}
+
+func (this *AdviseAll) Copy() Operator {
+	rv := &AdviseAll{plan: this.plan}
+	this.base.copy(&rv.base)
+	return rv
+}
+
+func (this *AdviseAll) RunOnce(context *Context, parent value.Value) {
+	this.runConsumer(this, context, parent)
+}
+
+func (this *AdviseAll) beforeItems(context *Context, parent value.Value) bool {
+	this.statements = make(map[string]bool, 64)
+	this.queries = 0
+	this.optimal = 0
+	this.indexes = newAdviseMerger()
+	this.covering = newAdviseMerger()
+	return true
+}
+
+func (this *AdviseAll) processItem(item value.AnnotatedValue, context *Context) bool {
+	doc, ok := item.Field(this.plan.Alias())
+	if !ok {
+		return true
+	}
+
+	// the text a prepared statement was prepared from is advised on,
+	// rather than the EXECUTE
+	text, ok := stringField(doc, "preparedText")
+	if !ok {
+		text, ok = stringField(doc, "statement")
+	}
+	if !ok || this.statements[text] {
+		return true
+	}
+	this.statements[text] = true
+
+	this.switchPhase(_SERVTIME)
+	advice, err := planner.AdviseIndexes(text, context.datastore, context.systemstore, context.namespace,
+		nil, nil, context.indexApiVersion, context.featureControls)
+	this.switchPhase(_EXECTIME)
+	if err != nil {
+		return true
+	}
+
+	this.queries++
+	if advice.Optimal() {
+		this.optimal++
+	}
+	for _, rec := range advice.Indexes() {
+		this.indexes.add(rec.Statement(), advice.Query())
+	}
+	for _, rec := range advice.CoveringIndexes() {
+		this.covering.add(rec.Statement(), advice.Query())
+	}
+	return true
+}
+
+func (this *AdviseAll) afterItems(context *Context) {
+	if this.stopped {
+		return
+	}
+
+	r := map[string]interface{}{
+		"queries":         this.queries,
+		"optimal_queries": this.optimal,
+	}
+	if len(this.indexes.indexes) > 0 {
+		r["recommended_indexes"] = this.indexes.report()
+	}
+	if len(this.covering.indexes) > 0 {
+		r["recommended_covering_indexes"] = this.covering.report()
+	}
+
+	this.sendItem(value.NewAnnotatedValue(r))
+}
+
+func (this *AdviseAll) MarshalJSON() ([]byte, error) {
+	r := this.plan.MarshalBase(func(r map[string]interface{}) {
+		this.marshalTimes(r)
+	})
+	return json.Marshal(r)
+}
+
+func (this *AdviseAll) reopen(context *Context) {
+	this.baseReopen(context)
+	this.statements = nil
+	this.indexes = nil
+	this.covering = nil
+}
+
+func stringField(doc value.Value, name string) (string, bool) {
+	val, ok := doc.Field(name)
+	if !ok || val.Type() != value.STRING {
+		return "", false
+	}
+
+	s, ok := val.Actual().(string)
+	return s, ok && s != ""
+}
+
+/*
+The recommended indexes, in the order first recommended, with the
+statements they would serve.
+*/
+type adviseMerger struct {
+	indexes []string
+	queries map[string][]interface{}
+}
+
+func newAdviseMerger() *adviseMerger {
+	return &adviseMerger{
+		queries: make(map[string][]interface{}, 16),
+	}
+}
+
+func (this *adviseMerger) add(index, query string) {
+	queries, ok := this.queries[index]
+	if !ok {
+		this.indexes = append(this.indexes, index)
+	}
+	this.queries[index] = append(queries, query)
+}
+
+func (this *adviseMerger) report() []interface{} {
+	rv := make([]interface{}, len(this.indexes))
+	for i, index := range this.indexes {
+		rv[i] = map[string]interface{}{
+			"index":   index,
+			"queries": this.queries[index],
+		}
+	}
+	return rv
+}
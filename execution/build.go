@@ -221,6 +221,11 @@ func (this *builder) VisitOrderedIntersectScan(plan *plan.OrderedIntersectScan)
 	return NewOrderedIntersectScan(plan, this.context, scans), nil
 }
 
+// Sample
+func (this *builder) VisitSample(plan *plan.Sample) (interface{}, error) {
+	return NewSample(plan, this.context), nil
+}
+
 // Fetch
 func (this *builder) VisitFetch(plan *plan.Fetch) (interface{}, error) {
 	return NewFetch(plan, this.context), nil
@@ -315,6 +320,11 @@ func (this *builder) VisitFinalGroup(plan *plan.FinalGroup) (interface{}, error)
 	return NewFinalGroup(plan, this.context), nil
 }
 
+// Window functions
+func (this *builder) VisitWindowAggregate(plan *plan.WindowAggregate) (interface{}, error) {
+	return NewWindowAggregate(plan, this.context), nil
+}
+
 // Project
 func (this *builder) VisitInitialProject(plan *plan.InitialProject) (interface{}, error) {
 	return NewInitialProject(plan, this.context), nil
@@ -349,6 +359,20 @@ func (this *builder) VisitUnionAll(plan *plan.UnionAll) (interface{}, error) {
 	return NewUnionAll(plan, this.context, children...), nil
 }
 
+func (this *builder) VisitRecursiveUnion(plan *plan.RecursiveUnion) (interface{}, error) {
+	first, e := plan.First().Accept(this)
+	if e != nil {
+		return nil, e
+	}
+
+	second, e := plan.Second().Accept(this)
+	if e != nil {
+		return nil, e
+	}
+
+	return NewRecursiveUnion(plan, this.context, first.(Operator), second.(Operator)), nil
+}
+
 func (this *builder) VisitIntersectAll(plan *plan.IntersectAll) (interface{}, error) {
 	first, e := plan.First().Accept(this)
 	if e != nil {
@@ -458,6 +482,11 @@ func (this *builder) VisitMerge(plan *plan.Merge) (interface{}, error) {
 	return NewMerge(plan, this.context, update, delete, insert), nil
 }
 
+// Truncate
+func (this *builder) VisitTruncate(plan *plan.Truncate) (interface{}, error) {
+	return NewTruncate(plan, this.context), nil
+}
+
 // Alias
 func (this *builder) VisitAlias(plan *plan.Alias) (interface{}, error) {
 	return NewAlias(plan, this.context), nil
@@ -540,6 +569,41 @@ func (this *builder) VisitRevokeRole(plan *plan.RevokeRole) (interface{}, error)
 	return NewRevokeRole(plan, this.context), nil
 }
 
+// CreateFunction
+func (this *builder) VisitCreateFunction(plan *plan.CreateFunction) (interface{}, error) {
+	return NewCreateFunction(plan, this.context), nil
+}
+
+// DropFunction
+func (this *builder) VisitDropFunction(plan *plan.DropFunction) (interface{}, error) {
+	return NewDropFunction(plan, this.context), nil
+}
+
+// ExecuteFunction
+func (this *builder) VisitExecuteFunction(plan *plan.ExecuteFunction) (interface{}, error) {
+	return NewExecuteFunction(plan, this.context), nil
+}
+
+// StartTransaction
+func (this *builder) VisitStartTransaction(plan *plan.StartTransaction) (interface{}, error) {
+	return NewStartTransaction(plan, this.context), nil
+}
+
+// CommitTransaction
+func (this *builder) VisitCommitTransaction(plan *plan.CommitTransaction) (interface{}, error) {
+	return NewCommitTransaction(plan, this.context), nil
+}
+
+// RollbackTransaction
+func (this *builder) VisitRollbackTransaction(plan *plan.RollbackTransaction) (interface{}, error) {
+	return NewRollbackTransaction(plan, this.context), nil
+}
+
+// Savepoint
+func (this *builder) VisitSavepoint(plan *plan.Savepoint) (interface{}, error) {
+	return NewSavepoint(plan, this.context), nil
+}
+
 // CreateIndex
 func (this *builder) VisitCreateIndex(plan *plan.CreateIndex) (interface{}, error) {
 	return NewCreateIndex(plan, this.context), nil
@@ -560,6 +624,11 @@ func (this *builder) VisitBuildIndexes(plan *plan.BuildIndexes) (interface{}, er
 	return NewBuildIndexes(plan, this.context), nil
 }
 
+// UpdateStatistics
+func (this *builder) VisitUpdateStatistics(plan *plan.UpdateStatistics) (interface{}, error) {
+	return NewUpdateStatistics(plan, this.context), nil
+}
+
 // Prepare
 func (this *builder) VisitPrepare(plan *plan.Prepare) (interface{}, error) {
 	return NewPrepare(plan, this.context, plan.Prepared()), nil
@@ -570,6 +639,15 @@ func (this *builder) VisitExplain(plan *plan.Explain) (interface{}, error) {
 	return NewExplain(plan, this.context), nil
 }
 
+// Advise
+func (this *builder) VisitAdvise(plan *plan.Advise) (interface{}, error) {
+	return NewAdvise(plan, this.context), nil
+}
+
+func (this *builder) VisitAdviseAll(plan *plan.AdviseAll) (interface{}, error) {
+	return NewAdviseAll(plan, this.context), nil
+}
+
 // Infer
 func (this *builder) VisitInferKeyspace(plan *plan.InferKeyspace) (interface{}, error) {
 	return NewInferKeyspace(plan, this.context), nil
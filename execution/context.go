@@ -14,9 +14,11 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
+	atomic "github.com/couchbase/go-couchbase/platform"
 	"github.com/couchbase/query/algebra"
 	"github.com/couchbase/query/auth"
 	"github.com/couchbase/query/datastore"
@@ -25,6 +27,7 @@ import (
 	"github.com/couchbase/query/plan"
 	"github.com/couchbase/query/planner"
 	"github.com/couchbase/query/timestamp"
+	"github.com/couchbase/query/transactions"
 	"github.com/couchbase/query/value"
 )
 
@@ -109,6 +112,8 @@ type Output interface {
 	MutationCount() uint64
 	SortCount() uint64
 	SetSortCount(i uint64)
+	UsedMemory() uint64
+	SetUsedMemory(i uint64)
 	AddPhaseOperator(p Phases)
 	AddPhaseCount(p Phases, c uint64)
 	FmtPhaseCounts() map[string]interface{}
@@ -118,6 +123,10 @@ type Output interface {
 }
 
 type Context struct {
+	// Aligned ints need to be declared right at the top
+	// of the struct to avoid alignment issues on x86 platforms
+	usedMemory atomic.AlignedInt64
+
 	requestId          string
 	datastore          datastore.Datastore
 	systemstore        datastore.Datastore
@@ -144,6 +153,8 @@ type Context struct {
 	authenticatedUsers auth.AuthenticatedUsers
 	mutex              sync.RWMutex
 	whitelist          map[string]interface{}
+	memoryQuota        int64
+	transaction        *transactions.Transaction
 }
 
 func NewContext(requestId string, datastore, systemstore datastore.Datastore,
@@ -221,6 +232,57 @@ func (this *Context) Readonly() bool {
 	return this.readonly
 }
 
+func (this *Context) Transaction() *transactions.Transaction {
+	return this.transaction
+}
+
+func (this *Context) SetTransaction(transaction *transactions.Transaction) {
+	this.transaction = transaction
+}
+
+/*
+In a transaction, documents are fetched and mutated through the
+transaction, which buffers the mutations until COMMIT.
+*/
+func (this *Context) keyspace(keyspace datastore.Keyspace) datastore.Keyspace {
+	if this.transaction != nil {
+		return this.transaction.Keyspace(keyspace)
+	}
+	return keyspace
+}
+
+/*
+In a transaction, index scans see the documents mutated by the
+transaction in place of their committed index entries. The keyspace
+of the scan is found from its term, if it is not given.
+*/
+func (this *Context) scanIndex(term *algebra.KeyspaceTerm, scan *transactions.IndexScan,
+	conn *datastore.IndexConnection) {
+	if this.transaction == nil {
+		scan.Scan(scan.Offset, scan.Limit, scan.Projection, conn)
+		return
+	}
+
+	if scan.Keyspace == nil {
+		store := this.datastore
+		if strings.ToLower(term.Namespace()) == "#system" {
+			store = this.systemstore
+		}
+
+		namespace, err := store.NamespaceByName(term.Namespace())
+		if err == nil {
+			scan.Keyspace, err = namespace.KeyspaceByName(term.Keyspace())
+		}
+		if err != nil {
+			this.Error(err)
+			close(conn.EntryChannel())
+			return
+		}
+	}
+
+	this.transaction.ScanIndex(scan, datastore.NewIndexConnection(this), conn)
+}
+
 func (this *Context) MaxParallelism() int {
 	return this.maxParallelism
 }
@@ -119,7 +119,7 @@ func (this *SendDelete) flushBatch(context *Context) bool {
 
 	this.switchPhase(_SERVTIME)
 
-	deleted_keys, e := this.plan.Keyspace().Delete(keys, context)
+	deleted_keys, e := context.keyspace(this.plan.Keyspace()).Delete(keys, context)
 
 	this.switchPhase(_EXECTIME)
 
@@ -22,6 +22,7 @@ type Distinct struct {
 	set     *value.Set
 	plan    *plan.Distinct
 	collect bool
+	memory  int64
 }
 
 func NewDistinct(plan *plan.Distinct, context *Context, collect bool) *Distinct {
@@ -65,6 +66,11 @@ func (this *Distinct) processItem(item value.AnnotatedValue, context *Context) b
 
 	if !this.set.Has(p.(value.Value)) {
 		this.set.Put(p.(value.Value), item)
+		size := valueSize(item)
+		this.memory += size
+		if !context.trackMemory(size) {
+			return false
+		}
 		return this.collect || this.sendItem(item)
 	}
 	return true
@@ -73,6 +79,8 @@ func (this *Distinct) processItem(item value.AnnotatedValue, context *Context) b
 func (this *Distinct) afterItems(context *Context) {
 	if !this.collect {
 		this.set = nil
+		context.releaseMemory(this.memory)
+		this.memory = 0
 	}
 }
 
@@ -90,4 +98,6 @@ func (this *Distinct) MarshalJSON() ([]byte, error) {
 func (this *Distinct) reopen(context *Context) {
 	this.baseReopen(context)
 	this.set = value.NewSet(int(context.GetPipelineCap()), false)
+	context.releaseMemory(this.memory)
+	this.memory = 0
 }
@@ -115,7 +115,7 @@ func (this *Fetch) flushBatch(context *Context) bool {
 	this.switchPhase(_SERVTIME)
 
 	// Fetch
-	errs := this.plan.Keyspace().Fetch(fetchKeys, fetchMap, context, this.plan.SubPaths())
+	errs := context.keyspace(this.plan.Keyspace()).Fetch(fetchKeys, fetchMap, context, this.plan.SubPaths())
 
 	this.switchPhase(_EXECTIME)
 
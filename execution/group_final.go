@@ -21,13 +21,15 @@ import (
 type FinalGroup struct {
 	base
 	plan   *plan.FinalGroup
-	groups map[string]value.AnnotatedValue
+	sets   *groupingSets
+	groups map[string]bool
 }
 
 func NewFinalGroup(plan *plan.FinalGroup, context *Context) *FinalGroup {
 	rv := &FinalGroup{
 		plan:   plan,
-		groups: make(map[string]value.AnnotatedValue),
+		sets:   newGroupingSets(plan.Keys(), plan.GroupingSets()),
+		groups: make(map[string]bool),
 	}
 
 	newBase(&rv.base, context)
@@ -42,7 +44,8 @@ func (this *FinalGroup) Accept(visitor Visitor) (interface{}, error) {
 func (this *FinalGroup) Copy() Operator {
 	rv := &FinalGroup{
 		plan:   this.plan,
-		groups: make(map[string]value.AnnotatedValue),
+		sets:   this.sets,
+		groups: make(map[string]bool),
 	}
 	this.base.copy(&rv.base)
 	return rv
@@ -55,7 +58,14 @@ func (this *FinalGroup) RunOnce(context *Context, parent value.Value) {
 func (this *FinalGroup) processItem(item value.AnnotatedValue, context *Context) bool {
 	// Generate the group key
 	var gk string
-	if len(this.plan.Keys()) > 0 {
+	if this.sets != nil {
+		var e error
+		gk, e = this.sets.groupValueKey(item)
+		if e != nil {
+			context.Fatal(errors.NewEvaluationError(e, "GROUP key"))
+			return false
+		}
+	} else if len(this.plan.Keys()) > 0 {
 		var e error
 		gk, e = groupKey(item, this.plan.Keys(), context)
 		if e != nil {
@@ -64,15 +74,14 @@ func (this *FinalGroup) processItem(item value.AnnotatedValue, context *Context)
 		}
 	}
 
-	// Get or seed the group value
-	gv := this.groups[gk]
-	if gv != nil {
+	// Groups are sent as they come, so only their keys are kept
+	if this.groups[gk] {
 		context.Fatal(errors.NewDuplicateFinalGroupError())
 		return false
 	}
 
-	gv = item
-	this.groups[gk] = gv
+	gv := item
+	this.groups[gk] = true
 
 	// Compute final aggregates
 	aggregates := gv.GetAttachment("aggregates")
@@ -89,7 +98,7 @@ func (this *FinalGroup) processItem(item value.AnnotatedValue, context *Context)
 			aggregates[agg.String()] = v
 		}
 
-		return true
+		return this.sendItem(gv)
 	default:
 		context.Fatal(errors.NewInvalidValueError(fmt.Sprintf(
 			"Invalid or missing aggregates of type %T.", aggregates)))
@@ -98,14 +107,8 @@ func (this *FinalGroup) processItem(item value.AnnotatedValue, context *Context)
 }
 
 func (this *FinalGroup) afterItems(context *Context) {
-	for _, av := range this.groups {
-		if !this.sendItem(av) {
-			return
-		}
-	}
-
 	// Mo matching inputs, so send default values
-	if len(this.plan.Keys()) == 0 && len(this.groups) == 0 {
+	if this.sets == nil && len(this.plan.Keys()) == 0 && len(this.groups) == 0 {
 		av := value.NewAnnotatedValue(nil)
 		aggregates := make(map[string]value.Value, len(this.plan.Aggregates()))
 		av.SetAttachment("aggregates", aggregates)
@@ -115,6 +118,26 @@ func (this *FinalGroup) afterItems(context *Context) {
 
 		this.sendItem(av)
 	}
+
+	// Likewise for the grand totals of grouping sets
+	if this.sets != nil && len(this.groups) == 0 {
+		for set, keys := range this.sets.sets {
+			if len(keys) > 0 {
+				continue
+			}
+
+			av := this.sets.seed(value.NewAnnotatedValue(nil), nil, set)
+			aggregates := make(map[string]value.Value, len(this.plan.Aggregates()))
+			av.SetAttachment("aggregates", aggregates)
+			for _, agg := range this.plan.Aggregates() {
+				aggregates[agg.String()] = agg.Default()
+			}
+
+			if !this.sendItem(av) {
+				return
+			}
+		}
+	}
 }
 
 func (this *FinalGroup) MarshalJSON() ([]byte, error) {
@@ -126,5 +149,5 @@ func (this *FinalGroup) MarshalJSON() ([]byte, error) {
 
 func (this *FinalGroup) reopen(context *Context) {
 	this.baseReopen(context)
-	this.groups = make(map[string]value.AnnotatedValue)
+	this.groups = make(map[string]bool)
 }
@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/couchbase/query/algebra"
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/plan"
 	"github.com/couchbase/query/value"
@@ -22,12 +23,15 @@ import (
 type InitialGroup struct {
 	base
 	plan   *plan.InitialGroup
+	sets   *groupingSets
 	groups map[string]value.AnnotatedValue
+	spill  groupSpill
 }
 
 func NewInitialGroup(plan *plan.InitialGroup, context *Context) *InitialGroup {
 	rv := &InitialGroup{
 		plan:   plan,
+		sets:   newGroupingSets(plan.Keys(), plan.GroupingSets()),
 		groups: make(map[string]value.AnnotatedValue),
 	}
 
@@ -43,6 +47,7 @@ func (this *InitialGroup) Accept(visitor Visitor) (interface{}, error) {
 func (this *InitialGroup) Copy() Operator {
 	rv := &InitialGroup{
 		plan:   this.plan,
+		sets:   this.sets,
 		groups: make(map[string]value.AnnotatedValue),
 	}
 	this.base.copy(&rv.base)
@@ -54,6 +59,10 @@ func (this *InitialGroup) RunOnce(context *Context, parent value.Value) {
 }
 
 func (this *InitialGroup) processItem(item value.AnnotatedValue, context *Context) bool {
+	if this.sets != nil {
+		return this.processGroupingSets(item, context)
+	}
+
 	// Generate the group key
 	var gk string
 	if len(this.plan.Keys()) > 0 {
@@ -65,10 +74,38 @@ func (this *InitialGroup) processItem(item value.AnnotatedValue, context *Contex
 		}
 	}
 
+	return this.cumulate(gk, item, nil, 0, context)
+}
+
+// Cumulate the item into one group for each grouping set
+func (this *InitialGroup) processGroupingSets(item value.AnnotatedValue, context *Context) bool {
+	keys, e := evaluateKeys(item, this.plan.Keys(), context)
+	if e != nil {
+		context.Fatal(errors.NewEvaluationError(e, "GROUP key"))
+		return false
+	}
+
+	for set := range this.sets.sets {
+		if !this.cumulate(this.sets.groupKey(keys, set), item, keys, set, context) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (this *InitialGroup) cumulate(gk string, item value.AnnotatedValue, keys value.Values,
+	set int, context *Context) bool {
+
 	// Get or seed the group value
 	gv := this.groups[gk]
-	if gv == nil {
-		gv = item
+	seeded := gv == nil
+	if seeded {
+		if this.sets != nil {
+			gv = this.sets.seed(item, keys, set)
+		} else {
+			gv = item
+		}
 		this.groups[gk] = gv
 
 		aggregates := make(map[string]value.Value, len(this.plan.Aggregates()))
@@ -87,7 +124,7 @@ func (this *InitialGroup) processItem(item value.AnnotatedValue, context *Contex
 	}
 
 	for _, agg := range this.plan.Aggregates() {
-		v, e := agg.CumulateInitial(item, aggregates[agg.String()], context)
+		v, e := algebra.CumulateInitial(agg, item, aggregates[agg.String()], context)
 		if e != nil {
 			context.Fatal(errors.NewGroupUpdateError(e, "Error updating initial GROUP value."))
 			return false
@@ -96,15 +133,29 @@ func (this *InitialGroup) processItem(item value.AnnotatedValue, context *Contex
 		aggregates[agg.String()] = v
 	}
 
+	if seeded {
+		spill, ok := this.spill.add(gv, context)
+		if spill {
+			return this.spillGroups(context)
+		}
+		return ok
+	}
+
 	return true
 }
 
-func (this *InitialGroup) afterItems(context *Context) {
-	for _, av := range this.groups {
-		if !this.sendItem(av) {
-			return
-		}
+func (this *InitialGroup) spillGroups(context *Context) bool {
+	if !this.spill.spill(this.groups, context) {
+		return false
 	}
+
+	this.groups = make(map[string]value.AnnotatedValue)
+	return true
+}
+
+func (this *InitialGroup) afterItems(context *Context) {
+	defer this.spill.release(context)
+	this.spill.send(this.groups, this.plan.Aggregates(), this.sendItem, context)
 }
 
 func (this *InitialGroup) MarshalJSON() ([]byte, error) {
@@ -11,7 +11,6 @@ package execution
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/plan"
@@ -22,12 +21,15 @@ import (
 type IntermediateGroup struct {
 	base
 	plan   *plan.IntermediateGroup
+	sets   *groupingSets
 	groups map[string]value.AnnotatedValue
+	spill  groupSpill
 }
 
 func NewIntermediateGroup(plan *plan.IntermediateGroup, context *Context) *IntermediateGroup {
 	rv := &IntermediateGroup{
 		plan:   plan,
+		sets:   newGroupingSets(plan.Keys(), plan.GroupingSets()),
 		groups: make(map[string]value.AnnotatedValue),
 	}
 
@@ -43,6 +45,7 @@ func (this *IntermediateGroup) Accept(visitor Visitor) (interface{}, error) {
 func (this *IntermediateGroup) Copy() Operator {
 	rv := &IntermediateGroup{
 		plan:   this.plan,
+		sets:   this.sets,
 		groups: make(map[string]value.AnnotatedValue),
 	}
 	this.base.copy(&rv.base)
@@ -56,7 +59,14 @@ func (this *IntermediateGroup) RunOnce(context *Context, parent value.Value) {
 func (this *IntermediateGroup) processItem(item value.AnnotatedValue, context *Context) bool {
 	// Generate the group key
 	var gk string
-	if len(this.plan.Keys()) > 0 {
+	if this.sets != nil {
+		var e error
+		gk, e = this.sets.groupValueKey(item)
+		if e != nil {
+			context.Fatal(errors.NewEvaluationError(e, "GROUP key"))
+			return false
+		}
+	} else if len(this.plan.Keys()) > 0 {
 		var e error
 		gk, e = groupKey(item, this.plan.Keys(), context)
 		if e != nil {
@@ -70,45 +80,29 @@ func (this *IntermediateGroup) processItem(item value.AnnotatedValue, context *C
 	if gv == nil {
 		gv = item
 		this.groups[gk] = gv
-		return true
+		spill, ok := this.spill.add(gv, context)
+		if spill {
+			return this.spillGroups(context)
+		}
+		return ok
 	}
 
 	// Cumulate aggregates
-	part, ok := item.GetAttachment("aggregates").(map[string]value.Value)
-	if !ok {
-		context.Fatal(errors.NewInvalidValueError(
-			fmt.Sprintf("Invalid partial aggregates %v of type %T", part, part)))
-		return false
-	}
+	return cumulateGroups(this.plan.Aggregates(), item, gv, context)
+}
 
-	cumulative := gv.GetAttachment("aggregates").(map[string]value.Value)
-	if !ok {
-		context.Fatal(errors.NewInvalidValueError(
-			fmt.Sprintf("Invalid cumulative aggregates %v of type %T", cumulative, cumulative)))
+func (this *IntermediateGroup) spillGroups(context *Context) bool {
+	if !this.spill.spill(this.groups, context) {
 		return false
 	}
 
-	for _, agg := range this.plan.Aggregates() {
-		a := agg.String()
-		v, e := agg.CumulateIntermediate(part[a], cumulative[a], context)
-		if e != nil {
-			context.Fatal(errors.NewGroupUpdateError(
-				e, "Error updating intermediate GROUP value."))
-			return false
-		}
-
-		cumulative[a] = v
-	}
-
+	this.groups = make(map[string]value.AnnotatedValue)
 	return true
 }
 
 func (this *IntermediateGroup) afterItems(context *Context) {
-	for _, av := range this.groups {
-		if !this.sendItem(av) {
-			return
-		}
-	}
+	defer this.spill.release(context)
+	this.spill.send(this.groups, this.plan.Aggregates(), this.sendItem, context)
 }
 
 func (this *IntermediateGroup) MarshalJSON() ([]byte, error) {
@@ -10,6 +10,11 @@
 package execution
 
 import (
+	"fmt"
+	"strconv"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/expression"
 	"github.com/couchbase/query/util"
 	"github.com/couchbase/query/value"
@@ -35,3 +40,305 @@ func groupKey(item value.Value, keys expression.Expressions, context *Context) (
 }
 
 var _GROUP_KEY_POOL = util.NewStringInterfacePool(16)
+
+/*
+Grouping sets, for ROLLUP, CUBE and GROUPING SETS. Each item is
+cumulated into one group per grouping set, and group keys include the
+set, so that groups of different sets never merge. The keys left out
+of a set are rolled up: the group value covers them as NULL, and flags
+them for GROUPING() in its "grouping" attachment. The planner has the
+expressions evaluated on groups refer to the keys through their
+covers.
+*/
+type groupingSets struct {
+	sets  [][]int
+	texts []string
+}
+
+func newGroupingSets(keys expression.Expressions, sets [][]int) *groupingSets {
+	if len(sets) == 0 {
+		return nil
+	}
+
+	texts := make([]string, len(keys))
+	for i, key := range keys {
+		if cover, ok := key.(*expression.Cover); ok {
+			texts[i] = cover.Text()
+		} else {
+			texts[i] = key.String()
+		}
+	}
+
+	return &groupingSets{
+		sets:  sets,
+		texts: texts,
+	}
+}
+
+func evaluateKeys(item value.Value, keys expression.Expressions, context *Context) (value.Values, error) {
+	rv := make(value.Values, len(keys))
+	for i, key := range keys {
+		k, e := key.Evaluate(item, context)
+		if e != nil {
+			return nil, e
+		}
+
+		rv[i] = k
+	}
+
+	return rv, nil
+}
+
+// The group key of an item within a grouping set
+func (this *groupingSets) groupKey(keys value.Values, set int) string {
+	kvs := _GROUP_KEY_POOL.GetCapped(len(this.sets[set]) + 1)
+	defer _GROUP_KEY_POOL.Put(kvs)
+
+	kvs["set"] = set
+	for _, pos := range this.sets[set] {
+		if keys[pos].Type() != value.MISSING {
+			kvs[strconv.Itoa(pos)] = keys[pos]
+		}
+	}
+
+	bytes, _ := value.NewValue(kvs).MarshalJSON()
+	return string(bytes)
+}
+
+// The group key of a group value received from another group operator
+func (this *groupingSets) groupValueKey(gv value.AnnotatedValue) (string, error) {
+	set, ok := gv.GetAttachment("grouping_set").(int)
+	if !ok || set < 0 || set >= len(this.sets) {
+		return "", fmt.Errorf("Invalid grouping set %v.", gv.GetAttachment("grouping_set"))
+	}
+
+	keys := make(value.Values, len(this.texts))
+	for _, pos := range this.sets[set] {
+		keys[pos] = gv.GetCover(this.texts[pos])
+		if keys[pos] == nil {
+			keys[pos] = value.MISSING_VALUE
+		}
+	}
+
+	return this.groupKey(keys, set), nil
+}
+
+// Seed the group value of an item within a grouping set. The keys are
+// those of the item, or nil when seeding a group with no input
+func (this *groupingSets) seed(item value.AnnotatedValue, keys value.Values, set int) value.AnnotatedValue {
+	gv := value.NewAnnotatedValue(item.GetValue())
+	for name, attachment := range item.Attachments() {
+		gv.SetAttachment(name, attachment)
+	}
+
+	if covers := item.Covers(); covers != nil {
+		for name, cover := range covers.Fields() {
+			gv.SetCover(name, value.NewValue(cover))
+		}
+	}
+
+	grouping := make(map[string]value.Value, len(this.texts))
+	for _, text := range this.texts {
+		grouping[text] = value.TRUE_VALUE
+		gv.SetCover(text, value.NULL_VALUE)
+	}
+
+	for _, pos := range this.sets[set] {
+		grouping[this.texts[pos]] = value.FALSE_VALUE
+		gv.SetCover(this.texts[pos], keys[pos])
+	}
+
+	gv.SetAttachment("grouping", grouping)
+	gv.SetAttachment("grouping_set", set)
+	return gv
+}
+
+// Cumulate the partial aggregates of a group into another
+func cumulateGroups(aggregates algebra.Aggregates, item, gv value.AnnotatedValue, context *Context) bool {
+	part, ok := item.GetAttachment("aggregates").(map[string]value.Value)
+	if !ok {
+		context.Fatal(errors.NewInvalidValueError(
+			fmt.Sprintf("Invalid partial aggregates %v of type %T", part, part)))
+		return false
+	}
+
+	cumulative, ok := gv.GetAttachment("aggregates").(map[string]value.Value)
+	if !ok {
+		context.Fatal(errors.NewInvalidValueError(
+			fmt.Sprintf("Invalid cumulative aggregates %v of type %T", cumulative, cumulative)))
+		return false
+	}
+
+	for _, agg := range aggregates {
+		a := agg.String()
+		v, e := agg.CumulateIntermediate(part[a], cumulative[a], context)
+		if e != nil {
+			context.Fatal(errors.NewGroupUpdateError(
+				e, "Error updating intermediate GROUP value."))
+			return false
+		}
+
+		cumulative[a] = v
+	}
+
+	return true
+}
+
+/*
+Spilling of groups. Once the request holds too much, the groups held so
+far are written out, partitioned on their group key. After all the
+input has been received, each partition is read back in turn, and its
+partial groups are merged with those still held in memory. A partition
+whose groups are still too many to hold is split again, and its parts
+are merged in its place.
+*/
+type groupSpill struct {
+	partitions *spillPartitions
+	memory     int64
+}
+
+// Track a new group, and return whether the groups should be spilled
+func (this *groupSpill) add(gv value.AnnotatedValue, context *Context) (spill, ok bool) {
+	size := valueSize(gv)
+	this.memory += size
+	return context.addSpillMemory(size, this.memory)
+}
+
+func (this *groupSpill) spill(groups map[string]value.AnnotatedValue, context *Context) bool {
+	if this.partitions == nil {
+		this.partitions = newSpillPartitions("group")
+	}
+
+	for gk, gv := range groups {
+		err := this.partitions.write(gk, gv)
+		if err != nil {
+			context.Fatal(err)
+			return false
+		}
+	}
+
+	context.releaseMemory(this.memory)
+	this.memory = 0
+	return true
+}
+
+// Send the groups, merging the spilled ones one partition at a time
+func (this *groupSpill) send(groups map[string]value.AnnotatedValue, aggregates algebra.Aggregates,
+	send func(value.AnnotatedValue) bool, context *Context) {
+
+	if this.partitions == nil {
+		for _, gv := range groups {
+			if !send(gv) {
+				return
+			}
+		}
+		return
+	}
+
+	var held [_SPILL_PARTITIONS]map[string]value.AnnotatedValue
+	for gk, gv := range groups {
+		p := this.partitions.partition(gk)
+		if held[p] == nil {
+			held[p] = make(map[string]value.AnnotatedValue)
+		}
+		held[p][gk] = gv
+	}
+
+	this.sendPartitions(this.partitions, &held, aggregates, send, context)
+}
+
+// Merge the spilled groups of each partition with those held, and send
+// them. Once the groups of a partition cross the spill threshold, they
+// are written out to partitions of the next level, followed by the rest
+// of the partition, and those are merged and sent in its place.
+func (this *groupSpill) sendPartitions(partitions *spillPartitions,
+	held *[_SPILL_PARTITIONS]map[string]value.AnnotatedValue, aggregates algebra.Aggregates,
+	send func(value.AnnotatedValue) bool, context *Context) bool {
+
+	for p, partition := range held {
+		if partition == nil {
+			partition = make(map[string]value.AnnotatedValue)
+		}
+
+		var split *spillPartitions
+		var err errors.Error
+		ok := true
+		e := partitions.read(p, func(gk string, item value.AnnotatedValue) bool {
+			if split != nil {
+				err = split.write(gk, item)
+				return err == nil
+			}
+
+			gv := partition[gk]
+			if gv != nil {
+				ok = cumulateGroups(aggregates, item, gv, context)
+				return ok
+			}
+
+			partition[gk] = item
+			size := valueSize(item)
+			this.memory += size
+			if !partitions.splittable() {
+				ok = context.trackMemory(size)
+				return ok
+			}
+
+			var spill bool
+			spill, ok = context.addSpillMemory(size, this.memory)
+			if spill {
+				split = partitions.child()
+				for gk, gv := range partition {
+					if err = split.write(gk, gv); err != nil {
+						return false
+					}
+				}
+				partition = nil
+				context.releaseMemory(this.memory)
+				this.memory = 0
+			}
+			return ok
+		})
+		partitions.closePartition(p)
+		held[p] = nil
+
+		if err == nil {
+			err = e
+		}
+		if err != nil {
+			context.Fatal(err)
+		}
+
+		if split != nil {
+			var none [_SPILL_PARTITIONS]map[string]value.AnnotatedValue
+			if err == nil && ok {
+				ok = this.sendPartitions(split, &none, aggregates, send, context)
+			}
+			split.close()
+		}
+		if err != nil || !ok {
+			return false
+		}
+
+		for _, gv := range partition {
+			if !send(gv) {
+				return false
+			}
+		}
+		context.releaseMemory(this.memory)
+		this.memory = 0
+	}
+
+	return true
+}
+
+func (this *groupSpill) release(context *Context) {
+	if this.partitions != nil {
+		this.partitions.close()
+		this.partitions = nil
+	}
+
+	if this.memory != 0 {
+		context.releaseMemory(this.memory)
+		this.memory = 0
+	}
+}
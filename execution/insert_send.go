@@ -162,7 +162,7 @@ func (this *SendInsert) flushBatch(context *Context) bool {
 
 	// Perform the actual INSERT
 	var er errors.Error
-	dpairs, er = this.plan.Keyspace().Insert(dpairs)
+	dpairs, er = context.keyspace(this.plan.Keyspace()).Insert(dpairs)
 
 	this.switchPhase(_EXECTIME)
 
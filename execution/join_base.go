@@ -77,7 +77,7 @@ func (this *joinBase) joinFetch(keyspace datastore.Keyspace, keyCount map[string
 	}
 
 	this.switchPhase(_SERVTIME)
-	errs := keyspace.Fetch(fetchKeys, pairMap, context, nil)
+	errs := context.keyspace(keyspace).Fetch(fetchKeys, pairMap, context, nil)
 	this.switchPhase(_EXECTIME)
 
 	fetchOk := true
@@ -27,6 +27,8 @@ type HashJoin struct {
 	hashTab   *util.HashTable
 	buildVals value.Values
 	probeVals value.Values
+	spill     hashSpill
+	matched   map[value.AnnotatedValue]bool
 }
 
 func NewHashJoin(plan *plan.HashJoin, context *Context, child Operator) *HashJoin {
@@ -79,6 +81,9 @@ func (this *HashJoin) beforeItems(context *Context, parent value.Value) bool {
 
 	// build hash table
 	this.hashTab = util.NewHashTable()
+	if this.plan.RightOuter() {
+		this.matched = make(map[value.AnnotatedValue]bool)
+	}
 
 	this.buildVals = make(value.Values, len(this.plan.BuildExprs()))
 	this.probeVals = make(value.Values, len(this.plan.ProbeExprs()))
@@ -91,7 +96,7 @@ func (this *HashJoin) beforeItems(context *Context, parent value.Value) bool {
 	go this.child.RunOnce(context, parent)
 
 	return buildHashTab(&(this.base), this.child, this.hashTab,
-		this.plan.BuildExprs(), this.buildVals, context)
+		this.plan.BuildExprs(), this.buildVals, &this.spill, context)
 }
 
 func marshalValue(val interface{}) ([]byte, error) {
@@ -106,8 +111,7 @@ func equalValue(val1, val2 interface{}) bool {
 }
 
 func buildHashTab(base *base, buildOp Operator, hashTab *util.HashTable,
-	buildExprs expression.Expressions, buildVals value.Values, context *Context) bool {
-	var err error
+	buildExprs expression.Expressions, buildVals value.Values, spill *hashSpill, context *Context) bool {
 	stopped := false
 	n := 1
 
@@ -116,22 +120,7 @@ loop:
 		build_item, child, cont := base.getItemChildrenOp(buildOp)
 		if cont {
 			if build_item != nil {
-				for i, be := range buildExprs {
-					buildVals[i], err = be.Evaluate(build_item, context)
-					if err != nil {
-						context.Error(errors.NewEvaluationError(err, "Hash Table Build Expression"))
-						return false
-					}
-				}
-				var buildVal value.Value
-				if len(buildVals) == 1 {
-					buildVal = buildVals[0]
-				} else {
-					buildVal = value.NewValue(buildVals)
-				}
-				err = hashTab.Put(buildVal, build_item, marshalValue, equalValue)
-				if err != nil {
-					context.Error(errors.NewHashTablePutError(err))
+				if !spill.build(hashTab, build_item, buildExprs, buildVals, context) {
 					return false
 				}
 			} else if child >= 0 {
@@ -157,6 +146,25 @@ loop:
 	return true
 }
 
+func getBuildVal(item value.AnnotatedValue, buildExprs expression.Expressions,
+	buildVals value.Values, context *Context) value.Value {
+
+	var err error
+	for i, be := range buildExprs {
+		buildVals[i], err = be.Evaluate(item, context)
+		if err != nil {
+			context.Error(errors.NewEvaluationError(err, "Hash Table Build Expression"))
+			return nil
+		}
+	}
+
+	if len(buildVals) == 1 {
+		return buildVals[0]
+	} else {
+		return value.NewValue(buildVals)
+	}
+}
+
 func getProbeVal(item value.AnnotatedValue, probeExprs expression.Expressions,
 	probeVals value.Values, context *Context) value.Value {
 
@@ -177,6 +185,14 @@ func getProbeVal(item value.AnnotatedValue, probeExprs expression.Expressions,
 }
 
 func (this *HashJoin) processItem(item value.AnnotatedValue, context *Context) bool {
+	if this.spill.spilled() {
+		return this.spill.probe(item, this.plan.ProbeExprs(), this.probeVals, context)
+	}
+
+	return this.joinItem(item, context)
+}
+
+func (this *HashJoin) joinItem(item value.AnnotatedValue, context *Context) bool {
 	defer this.switchPhase(_EXECTIME)
 
 	var err error
@@ -201,6 +217,9 @@ func (this *HashJoin) processItem(item value.AnnotatedValue, context *Context) b
 				this.plan.BuildAliases(), this.ansiFlags, context, "join")
 			if match && ok {
 				matched = true
+				if this.matched != nil {
+					this.matched[right_item] = true
+				}
 				ok = this.sendItem(joined)
 			}
 		} else {
@@ -223,7 +242,46 @@ func (this *HashJoin) processItem(item value.AnnotatedValue, context *Context) b
 }
 
 func (this *HashJoin) afterItems(context *Context) {
-	this.dropHashTable()
+	defer this.spill.release(context)
+	defer this.dropHashTable()
+
+	if this.stopped {
+		return
+	}
+
+	if !this.spill.spilled() {
+		this.sendUnmatched()
+		return
+	}
+
+	for {
+		this.dropHashTable()
+		this.hashTab = this.spill.buildPartition(this.plan.BuildExprs(), this.buildVals, context)
+		if this.hashTab == nil || !this.spill.probePartition(this.joinItem, context) ||
+			!this.sendUnmatched() {
+			return
+		}
+	}
+}
+
+/*
+For RIGHT and FULL OUTER JOINs, send the build items that no probe item
+matched, once all the probe items have been joined with the hash table.
+*/
+func (this *HashJoin) sendUnmatched() bool {
+	if this.matched == nil || this.hashTab == nil {
+		return true
+	}
+
+	for v := this.hashTab.Iterate(); v != nil; v = this.hashTab.Iterate() {
+		item := v.(value.AnnotatedValue)
+		if !this.matched[item] && !this.sendItem(item) {
+			return false
+		}
+	}
+
+	this.matched = make(map[value.AnnotatedValue]bool)
+	return true
 }
 
 func (this *HashJoin) dropHashTable() {
@@ -256,3 +314,240 @@ func (this *HashJoin) Done() {
 	}
 	this.child = nil
 }
+
+/*
+Spilling of hash joins and nests. Once the request holds too much, the
+hash table is written out, partitioned on the build values, and the rest
+of the build side follows it to disk. Probe items are then partitioned
+the same way, and once all of them have been received, each partition is
+joined in turn with a hash table holding only its own build items. A
+partition whose build items are still too many to hold is split again,
+on both sides, and its parts are joined in its place.
+*/
+type hashSpill struct {
+	buildSide *spillPartitions
+	probeSide *spillPartitions
+	pending   []*hashPartition // partitions still to be joined
+	current   *hashPartition   // partition whose hash table is built
+	splits    []*spillPartitions
+	memory    int64
+}
+
+// A partition of the build and probe sides, joined together
+type hashPartition struct {
+	buildSide *spillPartitions
+	probeSide *spillPartitions
+	p         int
+}
+
+func (this *hashSpill) spilled() bool {
+	return this.buildSide != nil
+}
+
+func (this *hashSpill) build(hashTab *util.HashTable, item value.AnnotatedValue,
+	buildExprs expression.Expressions, buildVals value.Values, context *Context) bool {
+
+	buildVal := getBuildVal(item, buildExprs, buildVals, context)
+	if buildVal == nil {
+		return false
+	}
+
+	if this.spilled() {
+		return this.write(this.buildSide, buildVal, item, context)
+	}
+
+	err := hashTab.Put(buildVal, item, marshalValue, equalValue)
+	if err != nil {
+		context.Error(errors.NewHashTablePutError(err))
+		return false
+	}
+
+	size := valueSize(item)
+	this.memory += size
+	spill, ok := context.addSpillMemory(size, this.memory)
+	if !spill {
+		return ok
+	}
+
+	// write out the hash table, and drop it
+	this.buildSide = newSpillPartitions("hash")
+	this.probeSide = newSpillPartitions("hash")
+	this.pending = newHashPartitions(this.buildSide, this.probeSide)
+	for v := hashTab.Iterate(); v != nil; v = hashTab.Iterate() {
+		built := v.(value.AnnotatedValue)
+		buildVal = getBuildVal(built, buildExprs, buildVals, context)
+		if buildVal == nil || !this.write(this.buildSide, buildVal, built, context) {
+			return false
+		}
+	}
+
+	hashTab.Drop()
+	context.releaseMemory(this.memory)
+	this.memory = 0
+	return true
+}
+
+func newHashPartitions(buildSide, probeSide *spillPartitions) []*hashPartition {
+	rv := make([]*hashPartition, _SPILL_PARTITIONS)
+	for p := range rv {
+		rv[p] = &hashPartition{buildSide: buildSide, probeSide: probeSide, p: p}
+	}
+	return rv
+}
+
+func (this *hashSpill) probe(item value.AnnotatedValue, probeExprs expression.Expressions,
+	probeVals value.Values, context *Context) bool {
+
+	probeVal := getProbeVal(item, probeExprs, probeVals, context)
+	if probeVal == nil {
+		return false
+	}
+
+	return this.write(this.probeSide, probeVal, item, context)
+}
+
+func (this *hashSpill) write(partitions *spillPartitions, val value.Value,
+	item value.AnnotatedValue, context *Context) bool {
+
+	bytes, e := marshalValue(val)
+	if e != nil {
+		context.Fatal(errors.NewSpillError(e, "hash"))
+		return false
+	}
+
+	err := partitions.write(string(bytes), item)
+	if err != nil {
+		context.Fatal(err)
+		return false
+	}
+	return true
+}
+
+// Build the hash table for the next partition to be joined, or return
+// nil once all have been. Partitions that cross the spill threshold are
+// split, and their parts are built first.
+func (this *hashSpill) buildPartition(buildExprs expression.Expressions,
+	buildVals value.Values, context *Context) *util.HashTable {
+
+	// the previous partition is no longer held
+	this.releasePartition(context)
+
+	for len(this.pending) > 0 {
+		part := this.pending[0]
+		this.pending = this.pending[1:]
+
+		hashTab := util.NewHashTable()
+		ok, split := true, false
+		err := part.buildSide.read(part.p, func(key string, item value.AnnotatedValue) bool {
+			buildVal := getBuildVal(item, buildExprs, buildVals, context)
+			if buildVal == nil {
+				ok = false
+			} else if e := hashTab.Put(buildVal, item, marshalValue, equalValue); e != nil {
+				context.Error(errors.NewHashTablePutError(e))
+				ok = false
+			} else {
+				size := valueSize(item)
+				this.memory += size
+				if part.buildSide.splittable() {
+					split, ok = context.addSpillMemory(size, this.memory)
+				} else {
+					ok = context.trackMemory(size)
+				}
+			}
+			return ok && !split
+		})
+
+		if err != nil {
+			context.Fatal(err)
+			ok = false
+		}
+
+		if ok && !split {
+			this.current = part
+			return hashTab
+		}
+
+		hashTab.Drop()
+		context.releaseMemory(this.memory)
+		this.memory = 0
+		if !ok || !this.split(part, context) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Split both sides of a partition with the next level's hash, and queue
+// the parts to be joined next
+func (this *hashSpill) split(part *hashPartition, context *Context) bool {
+	buildSide, err := part.buildSide.split(part.p)
+	if err != nil {
+		context.Fatal(err)
+		return false
+	}
+	this.splits = append(this.splits, buildSide)
+
+	probeSide, err := part.probeSide.split(part.p)
+	if err != nil {
+		context.Fatal(err)
+		return false
+	}
+	this.splits = append(this.splits, probeSide)
+
+	this.pending = append(newHashPartitions(buildSide, probeSide), this.pending...)
+	return true
+}
+
+// Probe the hash table of the current partition with its probe items
+func (this *hashSpill) probePartition(probe func(value.AnnotatedValue, *Context) bool,
+	context *Context) bool {
+
+	ok := true
+	err := this.current.probeSide.read(this.current.p, func(key string, item value.AnnotatedValue) bool {
+		ok = probe(item, context)
+		return ok
+	})
+
+	if err != nil {
+		context.Fatal(err)
+		return false
+	}
+	return ok
+}
+
+// Remove the current partition, which has been joined
+func (this *hashSpill) releasePartition(context *Context) {
+	if this.current != nil {
+		this.current.buildSide.closePartition(this.current.p)
+		this.current.probeSide.closePartition(this.current.p)
+		this.current = nil
+	}
+
+	context.releaseMemory(this.memory)
+	this.memory = 0
+}
+
+func (this *hashSpill) release(context *Context) {
+	for _, split := range this.splits {
+		split.close()
+	}
+	this.splits = nil
+	this.pending = nil
+	this.current = nil
+
+	if this.buildSide != nil {
+		this.buildSide.close()
+		this.buildSide = nil
+	}
+
+	if this.probeSide != nil {
+		this.probeSide.close()
+		this.probeSide = nil
+	}
+
+	if this.memory != 0 {
+		context.releaseMemory(this.memory)
+		this.memory = 0
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/couchbase/query/datastore"
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/transactions"
 	"github.com/couchbase/query/value"
 )
 
@@ -124,8 +125,16 @@ func (this *IndexJoin) scan(id string, context *Context,
 		consistency = datastore.SCAN_PLUS
 	}
 
-	this.plan.Index().Scan(context.RequestId(), span, false,
-		math.MaxInt64, consistency, nil, conn)
+	context.scanIndex(nil, &transactions.IndexScan{
+		Keyspace: this.plan.Keyspace(),
+		Index:    this.plan.Index(),
+		Matches:  func(key value.Values) bool { return datastore.MatchSpan(key, span) },
+		Limit:    math.MaxInt64,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			this.plan.Index().Scan(context.RequestId(), span, false,
+				limit, consistency, nil, conn)
+		},
+	}, conn)
 
 	wg.Done()
 }
@@ -27,9 +27,12 @@ const (
 
 type NLJoin struct {
 	base
-	plan      *plan.NLJoin
-	child     Operator
-	ansiFlags uint32
+	plan       *plan.NLJoin
+	child      Operator
+	ansiFlags  uint32
+	rightItems value.AnnotatedValues
+	matched    []bool
+	memory     int64
 }
 
 func NewNLJoin(plan *plan.NLJoin, context *Context, child Operator) *NLJoin {
@@ -80,12 +83,66 @@ func (this *NLJoin) beforeItems(context *Context, parent value.Value) bool {
 		}
 	}
 
+	if this.plan.RightOuter() {
+		return this.readRight(context, parent)
+	}
+
 	return true
 }
 
+/*
+For RIGHT and FULL OUTER JOINs, the right-hand side does not depend on
+the left-hand side. It is read once, and kept with a record of which of
+its items have been matched.
+*/
+func (this *NLJoin) readRight(context *Context, parent value.Value) bool {
+	this.child.SetOutput(this.child)
+	this.child.SetInput(nil)
+	this.child.SetParent(this)
+	this.child.SetStop(nil)
+
+	go this.child.RunOnce(context, parent)
+
+	ok := true
+	stopped := false
+	n := 1
+
+loop:
+	for ok {
+		right_item, child, cont := this.getItemChildrenOp(this.child)
+		if cont {
+			if right_item != nil {
+				this.rightItems = append(this.rightItems, right_item)
+				size := valueSize(right_item)
+				this.memory += size
+				ok = context.trackMemory(size)
+			} else if child >= 0 {
+				n--
+			} else {
+				break loop
+			}
+		} else {
+			stopped = true
+			break loop
+		}
+	}
+
+	if n > 0 {
+		notifyChildren(this.child)
+		this.childrenWaitNoStop(n)
+	}
+
+	this.matched = make([]bool, len(this.rightItems))
+	return ok && !stopped
+}
+
 func (this *NLJoin) processItem(item value.AnnotatedValue, context *Context) bool {
 	defer this.switchPhase(_EXECTIME)
 
+	if this.plan.RightOuter() {
+		return this.joinRight(item, context)
+	}
+
 	if (this.ansiFlags & ANSI_REOPEN_CHILD) != 0 {
 		if this.child != nil {
 			this.child.SendStop()
@@ -148,6 +205,58 @@ loop:
 	return true
 }
 
+func (this *NLJoin) joinRight(item value.AnnotatedValue, context *Context) bool {
+	matched := false
+	aliases := []string{this.plan.Alias()}
+	for i, right_item := range this.rightItems {
+		match, ok, joined := processAnsiExec(item, right_item, this.plan.Onclause(),
+			aliases, this.ansiFlags, context, "join")
+		if !ok {
+			return false
+		}
+
+		if match {
+			matched = true
+			this.matched[i] = true
+			if !this.sendItem(joined) {
+				return false
+			}
+		}
+	}
+
+	if this.plan.Outer() && !matched {
+		return this.sendItem(item)
+	}
+
+	return true
+}
+
+/*
+Send the right-hand side items that no item matched.
+*/
+func (this *NLJoin) afterItems(context *Context) {
+	defer this.releaseRight(context)
+
+	if this.stopped || !this.plan.RightOuter() {
+		return
+	}
+
+	for i, right_item := range this.rightItems {
+		if !this.matched[i] && !this.sendItem(right_item) {
+			return
+		}
+	}
+}
+
+func (this *NLJoin) releaseRight(context *Context) {
+	this.rightItems = nil
+	this.matched = nil
+	if this.memory != 0 {
+		context.releaseMemory(this.memory)
+		this.memory = 0
+	}
+}
+
 func processAnsiExec(item value.AnnotatedValue, right_item value.AnnotatedValue,
 	onclause expression.Expression, aliases []string, ansiFlags uint32, context *Context, op string) (
 	bool, bool, value.AnnotatedValue) {
@@ -219,6 +328,7 @@ func (this *NLJoin) SendStop() {
 
 func (this *NLJoin) reopen(context *Context) {
 	this.baseReopen(context)
+	this.releaseRight(context)
 	this.ansiFlags &^= ANSI_REOPEN_CHILD
 	if this.child != nil {
 		this.child.reopen(context)
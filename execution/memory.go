@@ -0,0 +1,80 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	atomic "github.com/couchbase/go-couchbase/platform"
+	"github.com/couchbase/query/errors"
+)
+
+/*
+Memory accounting.
+
+Operators that retain values, such as Order, Distinct, groups and hash
+joins, add the estimated size of what they hold to the request context,
+and take it off again once they let go. A request fails once it holds
+more than its memory quota, in bytes, and reports the most it has held
+as its used memory.
+*/
+
+var memoryQuota atomic.AlignedInt64
+
+// Zero or negative values leave requests without a quota
+func SetMemoryQuota(quota int64) {
+	atomic.StoreInt64(&memoryQuota, quota)
+}
+
+func GetMemoryQuota() int64 {
+	return atomic.LoadInt64(&memoryQuota)
+}
+
+func (this *Context) GetMemoryQuota() int64 {
+	if this.memoryQuota > 0 {
+		return this.memoryQuota
+	} else {
+		return GetMemoryQuota()
+	}
+}
+
+func (this *Context) MemoryQuota() int64 {
+	return this.memoryQuota
+}
+
+func (this *Context) SetMemoryQuota(memoryQuota int64) {
+	this.memoryQuota = memoryQuota
+}
+
+// Add the estimated size of values retained by an operator to the
+// request, failing it if this takes it over its memory quota
+func (this *Context) trackMemory(size int64) bool {
+	return this.checkMemory(this.addMemory(size))
+}
+
+// Take the size of values an operator no longer holds off the request
+func (this *Context) releaseMemory(size int64) {
+	atomic.AddInt64(&this.usedMemory, -size)
+}
+
+func (this *Context) addMemory(size int64) int64 {
+	memory := atomic.AddInt64(&this.usedMemory, size)
+	if memory > 0 {
+		this.output.SetUsedMemory(uint64(memory))
+	}
+	return memory
+}
+
+func (this *Context) checkMemory(memory int64) bool {
+	quota := this.GetMemoryQuota()
+	if quota > 0 && memory > quota {
+		this.Fatal(errors.NewMemoryQuotaExceededError(quota))
+		return false
+	}
+	return true
+}
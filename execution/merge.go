@@ -164,7 +164,7 @@ func (this *Merge) processKeyMatch(item value.AnnotatedValue,
 
 	ok = true
 	bvs := make(map[string]value.AnnotatedValue, 1)
-	errs := this.plan.Keyspace().Fetch([]string{k}, bvs, context, nil)
+	errs := context.keyspace(this.plan.Keyspace()).Fetch([]string{k}, bvs, context, nil)
 
 	this.switchPhase(_EXECTIME)
 
@@ -26,6 +26,7 @@ type HashNest struct {
 	hashTab   *util.HashTable
 	buildVals value.Values
 	probeVals value.Values
+	spill     hashSpill
 }
 
 func NewHashNest(plan *plan.HashNest, context *Context, child Operator) *HashNest {
@@ -90,10 +91,18 @@ func (this *HashNest) beforeItems(context *Context, parent value.Value) bool {
 	go this.child.RunOnce(context, parent)
 
 	return buildHashTab(&(this.base), this.child, this.hashTab,
-		this.plan.BuildExprs(), this.buildVals, context)
+		this.plan.BuildExprs(), this.buildVals, &this.spill, context)
 }
 
 func (this *HashNest) processItem(item value.AnnotatedValue, context *Context) bool {
+	if this.spill.spilled() {
+		return this.spill.probe(item, this.plan.ProbeExprs(), this.probeVals, context)
+	}
+
+	return this.nestItem(item, context)
+}
+
+func (this *HashNest) nestItem(item value.AnnotatedValue, context *Context) bool {
 	defer this.switchPhase(_EXECTIME)
 
 	var err error
@@ -144,7 +153,20 @@ func (this *HashNest) processItem(item value.AnnotatedValue, context *Context) b
 }
 
 func (this *HashNest) afterItems(context *Context) {
-	this.dropHashTable()
+	defer this.spill.release(context)
+	defer this.dropHashTable()
+
+	if this.stopped {
+		return
+	}
+
+	for this.spill.spilled() {
+		this.dropHashTable()
+		this.hashTab = this.spill.buildPartition(this.plan.BuildExprs(), this.buildVals, context)
+		if this.hashTab == nil || !this.spill.probePartition(this.nestItem, context) {
+			return
+		}
+	}
 }
 
 func (this *HashNest) dropHashTable() {
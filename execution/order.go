@@ -10,6 +10,7 @@
 package execution
 
 import (
+	"container/heap"
 	"encoding/json"
 
 	"github.com/couchbase/query/errors"
@@ -24,6 +25,8 @@ type Order struct {
 	values  value.AnnotatedValues
 	context *Context
 	terms   []string
+	runs    []*spillRun
+	memory  int64
 }
 
 const _ORDER_CAP = 1024
@@ -69,7 +72,15 @@ func (this *Order) processItem(item value.AnnotatedValue, context *Context) bool
 	}
 
 	this.values = append(this.values, item)
-	return true
+
+	// once the request holds too much, write out a sorted run
+	size := valueSize(item)
+	this.memory += size
+	spill, ok := context.addSpillMemory(size, this.memory)
+	if spill {
+		return this.spillValues(context)
+	}
+	return ok
 }
 
 func (this *Order) setupTerms(context *Context) {
@@ -82,6 +93,7 @@ func (this *Order) setupTerms(context *Context) {
 
 func (this *Order) afterItems(context *Context) {
 	defer this.releaseValues()
+	defer this.releaseRuns(context)
 	defer func() {
 		this.context = nil
 		this.terms = nil
@@ -95,8 +107,17 @@ func (this *Order) afterItems(context *Context) {
 	this.setupTerms(context)
 	sort.Sort(this)
 
-	context.SetSortCount(uint64(this.Len()))
-	context.AddPhaseCount(SORT, uint64(this.Len()))
+	count := uint64(this.Len())
+	for _, run := range this.runs {
+		count += uint64(run.count)
+	}
+	context.SetSortCount(count)
+	context.AddPhaseCount(SORT, count)
+
+	if len(this.runs) > 0 {
+		this.mergeRuns(context)
+		return
+	}
 
 	for _, av := range this.values {
 		if !this.sendItem(av) {
@@ -110,6 +131,159 @@ func (this *Order) releaseValues() {
 	this.values = nil
 }
 
+// Sort the values held so far and write them out as a run
+func (this *Order) spillValues(context *Context) bool {
+	this.setupTerms(context)
+	sort.Sort(this)
+
+	run, err := newSpillRun("sort")
+	if err != nil {
+		context.Fatal(err)
+		return false
+	}
+
+	for _, av := range this.values {
+		if !this.evaluateTerms(av) {
+			run.close()
+			return false
+		}
+
+		e := run.write(av)
+		if e != nil {
+			run.close()
+			context.Fatal(errors.NewSpillError(e, "sort"))
+			return false
+		}
+	}
+
+	e := run.suspend()
+	if e != nil {
+		run.close()
+		context.Fatal(errors.NewSpillError(e, "sort"))
+		return false
+	}
+
+	this.runs = append(this.runs, run)
+	for i := range this.values {
+		this.values[i] = nil
+	}
+	this.values = this.values[0:0]
+	context.releaseMemory(this.memory)
+	this.memory = 0
+	return true
+}
+
+// Runs are merged at most this many at a time, so that a sort does not
+// hold a file open for each of its runs
+const _MERGE_FAN_IN = 64
+
+// Merge the runs on disk with the values still in memory. While there
+// are too many runs to merge at once, the oldest ones are merged into
+// an intermediate run, in as many passes as needed.
+func (this *Order) mergeRuns(context *Context) {
+	for len(this.runs) > _MERGE_FAN_IN {
+		run, err := newSpillRun("sort")
+		if err != nil {
+			context.Fatal(err)
+			return
+		}
+
+		merged := this.runs[:_MERGE_FAN_IN]
+		this.runs = append(this.runs[_MERGE_FAN_IN:], run)
+
+		var e error
+		ok := this.merge(merged, false, context, func(item value.AnnotatedValue) bool {
+			e = run.write(item)
+			return e == nil
+		})
+		if e == nil {
+			e = run.suspend()
+		}
+
+		for _, m := range merged {
+			m.close()
+		}
+
+		if e != nil {
+			context.Fatal(errors.NewSpillError(e, "sort"))
+			return
+		}
+		if !ok {
+			return
+		}
+	}
+
+	this.merge(this.runs, true, context, this.sendItem)
+}
+
+// Merge sorted runs, and optionally the values held in memory, passing
+// each item in order to emit. Returns false if emit or reading a run
+// fails.
+func (this *Order) merge(runs []*spillRun, values bool, context *Context,
+	emit func(item value.AnnotatedValue) bool) bool {
+	merge := &orderMerge{order: this}
+	if values && len(this.values) > 0 {
+		merge.sources = append(merge.sources, &orderSource{item: this.values[0], next: 1})
+	}
+
+	for _, run := range runs {
+		err := run.resume()
+		var item value.AnnotatedValue
+		if err == nil {
+			item, err = run.read()
+		}
+		if err != nil {
+			context.Fatal(errors.NewSpillError(err, "sort"))
+			return false
+		}
+		if item != nil {
+			merge.sources = append(merge.sources, &orderSource{item: item, run: run})
+		}
+	}
+
+	heap.Init(merge)
+	for merge.Len() > 0 {
+		source := merge.sources[0]
+		if !emit(source.item) {
+			return false
+		}
+
+		if source.run != nil {
+			item, err := source.run.read()
+			if err != nil {
+				context.Fatal(errors.NewSpillError(err, "sort"))
+				return false
+			}
+			source.item = item
+		} else if source.next < len(this.values) {
+			source.item = this.values[source.next]
+			source.next++
+		} else {
+			source.item = nil
+		}
+
+		if source.item == nil {
+			heap.Pop(merge)
+		} else {
+			heap.Fix(merge, 0)
+		}
+	}
+
+	return true
+}
+
+func (this *Order) releaseRuns(context *Context) {
+	for _, run := range this.runs {
+		run.close()
+	}
+	this.runs = nil
+
+	if this.memory != 0 {
+		context.releaseMemory(this.memory)
+		this.memory = 0
+	}
+}
+
 func (this *Order) Len() int {
 	return len(this.values)
 }
@@ -158,6 +332,13 @@ func (this *Order) lessThan(v1 value.AnnotatedValue, v2 value.AnnotatedValue) bo
 
 		if c == 0 {
 			continue
+		}
+
+		// NULL and MISSING values go where the term places them
+		null1 := ev1.Type() <= value.NULL
+		null2 := ev2.Type() <= value.NULL
+		if null1 != null2 {
+			return null1 == term.NullsFirst()
 		} else if term.Descending() {
 			return c > 0
 		} else {
@@ -168,6 +349,26 @@ func (this *Order) lessThan(v1 value.AnnotatedValue, v2 value.AnnotatedValue) bo
 	return false
 }
 
+// Evaluate all the sort terms, so that spilled values carry them
+func (this *Order) evaluateTerms(item value.AnnotatedValue) bool {
+	for i, term := range this.plan.Terms() {
+		s := this.terms[i]
+		if _, ok := item.GetAttachment(s).(value.Value); ok {
+			continue
+		}
+
+		ev, e := term.Expression().Evaluate(item, this.context)
+		if e != nil {
+			this.context.Error(errors.NewEvaluationError(e, "ORDER BY"))
+			return false
+		}
+
+		item.SetAttachment(s, ev)
+	}
+
+	return true
+}
+
 func (this *Order) Swap(i, j int) {
 	this.values[i], this.values[j] = this.values[j], this.values[i]
 }
@@ -183,3 +384,38 @@ func (this *Order) reopen(context *Context) {
 	this.baseReopen(context)
 	this.values = _ORDER_POOL.Get()
 }
+
+// Heap of sorted sources, ordered by their current item
+type orderMerge struct {
+	order   *Order
+	sources []*orderSource
+}
+
+type orderSource struct {
+	item value.AnnotatedValue
+	run  *spillRun // nil for the values held in memory
+	next int
+}
+
+func (this *orderMerge) Len() int {
+	return len(this.sources)
+}
+
+func (this *orderMerge) Less(i, j int) bool {
+	return this.order.lessThan(this.sources[i].item, this.sources[j].item)
+}
+
+func (this *orderMerge) Swap(i, j int) {
+	this.sources[i], this.sources[j] = this.sources[j], this.sources[i]
+}
+
+func (this *orderMerge) Push(item interface{}) {
+	this.sources = append(this.sources, item.(*orderSource))
+}
+
+func (this *orderMerge) Pop() interface{} {
+	index := len(this.sources) - 1
+	source := this.sources[index]
+	this.sources = this.sources[0:index]
+	return source
+}
@@ -179,7 +179,7 @@ func (this *OrderLimit) afterItems(context *Context) {
 	if this.offset != nil {
 		offset = this.offset.offset
 	}
-	if offset >= int64(len) {
+	if offset >= int64(len) && this.runs == nil {
 		this.values = this.values[0:0]
 	}
 
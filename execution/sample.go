@@ -0,0 +1,261 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/value"
+)
+
+// Random entries are only drawn when the keyspace is this many times
+// larger than the sample, so that few draws return the same document
+const _RANDOM_SAMPLE_RATIO = 10
+
+/*
+Sampling of the keys produced by a scan. Every key is given a priority
+from a hash of the key and the seed, so that the same seed samples the
+same keys. SAMPLE n PERCENT keeps the keys whose priority falls in the
+lowest n percent (Bernoulli sampling), and SAMPLE n ROWS keeps the n keys
+of lowest priority (reservoir sampling).
+*/
+type Sample struct {
+	base
+	plan      *plan.Sample
+	seed      uint64
+	all       bool
+	threshold uint64
+	rows      int
+	reservoir sampleHeap
+	memory    int64
+}
+
+func NewSample(plan *plan.Sample, context *Context) *Sample {
+	rv := &Sample{
+		plan: plan,
+	}
+
+	newBase(&rv.base, context)
+	rv.output = rv
+	return rv
+}
+
+func (this *Sample) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitSample(this)
+}
+
+func (this *Sample) Copy() Operator {
+	rv := &Sample{plan: this.plan}
+	this.base.copy(&rv.base)
+	return rv
+}
+
+func (this *Sample) RunOnce(context *Context, parent value.Value) {
+	this.runConsumer(this, context, parent)
+}
+
+func (this *Sample) beforeItems(context *Context, parent value.Value) bool {
+	val, e := this.plan.Size().Evaluate(parent, context)
+	if e != nil {
+		context.Error(errors.NewEvaluationError(e, "SAMPLE"))
+		return false
+	}
+
+	size, ok := val.Actual().(float64)
+	switch {
+	case !ok || size < 0:
+		ok = false
+	case this.plan.Rows():
+		ok = math.Trunc(size) == size
+		this.rows = int(size)
+	default:
+		ok = size <= 100
+		this.all = size == 100
+		this.threshold = uint64(math.Ldexp(size/100, 64))
+	}
+
+	if !ok {
+		context.Error(errors.NewInvalidValueError(
+			fmt.Sprintf("Invalid SAMPLE value %v.", val.Actual())))
+		return false
+	}
+
+	if this.plan.Seed() != nil {
+		val, e = this.plan.Seed().Evaluate(parent, context)
+		if e != nil {
+			context.Error(errors.NewEvaluationError(e, "SAMPLE SEED"))
+			return false
+		}
+
+		seed, ok := val.Actual().(float64)
+		if !ok || math.Trunc(seed) != seed {
+			context.Error(errors.NewInvalidValueError(
+				fmt.Sprintf("Invalid SAMPLE SEED value %v.", val.Actual())))
+			return false
+		}
+		this.seed = uint64(int64(seed))
+	} else {
+		this.seed = uint64(rand.Int63())
+	}
+
+	if this.plan.Random() && this.rows > 0 {
+		count, err := context.keyspace(this.plan.Keyspace()).Count(context)
+		if err != nil {
+			context.Error(err)
+			return false
+		}
+
+		// Draw the sample from the keyspace, instead of scanning it
+		if int64(this.rows)*_RANDOM_SAMPLE_RATIO <= count {
+			this.sampleRandom(context, parent)
+			return false
+		}
+	}
+
+	this.reservoir = make(sampleHeap, 0, this.rows)
+	return true
+}
+
+func (this *Sample) processItem(item value.AnnotatedValue, context *Context) bool {
+	if this.all {
+		return this.sendItem(item)
+	}
+
+	key, ok := this.getDocumentKey(item, context)
+	if !ok {
+		return false
+	}
+
+	priority := this.priority(key)
+	if !this.plan.Rows() {
+		return priority >= this.threshold || this.sendItem(item)
+	}
+
+	if len(this.reservoir) < this.rows {
+		size := valueSize(item)
+		this.memory += size
+		heap.Push(&this.reservoir, sampleEntry{priority, item, size})
+		return context.trackMemory(size)
+	}
+
+	if this.rows == 0 || priority >= this.reservoir[0].priority {
+		return true
+	}
+
+	size := valueSize(item)
+	this.memory += size - this.reservoir[0].size
+	context.releaseMemory(this.reservoir[0].size)
+	this.reservoir[0] = sampleEntry{priority, item, size}
+	heap.Fix(&this.reservoir, 0)
+	return context.trackMemory(size)
+}
+
+func (this *Sample) afterItems(context *Context) {
+	for _, entry := range this.reservoir {
+		if !this.sendItem(entry.item) {
+			break
+		}
+	}
+
+	this.reservoir = nil
+	context.releaseMemory(this.memory)
+	this.memory = 0
+}
+
+/*
+Draw distinct keys from the random entries of the keyspace. A draw may
+return a key already sampled, so the number of draws is bounded.
+*/
+func (this *Sample) sampleRandom(context *Context, parent value.Value) {
+	provider := this.plan.Keyspace().(datastore.RandomEntryProvider)
+	keys := make(map[string]bool, this.rows)
+	for draws := 0; len(keys) < this.rows && draws < 2*this.rows; draws++ {
+		key, _, err := provider.GetRandomEntry()
+		if err != nil {
+			context.Error(err)
+			return
+		}
+
+		if key == "" || keys[key] {
+			continue
+		}
+
+		keys[key] = true
+		if !this.sendItem(this.newEmptyDocumentWithKey(key, parent, context)) {
+			return
+		}
+	}
+}
+
+// Hash the key with the seed, mixing the bits of the hash so that
+// the priorities are uniformly distributed
+func (this *Sample) priority(key string) uint64 {
+	var seed [8]byte
+	binary.LittleEndian.PutUint64(seed[:], this.seed)
+	h := fnv.New64a()
+	h.Write(seed[:])
+	h.Write([]byte(key))
+
+	p := h.Sum64()
+	p ^= p >> 33
+	p *= 0xff51afd7ed558ccd
+	p ^= p >> 33
+	p *= 0xc4ceb9fe1a85ec53
+	p ^= p >> 33
+	return p
+}
+
+func (this *Sample) MarshalJSON() ([]byte, error) {
+	r := this.plan.MarshalBase(func(r map[string]interface{}) {
+		this.marshalTimes(r)
+	})
+	return json.Marshal(r)
+}
+
+func (this *Sample) reopen(context *Context) {
+	this.baseReopen(context)
+	this.reservoir = nil
+	context.releaseMemory(this.memory)
+	this.memory = 0
+}
+
+type sampleEntry struct {
+	priority uint64
+	item     value.AnnotatedValue
+	size     int64
+}
+
+// Max-heap of the sampled rows, the row of highest priority on top
+type sampleHeap []sampleEntry
+
+func (this sampleHeap) Len() int           { return len(this) }
+func (this sampleHeap) Less(i, j int) bool { return this[i].priority > this[j].priority }
+func (this sampleHeap) Swap(i, j int)      { this[i], this[j] = this[j], this[i] }
+
+func (this *sampleHeap) Push(x interface{}) {
+	*this = append(*this, x.(sampleEntry))
+}
+
+func (this *sampleHeap) Pop() interface{} {
+	old := *this
+	n := len(old)
+	x := old[n-1]
+	*this = old[:n-1]
+	return x
+}
@@ -52,7 +52,7 @@ func (this *CountScan) RunOnce(context *Context, parent value.Value) {
 		defer this.notify()                          // Notify that I have stopped
 
 		this.switchPhase(_SERVTIME)
-		count, e := this.plan.Keyspace().Count(context)
+		count, e := context.keyspace(this.plan.Keyspace()).Count(context)
 		this.switchPhase(_EXECTIME)
 
 		if e != nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/couchbase/query/datastore"
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/transactions"
 	"github.com/couchbase/query/value"
 )
 
@@ -248,8 +249,16 @@ func (this *spanScan) scan(context *Context, conn *datastore.IndexConnection, pa
 
 	keyspaceTerm := this.plan.Term()
 	scanVector := context.ScanVectorSource().ScanVector(keyspaceTerm.Namespace(), keyspaceTerm.Keyspace())
-	this.plan.Index().Scan(context.RequestId(), dspan, this.plan.Distinct(), limit,
-		context.ScanConsistency(), scanVector, conn)
+	context.scanIndex(keyspaceTerm, &transactions.IndexScan{
+		Index:    this.plan.Index(),
+		Matches:  func(key value.Values) bool { return datastore.MatchSpan(key, dspan) },
+		Distinct: this.plan.Distinct(),
+		Limit:    limit,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			this.plan.Index().Scan(context.RequestId(), dspan, this.plan.Distinct(), limit,
+				context.ScanConsistency(), scanVector, conn)
+		},
+	}, conn)
 }
 
 func evalSpan(ps *plan.Span, parent value.Value, context *Context) (*datastore.Span, bool, error) {
@@ -16,6 +16,7 @@ import (
 	"github.com/couchbase/query/datastore"
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/transactions"
 	"github.com/couchbase/query/value"
 )
 
@@ -167,9 +168,29 @@ func (this *IndexScan2) scan(context *Context, conn *datastore.IndexConnection,
 		indexProjection = &datastore.IndexProjection{EntryKeys: proj.EntryKeys, PrimaryKey: proj.PrimaryKey}
 	}
 
-	plan.Index().Scan2(context.RequestId(), dspans, plan.Reverse(), plan.Distinct(), plan.Ordered(),
-		indexProjection, offset, limit,
-		context.ScanConsistency(), scanVector, conn)
+	context.scanIndex(plan.Term(), &transactions.IndexScan{
+		Index:      plan.Index(),
+		Matches:    func(key value.Values) bool { return matchSpans2(key, dspans) },
+		Reverse:    plan.Reverse(),
+		Distinct:   plan.Distinct(),
+		Offset:     offset,
+		Limit:      limit,
+		Projection: indexProjection,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			plan.Index().Scan2(context.RequestId(), dspans, plan.Reverse(), plan.Distinct(), plan.Ordered(),
+				projection, offset, limit,
+				context.ScanConsistency(), scanVector, conn)
+		},
+	}, conn)
+}
+
+func matchSpans2(key value.Values, spans datastore.Spans2) bool {
+	for _, span := range spans {
+		if datastore.MatchSpan2(key, span) {
+			return true
+		}
+	}
+	return false
 }
 
 func evalSpan2(pspans plan.Spans2, parent value.Value, context *Context) (datastore.Spans2, bool, error) {
@@ -17,6 +17,7 @@ import (
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/expression"
 	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/transactions"
 	"github.com/couchbase/query/value"
 )
 
@@ -167,9 +168,20 @@ func (this *IndexScan3) scan(context *Context, conn *datastore.IndexConnection,
 	indexProjection, indexOrder, indexGroupAggs := planToScanMapping(plan.Index(), plan.Projection(),
 		plan.OrderTerms(), plan.GroupAggs(), plan.Covers())
 
-	plan.Index().Scan3(context.RequestId(), dspans, plan.Reverse(), plan.Distinct(),
-		indexProjection, offset, limit, indexGroupAggs, indexOrder,
-		context.ScanConsistency(), scanVector, conn)
+	context.scanIndex(plan.Term(), &transactions.IndexScan{
+		Index:      plan.Index(),
+		Matches:    func(key value.Values) bool { return matchSpans2(key, dspans) },
+		Reverse:    plan.Reverse(),
+		Distinct:   plan.Distinct(),
+		Offset:     offset,
+		Limit:      limit,
+		Projection: indexProjection,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			plan.Index().Scan3(context.RequestId(), dspans, plan.Reverse(), plan.Distinct(),
+				projection, offset, limit, indexGroupAggs, indexOrder,
+				context.ScanConsistency(), scanVector, conn)
+		},
+	}, conn)
 }
 
 func planToScanMapping(index datastore.Index, proj *plan.IndexProjection, indexOrderTerms plan.IndexKeyOrders,
@@ -18,6 +18,7 @@ import (
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/logging"
 	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/transactions"
 	"github.com/couchbase/query/value"
 )
 
@@ -177,7 +178,14 @@ func (this *PrimaryScan) scanEntries(context *Context, conn *datastore.IndexConn
 	scanVector := context.ScanVectorSource().ScanVector(keyspace.NamespaceId(), keyspace.Name())
 
 	index := this.plan.Index()
-	index.ScanEntries(context.RequestId(), limit, context.ScanConsistency(), scanVector, conn)
+	context.scanIndex(nil, &transactions.IndexScan{
+		Keyspace: keyspace,
+		Index:    index,
+		Limit:    limit,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			index.ScanEntries(context.RequestId(), limit, context.ScanConsistency(), scanVector, conn)
+		},
+	}, conn)
 }
 
 func (this *PrimaryScan) scanChunk(context *Context, conn *datastore.IndexConnection, limit int64, indexEntry *datastore.IndexEntry) {
@@ -190,8 +198,16 @@ func (this *PrimaryScan) scanChunk(context *Context, conn *datastore.IndexConnec
 	}
 	keyspace := this.plan.Keyspace()
 	scanVector := context.ScanVectorSource().ScanVector(keyspace.NamespaceId(), keyspace.Name())
-	this.plan.Index().Scan(context.RequestId(), ds, true, limit,
-		context.ScanConsistency(), scanVector, conn)
+	context.scanIndex(nil, &transactions.IndexScan{
+		Keyspace: keyspace,
+		Index:    this.plan.Index(),
+		Matches:  func(key value.Values) bool { return datastore.MatchSpan(key, ds) },
+		Limit:    limit,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			this.plan.Index().Scan(context.RequestId(), ds, true, limit,
+				context.ScanConsistency(), scanVector, conn)
+		},
+	}, conn)
 }
 
 func (this *PrimaryScan) MarshalJSON() ([]byte, error) {
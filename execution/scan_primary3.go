@@ -17,6 +17,7 @@ import (
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/logging"
 	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/transactions"
 	"github.com/couchbase/query/value"
 )
 
@@ -174,8 +175,17 @@ func (this *PrimaryScan3) scanEntries(context *Context, conn *datastore.IndexCon
 	indexProjection, indexOrder, indexGroupAggs := planToScanMapping(index, this.plan.Projection(),
 		this.plan.OrderTerms(), this.plan.GroupAggs(), nil)
 
-	index.ScanEntries3(context.RequestId(), indexProjection, offset, limit, indexGroupAggs, indexOrder,
-		context.ScanConsistency(), scanVector, conn)
+	context.scanIndex(nil, &transactions.IndexScan{
+		Keyspace:   keyspace,
+		Index:      index,
+		Offset:     offset,
+		Limit:      limit,
+		Projection: indexProjection,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			index.ScanEntries3(context.RequestId(), projection, offset, limit, indexGroupAggs, indexOrder,
+				context.ScanConsistency(), scanVector, conn)
+		},
+	}, conn)
 }
 
 func (this *PrimaryScan3) scanChunk(context *Context, conn *datastore.IndexConnection, limit int64, indexEntry *datastore.IndexEntry) {
@@ -188,8 +198,16 @@ func (this *PrimaryScan3) scanChunk(context *Context, conn *datastore.IndexConne
 	}
 	keyspace := this.plan.Keyspace()
 	scanVector := context.ScanVectorSource().ScanVector(keyspace.NamespaceId(), keyspace.Name())
-	this.plan.Index().Scan(context.RequestId(), ds, true, limit,
-		context.ScanConsistency(), scanVector, conn)
+	context.scanIndex(nil, &transactions.IndexScan{
+		Keyspace: keyspace,
+		Index:    this.plan.Index(),
+		Matches:  func(key value.Values) bool { return datastore.MatchSpan(key, ds) },
+		Limit:    limit,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			this.plan.Index().Scan(context.RequestId(), ds, true, limit,
+				context.ScanConsistency(), scanVector, conn)
+		},
+	}, conn)
 }
 
 func (this *PrimaryScan3) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,776 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	go_atomic "sync/atomic"
+
+	atomic "github.com/couchbase/go-couchbase/platform"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/util"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Spilling of operator state to local disk.
+
+Operators that retain all of their input add the estimated size of what
+they hold to the request context. Once the request crosses the spill
+threshold, they write their state to runs in the spill directory, and
+read it back once all the input has been received.
+
+A run is a sequence of length prefixed JSON records, each holding an
+annotated value together with its attachments, covers and id, so that
+values read back behave as the ones that were written.
+*/
+
+const _SPILL_THRESHOLD = 512 * 1024 * 1024
+
+var spillThreshold atomic.AlignedInt64
+var spillDir go_atomic.Value
+
+func init() {
+	atomic.StoreInt64(&spillThreshold, _SPILL_THRESHOLD)
+	spillDir.Store("")
+}
+
+// Zero or negative values disable spilling
+func SetSpillThreshold(threshold int64) {
+	atomic.StoreInt64(&spillThreshold, threshold)
+}
+
+func GetSpillThreshold() int64 {
+	return atomic.LoadInt64(&spillThreshold)
+}
+
+// An empty directory stands for the default temporary directory
+func SetSpillDir(dir string) {
+	spillDir.Store(dir)
+}
+
+func GetSpillDir() string {
+	return spillDir.Load().(string)
+}
+
+// Operators only spill once they hold this fraction of the threshold,
+// so as not to write out tiny runs while others hold the memory
+const _SPILL_SHARE = 8
+
+// Add the estimated size of retained values to the request, and
+// return whether an operator holding the given size should spill.
+// Requests with a memory quota below the threshold spill before they
+// exceed it, and only fail if no operator holds enough to spill
+func (this *Context) addSpillMemory(size, held int64) (spill, ok bool) {
+	memory := this.addMemory(size)
+	threshold := GetSpillThreshold()
+	if threshold > 0 {
+		quota := this.GetMemoryQuota()
+		if quota > 0 && quota < threshold {
+			threshold = quota
+		}
+		if memory > threshold && held >= threshold/_SPILL_SHARE {
+			return true, true
+		}
+	}
+	return false, this.checkMemory(memory)
+}
+
+type spillRun struct {
+	name   string
+	file   *os.File // nil while the run is suspended
+	writer *bufio.Writer
+	reader *bufio.Reader
+	length [binary.MaxVarintLen64]byte
+	count  int
+}
+
+func newSpillRun(what string) (*spillRun, errors.Error) {
+	file, err := ioutil.TempFile(GetSpillDir(), "n1ql-"+what+"-")
+	if err != nil {
+		return nil, errors.NewSpillError(err, what)
+	}
+
+	return &spillRun{
+		name:   file.Name(),
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+func (this *spillRun) write(item value.AnnotatedValue) error {
+	return this.writeKey("", item)
+}
+
+// Write a value together with the key it is hashed on
+func (this *spillRun) writeKey(key string, item value.AnnotatedValue) error {
+	record, err := newSpillRecord(item)
+	if err != nil {
+		return err
+	}
+	record.Key = key
+
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	n := binary.PutUvarint(this.length[:], uint64(len(bytes)))
+	_, err = this.writer.Write(this.length[:n])
+	if err == nil {
+		_, err = this.writer.Write(bytes)
+	}
+
+	if err == nil {
+		this.count++
+	}
+	return err
+}
+
+// Get ready to read back what has been written, or to read it again
+func (this *spillRun) rewind() error {
+	var err error
+	if this.writer != nil {
+		err = this.writer.Flush()
+		this.writer = nil
+	}
+	if err == nil {
+		_, err = this.file.Seek(0, os.SEEK_SET)
+	}
+
+	this.reader = bufio.NewReader(this.file)
+	return err
+}
+
+// Close the file of a complete run until it is read back, so that
+// runs waiting to be merged do not hold open files
+func (this *spillRun) suspend() error {
+	err := this.writer.Flush()
+	if cerr := this.file.Close(); err == nil {
+		err = cerr
+	}
+
+	this.writer = nil
+	this.file = nil
+	return err
+}
+
+// Reopen a suspended run to read it back
+func (this *spillRun) resume() error {
+	file, err := os.Open(this.name)
+	if err != nil {
+		return err
+	}
+
+	this.file = file
+	this.reader = bufio.NewReader(file)
+	return nil
+}
+
+// Returns nil once the run is exhausted
+func (this *spillRun) read() (value.AnnotatedValue, error) {
+	_, item, err := this.readKey()
+	return item, err
+}
+
+func (this *spillRun) readKey() (string, value.AnnotatedValue, error) {
+	length, err := binary.ReadUvarint(this.reader)
+	if err == io.EOF {
+		return "", nil, nil
+	} else if err != nil {
+		return "", nil, err
+	}
+
+	bytes := make([]byte, length)
+	_, err = io.ReadFull(this.reader, bytes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	record := &spillRecord{}
+	err = json.Unmarshal(bytes, record)
+	if err != nil {
+		return "", nil, err
+	}
+
+	item, err := record.annotatedValue()
+	return record.Key, item, err
+}
+
+func (this *spillRun) close() {
+	if this.file != nil {
+		this.file.Close()
+		this.file = nil
+	}
+	os.Remove(this.name)
+}
+
+// Hash based operators spill to a fixed number of partitions, so that
+// values with equal keys always end up in the same partition, and each
+// partition can be processed in turn
+const _SPILL_PARTITIONS = 16
+
+// A partition too large to be processed in memory is split again into
+// partitions, hashing with a different seed, up to this many levels.
+// Beyond that, keys are likely to be equal, and splitting cannot help.
+const _SPILL_LEVELS = 4
+
+type spillPartitions struct {
+	what  string
+	level int // seeds the hash
+	runs  [_SPILL_PARTITIONS]*spillRun
+}
+
+func newSpillPartitions(what string) *spillPartitions {
+	return &spillPartitions{what: what}
+}
+
+// The upper half of the hash is used, as hash tables use the lower one.
+// Below the first level, the key is hashed together with the level.
+func spillPartition(key string, level int) int {
+	bytes := []byte(key)
+	if level > 0 {
+		bytes = append([]byte(strconv.Itoa(level)+":"), bytes...)
+	}
+	return int((util.SeaHashSum64(bytes) >> 32) % _SPILL_PARTITIONS)
+}
+
+func (this *spillPartitions) partition(key string) int {
+	return spillPartition(key, this.level)
+}
+
+// Whether a partition can be split again
+func (this *spillPartitions) splittable() bool {
+	return this.level+1 < _SPILL_LEVELS
+}
+
+// Partitions of the next level, for the values of a partition too
+// large to be processed in memory
+func (this *spillPartitions) child() *spillPartitions {
+	return &spillPartitions{what: this.what, level: this.level + 1}
+}
+
+// Split a partition into partitions of the next level. The partition
+// is removed.
+func (this *spillPartitions) split(p int) (*spillPartitions, errors.Error) {
+	rv := this.child()
+	var err errors.Error
+	e := this.read(p, func(key string, item value.AnnotatedValue) bool {
+		err = rv.write(key, item)
+		return err == nil
+	})
+	this.closePartition(p)
+
+	if err == nil {
+		err = e
+	}
+	if err != nil {
+		rv.close()
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (this *spillPartitions) write(key string, item value.AnnotatedValue) errors.Error {
+	p := this.partition(key)
+	run := this.runs[p]
+	if run == nil {
+		var err errors.Error
+		run, err = newSpillRun(this.what)
+		if err != nil {
+			return err
+		}
+		this.runs[p] = run
+	}
+
+	e := run.writeKey(key, item)
+	if e != nil {
+		return errors.NewSpillError(e, this.what)
+	}
+	return nil
+}
+
+// Read back every value written to a partition
+func (this *spillPartitions) read(p int, process func(key string, item value.AnnotatedValue) bool) errors.Error {
+	run := this.runs[p]
+	if run == nil {
+		return nil
+	}
+
+	e := run.rewind()
+	for e == nil {
+		var key string
+		var item value.AnnotatedValue
+		key, item, e = run.readKey()
+		if e != nil || item == nil || !process(key, item) {
+			break
+		}
+	}
+
+	if e != nil {
+		return errors.NewSpillError(e, this.what)
+	}
+	return nil
+}
+
+// Remove a partition once it has been processed
+func (this *spillPartitions) closePartition(p int) {
+	if this.runs[p] != nil {
+		this.runs[p].close()
+		this.runs[p] = nil
+	}
+}
+
+func (this *spillPartitions) close() {
+	for p := range this.runs {
+		this.closePartition(p)
+	}
+}
+
+type spillRecord struct {
+	Key         string                      `json:"k,omitempty"`
+	Value       json.RawMessage             `json:"v,omitempty"`
+	Fields      map[string]*spillRecord     `json:"f,omitempty"`
+	Self        []string                    `json:"s,omitempty"`
+	Annotated   bool                        `json:"n,omitempty"`
+	Attachments map[string]*spillAttachment `json:"a,omitempty"`
+	Covers      *spillRecord                `json:"c,omitempty"`
+	Id          *spillAttachment            `json:"i,omitempty"`
+	Bit         uint8                       `json:"b,omitempty"`
+}
+
+func newSpillRecord(val value.Value) (*spillRecord, error) {
+	rv := &spillRecord{}
+
+	switch val.Type() {
+	case value.MISSING:
+	case value.OBJECT:
+		// Annotated fields, such as keyspace documents, keep their
+		// own annotations, and covering scans reference the value
+		// from itself
+		fields := val.Fields()
+		plain := make(map[string]interface{}, len(fields))
+		for name, field := range fields {
+			if av, ok := field.(value.AnnotatedValue); ok {
+				if av == val {
+					rv.Self = append(rv.Self, name)
+					continue
+				}
+
+				if rv.Fields == nil {
+					rv.Fields = make(map[string]*spillRecord)
+				}
+
+				record, err := newSpillRecord(av)
+				if err != nil {
+					return nil, err
+				}
+				rv.Fields[name] = record
+			} else {
+				plain[name] = field
+			}
+		}
+
+		bytes, err := value.NewValue(plain).MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		rv.Value = bytes
+	default:
+		bytes, err := val.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		rv.Value = bytes
+	}
+
+	av, ok := val.(value.AnnotatedValue)
+	if !ok {
+		return rv, nil
+	}
+
+	rv.Annotated = true
+	rv.Bit = av.Bit()
+
+	attachments := av.Attachments()
+	if len(attachments) > 0 {
+		rv.Attachments = make(map[string]*spillAttachment, len(attachments))
+		for name, attachment := range attachments {
+			a, err := newSpillAttachment(attachment)
+			if err != nil {
+				return nil, err
+			}
+			rv.Attachments[name] = a
+		}
+	}
+
+	if covers := av.Covers(); covers != nil {
+		c, err := newSpillRecord(covers)
+		if err != nil {
+			return nil, err
+		}
+		rv.Covers = c
+	}
+
+	if id := av.GetId(); id != nil {
+		i, err := newSpillAttachment(id)
+		if err != nil {
+			return nil, err
+		}
+		rv.Id = i
+	}
+
+	return rv, nil
+}
+
+func (this *spillRecord) value() (value.Value, error) {
+	var rv value.Value
+	if this.Value == nil {
+		rv = value.MISSING_VALUE
+	} else {
+		rv = value.NewValue([]byte(this.Value))
+	}
+
+	if len(this.Fields) > 0 {
+		fields := make(map[string]interface{}, len(this.Fields))
+		for name, field := range rv.Fields() {
+			fields[name] = field
+		}
+
+		for name, record := range this.Fields {
+			field, err := record.value()
+			if err != nil {
+				return nil, err
+			}
+			fields[name] = field
+		}
+		rv = value.NewValue(fields)
+	}
+
+	if !this.Annotated {
+		return rv, nil
+	}
+
+	av := value.NewAnnotatedValue(rv)
+	av.SetBit(this.Bit)
+
+	for name, attachment := range this.Attachments {
+		a, err := attachment.attachment()
+		if err != nil {
+			return nil, err
+		}
+		av.SetAttachment(name, a)
+	}
+
+	if this.Covers != nil {
+		covers, err := this.Covers.value()
+		if err != nil {
+			return nil, err
+		}
+		for name, cover := range covers.Fields() {
+			av.SetCover(name, value.NewValue(cover))
+		}
+	}
+
+	if this.Id != nil {
+		id, err := this.Id.attachment()
+		if err != nil {
+			return nil, err
+		}
+		av.SetId(id)
+	}
+
+	for _, name := range this.Self {
+		av.SetField(name, av)
+	}
+
+	return av, nil
+}
+
+func (this *spillRecord) annotatedValue() (value.AnnotatedValue, error) {
+	val, err := this.value()
+	if err != nil {
+		return nil, err
+	}
+	return value.NewAnnotatedValue(val), nil
+}
+
+// Attachments keep their Go type, as their consumers assert it
+type spillAttachment struct {
+	Kind   string                      `json:"k"`
+	Value  *spillRecord                `json:"v,omitempty"`
+	Map    map[string]*spillAttachment `json:"m,omitempty"`
+	Scalar string                      `json:"s,omitempty"`
+	Set    []*spillRecord              `json:"set,omitempty"`
+	Digest *spillDigest                `json:"td,omitempty"`
+}
+
+// t-digests of approximate percentiles
+type spillDigest struct {
+	Compression float64         `json:"c"`
+	Min         float64         `json:"min"`
+	Max         float64         `json:"max"`
+	Centroids   []util.Centroid `json:"cs"`
+}
+
+const (
+	_SPILL_VALUE     = "v"
+	_SPILL_MAP       = "m"
+	_SPILL_VALUE_MAP = "vm"
+	_SPILL_STRING    = "s"
+	_SPILL_BOOL      = "b"
+	_SPILL_INT       = "i"
+	_SPILL_INT64     = "i64"
+	_SPILL_UINT32    = "u32"
+	_SPILL_UINT64    = "u64"
+	_SPILL_FLOAT64   = "f64"
+	_SPILL_SET       = "set"
+	_SPILL_HLL       = "hll"
+	_SPILL_TDIGEST   = "td"
+)
+
+func newSpillAttachment(attachment interface{}) (*spillAttachment, error) {
+	switch attachment := attachment.(type) {
+	case value.Value:
+		record, err := newSpillRecord(attachment)
+		if err != nil {
+			return nil, err
+		}
+		return &spillAttachment{Kind: _SPILL_VALUE, Value: record}, nil
+	case map[string]interface{}:
+		rv := &spillAttachment{Kind: _SPILL_MAP, Map: make(map[string]*spillAttachment, len(attachment))}
+		for name, a := range attachment {
+			m, err := newSpillAttachment(a)
+			if err != nil {
+				return nil, err
+			}
+			rv.Map[name] = m
+		}
+		return rv, nil
+	case map[string]value.Value:
+		rv := &spillAttachment{Kind: _SPILL_VALUE_MAP, Map: make(map[string]*spillAttachment, len(attachment))}
+		for name, a := range attachment {
+			m, err := newSpillAttachment(a)
+			if err != nil {
+				return nil, err
+			}
+			rv.Map[name] = m
+		}
+		return rv, nil
+	case *value.Set:
+		// sets of distinct aggregates collect their values
+		values := attachment.Values()
+		if values == nil && attachment.Len() > 0 {
+			return nil, fmt.Errorf("Cannot spill set without values")
+		}
+
+		rv := &spillAttachment{Kind: _SPILL_SET, Scalar: strconv.Itoa(attachment.ObjectCap()),
+			Set: make([]*spillRecord, len(values))}
+		for i, v := range values {
+			if v != nil {
+				record, err := newSpillRecord(v)
+				if err != nil {
+					return nil, err
+				}
+				rv.Set[i] = record
+			}
+		}
+		return rv, nil
+	case *util.HyperLogLog:
+		// sketches of approximate aggregates
+		return &spillAttachment{Kind: _SPILL_HLL,
+			Scalar: base64.StdEncoding.EncodeToString(attachment.Registers())}, nil
+	case *util.TDigest:
+		return &spillAttachment{Kind: _SPILL_TDIGEST, Digest: &spillDigest{
+			Compression: attachment.Compression(),
+			Min:         attachment.Min(),
+			Max:         attachment.Max(),
+			Centroids:   attachment.Centroids(),
+		}}, nil
+	case string:
+		return &spillAttachment{Kind: _SPILL_STRING, Scalar: attachment}, nil
+	case bool:
+		return &spillAttachment{Kind: _SPILL_BOOL, Scalar: strconv.FormatBool(attachment)}, nil
+	case int:
+		return &spillAttachment{Kind: _SPILL_INT, Scalar: strconv.Itoa(attachment)}, nil
+	case int64:
+		return &spillAttachment{Kind: _SPILL_INT64, Scalar: strconv.FormatInt(attachment, 10)}, nil
+	case uint32:
+		return &spillAttachment{Kind: _SPILL_UINT32, Scalar: strconv.FormatUint(uint64(attachment), 10)}, nil
+	case uint64:
+		return &spillAttachment{Kind: _SPILL_UINT64, Scalar: strconv.FormatUint(attachment, 10)}, nil
+	case float64:
+		return &spillAttachment{Kind: _SPILL_FLOAT64, Scalar: strconv.FormatFloat(attachment, 'g', -1, 64)}, nil
+	default:
+		return nil, fmt.Errorf("Cannot spill attachment of type %T", attachment)
+	}
+}
+
+func (this *spillAttachment) attachment() (interface{}, error) {
+	switch this.Kind {
+	case _SPILL_VALUE:
+		return this.Value.value()
+	case _SPILL_MAP:
+		rv := make(map[string]interface{}, len(this.Map))
+		for name, a := range this.Map {
+			m, err := a.attachment()
+			if err != nil {
+				return nil, err
+			}
+			rv[name] = m
+		}
+		return rv, nil
+	case _SPILL_VALUE_MAP:
+		rv := make(map[string]value.Value, len(this.Map))
+		for name, a := range this.Map {
+			m, err := a.attachment()
+			if err != nil {
+				return nil, err
+			}
+			v, ok := m.(value.Value)
+			if !ok {
+				return nil, fmt.Errorf("Invalid spilled value of type %T", m)
+			}
+			rv[name] = v
+		}
+		return rv, nil
+	case _SPILL_SET:
+		objectCap, err := strconv.Atoi(this.Scalar)
+		if err != nil {
+			return nil, err
+		}
+
+		rv := value.NewSet(objectCap, true)
+		for _, record := range this.Set {
+			if record == nil {
+				rv.Add(nil)
+				continue
+			}
+
+			v, err := record.value()
+			if err != nil {
+				return nil, err
+			}
+			rv.Add(v)
+		}
+		return rv, nil
+	case _SPILL_HLL:
+		registers, err := base64.StdEncoding.DecodeString(this.Scalar)
+		if err != nil {
+			return nil, err
+		}
+		return util.NewHyperLogLogFromRegisters(registers)
+	case _SPILL_TDIGEST:
+		if this.Digest == nil {
+			return nil, fmt.Errorf("Missing spilled t-digest")
+		}
+		return util.NewTDigestFromCentroids(this.Digest.Compression, this.Digest.Min, this.Digest.Max,
+			this.Digest.Centroids), nil
+	case _SPILL_STRING:
+		return this.Scalar, nil
+	case _SPILL_BOOL:
+		return strconv.ParseBool(this.Scalar)
+	case _SPILL_INT:
+		return strconv.Atoi(this.Scalar)
+	case _SPILL_INT64:
+		return strconv.ParseInt(this.Scalar, 10, 64)
+	case _SPILL_UINT32:
+		u, err := strconv.ParseUint(this.Scalar, 10, 32)
+		return uint32(u), err
+	case _SPILL_UINT64:
+		return strconv.ParseUint(this.Scalar, 10, 64)
+	case _SPILL_FLOAT64:
+		return strconv.ParseFloat(this.Scalar, 64)
+	default:
+		return nil, fmt.Errorf("Invalid spilled attachment kind %s", this.Kind)
+	}
+}
+
+const (
+	_SIZE_SCALAR = 16
+	_SIZE_STRING = 16
+	_SIZE_ARRAY  = 24
+	_SIZE_OBJECT = 48
+	_SIZE_FIELD  = 16
+)
+
+// Rough estimate of the memory held by a value and its attachments
+func valueSize(val interface{}) int64 {
+	switch val := val.(type) {
+	case value.AnnotatedValue:
+		var size int64
+		if val.Type() == value.OBJECT {
+			size = _SIZE_OBJECT
+			for name, field := range val.Fields() {
+				if av, ok := field.(value.AnnotatedValue); !ok || av != val {
+					size += _SIZE_FIELD + int64(len(name)) + valueSize(field)
+				}
+			}
+		} else {
+			size = valueSize(val.GetValue())
+		}
+		for name, attachment := range val.Attachments() {
+			size += _SIZE_FIELD + int64(len(name)) + valueSize(attachment)
+		}
+		if covers := val.Covers(); covers != nil {
+			size += valueSize(covers)
+		}
+		return size
+	case value.Value:
+		switch val.Type() {
+		case value.OBJECT:
+			return valueSize(val.Fields())
+		case value.ARRAY:
+			return valueSize(val.Actual())
+		case value.STRING:
+			return _SIZE_STRING + int64(len(val.Actual().(string)))
+		case value.BINARY:
+			return _SIZE_ARRAY + int64(len(val.Actual().([]byte)))
+		default:
+			return _SIZE_SCALAR
+		}
+	case map[string]interface{}:
+		size := int64(_SIZE_OBJECT)
+		for name, field := range val {
+			size += _SIZE_FIELD + int64(len(name)) + valueSize(field)
+		}
+		return size
+	case map[string]value.Value:
+		size := int64(_SIZE_OBJECT)
+		for name, field := range val {
+			size += _SIZE_FIELD + int64(len(name)) + valueSize(field)
+		}
+		return size
+	case []interface{}:
+		size := int64(_SIZE_ARRAY)
+		for _, elem := range val {
+			size += valueSize(elem)
+		}
+		return size
+	case string:
+		return _SIZE_STRING + int64(len(val))
+	case *util.HyperLogLog:
+		return _SIZE_ARRAY + int64(len(val.Registers()))
+	case *util.TDigest:
+		return _SIZE_ARRAY + 2*_SIZE_SCALAR*int64(len(val.Centroids()))
+	case nil:
+		return 0
+	default:
+		return _SIZE_SCALAR
+	}
+}
@@ -0,0 +1,79 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/transactions"
+	"github.com/couchbase/query/value"
+)
+
+type StartTransaction struct {
+	base
+	plan *plan.StartTransaction
+}
+
+func NewStartTransaction(plan *plan.StartTransaction, context *Context) *StartTransaction {
+	rv := &StartTransaction{
+		plan: plan,
+	}
+
+	newRedirectBase(&rv.base)
+	rv.output = rv
+	return rv
+}
+
+func (this *StartTransaction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitStartTransaction(this)
+}
+
+func (this *StartTransaction) Copy() Operator {
+	rv := &StartTransaction{plan: this.plan}
+	this.base.copy(&rv.base)
+	return rv
+}
+
+func (this *StartTransaction) RunOnce(context *Context, parent value.Value) {
+	this.once.Do(func() {
+		defer context.Recover() // Recover from any panic
+		active := this.active()
+		defer this.close(context)
+		this.switchPhase(_EXECTIME)
+		defer this.switchPhase(_NOTIME)
+		defer this.notify() // Notify that I have stopped
+		if !active || context.Readonly() {
+			return
+		}
+
+		if context.Transaction() != nil {
+			context.Error(errors.NewTransactionInProgressError(context.Transaction().Id()))
+			return
+		}
+
+		tx, err := transactions.Start(context.Datastore(),
+			transactions.Owner(context.Credentials(), context.OriginalHttpRequest()))
+		if err != nil {
+			context.Error(err)
+			return
+		}
+
+		this.sendItem(value.NewAnnotatedValue(map[string]interface{}{"txid": tx.Id()}))
+	})
+}
+
+func (this *StartTransaction) MarshalJSON() ([]byte, error) {
+	r := this.plan.MarshalBase(func(r map[string]interface{}) {
+		this.marshalTimes(r)
+	})
+	return json.Marshal(r)
+}
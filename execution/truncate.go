@@ -0,0 +1,84 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/value"
+)
+
+type Truncate struct {
+	base
+	plan *plan.Truncate
+}
+
+func NewTruncate(plan *plan.Truncate, context *Context) *Truncate {
+	rv := &Truncate{
+		plan: plan,
+	}
+
+	newRedirectBase(&rv.base)
+	rv.output = rv
+	return rv
+}
+
+func (this *Truncate) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitTruncate(this)
+}
+
+func (this *Truncate) Copy() Operator {
+	rv := &Truncate{plan: this.plan}
+	this.base.copy(&rv.base)
+	return rv
+}
+
+func (this *Truncate) RunOnce(context *Context, parent value.Value) {
+	this.once.Do(func() {
+		defer context.Recover() // Recover from any panic
+		this.active()
+		defer this.close(context)
+		this.switchPhase(_EXECTIME)
+		defer this.switchPhase(_NOTIME)
+		defer this.notify() // Notify that I have stopped
+
+		if context.Readonly() {
+			return
+		}
+
+		if context.Transaction() != nil {
+			context.Error(errors.NewTransactionStatementError("TRUNCATE"))
+			return
+		}
+
+		keyspace, ok := this.plan.Keyspace().(datastore.TruncateKeyspace)
+		if !ok {
+			context.Error(errors.NewTruncateNotSupportedError(this.plan.Keyspace().Name()))
+			return
+		}
+
+		// Actually truncate keyspace
+		this.switchPhase(_SERVTIME)
+		err := keyspace.Truncate(context)
+		if err != nil {
+			context.Error(err)
+		}
+	})
+}
+
+func (this *Truncate) MarshalJSON() ([]byte, error) {
+	r := this.plan.MarshalBase(func(r map[string]interface{}) {
+		this.marshalTimes(r)
+	})
+	return json.Marshal(r)
+}
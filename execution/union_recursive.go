@@ -0,0 +1,278 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/value"
+)
+
+type RecursiveUnion struct {
+	base
+	plan         *plan.RecursiveUnion
+	first        Operator
+	second       Operator
+	reopenFirst  bool
+	reopenSecond bool
+}
+
+func NewRecursiveUnion(plan *plan.RecursiveUnion, context *Context, first, second Operator) *RecursiveUnion {
+	rv := &RecursiveUnion{
+		plan:   plan,
+		first:  first,
+		second: second,
+	}
+
+	newBase(&rv.base, context)
+	rv.trackChildren(2)
+	rv.output = rv
+	return rv
+}
+
+func (this *RecursiveUnion) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitRecursiveUnion(this)
+}
+
+func (this *RecursiveUnion) Copy() Operator {
+	rv := &RecursiveUnion{
+		plan:   this.plan,
+		first:  this.first.Copy(),
+		second: this.second.Copy(),
+	}
+
+	this.base.copy(&rv.base)
+	return rv
+}
+
+func (this *RecursiveUnion) RunOnce(context *Context, parent value.Value) {
+	this.once.Do(func() {
+		defer context.Recover() // Recover from any panic
+		active := this.active()
+		defer this.close(context)
+		this.switchPhase(_EXECTIME)
+		defer this.switchPhase(_NOTIME)
+		defer this.notify() // Notify that I have stopped
+
+		if !active || !context.assert(this.first != nil && this.second != nil,
+			"Recursive union has no children") {
+			return
+		}
+
+		var distinct, cycles *value.Set
+		if this.plan.Distinct() {
+			distinct = value.NewSet(_RECURSIVE_SET_CAP, false)
+		}
+
+		if len(this.plan.Cycle()) > 0 {
+			cycles = value.NewSet(_RECURSIVE_SET_CAP, false)
+		}
+
+		levels := this.plan.Levels()
+		documents := this.plan.Documents()
+		docs := int64(0)
+
+		// Run the anchor
+		items, ok := this.runChild(this.first, &this.reopenFirst, context, parent)
+
+		for level := int64(0); ok; level++ {
+			working := make([]interface{}, 0, len(items))
+
+			// Send new rows, and keep them for the next iteration
+			for _, item := range items {
+				if documents >= 0 && docs >= documents {
+					break
+				}
+
+				var p value.Value = item
+				if pv := item.GetAttachment("projection"); pv != nil {
+					p = pv.(value.Value)
+				}
+
+				if distinct != nil {
+					if distinct.Has(p) {
+						continue
+					}
+
+					distinct.Put(p, p)
+				}
+
+				if cycles != nil {
+					key, err := this.cycleKey(p, context)
+					if err != nil {
+						context.Error(errors.NewEvaluationError(err, "CYCLE"))
+						return
+					}
+
+					if cycles.Has(key) {
+						continue
+					}
+
+					cycles.Put(key, key)
+				}
+
+				// Without a levels option, a recursion that is still
+				// producing rows past the default maximum is an error
+				// rather than a silently truncated result
+				if levels < 0 && level > _RECURSIVE_MAX_LEVELS {
+					context.Error(errors.NewRecursionLevelsExceededError(
+						strings.TrimPrefix(this.plan.WorkingSet(), "#"), _RECURSIVE_MAX_LEVELS))
+					return
+				}
+
+				if !this.sendItem(item) {
+					return
+				}
+
+				docs++
+				working = append(working, p)
+			}
+
+			if len(working) == 0 || (levels >= 0 && level >= levels) ||
+				(documents >= 0 && docs >= documents) {
+				break
+			}
+
+			// Run the recursive member over the rows of this iteration
+			scope := value.NewScopeValue(map[string]interface{}{
+				this.plan.WorkingSet(): working,
+			}, parent)
+			items, ok = this.runChild(this.second, &this.reopenSecond, context, scope)
+		}
+
+		context.SetSortCount(0)
+	})
+}
+
+/*
+Run child to completion, and return the items it produced.
+*/
+func (this *RecursiveUnion) runChild(child Operator, reopen *bool, context *Context,
+	parent value.Value) ([]value.AnnotatedValue, bool) {
+	if *reopen {
+		child.SendStop()
+		child.reopen(context)
+	} else {
+		*reopen = true
+	}
+
+	child.SetOutput(child)
+	child.SetInput(nil)
+	child.SetParent(this)
+	child.SetStop(nil)
+
+	go child.RunOnce(context, parent)
+
+	items := make([]value.AnnotatedValue, 0, _RECURSIVE_SET_CAP)
+	stopped := false
+	n := 1
+
+loop:
+	for {
+		item, c, cont := this.getItemChildrenOp(child)
+		if cont {
+			if item != nil {
+				items = append(items, item)
+			} else if c >= 0 {
+				n--
+			} else {
+				break loop
+			}
+		} else {
+			stopped = true
+			break loop
+		}
+	}
+
+	if n > 0 {
+		notifyChildren(child)
+		this.childrenWaitNoStop(n)
+	}
+
+	return items, !stopped
+}
+
+func (this *RecursiveUnion) cycleKey(item value.Value, context *Context) (value.Value, error) {
+	cycle := this.plan.Cycle()
+	key := make([]interface{}, len(cycle))
+	for i, expr := range cycle {
+		v, err := expr.Evaluate(item, context)
+		if err != nil {
+			return nil, err
+		}
+
+		key[i] = v
+	}
+
+	return value.NewValue(key), nil
+}
+
+func (this *RecursiveUnion) MarshalJSON() ([]byte, error) {
+	r := this.plan.MarshalBase(func(r map[string]interface{}) {
+		this.marshalTimes(r)
+		r["first"] = this.first
+		r["second"] = this.second
+	})
+	return json.Marshal(r)
+}
+
+func (this *RecursiveUnion) accrueTimes(o Operator) {
+	if baseAccrueTimes(this, o) {
+		return
+	}
+	copy, _ := o.(*RecursiveUnion)
+	this.first.accrueTimes(copy.first)
+	this.second.accrueTimes(copy.second)
+}
+
+func (this *RecursiveUnion) SendStop() {
+	this.baseSendStop()
+	first := this.first
+	second := this.second
+	if first != nil {
+		first.SendStop()
+	}
+	if second != nil {
+		second.SendStop()
+	}
+}
+
+func (this *RecursiveUnion) reopen(context *Context) {
+	this.baseReopen(context)
+	if this.first != nil {
+		this.first.reopen(context)
+	}
+	if this.second != nil {
+		this.second.reopen(context)
+	}
+	this.reopenFirst = false
+	this.reopenSecond = false
+}
+
+func (this *RecursiveUnion) Done() {
+	this.baseDone()
+	if this.first != nil {
+		this.first.Done()
+	}
+	if this.second != nil {
+		this.second.Done()
+	}
+	this.first = nil
+	this.second = nil
+}
+
+const _RECURSIVE_SET_CAP = 64
+
+// Maximum number of iterations of a recursive member that has no
+// levels option
+const _RECURSIVE_MAX_LEVELS = 100
@@ -151,7 +151,7 @@ func (this *SendUpdate) flushBatch(context *Context) bool {
 
 	this.switchPhase(_SERVTIME)
 
-	pairs, e := this.plan.Keyspace().Update(pairs)
+	pairs, e := context.keyspace(this.plan.Keyspace()).Update(pairs)
 
 	this.switchPhase(_EXECTIME)
 
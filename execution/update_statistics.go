@@ -0,0 +1,150 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Collect the values of the terms in the sampled documents, and store the
+statistics built from them in the keyspace. No rows are returned.
+*/
+type UpdateStatistics struct {
+	base
+	plan   *plan.UpdateStatistics
+	values []value.Values
+	docs   int64
+	memory int64
+	failed bool
+}
+
+func NewUpdateStatistics(plan *plan.UpdateStatistics, context *Context) *UpdateStatistics {
+	rv := &UpdateStatistics{
+		plan: plan,
+	}
+
+	newBase(&rv.base, context)
+	rv.output = rv
+	return rv
+}
+
+func (this *UpdateStatistics) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitUpdateStatistics(this)
+}
+
+func (this *UpdateStatistics) Copy() Operator {
+	rv := &UpdateStatistics{plan: this.plan}
+	this.base.copy(&rv.base)
+	return rv
+}
+
+func (this *UpdateStatistics) RunOnce(context *Context, parent value.Value) {
+	this.runConsumer(this, context, parent)
+}
+
+func (this *UpdateStatistics) beforeItems(context *Context, parent value.Value) bool {
+	this.values = make([]value.Values, len(this.plan.Terms()))
+	this.docs = 0
+	this.failed = false
+	return true
+}
+
+func (this *UpdateStatistics) processItem(item value.AnnotatedValue, context *Context) bool {
+	doc, ok := item.Field(this.plan.Alias())
+	if !ok {
+		return true
+	}
+
+	this.docs++
+	for i, term := range this.plan.Terms() {
+		val, err := term.Evaluate(doc, context)
+		if err != nil {
+			context.Error(errors.NewEvaluationError(err, "UPDATE STATISTICS term"))
+			this.failed = true
+			return false
+		}
+
+		if val.Type() == value.MISSING {
+			continue
+		}
+
+		size := valueSize(val)
+		this.memory += size
+		this.values[i] = append(this.values[i], val)
+		if !context.trackMemory(size) {
+			this.failed = true
+			return false
+		}
+	}
+
+	return true
+}
+
+func (this *UpdateStatistics) afterItems(context *Context) {
+	defer this.release(context)
+
+	// don't store statistics of an incomplete sample
+	if this.stopped || this.failed {
+		return
+	}
+
+	keyspace, ok := this.plan.Keyspace().(datastore.StatisticsKeyspace)
+	if !ok {
+		context.Error(errors.NewUpdateStatisticsNotSupportedError(this.plan.Keyspace().Name()))
+		return
+	}
+
+	total, err := keyspace.Count(context)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	stats := make([]*datastore.ExpressionStatistics, len(this.plan.Terms()))
+	for i, term := range this.plan.Terms() {
+		stats[i] = datastore.NewExpressionStatistics(expression.NewStringer().Visit(term),
+			this.values[i], this.docs, total)
+	}
+
+	this.switchPhase(_SERVTIME)
+	err = keyspace.UpdateStatistics(stats)
+	if err != nil {
+		context.Error(err)
+	}
+}
+
+func (this *UpdateStatistics) readonly() bool {
+	return false
+}
+
+func (this *UpdateStatistics) release(context *Context) {
+	this.values = nil
+	context.releaseMemory(this.memory)
+	this.memory = 0
+}
+
+func (this *UpdateStatistics) MarshalJSON() ([]byte, error) {
+	r := this.plan.MarshalBase(func(r map[string]interface{}) {
+		this.marshalTimes(r)
+	})
+	return json.Marshal(r)
+}
+
+func (this *UpdateStatistics) reopen(context *Context) {
+	this.baseReopen(context)
+	this.release(context)
+}
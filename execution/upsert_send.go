@@ -132,7 +132,7 @@ func (this *SendUpsert) flushBatch(context *Context) bool {
 
 	// Perform the actual UPSERT
 	var er errors.Error
-	dpairs, er = this.plan.Keyspace().Upsert(dpairs)
+	dpairs, er = context.keyspace(this.plan.Keyspace()).Upsert(dpairs)
 
 	this.switchPhase(_EXECTIME)
 
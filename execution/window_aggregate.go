@@ -0,0 +1,496 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Computes window functions sharing the same PARTITION BY and ORDER BY.
+The input is sorted on those terms by a preceding Order, so each
+partition is buffered, computed and sent before the next one starts.
+*/
+type WindowAggregate struct {
+	base
+	plan      *plan.WindowAggregate
+	values    value.AnnotatedValues
+	partition value.Values
+}
+
+var _WINDOW_POOL = value.NewAnnotatedPool(_ORDER_CAP)
+
+func NewWindowAggregate(plan *plan.WindowAggregate, context *Context) *WindowAggregate {
+	rv := &WindowAggregate{
+		plan:   plan,
+		values: _WINDOW_POOL.Get(),
+	}
+
+	newBase(&rv.base, context)
+	rv.output = rv
+	return rv
+}
+
+func (this *WindowAggregate) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitWindowAggregate(this)
+}
+
+func (this *WindowAggregate) Copy() Operator {
+	rv := &WindowAggregate{
+		plan:   this.plan,
+		values: _WINDOW_POOL.Get(),
+	}
+	this.base.copy(&rv.base)
+	return rv
+}
+
+func (this *WindowAggregate) RunOnce(context *Context, parent value.Value) {
+	defer this.releaseValues()
+	this.runConsumer(this, context, parent)
+}
+
+func (this *WindowAggregate) processItem(item value.AnnotatedValue, context *Context) bool {
+	partition, e := windowKeys(item, this.plan.WindowTerm().PartitionBy(), context)
+	if e != nil {
+		context.Fatal(errors.NewEvaluationError(e, "window PARTITION BY"))
+		return false
+	}
+
+	if len(this.values) > 0 && !windowKeysEqual(partition, this.partition) {
+		if !this.computePartition(context) {
+			return false
+		}
+	}
+
+	if len(this.values) == cap(this.values) {
+		values := make(value.AnnotatedValues, len(this.values), len(this.values)<<1)
+		copy(values, this.values)
+		this.releaseValues()
+		this.values = values
+	}
+
+	this.partition = partition
+	this.values = append(this.values, item)
+	return true
+}
+
+func (this *WindowAggregate) afterItems(context *Context) {
+	if this.stopped || len(this.values) == 0 {
+		return
+	}
+
+	this.computePartition(context)
+}
+
+/*
+Compute the window functions for the buffered partition, and send
+its rows.
+*/
+func (this *WindowAggregate) computePartition(context *Context) bool {
+	rows := this.values
+	wTerm := this.plan.WindowTerm()
+
+	var orderBy expression.Expressions
+	if wTerm.OrderBy() != nil {
+		orderBy = wTerm.OrderBy().Expressions()
+	}
+
+	// Peer groups: rows sharing the same ORDER BY values
+	keys := make([]value.Values, len(rows))
+	peerStarts := make([]int, len(rows))
+	peerEnds := make([]int, len(rows))
+	peerGroups := make([]int, len(rows))
+	start, group := 0, 0
+	for i, row := range rows {
+		var e error
+		keys[i], e = windowKeys(row, orderBy, context)
+		if e != nil {
+			context.Fatal(errors.NewEvaluationError(e, "window ORDER BY"))
+			return false
+		}
+
+		if i > 0 && !windowKeysEqual(keys[i], keys[i-1]) {
+			for j := start; j < i; j++ {
+				peerEnds[j] = i
+			}
+			start = i
+			group++
+		}
+
+		peerStarts[i] = start
+		peerGroups[i] = group
+	}
+
+	for j := start; j < len(rows); j++ {
+		peerEnds[j] = len(rows)
+	}
+
+	state := &algebra.WindowState{Rows: rows}
+	frames := &windowFrames{
+		state:      state,
+		peerStarts: peerStarts,
+		peerEnds:   peerEnds,
+		keys:       keys,
+	}
+
+	if len(orderBy) > 0 {
+		frames.descending = wTerm.OrderBy().Terms()[0].Descending()
+	}
+
+	for _, agg := range this.plan.Aggregates() {
+		err := frames.setup(agg, wTerm.OrderBy() != nil, context)
+		if err != nil {
+			context.Fatal(errors.NewEvaluationError(err, "window frame"))
+			return false
+		}
+
+		name := agg.String()
+		var cumulative, result value.Value
+		prevStart, prevEnd := -1, -1
+
+		for i, row := range rows {
+			state.Current = i
+			state.PeerStart = peerStarts[i]
+			state.PeerEnd = peerEnds[i]
+			state.PeerGroup = peerGroups[i]
+			state.FrameStart, state.FrameEnd = frames.bounds(i)
+
+			switch agg := agg.(type) {
+			case algebra.WindowOnlyFunction:
+				result, err = agg.EvaluateWindow(state, context)
+			case algebra.Aggregate:
+				// Cumulate incrementally while the frame only grows
+				if state.FrameStart != prevStart || state.FrameEnd < prevEnd {
+					cumulative = agg.Default()
+					result = nil
+					prevEnd = state.FrameStart
+				}
+
+				if result == nil || state.FrameEnd != prevEnd {
+					for _, frameRow := range rows[prevEnd:state.FrameEnd] {
+						cumulative, err = algebra.CumulateInitial(agg, frameRow, cumulative, context)
+						if err != nil {
+							break
+						}
+					}
+
+					if err == nil {
+						result, err = agg.ComputeFinal(cumulative.Copy(), context)
+					}
+				}
+
+				prevStart, prevEnd = state.FrameStart, state.FrameEnd
+			default:
+				err = fmt.Errorf("Invalid window function %s.", name)
+			}
+
+			if err != nil {
+				context.Fatal(errors.NewEvaluationError(err, "window function"))
+				return false
+			}
+
+			aggregates, ok := row.GetAttachment("aggregates").(map[string]value.Value)
+			if !ok {
+				aggregates = make(map[string]value.Value, len(this.plan.Aggregates()))
+				row.SetAttachment("aggregates", aggregates)
+			}
+
+			aggregates[name] = result
+		}
+	}
+
+	for _, row := range rows {
+		if !this.sendItem(row) {
+			return false
+		}
+	}
+
+	this.values = this.values[0:0]
+	return true
+}
+
+func (this *WindowAggregate) releaseValues() {
+	_WINDOW_POOL.Put(this.values)
+	this.values = nil
+}
+
+func (this *WindowAggregate) MarshalJSON() ([]byte, error) {
+	r := this.plan.MarshalBase(func(r map[string]interface{}) {
+		this.marshalTimes(r)
+	})
+	return json.Marshal(r)
+}
+
+func (this *WindowAggregate) reopen(context *Context) {
+	this.baseReopen(context)
+	this.values = _WINDOW_POOL.Get()
+	this.partition = nil
+}
+
+/*
+Evaluate PARTITION BY or ORDER BY terms, reusing the values attached
+by the preceding Order.
+*/
+func windowKeys(item value.AnnotatedValue, exprs expression.Expressions, context *Context) (
+	value.Values, error) {
+	if len(exprs) == 0 {
+		return nil, nil
+	}
+
+	keys := make(value.Values, len(exprs))
+	for i, expr := range exprs {
+		s := expr.String()
+		if v, ok := item.GetAttachment(s).(value.Value); ok {
+			keys[i] = v
+			continue
+		}
+
+		v, e := expr.Evaluate(item, context)
+		if e != nil {
+			return nil, e
+		}
+
+		item.SetAttachment(s, v)
+		keys[i] = v
+	}
+
+	return keys, nil
+}
+
+func windowKeysEqual(keys1, keys2 value.Values) bool {
+	for i, key := range keys1 {
+		if key.Collate(keys2[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Computes the frame of each row of a partition, for one window
+function.
+*/
+type windowFrames struct {
+	state      *algebra.WindowState
+	peerStarts []int
+	peerEnds   []int
+	keys       []value.Values
+	descending bool
+
+	frame       *algebra.WindowFrame
+	startOffset float64
+	endOffset   float64
+}
+
+/*
+Resolve the frame of the window function, and evaluate its offsets.
+*/
+func (this *windowFrames) setup(agg algebra.WindowFunction, ordered bool, context *Context) (err error) {
+	this.frame = agg.WindowTerm().Frame()
+
+	usesFrame := true
+	if windowOnly, ok := agg.(algebra.WindowOnlyFunction); ok {
+		usesFrame = windowOnly.UsesFrame()
+	}
+
+	switch {
+	case !usesFrame:
+		this.frame = nil
+	case this.frame == nil && ordered:
+		// RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW
+		this.frame = algebra.NewWindowFrame(algebra.WINDOW_FRAME_RANGE,
+			algebra.NewWindowFrameExtent(nil, algebra.WINDOW_UNBOUNDED_PRECEDING),
+			algebra.NewWindowFrameExtent(nil, algebra.WINDOW_CURRENT_ROW))
+	}
+
+	if this.frame == nil {
+		return
+	}
+
+	this.startOffset, err = this.offset(this.frame.Start(), context)
+	if err == nil {
+		this.endOffset, err = this.offset(this.frame.End(), context)
+	}
+
+	return
+}
+
+func (this *windowFrames) offset(extent *algebra.WindowFrameExtent, context *Context) (float64, error) {
+	expr := extent.ValueExpression()
+	if expr == nil {
+		return 0, nil
+	}
+
+	v, e := expr.Evaluate(this.state.Rows[0], context)
+	if e != nil {
+		return 0, e
+	}
+
+	if offset, ok := windowNumber(v); ok && offset >= 0 &&
+		(this.frame.Units() == algebra.WINDOW_FRAME_RANGE || offset == math.Trunc(offset)) {
+		return offset, nil
+	}
+
+	return 0, fmt.Errorf("Invalid window frame offset %v.", v)
+}
+
+/*
+Returns the frame of row i as Rows[start:end]. Without a frame, the
+whole partition.
+*/
+func (this *windowFrames) bounds(i int) (start, end int) {
+	n := len(this.state.Rows)
+	if this.frame == nil {
+		return 0, n
+	}
+
+	if this.frame.Units() == algebra.WINDOW_FRAME_ROWS {
+		start = this.rowsBound(i, this.frame.Start(), this.startOffset)
+		end = this.rowsBound(i, this.frame.End(), this.endOffset) + 1
+	} else {
+		start, end = this.rangeBounds(i)
+	}
+
+	if start < 0 {
+		start = 0
+	}
+
+	if end > n {
+		end = n
+	}
+
+	if start > end {
+		start = end
+	}
+
+	return
+}
+
+func (this *windowFrames) rowsBound(i int, extent *algebra.WindowFrameExtent, offset float64) int {
+	switch extent.Type() {
+	case algebra.WINDOW_UNBOUNDED_PRECEDING:
+		return 0
+	case algebra.WINDOW_VALUE_PRECEDING:
+		return i - int(offset)
+	case algebra.WINDOW_VALUE_FOLLOWING:
+		return i + int(offset)
+	case algebra.WINDOW_UNBOUNDED_FOLLOWING:
+		return len(this.state.Rows) - 1
+	default:
+		return i
+	}
+}
+
+/*
+RANGE frames. Offsets apply to the single numeric ORDER BY key; rows
+with a non-numeric key only range over their peers.
+*/
+func (this *windowFrames) rangeBounds(i int) (start, end int) {
+	n := len(this.state.Rows)
+	startType := this.frame.Start().Type()
+	endType := this.frame.End().Type()
+
+	start, end = this.peerStarts[i], this.peerEnds[i]
+	if startType == algebra.WINDOW_UNBOUNDED_PRECEDING {
+		start = 0
+	}
+
+	if endType == algebra.WINDOW_UNBOUNDED_FOLLOWING {
+		end = n
+	}
+
+	valueStart := startType == algebra.WINDOW_VALUE_PRECEDING || startType == algebra.WINDOW_VALUE_FOLLOWING
+	valueEnd := endType == algebra.WINDOW_VALUE_PRECEDING || endType == algebra.WINDOW_VALUE_FOLLOWING
+	if !valueStart && !valueEnd {
+		return
+	}
+
+	pos, ok := this.position(i)
+	if !ok {
+		return
+	}
+
+	// Numeric keys are contiguous in collation order
+	numStart, numEnd := i, i+1
+	for numStart > 0 && this.isNumeric(numStart-1) {
+		numStart--
+	}
+	for numEnd < n && this.isNumeric(numEnd) {
+		numEnd++
+	}
+
+	if valueStart {
+		lo := pos - this.startOffset
+		if startType == algebra.WINDOW_VALUE_FOLLOWING {
+			lo = pos + this.startOffset
+		}
+
+		start = numStart + sort.Search(numEnd-numStart, func(j int) bool {
+			p, _ := this.position(numStart + j)
+			return p >= lo
+		})
+	}
+
+	if valueEnd {
+		hi := pos + this.endOffset
+		if endType == algebra.WINDOW_VALUE_PRECEDING {
+			hi = pos - this.endOffset
+		}
+
+		end = numStart + sort.Search(numEnd-numStart, func(j int) bool {
+			p, _ := this.position(numStart + j)
+			return p > hi
+		})
+	}
+
+	return
+}
+
+/*
+The ORDER BY key of row i, negated for descending order so that
+positions increase along the partition.
+*/
+func (this *windowFrames) position(i int) (float64, bool) {
+	if !this.isNumeric(i) {
+		return 0, false
+	}
+
+	pos, _ := windowNumber(this.keys[i][0])
+	if this.descending {
+		pos = -pos
+	}
+
+	return pos, true
+}
+
+func (this *windowFrames) isNumeric(i int) bool {
+	return len(this.keys[i]) > 0 && this.keys[i][0].Type() == value.NUMBER
+}
+
+func windowNumber(v value.Value) (float64, bool) {
+	switch actual := v.Actual().(type) {
+	case float64:
+		return actual, true
+	case int64:
+		return float64(actual), true
+	}
+
+	return 0, false
+}
@@ -44,6 +44,7 @@ type Formalizer struct {
 	allowed     *value.ScopeValue
 	identifiers *value.ScopeValue
 	aliases     *value.ScopeValue
+	withs       Bindings
 	flags       uint32
 }
 
@@ -61,9 +62,11 @@ func NewKeyspaceFormalizer(keyspace string, parent *Formalizer) *Formalizer {
 
 func newFormalizer(keyspace string, parent *Formalizer, mapSelf, mapKeyspace bool) *Formalizer {
 	var pv, av value.Value
+	var withs Bindings
 	if parent != nil {
 		pv = parent.allowed
 		av = parent.aliases
+		withs = parent.withs
 		mapSelf = mapSelf || parent.mapSelf()
 		mapKeyspace = mapKeyspace || parent.mapKeyspace()
 	}
@@ -81,6 +84,7 @@ func newFormalizer(keyspace string, parent *Formalizer, mapSelf, mapKeyspace boo
 		allowed:     value.NewScopeValue(make(map[string]interface{}), pv),
 		identifiers: value.NewScopeValue(make(map[string]interface{}, 64), nil),
 		aliases:     value.NewScopeValue(make(map[string]interface{}), av),
+		withs:       withs,
 		flags:       flags,
 	}
 
@@ -396,6 +400,7 @@ func (this *Formalizer) Copy() *Formalizer {
 	f.allowed = this.allowed.Copy().(*value.ScopeValue)
 	f.identifiers = this.identifiers.Copy().(*value.ScopeValue)
 	f.aliases = this.aliases.Copy().(*value.ScopeValue)
+	f.withs = this.withs
 	f.flags = this.flags
 	return f
 }
@@ -448,3 +453,30 @@ func (this *Formalizer) SetAllowedAlias(alias string, isKeyspace bool) {
 	}
 	this.allowed.SetField(alias, value.NewValue(ident_flags))
 }
+
+/*
+Returns the WITH clause of the enclosing statement.
+*/
+func (this *Formalizer) Withs() Bindings {
+	return this.withs
+}
+
+/*
+Sets the WITH clause visible to this formalizer and its children.
+*/
+func (this *Formalizer) SetWiths(withs Bindings) {
+	this.withs = withs
+}
+
+/*
+Returns the expression bound to a WITH alias, or nil.
+*/
+func (this *Formalizer) With(alias string) Expression {
+	for _, b := range this.withs {
+		if b.Variable() == alias {
+			return b.Expression()
+		}
+	}
+
+	return nil
+}
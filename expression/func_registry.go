@@ -19,11 +19,21 @@ Based on the input string name it looks through a map and
 retrieves the function that corresponds to it. If the
 function exists it returns true and the function. While
 looking into the map, convert the string name to lower
-case.
+case. Names that are not builtin functions are looked up in
+the catalog of user defined functions.
 */
 func GetFunction(name string) (Function, bool) {
 	rv, ok := _FUNCTIONS[strings.ToLower(name)]
-	return rv, ok
+	if ok {
+		return rv, ok
+	}
+
+	function, ok := getUserFunction(name)
+	if ok {
+		return NewUserFunctionCall(function.Name(), len(function.Parameters())), ok
+	}
+
+	return nil, false
 }
 
 /*
@@ -0,0 +1,163 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package expression
+
+import (
+	"strings"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/value"
+)
+
+/*
+Definition of a user defined function, as created by CREATE
+FUNCTION. The body is an expression over the parameters.
+*/
+type UserFunction interface {
+	Name() string
+	Parameters() []string
+	Body() Expression
+}
+
+/*
+Catalog of user defined functions. Names are case insensitive.
+*/
+type UserFunctions interface {
+	Get(name string) (UserFunction, bool)
+}
+
+var _USER_FUNCTIONS UserFunctions
+
+/*
+Registers the catalog of user defined functions, which GetFunction
+consults for names that are not builtin functions.
+*/
+func SetUserFunctions(functions UserFunctions) {
+	_USER_FUNCTIONS = functions
+}
+
+func getUserFunction(name string) (UserFunction, bool) {
+	if _USER_FUNCTIONS == nil {
+		return nil, false
+	}
+
+	return _USER_FUNCTIONS.Get(strings.ToLower(name))
+}
+
+/*
+Returns true if name is a builtin function.
+*/
+func IsBuiltinFunction(name string) bool {
+	_, ok := _FUNCTIONS[strings.ToLower(name)]
+	return ok
+}
+
+///////////////////////////////////////////////////
+//
+// UserFunctionCall
+//
+///////////////////////////////////////////////////
+
+/*
+This represents a call to a user defined function. The definition is
+resolved by name on every evaluation, so that a call fails once the
+function has been dropped. The body is evaluated with each parameter
+bound to the value of the corresponding argument.
+*/
+type UserFunctionCall struct {
+	FunctionBase
+	arity int
+}
+
+func NewUserFunctionCall(name string, arity int, operands ...Expression) Function {
+	rv := &UserFunctionCall{
+		*NewFunctionBase(strings.ToLower(name), operands...),
+		arity,
+	}
+
+	rv.setVolatile()
+	rv.expr = rv
+	return rv
+}
+
+/*
+Visitor pattern.
+*/
+func (this *UserFunctionCall) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitFunction(this)
+}
+
+func (this *UserFunctionCall) Type() value.Type { return value.JSON }
+
+func (this *UserFunctionCall) Evaluate(item value.Value, context Context) (value.Value, error) {
+	return this.Eval(this, item, context)
+}
+
+/*
+Calling a user defined function requires the privilege to execute
+functions, in addition to the privileges of the body and of the
+arguments, so that a function cannot be used to bypass the
+privileges its body requires.
+*/
+func (this *UserFunctionCall) Privileges() *auth.Privileges {
+	unionPrivileges := auth.NewPrivileges()
+	unionPrivileges.Add("", auth.PRIV_QUERY_EXECUTE_FUNCTIONS)
+
+	function, ok := getUserFunction(this.Name())
+	if ok {
+		unionPrivileges.AddAll(function.Body().Privileges())
+	}
+
+	children := this.Children()
+	for _, child := range children {
+		unionPrivileges.AddAll(child.Privileges())
+	}
+
+	return unionPrivileges
+}
+
+func (this *UserFunctionCall) Apply(context Context, args ...value.Value) (value.Value, error) {
+	function, ok := getUserFunction(this.Name())
+	if !ok {
+		return nil, errors.NewNoSuchFunctionError(this.Name())
+	}
+
+	params := function.Parameters()
+	if len(params) != len(args) {
+		return nil, errors.NewFunctionArgumentsError(this.Name(), len(params), len(args))
+	}
+
+	bindings := make(map[string]interface{}, len(params))
+	for i, param := range params {
+		bindings[param] = args[i]
+	}
+
+	return function.Body().Evaluate(value.NewScopeValue(bindings, nil), context)
+}
+
+/*
+The number of arguments is the number of parameters of the
+definition at the time of the call's construction.
+*/
+func (this *UserFunctionCall) MinArgs() int { return this.arity }
+
+func (this *UserFunctionCall) MaxArgs() int { return this.arity }
+
+/*
+Factory method pattern.
+*/
+func (this *UserFunctionCall) Constructor() FunctionConstructor {
+	name := this.Name()
+	arity := this.arity
+	return func(operands ...Expression) Function {
+		return NewUserFunctionCall(name, arity, operands...)
+	}
+}
@@ -69,6 +69,17 @@ Factory method pattern.
 */
 type FunctionConstructor func(operands ...Expression) Function
 
+/*
+Implemented by functions whose N1QL representation carries clauses
+after the argument list, such as the OVER clause of window functions.
+Suffix() returns the text of those clauses, including a leading space.
+*/
+type SuffixedFunction interface {
+	Function
+
+	Suffix() string
+}
+
 /*
 A unary function is one that has on operand. It inherits
 from Function and contains one additional method to return
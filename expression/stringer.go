@@ -600,6 +600,11 @@ func (this *Stringer) VisitFunction(expr Function) (interface{}, error) {
 	}
 
 	buf.WriteString(")")
+
+	if suffixed, ok := expr.(SuffixedFunction); ok {
+		buf.WriteString(suffixed.Suffix())
+	}
+
 	return buf.String(), nil
 }
 
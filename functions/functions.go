@@ -0,0 +1,309 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/logging"
+	"github.com/couchbase/query/parser/n1ql"
+)
+
+/*
+Definition of a user defined function.
+*/
+type Function struct {
+	name       string
+	parameters []string
+	body       expression.Expression
+}
+
+func NewFunction(name string, parameters []string, body expression.Expression) *Function {
+	return &Function{
+		name:       strings.ToLower(name),
+		parameters: parameters,
+		body:       body,
+	}
+}
+
+func (this *Function) Name() string {
+	return this.name
+}
+
+func (this *Function) Parameters() []string {
+	return this.parameters
+}
+
+func (this *Function) Body() expression.Expression {
+	return this.body
+}
+
+/*
+Representation as a N1QL statement.
+*/
+func (this *Function) Text() string {
+	return "create function " + this.name + "(" + strings.Join(this.parameters, ", ") +
+		") { " + this.body.String() + " }"
+}
+
+/*
+The catalog of functions is held in memory by each query node, as a
+cache of the definitions persisted by the datastore. Datastores that
+do not implement datastore.FunctionsDatastore do not persist them:
+functions must then be created again after a restart.
+*/
+type functionCache struct {
+	sync.RWMutex
+	functions map[string]*Function
+	store     datastore.FunctionsDatastore
+	update    sync.Mutex // serializes loads and changes of the catalog
+}
+
+var functions = &functionCache{
+	functions: make(map[string]*Function),
+}
+
+func init() {
+	expression.SetUserFunctions(functions)
+}
+
+/*
+Sets the datastore that persists the catalog, and loads the functions
+it holds. Called at startup.
+*/
+func Init(store datastore.Datastore) errors.Error {
+	functions.update.Lock()
+	defer functions.update.Unlock()
+
+	functions.store, _ = store.(datastore.FunctionsDatastore)
+	return functions.load()
+}
+
+/*
+Reloads the catalog from the datastore, so that it includes the
+changes made by other query nodes. system:functions reloads the
+catalog before it is read.
+*/
+func Load() errors.Error {
+	functions.update.Lock()
+	defer functions.update.Unlock()
+
+	return functions.load()
+}
+
+/*
+Replaces the functions whose definitions have changed in the
+datastore. A body can only be parsed once the functions it calls are
+in the catalog, so the definitions are parsed in rounds until no more
+can be. Must be called with the update lock held, and not the cache
+lock, which parsing takes.
+*/
+func (this *functionCache) load() errors.Error {
+	if this.store == nil {
+		return nil
+	}
+
+	definitions, err := this.store.FunctionDefinitions()
+	if err != nil {
+		return err
+	}
+
+	pending := make(map[string]string, len(definitions))
+	this.Lock()
+	for name, function := range this.functions {
+		if definitions[name] != function.Text() {
+			delete(this.functions, name)
+		}
+	}
+	for name, definition := range definitions {
+		if _, ok := this.functions[name]; !ok {
+			pending[name] = definition
+		}
+	}
+	this.Unlock()
+
+	for len(pending) > 0 {
+		loaded := 0
+		parseErrs := make(map[string]error, len(pending))
+		for name, definition := range pending {
+			function, err := parseFunction(definition)
+			if err != nil {
+				parseErrs[name] = err
+				continue
+			}
+
+			this.Lock()
+			this.functions[name] = function
+			this.Unlock()
+			delete(pending, name)
+			loaded++
+		}
+
+		if loaded == 0 {
+			for name, err := range parseErrs {
+				logging.Errorf("Unable to load function %s: %v", name, err)
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+func parseFunction(definition string) (*Function, error) {
+	stmt, err := n1ql.ParseStatement(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	create, ok := stmt.(*algebra.CreateFunction)
+	if !ok {
+		return nil, fmt.Errorf("Definition is not a CREATE FUNCTION statement.")
+	}
+	return NewFunction(create.Name(), create.Parameters(), create.Body()), nil
+}
+
+func (this *functionCache) Get(name string) (expression.UserFunction, bool) {
+	this.RLock()
+	defer this.RUnlock()
+
+	rv, ok := this.functions[name]
+	if !ok {
+		return nil, false
+	}
+	return rv, true
+}
+
+func CountFunctions() int {
+	functions.RLock()
+	defer functions.RUnlock()
+
+	return len(functions.functions)
+}
+
+func NameFunctions() []string {
+	functions.RLock()
+	defer functions.RUnlock()
+
+	names := make([]string, 0, len(functions.functions))
+	for name, _ := range functions.functions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func GetFunction(name string) *Function {
+	functions.RLock()
+	defer functions.RUnlock()
+
+	return functions.functions[strings.ToLower(name)]
+}
+
+/*
+Adds a function, and persists its definition. The body may only call
+functions that already exist, so a function can only call itself if a
+function it calls has been dropped and recreated since. Such calls
+are rejected.
+*/
+func AddFunction(function *Function) errors.Error {
+	functions.update.Lock()
+	defer functions.update.Unlock()
+
+	err := functions.load()
+	if err != nil {
+		return err
+	}
+
+	functions.Lock()
+	defer functions.Unlock()
+
+	if _, ok := functions.functions[function.name]; ok {
+		return errors.NewFunctionExistsError(function.name)
+	}
+
+	if functions.calls(function.body, function.name, make(map[string]bool)) {
+		return errors.NewRecursiveFunctionError(function.name)
+	}
+
+	if functions.store != nil {
+		err = functions.store.SetFunctionDefinition(function.name, function.Text())
+		if err != nil {
+			return errors.NewFunctionStorageError(err, function.name)
+		}
+	}
+
+	functions.functions[function.name] = function
+	return nil
+}
+
+func DeleteFunction(name string) errors.Error {
+	functions.update.Lock()
+	defer functions.update.Unlock()
+
+	err := functions.load()
+	if err != nil {
+		return err
+	}
+
+	functions.Lock()
+	defer functions.Unlock()
+
+	name = strings.ToLower(name)
+	if _, ok := functions.functions[name]; !ok {
+		return errors.NewNoSuchFunctionError(name)
+	}
+
+	if functions.store != nil {
+		err = functions.store.DeleteFunctionDefinition(name)
+		if err != nil {
+			return errors.NewFunctionStorageError(err, name)
+		}
+	}
+
+	delete(functions.functions, name)
+	return nil
+}
+
+/*
+Returns true if expr calls the named function, directly or through
+other user defined functions.
+*/
+func (this *functionCache) calls(expr expression.Expression, name string, visited map[string]bool) bool {
+	if call, ok := expr.(*expression.UserFunctionCall); ok {
+		callee := call.Name()
+		if callee == name {
+			return true
+		}
+
+		if !visited[callee] {
+			visited[callee] = true
+			if function, ok := this.functions[callee]; ok && this.calls(function.body, name, visited) {
+				return true
+			}
+		}
+	}
+
+	for _, child := range expr.Children() {
+		if child != nil && this.calls(child, name, visited) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package functions
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/datastore/file"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+func TestPersistence(t *testing.T) {
+	dir, er := ioutil.TempDir("", "functions")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+	defer os.RemoveAll(dir)
+	defer Init(nil)
+
+	store, err := file.NewDatastore(dir)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	if err = Init(store); err != nil {
+		t.Fatalf("failed to load functions: %v", err)
+	}
+	addFunction(t, "create function inc(x) { x + 1 }")
+	addFunction(t, "create function double_inc(x) { inc(x) * 2 }")
+	addFunction(t, "create function quadruple_inc(x) { double_inc(x) * 2 }")
+	if err = DeleteFunction("quadruple_inc"); err != nil {
+		t.Fatalf("failed to drop function: %v", err)
+	}
+
+	// a restart loads the functions, callees first
+	functions.functions = make(map[string]*Function)
+	if err = Init(store); err != nil {
+		t.Fatalf("failed to load functions: %v", err)
+	}
+	if names := NameFunctions(); len(names) != 2 || names[0] != "double_inc" || names[1] != "inc" {
+		t.Fatalf("expected double_inc and inc, got %v", names)
+	}
+	verifyCall(t, "double_inc", 3, 8)
+
+	// changes made by another node are seen on the next load
+	fstore := store.(datastore.FunctionsDatastore)
+	fstore.SetFunctionDefinition("inc", "create function inc(x) { x + 2 }")
+	fstore.DeleteFunctionDefinition("double_inc")
+	if err = Load(); err != nil {
+		t.Fatalf("failed to load functions: %v", err)
+	}
+	if names := NameFunctions(); len(names) != 1 || names[0] != "inc" {
+		t.Fatalf("expected inc, got %v", names)
+	}
+	verifyCall(t, "inc", 3, 5)
+
+	if err = DeleteFunction("inc"); err != nil {
+		t.Fatalf("failed to drop function: %v", err)
+	}
+	if definitions, _ := fstore.FunctionDefinitions(); len(definitions) != 0 {
+		t.Errorf("expected no definitions, got %v", definitions)
+	}
+}
+
+func addFunction(t *testing.T, definition string) {
+	function, err := parseFunction(definition)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", definition, err)
+	}
+
+	if err := AddFunction(function); err != nil {
+		t.Fatalf("failed to add function %s: %v", function.Name(), err)
+	}
+}
+
+func verifyCall(t *testing.T, name string, arg, expected int) {
+	f, ok := expression.GetFunction(name)
+	if !ok {
+		t.Fatalf("function %s not found", name)
+	}
+
+	call := f.Constructor()(expression.NewConstant(arg))
+	v, err := call.Evaluate(nil, expression.NewIndexContext())
+	if err != nil {
+		t.Fatalf("failed to call %s: %v", name, err)
+	}
+	if !v.Equals(value.NewValue(expected)).Truth() {
+		t.Errorf("expected %s(%d) to be %d, got %v", name, arg, expected, v)
+	}
+}
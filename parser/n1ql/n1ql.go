@@ -12,11 +12,13 @@ package n1ql
 import (
 	"fmt"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/couchbase/query/algebra"
 	"github.com/couchbase/query/expression"
 	"github.com/couchbase/query/logging"
+	"github.com/couchbase/query/value"
 )
 
 func ParseStatement(input string) (algebra.Statement, error) {
@@ -86,6 +88,8 @@ type lexer struct {
 	parsingStmt      bool
 	lastScannerError string
 	text             string
+	lastToken        int
+	prevToken        int
 }
 
 func newLexer(nex *Lexer) *lexer {
@@ -96,7 +100,30 @@ func newLexer(nex *Lexer) *lexer {
 }
 
 func (this *lexer) Lex(lval *yySymType) int {
-	return this.nex.Lex(lval)
+	for {
+		token := this.nex.Lex(lval)
+
+		// optimizer hints only follow the SELECT, UPDATE, DELETE or MERGE
+		// that begins a query block; elsewhere they are comments
+		if token == OPTIM_HINTS && !this.hintsAllowed() {
+			continue
+		}
+
+		this.prevToken, this.lastToken = this.lastToken, token
+		return token
+	}
+}
+
+func (this *lexer) hintsAllowed() bool {
+	switch this.lastToken {
+	case SELECT, MERGE:
+		return true
+	case UPDATE, DELETE:
+		// not the actions of MERGE
+		return this.prevToken != THEN
+	default:
+		return false
+	}
 }
 
 func (this *lexer) Remainder(offset int) string {
@@ -137,3 +164,327 @@ func (this *lexer) nextParam() int {
 	this.posParam++
 	return this.posParam
 }
+
+/*
+Build a window frame, checking that its extents are in frame order.
+*/
+func newWindowFrame(yylex yyLexer, units int64, start, end *algebra.WindowFrameExtent) *algebra.WindowFrame {
+	if start.Type() == algebra.WINDOW_UNBOUNDED_FOLLOWING {
+		yylex.Error("Window frame cannot start at UNBOUNDED FOLLOWING.")
+	} else if end.Type() == algebra.WINDOW_UNBOUNDED_PRECEDING {
+		yylex.Error("Window frame cannot end at UNBOUNDED PRECEDING.")
+	} else if start.Type() > end.Type() {
+		yylex.Error("Window frame cannot start after its end.")
+	}
+
+	return algebra.NewWindowFrame(int(units), start, end)
+}
+
+/*
+Attach the WITHIN GROUP clause, or the ORDER BY clause inside the
+parentheses, to an ordered aggregate. Ordered-set aggregates require
+a WITHIN GROUP clause with a single sort term, and a constant argument.
+*/
+func setWithinGroup(yylex yyLexer, f expression.Function, within algebra.SortTerms, orderBy bool) expression.Function {
+	clause := "a WITHIN GROUP"
+	if orderBy {
+		clause = "an ORDER BY"
+	}
+
+	agg, ok := f.(algebra.OrderedAggregate)
+	if !ok {
+		if within != nil {
+			yylex.Error(fmt.Sprintf("Function %s cannot have %s clause.", f.Name(), clause))
+		}
+		return f
+	}
+
+	if agg.OrderedSet() {
+		if within == nil || orderBy {
+			yylex.Error(fmt.Sprintf("Aggregate %s requires a WITHIN GROUP clause.", f.Name()))
+			return f
+		}
+
+		if len(within) != 1 {
+			yylex.Error(fmt.Sprintf("Aggregate %s requires a single WITHIN GROUP sort term.", f.Name()))
+		}
+
+		if agg.Operand() == nil || agg.Operand().Static() == nil {
+			yylex.Error(fmt.Sprintf("The argument of aggregate %s must be a constant.", f.Name()))
+		}
+	}
+
+	if within != nil {
+		agg.SetWithinGroup(within)
+	}
+
+	return agg
+}
+
+/*
+Attach the FILTER clause, if any, to a function, checking that the
+function is an aggregate.
+*/
+func setFilter(yylex yyLexer, f expression.Function, filter expression.Expression) expression.Function {
+	if filter == nil {
+		return f
+	}
+
+	agg, ok := f.(algebra.Aggregate)
+	if !ok {
+		yylex.Error(fmt.Sprintf("Function %s cannot have a FILTER clause.", f.Name()))
+		return f
+	}
+
+	agg.SetFilter(filter)
+	return agg
+}
+
+/*
+Attach the OVER clause, if any, to a function, checking that the
+function and the OVER clause are compatible.
+*/
+func setWindowTerm(yylex yyLexer, f expression.Function, wTerm *algebra.WindowTerm) expression.Function {
+	windowOnly, isWindowOnly := f.(algebra.WindowOnlyFunction)
+	if wTerm == nil {
+		if isWindowOnly {
+			yylex.Error(fmt.Sprintf("Window function %s requires an OVER clause.", f.Name()))
+		}
+		return f
+	}
+
+	wf, ok := f.(algebra.WindowFunction)
+	if !ok {
+		yylex.Error(fmt.Sprintf("Function %s cannot have an OVER clause.", f.Name()))
+		return f
+	}
+
+	frame := wTerm.Frame()
+	if isWindowOnly {
+		if windowOnly.RequiresOrder() && wTerm.OrderBy() == nil {
+			yylex.Error(fmt.Sprintf("Window function %s requires an ORDER BY clause.", f.Name()))
+		}
+
+		if frame != nil && !windowOnly.UsesFrame() {
+			yylex.Error(fmt.Sprintf("Window function %s cannot have a window frame clause.", f.Name()))
+		}
+	}
+
+	if frame != nil && frame.Units() == algebra.WINDOW_FRAME_RANGE && len(frame.Expressions()) > 0 &&
+		(wTerm.OrderBy() == nil || len(wTerm.OrderBy().Terms()) != 1) {
+		yylex.Error("Window frame RANGE with offsets requires exactly one ORDER BY term.")
+	}
+
+	wf.SetWindowTerm(wTerm)
+	return wf
+}
+
+/*
+Build a WITH RECURSIVE term. A fullselect whose subresult is a
+UNION or UNION ALL becomes an anchor and a recursive member; any
+other fullselect is a regular WITH term.
+*/
+func newRecursiveWith(yylex yyLexer, alias string, sel *algebra.Select, cycle expression.Expressions,
+	options expression.Expression) *expression.Binding {
+	var first, second algebra.Subresult
+	var distinct bool
+
+	switch sr := sel.Subresult().(type) {
+	case *algebra.Union:
+		first, second, distinct = sr.First(), sr.Second(), true
+	case *algebra.UnionAll:
+		first, second = sr.First(), sr.Second()
+	default:
+		if cycle != nil || options != nil {
+			yylex.Error(fmt.Sprintf("CYCLE and OPTIONS require UNION or UNION ALL in recursive WITH %s.", alias))
+		}
+		return expression.NewSimpleBinding(alias, algebra.NewSubquery(sel))
+	}
+
+	var opts value.Value
+	if options != nil {
+		opts = options.Value()
+		if opts == nil {
+			yylex.Error("OPTIONS value must be static.")
+		}
+	}
+
+	ru := algebra.NewRecursiveUnion(alias, first, second, distinct, cycle, opts)
+	sel = algebra.NewSelect(ru, sel.Order(), sel.Offset(), sel.Limit())
+	return expression.NewSimpleBinding(alias, algebra.NewSubquery(sel))
+}
+
+/*
+Build an EXECUTE FUNCTION statement, which calls a user defined
+function.
+*/
+func newExecuteFunction(yylex yyLexer, name string, args expression.Expressions) algebra.Statement {
+	f, ok := expression.GetFunction(name)
+	if _, user := f.(*expression.UserFunctionCall); !ok || !user {
+		yylex.Error(fmt.Sprintf("Function %s does not exist.", name))
+		return nil
+	}
+
+	if len(args) < f.MinArgs() || len(args) > f.MaxArgs() {
+		yylex.Error(fmt.Sprintf("Wrong number of arguments to function %s.", name))
+		return nil
+	}
+
+	return algebra.NewExecuteFunction(f.Constructor()(args...))
+}
+
+/*
+Build the optimizer hints of a query block from the hint comment.
+A hint that cannot be parsed is kept as an invalid hint, and does not
+fail the statement.
+*/
+func newOptimHints(text string) *algebra.OptimHints {
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "/*+"), "*/")
+	tokens := hintTokens(text)
+	hints := make([]*algebra.OptimHint, 0, len(tokens))
+
+	for i := 0; i < len(tokens); {
+		start := tokens[i].start
+		name := tokens[i].s
+		i++
+
+		var args []string
+		if i < len(tokens) && tokens[i].s == "(" {
+			i++
+			for i < len(tokens) && tokens[i].s != ")" {
+				if tokens[i].s != "," {
+					args = append(args, tokens[i].s)
+				}
+				i++
+			}
+			if i == len(tokens) {
+				hints = append(hints, algebra.NewInvalidHint(strings.TrimSpace(text[start:]),
+					"missing ) in hint"))
+				break
+			}
+			i++
+		}
+
+		hints = append(hints, newOptimHint(name, args, text[start:tokens[i-1].end])...)
+	}
+
+	return algebra.NewOptimHints(hints)
+}
+
+func newOptimHint(name string, args []string, text string) []*algebra.OptimHint {
+	invalid := func(err string) []*algebra.OptimHint {
+		return []*algebra.OptimHint{algebra.NewInvalidHint(text, err)}
+	}
+
+	name = strings.ToUpper(name)
+	for _, arg := range args {
+		if arg == "(" || (arg == "/" && name != "USE_HASH") {
+			return invalid(fmt.Sprintf("unexpected %s in %s hint", arg, name))
+		}
+	}
+
+	switch name {
+	case "INDEX", "NO_INDEX":
+		if len(args) == 0 {
+			return invalid(fmt.Sprintf("%s hint requires a keyspace", name))
+		}
+		if name == "INDEX" {
+			return []*algebra.OptimHint{algebra.NewIndexHint(args[0], args[1:])}
+		}
+		return []*algebra.OptimHint{algebra.NewNoIndexHint(args[0], args[1:])}
+	case "USE_HASH", "USE_NL":
+		if len(args) == 0 {
+			return invalid(fmt.Sprintf("%s hint requires a keyspace", name))
+		}
+		rv := make([]*algebra.OptimHint, 0, len(args))
+		for i := 0; i < len(args); i++ {
+			if args[i] == "/" {
+				return invalid("USE_HASH option must follow a keyspace")
+			}
+
+			joinHint := algebra.JoinHint(algebra.USE_NL)
+			if name == "USE_HASH" {
+				joinHint = algebra.USE_HASH_BUILD
+				if i+1 < len(args) && args[i+1] == "/" {
+					option := ""
+					if i+2 < len(args) {
+						option = strings.ToUpper(args[i+2])
+					}
+					switch option {
+					case "BUILD":
+					case "PROBE":
+						joinHint = algebra.USE_HASH_PROBE
+					default:
+						return invalid("USE_HASH option must be BUILD or PROBE")
+					}
+					rv = append(rv, algebra.NewJoinOptimHint(args[i], joinHint))
+					i += 2
+					continue
+				}
+			}
+			rv = append(rv, algebra.NewJoinOptimHint(args[i], joinHint))
+		}
+		return rv
+	case "ORDERED":
+		if len(args) > 0 {
+			return invalid("ORDERED hint takes no arguments")
+		}
+		return []*algebra.OptimHint{algebra.NewOrderedHint()}
+	case "PARALLEL":
+		if len(args) != 1 {
+			return invalid("PARALLEL hint requires a number of streams")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return invalid("PARALLEL hint requires a positive number of streams")
+		}
+		return []*algebra.OptimHint{algebra.NewParallelHint(n)}
+	default:
+		return invalid(fmt.Sprintf("unknown hint %s", name))
+	}
+}
+
+type hintToken struct {
+	s          string
+	start, end int
+}
+
+/*
+Split the text of the hint comment into names, back-quoted names, and
+the punctuation ( ) , and /.
+*/
+func hintTokens(text string) []*hintToken {
+	var tokens []*hintToken
+	for i := 0; i < len(text); {
+		j := i + 1
+		switch c := text[i]; c {
+		case ' ', '\t', '\n', '\r':
+			i = j
+			continue
+		case '(', ')', ',', '/':
+		case '`':
+			for j < len(text) && text[j] != '`' {
+				j++
+				if j+1 < len(text) && text[j] == '`' && text[j+1] == '`' {
+					j += 2
+				}
+			}
+			if j < len(text) {
+				j++
+				s, err := UnmarshalBackQuoted(text[i:j])
+				if err == nil {
+					tokens = append(tokens, &hintToken{s, i, j})
+					i = j
+					continue
+				}
+			}
+		default:
+			for j < len(text) && !strings.ContainsRune(" \t\n\r(),/`", rune(text[j])) {
+				j++
+			}
+		}
+		tokens = append(tokens, &hintToken{text[i:j], i, j})
+		i = j
+	}
+	return tokens
+}
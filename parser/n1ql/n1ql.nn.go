@@ -1586,6 +1586,212 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1}, []int{ /* End-of-input transitions */ -1, -1}, nil},
 
+	// [aA][dD][vV][iI][sS][eE]
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 86:
+				return -1
+			case 97:
+				return 1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return 2
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 86:
+				return -1
+			case 97:
+				return -1
+			case 100:
+				return 2
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 86:
+				return 3
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return -1
+			case 118:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return 4
+			case 83:
+				return -1
+			case 86:
+				return -1
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return 4
+			case 115:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return 5
+			case 86:
+				return -1
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return 5
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return 6
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 86:
+				return -1
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return 6
+			case 105:
+				return -1
+			case 115:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 86:
+				return -1
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 	// [aA][lL][lL]
 	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -6362,394 +6568,866 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 
-	// [dD][aA][tT][aA][bB][aA][sS][eE]
-	{[]bool{false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+	// [cC][uU][bB][eE]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 65:
-				return -1
 			case 66:
 				return -1
-			case 68:
+			case 67:
 				return 1
 			case 69:
 				return -1
-			case 83:
-				return -1
-			case 84:
-				return -1
-			case 97:
+			case 85:
 				return -1
 			case 98:
 				return -1
-			case 100:
+			case 99:
 				return 1
 			case 101:
 				return -1
-			case 115:
-				return -1
-			case 116:
+			case 117:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return 2
 			case 66:
 				return -1
-			case 68:
+			case 67:
 				return -1
 			case 69:
 				return -1
-			case 83:
-				return -1
-			case 84:
-				return -1
-			case 97:
+			case 85:
 				return 2
 			case 98:
 				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
 				return -1
-			case 115:
-				return -1
-			case 116:
-				return -1
+			case 117:
+				return 2
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return -1
 			case 66:
-				return -1
-			case 68:
+				return 3
+			case 67:
 				return -1
 			case 69:
 				return -1
-			case 83:
-				return -1
-			case 84:
-				return 3
-			case 97:
+			case 85:
 				return -1
 			case 98:
-				return -1
-			case 100:
+				return 3
+			case 99:
 				return -1
 			case 101:
 				return -1
-			case 115:
+			case 117:
 				return -1
-			case 116:
-				return 3
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return 4
 			case 66:
 				return -1
-			case 68:
+			case 67:
 				return -1
 			case 69:
-				return -1
-			case 83:
-				return -1
-			case 84:
-				return -1
-			case 97:
 				return 4
+			case 85:
+				return -1
 			case 98:
 				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
-				return -1
-			case 115:
-				return -1
-			case 116:
+				return 4
+			case 117:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return -1
 			case 66:
-				return 5
-			case 68:
-				return -1
-			case 69:
 				return -1
-			case 83:
+			case 67:
 				return -1
-			case 84:
+			case 69:
 				return -1
-			case 97:
+			case 85:
 				return -1
 			case 98:
-				return 5
-			case 100:
 				return -1
-			case 101:
+			case 99:
 				return -1
-			case 115:
+			case 101:
 				return -1
-			case 116:
+			case 117:
 				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
+	// [cC][uU][rR][rR][eE][nN][tT]
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 65:
-				return 6
-			case 66:
-				return -1
-			case 68:
-				return -1
+			case 67:
+				return 1
 			case 69:
 				return -1
-			case 83:
+			case 78:
 				return -1
-			case 84:
+			case 82:
 				return -1
-			case 97:
-				return 6
-			case 98:
+			case 84:
 				return -1
-			case 100:
+			case 85:
 				return -1
+			case 99:
+				return 1
 			case 101:
 				return -1
-			case 115:
+			case 110:
+				return -1
+			case 114:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
 				return -1
-			case 66:
+			case 69:
 				return -1
-			case 68:
+			case 78:
 				return -1
-			case 69:
+			case 82:
 				return -1
-			case 83:
-				return 7
 			case 84:
 				return -1
-			case 97:
+			case 85:
+				return 2
+			case 99:
 				return -1
-			case 98:
+			case 101:
 				return -1
-			case 100:
+			case 110:
 				return -1
-			case 101:
+			case 114:
 				return -1
-			case 115:
-				return 7
 			case 116:
 				return -1
+			case 117:
+				return 2
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return -1
-			case 66:
-				return -1
-			case 68:
+			case 67:
 				return -1
 			case 69:
-				return 8
-			case 83:
 				return -1
-			case 84:
+			case 78:
 				return -1
-			case 97:
+			case 82:
+				return 3
+			case 84:
 				return -1
-			case 98:
+			case 85:
 				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
-				return 8
-			case 115:
 				return -1
+			case 110:
+				return -1
+			case 114:
+				return 3
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return -1
-			case 66:
-				return -1
-			case 68:
+			case 67:
 				return -1
 			case 69:
 				return -1
-			case 83:
+			case 78:
 				return -1
+			case 82:
+				return 4
 			case 84:
 				return -1
-			case 97:
-				return -1
-			case 98:
+			case 85:
 				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
 				return -1
-			case 115:
+			case 110:
 				return -1
+			case 114:
+				return 4
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
-
-	// [dD][aA][tT][aA][sS][eE][tT]
-	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
 				return -1
-			case 68:
-				return 1
 			case 69:
+				return 5
+			case 78:
 				return -1
-			case 83:
+			case 82:
 				return -1
 			case 84:
 				return -1
-			case 97:
+			case 85:
+				return -1
+			case 99:
 				return -1
-			case 100:
-				return 1
 			case 101:
+				return 5
+			case 110:
 				return -1
-			case 115:
+			case 114:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return 2
-			case 68:
+			case 67:
 				return -1
 			case 69:
 				return -1
-			case 83:
+			case 78:
+				return 6
+			case 82:
 				return -1
 			case 84:
 				return -1
-			case 97:
-				return 2
-			case 100:
+			case 85:
+				return -1
+			case 99:
 				return -1
 			case 101:
 				return -1
-			case 115:
+			case 110:
+				return 6
+			case 114:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return -1
-			case 68:
+			case 67:
 				return -1
 			case 69:
 				return -1
-			case 83:
+			case 78:
+				return -1
+			case 82:
 				return -1
 			case 84:
-				return 3
-			case 97:
+				return 7
+			case 85:
 				return -1
-			case 100:
+			case 99:
 				return -1
 			case 101:
 				return -1
-			case 115:
+			case 110:
+				return -1
+			case 114:
 				return -1
 			case 116:
-				return 3
+				return 7
+			case 117:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return 4
-			case 68:
+			case 67:
 				return -1
 			case 69:
 				return -1
-			case 83:
+			case 78:
+				return -1
+			case 82:
 				return -1
 			case 84:
 				return -1
-			case 97:
-				return 4
-			case 100:
+			case 85:
+				return -1
+			case 99:
 				return -1
 			case 101:
 				return -1
-			case 115:
+			case 110:
+				return -1
+			case 114:
 				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	// [cC][yY][cC][lL][eE]
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 65:
-				return -1
-			case 68:
-				return -1
+			case 67:
+				return 1
 			case 69:
 				return -1
-			case 83:
-				return 5
-			case 84:
-				return -1
-			case 97:
+			case 76:
 				return -1
-			case 100:
+			case 89:
 				return -1
+			case 99:
+				return 1
 			case 101:
 				return -1
-			case 115:
-				return 5
-			case 116:
+			case 108:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 76:
+				return -1
+			case 89:
+				return 2
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 121:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return 3
+			case 69:
+				return -1
+			case 76:
+				return -1
+			case 89:
+				return -1
+			case 99:
+				return 3
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 76:
+				return 4
+			case 89:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return 4
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return 5
+			case 76:
+				return -1
+			case 89:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return 5
+			case 108:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 76:
+				return -1
+			case 89:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+	// [dD][aA][tT][aA][bB][aA][sS][eE]
+	{[]bool{false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return -1
+			case 68:
+				return 1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return 1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 2
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return 2
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return 3
+			case 97:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 4
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return 4
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return 5
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 98:
+				return 5
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 6
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return 6
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return 7
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return 7
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return 8
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return 8
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// [dD][aA][tT][aA][sS][eE][tT]
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return 1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 100:
+				return 1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 2
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return 2
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return 3
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 4
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return 4
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return 5
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return 5
+			case 116:
 				return -1
 			}
 			return -1
@@ -10895,6 +11573,212 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
 
+	// [fF][iI][lL][tT][eE][rR]
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 70:
+				return 1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return 1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return 2
+			case 76:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return 2
+			case 108:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return 3
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return 3
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return 4
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return 4
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return 5
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 101:
+				return 5
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 82:
+				return 6
+			case 84:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 114:
+				return 6
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 	// [fF][iI][rR][sS][tT]
 	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -11285,21 +12169,37 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
 
-	// [fF][oO][rR]
-	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+	// [fF][oO][lL][lL][oO][wW][iI][nN][gG]
+	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 70:
 				return 1
+			case 71:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 78:
+				return -1
 			case 79:
 				return -1
-			case 82:
+			case 87:
 				return -1
 			case 102:
 				return 1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
 			case 111:
 				return -1
-			case 114:
+			case 119:
 				return -1
 			}
 			return -1
@@ -11308,15 +12208,31 @@ var dfas = []dfa{
 			switch r {
 			case 70:
 				return -1
+			case 71:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 78:
+				return -1
 			case 79:
 				return 2
-			case 82:
+			case 87:
 				return -1
 			case 102:
 				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
 			case 111:
 				return 2
-			case 114:
+			case 119:
 				return -1
 			}
 			return -1
@@ -11325,233 +12241,283 @@ var dfas = []dfa{
 			switch r {
 			case 70:
 				return -1
-			case 79:
-				return -1
-			case 82:
-				return 3
-			case 102:
+			case 71:
 				return -1
-			case 111:
+			case 73:
 				return -1
-			case 114:
+			case 76:
 				return 3
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
-			case 70:
+			case 78:
 				return -1
 			case 79:
 				return -1
-			case 82:
+			case 87:
 				return -1
 			case 102:
 				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return 3
+			case 110:
+				return -1
 			case 111:
 				return -1
-			case 114:
+			case 119:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
-
-	// [fF][oO][rR][cC][eE]
-	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 67:
+			case 70:
 				return -1
-			case 69:
+			case 71:
 				return -1
-			case 70:
-				return 1
-			case 79:
+			case 73:
 				return -1
-			case 82:
+			case 76:
+				return 4
+			case 78:
 				return -1
-			case 99:
+			case 79:
 				return -1
-			case 101:
+			case 87:
 				return -1
 			case 102:
-				return 1
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return 4
+			case 110:
+				return -1
 			case 111:
 				return -1
-			case 114:
+			case 119:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 67:
+			case 70:
 				return -1
-			case 69:
+			case 71:
 				return -1
-			case 70:
+			case 73:
 				return -1
-			case 79:
-				return 2
-			case 82:
+			case 76:
 				return -1
-			case 99:
+			case 78:
 				return -1
-			case 101:
+			case 79:
+				return 5
+			case 87:
 				return -1
 			case 102:
 				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
 			case 111:
-				return 2
-			case 114:
+				return 5
+			case 119:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 67:
+			case 70:
 				return -1
-			case 69:
+			case 71:
 				return -1
-			case 70:
+			case 73:
 				return -1
-			case 79:
+			case 76:
 				return -1
-			case 82:
-				return 3
-			case 99:
+			case 78:
 				return -1
-			case 101:
+			case 79:
 				return -1
+			case 87:
+				return 6
 			case 102:
 				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
 			case 111:
 				return -1
-			case 114:
-				return 3
+			case 119:
+				return 6
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 67:
-				return 4
-			case 69:
-				return -1
 			case 70:
 				return -1
-			case 79:
+			case 71:
 				return -1
-			case 82:
+			case 73:
+				return 7
+			case 76:
 				return -1
-			case 99:
-				return 4
-			case 101:
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 87:
 				return -1
 			case 102:
 				return -1
+			case 103:
+				return -1
+			case 105:
+				return 7
+			case 108:
+				return -1
+			case 110:
+				return -1
 			case 111:
 				return -1
-			case 114:
+			case 119:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 67:
-				return -1
-			case 69:
-				return 5
 			case 70:
 				return -1
-			case 79:
+			case 71:
 				return -1
-			case 82:
+			case 73:
 				return -1
-			case 99:
+			case 76:
+				return -1
+			case 78:
+				return 8
+			case 79:
+				return -1
+			case 87:
 				return -1
-			case 101:
-				return 5
 			case 102:
 				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return 8
 			case 111:
 				return -1
-			case 114:
+			case 119:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 67:
+			case 70:
 				return -1
-			case 69:
+			case 71:
+				return 9
+			case 73:
 				return -1
-			case 70:
+			case 76:
+				return -1
+			case 78:
 				return -1
 			case 79:
 				return -1
-			case 82:
+			case 87:
 				return -1
-			case 99:
+			case 102:
 				return -1
-			case 101:
+			case 103:
+				return 9
+			case 105:
 				return -1
-			case 102:
+			case 108:
+				return -1
+			case 110:
 				return -1
 			case 111:
 				return -1
-			case 114:
+			case 119:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
-
-	// [fF][rR][oO][mM]
-	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 70:
-				return 1
-			case 77:
+				return -1
+			case 71:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 78:
 				return -1
 			case 79:
 				return -1
-			case 82:
+			case 87:
 				return -1
 			case 102:
-				return 1
-			case 109:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 110:
 				return -1
 			case 111:
 				return -1
-			case 114:
+			case 119:
 				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	// [fF][oO][rR]
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 70:
-				return -1
-			case 77:
-				return -1
+				return 1
 			case 79:
 				return -1
 			case 82:
-				return 2
-			case 102:
-				return -1
-			case 109:
 				return -1
+			case 102:
+				return 1
 			case 111:
 				return -1
 			case 114:
-				return 2
+				return -1
 			}
 			return -1
 		},
@@ -11559,18 +12525,14 @@ var dfas = []dfa{
 			switch r {
 			case 70:
 				return -1
-			case 77:
-				return -1
 			case 79:
-				return 3
+				return 2
 			case 82:
 				return -1
 			case 102:
 				return -1
-			case 109:
-				return -1
 			case 111:
-				return 3
+				return 2
 			case 114:
 				return -1
 			}
@@ -11580,20 +12542,16 @@ var dfas = []dfa{
 			switch r {
 			case 70:
 				return -1
-			case 77:
-				return 4
 			case 79:
 				return -1
 			case 82:
-				return -1
+				return 3
 			case 102:
 				return -1
-			case 109:
-				return 4
 			case 111:
 				return -1
 			case 114:
-				return -1
+				return 3
 			}
 			return -1
 		},
@@ -11601,16 +12559,12 @@ var dfas = []dfa{
 			switch r {
 			case 70:
 				return -1
-			case 77:
-				return -1
 			case 79:
 				return -1
 			case 82:
 				return -1
 			case 102:
 				return -1
-			case 109:
-				return -1
 			case 111:
 				return -1
 			case 114:
@@ -11618,10 +12572,273 @@ var dfas = []dfa{
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
 
-	// [fF][tT][sS]
-	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+	// [fF][oO][rR][cC][eE]
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return 1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return 1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 79:
+				return 2
+			case 82:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 111:
+				return 2
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return 3
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return 4
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 99:
+				return 4
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return 5
+			case 70:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return 5
+			case 102:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// [fF][rR][oO][mM]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 70:
+				return 1
+			case 77:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 102:
+				return 1
+			case 109:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 70:
+				return -1
+			case 77:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return 2
+			case 102:
+				return -1
+			case 109:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 70:
+				return -1
+			case 77:
+				return -1
+			case 79:
+				return 3
+			case 82:
+				return -1
+			case 102:
+				return -1
+			case 109:
+				return -1
+			case 111:
+				return 3
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 70:
+				return -1
+			case 77:
+				return 4
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 102:
+				return -1
+			case 109:
+				return 4
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 70:
+				return -1
+			case 77:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 102:
+				return -1
+			case 109:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
+
+	// [fF][tT][sS]
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 70:
@@ -11692,6 +12909,94 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
 
+	// [fF][uU][lL][lL]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 70:
+				return 1
+			case 76:
+				return -1
+			case 85:
+				return -1
+			case 102:
+				return 1
+			case 108:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 70:
+				return -1
+			case 76:
+				return -1
+			case 85:
+				return 2
+			case 102:
+				return -1
+			case 108:
+				return -1
+			case 117:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 70:
+				return -1
+			case 76:
+				return 3
+			case 85:
+				return -1
+			case 102:
+				return -1
+			case 108:
+				return 3
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 70:
+				return -1
+			case 76:
+				return 4
+			case 85:
+				return -1
+			case 102:
+				return -1
+			case 108:
+				return 4
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 70:
+				return -1
+			case 76:
+				return -1
+			case 85:
+				return -1
+			case 102:
+				return -1
+			case 108:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
 	// [fF][uU][nN][cC][tT][iI][oO][nN]
 	{[]bool{false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -12301,21 +13606,37 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
 
-	// [gG][sS][iI]
-	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+	// [gG][rR][oO][uU][pP][iI][nN][gG]
+	{[]bool{false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 71:
 				return 1
 			case 73:
 				return -1
-			case 83:
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
 				return -1
 			case 103:
 				return 1
 			case 105:
 				return -1
-			case 115:
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
 				return -1
 			}
 			return -1
@@ -12326,14 +13647,30 @@ var dfas = []dfa{
 				return -1
 			case 73:
 				return -1
-			case 83:
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
 				return 2
+			case 85:
+				return -1
 			case 103:
 				return -1
 			case 105:
 				return -1
-			case 115:
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
 				return 2
+			case 117:
+				return -1
 			}
 			return -1
 		},
@@ -12342,14 +13679,30 @@ var dfas = []dfa{
 			case 71:
 				return -1
 			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
 				return 3
-			case 83:
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
 				return -1
 			case 103:
 				return -1
 			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
 				return 3
-			case 115:
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
 				return -1
 			}
 			return -1
@@ -12360,100 +13713,352 @@ var dfas = []dfa{
 				return -1
 			case 73:
 				return -1
-			case 83:
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
 				return -1
+			case 85:
+				return 4
 			case 103:
 				return -1
 			case 105:
 				return -1
-			case 115:
-				return -1
-			}
-			return -1
-		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
-
-	// [hH][aA][sS][hH]
-	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
-		func(r rune) int {
-			switch r {
-			case 65:
+			case 110:
 				return -1
-			case 72:
-				return 1
-			case 83:
+			case 111:
 				return -1
-			case 97:
+			case 112:
 				return -1
-			case 104:
-				return 1
-			case 115:
+			case 114:
 				return -1
+			case 117:
+				return 4
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return 2
-			case 72:
+			case 71:
 				return -1
-			case 83:
+			case 73:
 				return -1
-			case 97:
-				return 2
-			case 104:
+			case 78:
 				return -1
-			case 115:
+			case 79:
 				return -1
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
-			case 65:
+			case 80:
+				return 5
+			case 82:
 				return -1
-			case 72:
+			case 85:
 				return -1
-			case 83:
-				return 3
-			case 97:
+			case 103:
 				return -1
-			case 104:
+			case 105:
 				return -1
-			case 115:
-				return 3
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
-			case 65:
+			case 110:
 				return -1
-			case 72:
-				return 4
-			case 83:
+			case 111:
 				return -1
-			case 97:
+			case 112:
+				return 5
+			case 114:
 				return -1
-			case 104:
-				return 4
-			case 115:
+			case 117:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 71:
 				return -1
-			case 72:
+			case 73:
+				return 6
+			case 78:
 				return -1
-			case 83:
+			case 79:
 				return -1
-			case 97:
+			case 80:
 				return -1
-			case 104:
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return 6
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 71:
+				return -1
+			case 73:
+				return -1
+			case 78:
+				return 7
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return 7
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 71:
+				return 8
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 103:
+				return 8
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 71:
+				return -1
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	// [gG][sS][iI]
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 71:
+				return 1
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 103:
+				return 1
+			case 105:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 71:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return 2
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 71:
+				return -1
+			case 73:
+				return 3
+			case 83:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return 3
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 71:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+
+	// [hH][aA][sS][hH]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 72:
+				return 1
+			case 83:
+				return -1
+			case 97:
+				return -1
+			case 104:
+				return 1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 2
+			case 72:
+				return -1
+			case 83:
+				return -1
+			case 97:
+				return 2
+			case 104:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 72:
+				return -1
+			case 83:
+				return 3
+			case 97:
+				return -1
+			case 104:
+				return -1
+			case 115:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 72:
+				return 4
+			case 83:
+				return -1
+			case 97:
+				return -1
+			case 104:
+				return 4
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 72:
+				return -1
+			case 83:
+				return -1
+			case 97:
+				return -1
+			case 104:
 				return -1
 			case 115:
 				return -1
@@ -18782,6 +20387,135 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
 
+	// [nN][uU][lL][lL][sS]
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 78:
+				return 1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return 1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 78:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return 2
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return 3
+			case 78:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return 3
+			case 110:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return 4
+			case 78:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return 4
+			case 110:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 78:
+				return -1
+			case 83:
+				return 5
+			case 85:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 115:
+				return 5
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 78:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return -1
+			case 110:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
 	// [nN][uN][mM][bB][eE][rR]
 	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -19583,6 +21317,241 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 
+	// [oO][pP][tT][iI][oO][nN][sS]
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return 1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return 1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return 2
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return 2
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return 3
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 73:
+				return 4
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 105:
+				return 4
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return 5
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return 5
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 73:
+				return -1
+			case 78:
+				return 6
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return 6
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return 7
+			case 84:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return 7
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
 	// [oO][rR]
 	{[]bool{false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -20917,601 +22886,723 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
 
-	// [pP][oO][oO][lL]
-	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
+	// [pP][eE][rR][cC][eE][nN][tT]
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 76:
+			case 67:
 				return -1
-			case 79:
+			case 69:
+				return -1
+			case 78:
 				return -1
 			case 80:
 				return 1
-			case 108:
+			case 82:
 				return -1
-			case 111:
+			case 84:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
 				return -1
 			case 112:
 				return 1
+			case 114:
+				return -1
+			case 116:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 76:
+			case 67:
 				return -1
-			case 79:
+			case 69:
 				return 2
+			case 78:
+				return -1
 			case 80:
 				return -1
-			case 108:
+			case 82:
 				return -1
-			case 111:
+			case 84:
+				return -1
+			case 99:
+				return -1
+			case 101:
 				return 2
+			case 110:
+				return -1
 			case 112:
 				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 76:
+			case 67:
 				return -1
-			case 79:
-				return 3
-			case 80:
+			case 69:
 				return -1
-			case 108:
+			case 78:
 				return -1
-			case 111:
+			case 80:
+				return -1
+			case 82:
 				return 3
+			case 84:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
 			case 112:
 				return -1
+			case 114:
+				return 3
+			case 116:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 76:
+			case 67:
 				return 4
-			case 79:
+			case 69:
+				return -1
+			case 78:
 				return -1
 			case 80:
 				return -1
-			case 108:
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 99:
 				return 4
-			case 111:
+			case 101:
+				return -1
+			case 110:
 				return -1
 			case 112:
 				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 76:
+			case 67:
 				return -1
-			case 79:
+			case 69:
+				return 5
+			case 78:
 				return -1
 			case 80:
 				return -1
-			case 108:
+			case 82:
 				return -1
-			case 111:
+			case 84:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return 5
+			case 110:
 				return -1
 			case 112:
 				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
-
-	// [pP][rR][eE][pP][aA][rR][eE]
-	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
 				return -1
 			case 69:
 				return -1
+			case 78:
+				return 6
 			case 80:
-				return 1
+				return -1
 			case 82:
 				return -1
-			case 97:
+			case 84:
+				return -1
+			case 99:
 				return -1
 			case 101:
 				return -1
+			case 110:
+				return 6
 			case 112:
-				return 1
+				return -1
 			case 114:
 				return -1
+			case 116:
+				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
 				return -1
 			case 69:
 				return -1
+			case 78:
+				return -1
 			case 80:
 				return -1
 			case 82:
-				return 2
-			case 97:
+				return -1
+			case 84:
+				return 7
+			case 99:
 				return -1
 			case 101:
 				return -1
+			case 110:
+				return -1
 			case 112:
 				return -1
 			case 114:
-				return 2
+				return -1
+			case 116:
+				return 7
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
 				return -1
 			case 69:
-				return 3
+				return -1
+			case 78:
+				return -1
 			case 80:
 				return -1
 			case 82:
 				return -1
-			case 97:
+			case 84:
+				return -1
+			case 99:
 				return -1
 			case 101:
-				return 3
+				return -1
+			case 110:
+				return -1
 			case 112:
 				return -1
 			case 114:
 				return -1
+			case 116:
+				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	// [pP][oO][oO][lL]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 76:
 				return -1
-			case 69:
+			case 79:
 				return -1
 			case 80:
-				return 4
-			case 82:
-				return -1
-			case 97:
+				return 1
+			case 108:
 				return -1
-			case 101:
+			case 111:
 				return -1
 			case 112:
-				return 4
-			case 114:
-				return -1
+				return 1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return 5
-			case 69:
+			case 76:
 				return -1
+			case 79:
+				return 2
 			case 80:
 				return -1
-			case 82:
-				return -1
-			case 97:
-				return 5
-			case 101:
+			case 108:
 				return -1
+			case 111:
+				return 2
 			case 112:
 				return -1
-			case 114:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
-				return -1
-			case 69:
+			case 76:
 				return -1
+			case 79:
+				return 3
 			case 80:
 				return -1
-			case 82:
-				return 6
-			case 97:
-				return -1
-			case 101:
+			case 108:
 				return -1
+			case 111:
+				return 3
 			case 112:
 				return -1
-			case 114:
-				return 6
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 76:
+				return 4
+			case 79:
 				return -1
-			case 69:
-				return 7
 			case 80:
 				return -1
-			case 82:
-				return -1
-			case 97:
+			case 108:
+				return 4
+			case 111:
 				return -1
-			case 101:
-				return 7
 			case 112:
 				return -1
-			case 114:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 76:
 				return -1
-			case 69:
+			case 79:
 				return -1
 			case 80:
 				return -1
-			case 82:
-				return -1
-			case 97:
+			case 108:
 				return -1
-			case 101:
+			case 111:
 				return -1
 			case 112:
 				return -1
-			case 114:
-				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
 
-	// [pP][rR][iI][mM][aA][rR][yY]
-	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+	// [pP][rR][eE][cC][eE][dD][iI][nN][gG]
+	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 71:
 				return -1
 			case 73:
 				return -1
-			case 77:
+			case 78:
 				return -1
 			case 80:
 				return 1
 			case 82:
 				return -1
-			case 89:
+			case 99:
 				return -1
-			case 97:
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 103:
 				return -1
 			case 105:
 				return -1
-			case 109:
+			case 110:
 				return -1
 			case 112:
 				return 1
 			case 114:
 				return -1
-			case 121:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 71:
 				return -1
 			case 73:
 				return -1
-			case 77:
+			case 78:
 				return -1
 			case 80:
 				return -1
 			case 82:
 				return 2
-			case 89:
+			case 99:
 				return -1
-			case 97:
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 103:
 				return -1
 			case 105:
 				return -1
-			case 109:
+			case 110:
 				return -1
 			case 112:
 				return -1
 			case 114:
 				return 2
-			case 121:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
 				return -1
-			case 73:
+			case 68:
+				return -1
+			case 69:
 				return 3
-			case 77:
+			case 71:
+				return -1
+			case 73:
+				return -1
+			case 78:
 				return -1
 			case 80:
 				return -1
 			case 82:
 				return -1
-			case 89:
+			case 99:
 				return -1
-			case 97:
+			case 100:
 				return -1
-			case 105:
+			case 101:
 				return 3
-			case 109:
+			case 103:
+				return -1
+			case 105:
+				return -1
+			case 110:
 				return -1
 			case 112:
 				return -1
 			case 114:
 				return -1
-			case 121:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
+				return 4
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 71:
 				return -1
 			case 73:
 				return -1
-			case 77:
-				return 4
+			case 78:
+				return -1
 			case 80:
 				return -1
 			case 82:
 				return -1
-			case 89:
+			case 99:
+				return 4
+			case 100:
 				return -1
-			case 97:
+			case 101:
+				return -1
+			case 103:
 				return -1
 			case 105:
 				return -1
-			case 109:
-				return 4
+			case 110:
+				return -1
 			case 112:
 				return -1
 			case 114:
 				return -1
-			case 121:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
+				return -1
+			case 68:
+				return -1
+			case 69:
 				return 5
+			case 71:
+				return -1
 			case 73:
 				return -1
-			case 77:
+			case 78:
 				return -1
 			case 80:
 				return -1
 			case 82:
 				return -1
-			case 89:
+			case 99:
 				return -1
-			case 97:
+			case 100:
+				return -1
+			case 101:
 				return 5
+			case 103:
+				return -1
 			case 105:
 				return -1
-			case 109:
+			case 110:
 				return -1
 			case 112:
 				return -1
 			case 114:
 				return -1
-			case 121:
-				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
+				return -1
+			case 68:
+				return 6
+			case 69:
+				return -1
+			case 71:
 				return -1
 			case 73:
 				return -1
-			case 77:
+			case 78:
 				return -1
 			case 80:
 				return -1
 			case 82:
+				return -1
+			case 99:
+				return -1
+			case 100:
 				return 6
-			case 89:
+			case 101:
 				return -1
-			case 97:
+			case 103:
 				return -1
 			case 105:
 				return -1
-			case 109:
+			case 110:
 				return -1
 			case 112:
 				return -1
 			case 114:
-				return 6
-			case 121:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
 				return -1
-			case 73:
+			case 68:
 				return -1
-			case 77:
+			case 69:
+				return -1
+			case 71:
+				return -1
+			case 73:
+				return 7
+			case 78:
 				return -1
 			case 80:
 				return -1
 			case 82:
 				return -1
-			case 89:
-				return 7
-			case 97:
+			case 99:
 				return -1
-			case 105:
+			case 100:
 				return -1
-			case 109:
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 105:
+				return 7
+			case 110:
 				return -1
 			case 112:
 				return -1
 			case 114:
 				return -1
-			case 121:
-				return 7
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
 				return -1
-			case 73:
+			case 68:
 				return -1
-			case 77:
+			case 69:
+				return -1
+			case 71:
+				return -1
+			case 73:
 				return -1
+			case 78:
+				return 8
 			case 80:
 				return -1
 			case 82:
 				return -1
-			case 89:
+			case 99:
 				return -1
-			case 97:
+			case 100:
 				return -1
-			case 105:
+			case 101:
 				return -1
-			case 109:
+			case 103:
+				return -1
+			case 105:
 				return -1
+			case 110:
+				return 8
 			case 112:
 				return -1
 			case 114:
 				return -1
-			case 121:
-				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
-
-	// [pP][rR][iI][vV][aA][tT][eE]
-	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
+				return -1
+			case 68:
 				return -1
 			case 69:
 				return -1
+			case 71:
+				return 9
 			case 73:
 				return -1
+			case 78:
+				return -1
 			case 80:
-				return 1
-			case 82:
 				return -1
-			case 84:
+			case 82:
 				return -1
-			case 86:
+			case 99:
 				return -1
-			case 97:
+			case 100:
 				return -1
 			case 101:
 				return -1
+			case 103:
+				return 9
 			case 105:
 				return -1
-			case 112:
-				return 1
-			case 114:
+			case 110:
 				return -1
-			case 116:
+			case 112:
 				return -1
-			case 118:
+			case 114:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
-			case 65:
+			case 67:
+				return -1
+			case 68:
 				return -1
 			case 69:
 				return -1
+			case 71:
+				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
 			case 80:
 				return -1
 			case 82:
-				return 2
-			case 84:
 				return -1
-			case 86:
+			case 99:
 				return -1
-			case 97:
+			case 100:
 				return -1
 			case 101:
 				return -1
+			case 103:
+				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
 			case 112:
 				return -1
 			case 114:
-				return 2
-			case 116:
-				return -1
-			case 118:
 				return -1
 			}
 			return -1
 		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	// [pP][rR][eE][pP][aA][rR][eE]
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
 			case 65:
 				return -1
 			case 69:
 				return -1
-			case 73:
-				return 3
 			case 80:
-				return -1
+				return 1
 			case 82:
 				return -1
-			case 84:
-				return -1
-			case 86:
-				return -1
 			case 97:
 				return -1
 			case 101:
 				return -1
-			case 105:
-				return 3
 			case 112:
-				return -1
+				return 1
 			case 114:
 				return -1
-			case 116:
-				return -1
-			case 118:
-				return -1
 			}
 			return -1
 		},
@@ -21521,8 +23612,494 @@ var dfas = []dfa{
 				return -1
 			case 69:
 				return -1
-			case 73:
-				return -1
+			case 80:
+				return -1
+			case 82:
+				return 2
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return 2
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return 3
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return 3
+			case 112:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 80:
+				return 4
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 112:
+				return 4
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 5
+			case 69:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return 5
+			case 101:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return 6
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return 6
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return 7
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return 7
+			case 112:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// [pP][rR][iI][mM][aA][rR][yY]
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 73:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return 1
+			case 82:
+				return -1
+			case 89:
+				return -1
+			case 97:
+				return -1
+			case 105:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return 1
+			case 114:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 73:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return 2
+			case 89:
+				return -1
+			case 97:
+				return -1
+			case 105:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return 2
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 73:
+				return 3
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 89:
+				return -1
+			case 97:
+				return -1
+			case 105:
+				return 3
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 73:
+				return -1
+			case 77:
+				return 4
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 89:
+				return -1
+			case 97:
+				return -1
+			case 105:
+				return -1
+			case 109:
+				return 4
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 5
+			case 73:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 89:
+				return -1
+			case 97:
+				return 5
+			case 105:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 73:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return 6
+			case 89:
+				return -1
+			case 97:
+				return -1
+			case 105:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return 6
+			case 121:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 73:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 89:
+				return 7
+			case 97:
+				return -1
+			case 105:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 121:
+				return 7
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 73:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 89:
+				return -1
+			case 97:
+				return -1
+			case 105:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 121:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// [pP][rR][iI][vV][aA][tT][eE]
+	{[]bool{false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 80:
+				return 1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 86:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 112:
+				return 1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return 2
+			case 84:
+				return -1
+			case 86:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return 2
+			case 116:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return 3
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 86:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return 3
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
 			case 80:
 				return -1
 			case 82:
@@ -22711,6 +25288,159 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 
+	// [rR][aA][nN][gG][eE]
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 71:
+				return -1
+			case 78:
+				return -1
+			case 82:
+				return 1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 2
+			case 69:
+				return -1
+			case 71:
+				return -1
+			case 78:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return 2
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 71:
+				return -1
+			case 78:
+				return 3
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 110:
+				return 3
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 71:
+				return 4
+			case 78:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 103:
+				return 4
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return 5
+			case 71:
+				return -1
+			case 78:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return 5
+			case 103:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 71:
+				return -1
+			case 78:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 103:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
 	// [rR][aA][wW]
 	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -22937,6 +25667,339 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
 
+	// [rR][eE][cC][uU][rR][sS][iI][vV][eE]
+	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return 1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 86:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return 1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return 2
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 86:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return 2
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return 3
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 86:
+				return -1
+			case 99:
+				return 3
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return 4
+			case 86:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return 4
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return 5
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 86:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return 5
+			case 115:
+				return -1
+			case 117:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return 6
+			case 85:
+				return -1
+			case 86:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return 6
+			case 117:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return 7
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 86:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return 7
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 86:
+				return 8
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			case 118:
+				return 8
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return 9
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 86:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return 9
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 85:
+				return -1
+			case 86:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 117:
+				return -1
+			case 118:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
 	// [rR][eE][dD][uU][cC][eE]
 	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -23295,52 +26358,316 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 
-	// [rR][eE][tT][uU][rR][nN]
-	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+	// [rR][eE][sS][tT][rR][iI][cC][tT]
+	{[]bool{false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
+			case 67:
+				return -1
 			case 69:
 				return -1
-			case 78:
+			case 73:
 				return -1
 			case 82:
 				return 1
+			case 83:
+				return -1
 			case 84:
 				return -1
-			case 85:
+			case 99:
 				return -1
 			case 101:
 				return -1
-			case 110:
+			case 105:
 				return -1
 			case 114:
 				return 1
-			case 116:
+			case 115:
 				return -1
-			case 117:
+			case 116:
 				return -1
 			}
 			return -1
 		},
 		func(r rune) int {
 			switch r {
+			case 67:
+				return -1
 			case 69:
 				return 2
-			case 78:
+			case 73:
 				return -1
 			case 82:
 				return -1
+			case 83:
+				return -1
 			case 84:
 				return -1
-			case 85:
+			case 99:
 				return -1
 			case 101:
 				return 2
-			case 110:
+			case 105:
 				return -1
 			case 114:
 				return -1
-			case 116:
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return 3
+			case 84:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return 3
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return 4
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 4
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return 5
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return 5
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return 6
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return 6
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return 7
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 99:
+				return 7
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return 8
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 8
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 67:
+				return -1
+			case 69:
+				return -1
+			case 73:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 99:
+				return -1
+			case 101:
+				return -1
+			case 105:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	// [rR][eE][tT][uU][rR][nN]
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 78:
+				return -1
+			case 82:
+				return 1
+			case 84:
+				return -1
+			case 85:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 114:
+				return 1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return 2
+			case 78:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 85:
+				return -1
+			case 101:
+				return 2
+			case 110:
+				return -1
+			case 114:
+				return -1
+			case 116:
 				return -1
 			case 117:
 				return -1
@@ -24402,17 +27729,890 @@ var dfas = []dfa{
 				return -1
 			case 97:
 				return -1
-			case 98:
-				return 5
-			case 99:
-				return -1
-			case 107:
+			case 98:
+				return 5
+			case 99:
+				return -1
+			case 107:
+				return -1
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 6
+			case 66:
+				return -1
+			case 67:
+				return -1
+			case 75:
+				return -1
+			case 76:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return 6
+			case 98:
+				return -1
+			case 99:
+				return -1
+			case 107:
+				return -1
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return -1
+			case 67:
+				return 7
+			case 75:
+				return -1
+			case 76:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 98:
+				return -1
+			case 99:
+				return 7
+			case 107:
+				return -1
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return -1
+			case 67:
+				return -1
+			case 75:
+				return 8
+			case 76:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 98:
+				return -1
+			case 99:
+				return -1
+			case 107:
+				return 8
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 66:
+				return -1
+			case 67:
+				return -1
+			case 75:
+				return -1
+			case 76:
+				return -1
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 97:
+				return -1
+			case 98:
+				return -1
+			case 99:
+				return -1
+			case 107:
+				return -1
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+
+	// [rR][oO][lL][lL][uU][pP]
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return 1
+			case 85:
+				return -1
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return 1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 79:
+				return 2
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return -1
+			case 111:
+				return 2
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return 3
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return 3
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return 4
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return 4
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
+				return 5
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return 5
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return 6
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return 6
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 76:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 85:
+				return -1
+			case 108:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
+	// [rR][oO][wW]
+	{[]bool{false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 79:
+				return -1
+			case 82:
+				return 1
+			case 87:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return 1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 79:
+				return 2
+			case 82:
+				return -1
+			case 87:
+				return -1
+			case 111:
+				return 2
+			case 114:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 87:
+				return 3
+			case 111:
+				return -1
+			case 114:
+				return -1
+			case 119:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 87:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
+	// [rR][oO][wW][sS]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 79:
+				return -1
+			case 82:
+				return 1
+			case 83:
+				return -1
+			case 87:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return 1
+			case 115:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 79:
+				return 2
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 87:
+				return -1
+			case 111:
+				return 2
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 87:
+				return 3
+			case 111:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 119:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return 4
+			case 87:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return 4
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 79:
+				return -1
+			case 82:
+				return -1
+			case 83:
+				return -1
+			case 87:
+				return -1
+			case 111:
+				return -1
+			case 114:
+				return -1
+			case 115:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
+	// [sS][aA][mM][pP][lL][eE]
+	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 76:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return 1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 2
+			case 69:
+				return -1
+			case 76:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 97:
+				return 2
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 76:
+				return -1
+			case 77:
+				return 3
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 109:
+				return 3
+			case 112:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 76:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return 4
+			case 83:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return 4
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 76:
+				return 5
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return 5
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return 6
+			case 76:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return 6
+			case 108:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 76:
+				return -1
+			case 77:
+				return -1
+			case 80:
+				return -1
+			case 83:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 108:
+				return -1
+			case 109:
+				return -1
+			case 112:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
+	// [sS][aA][tT][iI][sS][fF][iI][eE][sS]
+	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return 1
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return 1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return 2
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return 2
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return 3
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return 4
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return 4
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return -1
+			case 73:
+				return -1
+			case 83:
+				return 5
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 101:
+				return -1
+			case 102:
+				return -1
+			case 105:
+				return -1
+			case 115:
+				return 5
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 65:
+				return -1
+			case 69:
+				return -1
+			case 70:
+				return 6
+			case 73:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 97:
+				return -1
+			case 101:
 				return -1
-			case 108:
+			case 102:
+				return 6
+			case 105:
 				return -1
-			case 111:
+			case 115:
 				return -1
-			case 114:
+			case 116:
 				return -1
 			}
 			return -1
@@ -24420,32 +28620,28 @@ var dfas = []dfa{
 		func(r rune) int {
 			switch r {
 			case 65:
-				return 6
-			case 66:
 				return -1
-			case 67:
-				return -1
-			case 75:
+			case 69:
 				return -1
-			case 76:
+			case 70:
 				return -1
-			case 79:
+			case 73:
+				return 7
+			case 83:
 				return -1
-			case 82:
+			case 84:
 				return -1
 			case 97:
-				return 6
-			case 98:
-				return -1
-			case 99:
 				return -1
-			case 107:
+			case 101:
 				return -1
-			case 108:
+			case 102:
 				return -1
-			case 111:
+			case 105:
+				return 7
+			case 115:
 				return -1
-			case 114:
+			case 116:
 				return -1
 			}
 			return -1
@@ -24454,31 +28650,27 @@ var dfas = []dfa{
 			switch r {
 			case 65:
 				return -1
-			case 66:
-				return -1
-			case 67:
-				return 7
-			case 75:
+			case 69:
+				return 8
+			case 70:
 				return -1
-			case 76:
+			case 73:
 				return -1
-			case 79:
+			case 83:
 				return -1
-			case 82:
+			case 84:
 				return -1
 			case 97:
 				return -1
-			case 98:
-				return -1
-			case 99:
-				return 7
-			case 107:
+			case 101:
+				return 8
+			case 102:
 				return -1
-			case 108:
+			case 105:
 				return -1
-			case 111:
+			case 115:
 				return -1
-			case 114:
+			case 116:
 				return -1
 			}
 			return -1
@@ -24487,31 +28679,27 @@ var dfas = []dfa{
 			switch r {
 			case 65:
 				return -1
-			case 66:
-				return -1
-			case 67:
+			case 69:
 				return -1
-			case 75:
-				return 8
-			case 76:
+			case 70:
 				return -1
-			case 79:
+			case 73:
 				return -1
-			case 82:
+			case 83:
+				return 9
+			case 84:
 				return -1
 			case 97:
 				return -1
-			case 98:
-				return -1
-			case 99:
+			case 101:
 				return -1
-			case 107:
-				return 8
-			case 108:
+			case 102:
 				return -1
-			case 111:
+			case 105:
 				return -1
-			case 114:
+			case 115:
+				return 9
+			case 116:
 				return -1
 			}
 			return -1
@@ -24520,38 +28708,34 @@ var dfas = []dfa{
 			switch r {
 			case 65:
 				return -1
-			case 66:
-				return -1
-			case 67:
+			case 69:
 				return -1
-			case 75:
+			case 70:
 				return -1
-			case 76:
+			case 73:
 				return -1
-			case 79:
+			case 83:
 				return -1
-			case 82:
+			case 84:
 				return -1
 			case 97:
 				return -1
-			case 98:
-				return -1
-			case 99:
+			case 101:
 				return -1
-			case 107:
+			case 102:
 				return -1
-			case 108:
+			case 105:
 				return -1
-			case 111:
+			case 115:
 				return -1
-			case 114:
+			case 116:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
 
-	// [sS][aA][tT][iI][sS][fF][iI][eE][sS]
+	// [sS][aA][vV][eE][pP][oO][iI][nN][tT]
 	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
@@ -24559,26 +28743,38 @@ var dfas = []dfa{
 				return -1
 			case 69:
 				return -1
-			case 70:
-				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 83:
 				return 1
 			case 84:
 				return -1
+			case 86:
+				return -1
 			case 97:
 				return -1
 			case 101:
 				return -1
-			case 102:
-				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 115:
 				return 1
 			case 116:
 				return -1
+			case 118:
+				return -1
 			}
 			return -1
 		},
@@ -24588,26 +28784,38 @@ var dfas = []dfa{
 				return 2
 			case 69:
 				return -1
-			case 70:
-				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 83:
 				return -1
 			case 84:
 				return -1
+			case 86:
+				return -1
 			case 97:
 				return 2
 			case 101:
 				return -1
-			case 102:
-				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 115:
 				return -1
 			case 116:
 				return -1
+			case 118:
+				return -1
 			}
 			return -1
 		},
@@ -24617,25 +28825,37 @@ var dfas = []dfa{
 				return -1
 			case 69:
 				return -1
-			case 70:
-				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 83:
 				return -1
 			case 84:
+				return -1
+			case 86:
 				return 3
 			case 97:
 				return -1
 			case 101:
 				return -1
-			case 102:
-				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 115:
 				return -1
 			case 116:
+				return -1
+			case 118:
 				return 3
 			}
 			return -1
@@ -24645,27 +28865,39 @@ var dfas = []dfa{
 			case 65:
 				return -1
 			case 69:
+				return 4
+			case 73:
 				return -1
-			case 70:
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
 				return -1
-			case 73:
-				return 4
 			case 83:
 				return -1
 			case 84:
 				return -1
+			case 86:
+				return -1
 			case 97:
 				return -1
 			case 101:
+				return 4
+			case 105:
 				return -1
-			case 102:
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
 				return -1
-			case 105:
-				return 4
 			case 115:
 				return -1
 			case 116:
 				return -1
+			case 118:
+				return -1
 			}
 			return -1
 		},
@@ -24675,26 +28907,38 @@ var dfas = []dfa{
 				return -1
 			case 69:
 				return -1
-			case 70:
-				return -1
 			case 73:
 				return -1
-			case 83:
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
 				return 5
+			case 83:
+				return -1
 			case 84:
 				return -1
+			case 86:
+				return -1
 			case 97:
 				return -1
 			case 101:
 				return -1
-			case 102:
-				return -1
 			case 105:
 				return -1
-			case 115:
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
 				return 5
+			case 115:
+				return -1
 			case 116:
 				return -1
+			case 118:
+				return -1
 			}
 			return -1
 		},
@@ -24704,26 +28948,38 @@ var dfas = []dfa{
 				return -1
 			case 69:
 				return -1
-			case 70:
-				return 6
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return 6
+			case 80:
+				return -1
 			case 83:
 				return -1
 			case 84:
 				return -1
+			case 86:
+				return -1
 			case 97:
 				return -1
 			case 101:
 				return -1
-			case 102:
-				return 6
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return 6
+			case 112:
+				return -1
 			case 115:
 				return -1
 			case 116:
 				return -1
+			case 118:
+				return -1
 			}
 			return -1
 		},
@@ -24733,26 +28989,38 @@ var dfas = []dfa{
 				return -1
 			case 69:
 				return -1
-			case 70:
-				return -1
 			case 73:
 				return 7
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 83:
 				return -1
 			case 84:
 				return -1
+			case 86:
+				return -1
 			case 97:
 				return -1
 			case 101:
 				return -1
-			case 102:
-				return -1
 			case 105:
 				return 7
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 115:
 				return -1
 			case 116:
 				return -1
+			case 118:
+				return -1
 			}
 			return -1
 		},
@@ -24761,27 +29029,39 @@ var dfas = []dfa{
 			case 65:
 				return -1
 			case 69:
-				return 8
-			case 70:
 				return -1
 			case 73:
 				return -1
+			case 78:
+				return 8
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 83:
 				return -1
 			case 84:
 				return -1
+			case 86:
+				return -1
 			case 97:
 				return -1
 			case 101:
-				return 8
-			case 102:
 				return -1
 			case 105:
 				return -1
+			case 110:
+				return 8
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 115:
 				return -1
 			case 116:
 				return -1
+			case 118:
+				return -1
 			}
 			return -1
 		},
@@ -24791,25 +29071,37 @@ var dfas = []dfa{
 				return -1
 			case 69:
 				return -1
-			case 70:
-				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 83:
-				return 9
+				return -1
 			case 84:
+				return 9
+			case 86:
 				return -1
 			case 97:
 				return -1
 			case 101:
 				return -1
-			case 102:
-				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 115:
-				return 9
+				return -1
 			case 116:
+				return 9
+			case 118:
 				return -1
 			}
 			return -1
@@ -24820,31 +29112,42 @@ var dfas = []dfa{
 				return -1
 			case 69:
 				return -1
-			case 70:
-				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 83:
 				return -1
 			case 84:
 				return -1
+			case 86:
+				return -1
 			case 97:
 				return -1
 			case 101:
 				return -1
-			case 102:
-				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 115:
 				return -1
 			case 116:
 				return -1
+			case 118:
+				return -1
 			}
 			return -1
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
-
 	// [sS][cC][hH][eE][mM][aA]
 	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -25052,6 +29355,94 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1}, nil},
 
+	// [sS][eE][eE][dD]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return 1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 68:
+				return -1
+			case 69:
+				return 2
+			case 83:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return 2
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 68:
+				return -1
+			case 69:
+				return 3
+			case 83:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return 3
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 68:
+				return 4
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 100:
+				return 4
+			case 101:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
 	// [sS][eE][lL][eE][cC][tT]
 	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -25412,6 +29803,94 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1}, nil},
 
+	// [sS][eE][tT][sS]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 83:
+				return 1
+			case 84:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return 1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return 2
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 101:
+				return 2
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return 3
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return 3
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 83:
+				return 4
+			case 84:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return 4
+			case 116:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 83:
+				return -1
+			case 84:
+				return -1
+			case 101:
+				return -1
+			case 115:
+				return -1
+			case 116:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
 	// [sS][hH][oO][wW]
 	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -27639,6 +32118,299 @@ var dfas = []dfa{
 		},
 	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
 
+	// [uU][nN][bB][oO][uU][nN][dD][eE][dD]
+	{[]bool{false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 85:
+				return 1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 117:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 78:
+				return 2
+			case 79:
+				return -1
+			case 85:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return 2
+			case 111:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return 3
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 85:
+				return -1
+			case 98:
+				return 3
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return 4
+			case 85:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return 4
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 85:
+				return 5
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 117:
+				return 5
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 78:
+				return 6
+			case 79:
+				return -1
+			case 85:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return 6
+			case 111:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return 7
+			case 69:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 85:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return 7
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return 8
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 85:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return 8
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return 9
+			case 69:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 85:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return 9
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 66:
+				return -1
+			case 68:
+				return -1
+			case 69:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 85:
+				return -1
+			case 98:
+				return -1
+			case 100:
+				return -1
+			case 101:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 117:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
 	// [uU][nN][dD][eE][rR]
 	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -30574,38 +35346,478 @@ var dfas = []dfa{
 			switch r {
 			case 69:
 				return -1
-			case 72:
-				return 2
+			case 72:
+				return 2
+			case 82:
+				return -1
+			case 87:
+				return -1
+			case 101:
+				return -1
+			case 104:
+				return 2
+			case 114:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return 3
+			case 72:
+				return -1
+			case 82:
+				return -1
+			case 87:
+				return -1
+			case 101:
+				return 3
+			case 104:
+				return -1
+			case 114:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 72:
+				return -1
+			case 82:
+				return 4
+			case 87:
+				return -1
+			case 101:
+				return -1
+			case 104:
+				return -1
+			case 114:
+				return 4
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return 5
+			case 72:
+				return -1
+			case 82:
+				return -1
+			case 87:
+				return -1
+			case 101:
+				return 5
+			case 104:
+				return -1
+			case 114:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 72:
+				return -1
+			case 82:
+				return -1
+			case 87:
+				return -1
+			case 101:
+				return -1
+			case 104:
+				return -1
+			case 114:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// [wW][hH][iI][lL][eE]
+	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 72:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 87:
+				return 1
+			case 101:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 119:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 72:
+				return 2
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 87:
+				return -1
+			case 101:
+				return -1
+			case 104:
+				return 2
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 72:
+				return -1
+			case 73:
+				return 3
+			case 76:
+				return -1
+			case 87:
+				return -1
+			case 101:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return 3
+			case 108:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 72:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return 4
+			case 87:
+				return -1
+			case 101:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return 4
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return 5
+			case 72:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 87:
+				return -1
+			case 101:
+				return 5
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 69:
+				return -1
+			case 72:
+				return -1
+			case 73:
+				return -1
+			case 76:
+				return -1
+			case 87:
+				return -1
+			case 101:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 108:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
+
+	// [wW][iI][tT][hH]
+	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 72:
+				return -1
+			case 73:
+				return -1
+			case 84:
+				return -1
+			case 87:
+				return 1
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 116:
+				return -1
+			case 119:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 72:
+				return -1
+			case 73:
+				return 2
+			case 84:
+				return -1
+			case 87:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return 2
+			case 116:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 72:
+				return -1
+			case 73:
+				return -1
+			case 84:
+				return 3
+			case 87:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 116:
+				return 3
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 72:
+				return 4
+			case 73:
+				return -1
+			case 84:
+				return -1
+			case 87:
+				return -1
+			case 104:
+				return 4
+			case 105:
+				return -1
+			case 116:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 72:
+				return -1
+			case 73:
+				return -1
+			case 84:
+				return -1
+			case 87:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 116:
+				return -1
+			case 119:
+				return -1
+			}
+			return -1
+		},
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
+
+	// [wW][iI][tT][hH][iI][nN][ \t\n\r\f]+[gG][rR][oO][uU][pP]
+	{[]bool{false, false, false, false, false, false, false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
+		func(r rune) int {
+			switch r {
+			case 9:
+				return -1
+			case 10:
+				return -1
+			case 12:
+				return -1
+			case 13:
+				return -1
+			case 32:
+				return -1
+			case 71:
+				return -1
+			case 72:
+				return -1
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 85:
+				return -1
+			case 87:
+				return 1
+			case 103:
+				return -1
+			case 104:
+				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
+			case 119:
+				return 1
+			}
+			return -1
+		},
+		func(r rune) int {
+			switch r {
+			case 9:
+				return -1
+			case 10:
+				return -1
+			case 12:
+				return -1
+			case 13:
+				return -1
+			case 32:
+				return -1
+			case 71:
+				return -1
+			case 72:
+				return -1
+			case 73:
+				return 2
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 82:
 				return -1
+			case 84:
+				return -1
+			case 85:
+				return -1
 			case 87:
 				return -1
-			case 101:
+			case 103:
 				return -1
 			case 104:
-				return 2
-			case 114:
 				return -1
-			case 119:
+			case 105:
+				return 2
+			case 110:
 				return -1
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
-			case 69:
-				return 3
-			case 72:
+			case 111:
 				return -1
-			case 82:
+			case 112:
 				return -1
-			case 87:
+			case 114:
 				return -1
-			case 101:
-				return 3
-			case 104:
+			case 116:
 				return -1
-			case 114:
+			case 117:
 				return -1
 			case 119:
 				return -1
@@ -30614,41 +35826,54 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 69:
+			case 9:
 				return -1
-			case 72:
+			case 10:
 				return -1
-			case 82:
-				return 4
-			case 87:
+			case 12:
 				return -1
-			case 101:
+			case 13:
 				return -1
-			case 104:
+			case 32:
 				return -1
-			case 114:
-				return 4
-			case 119:
+			case 71:
 				return -1
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
-			case 69:
-				return 5
 			case 72:
 				return -1
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
 			case 82:
 				return -1
+			case 84:
+				return 3
+			case 85:
+				return -1
 			case 87:
 				return -1
-			case 101:
-				return 5
+			case 103:
+				return -1
 			case 104:
 				return -1
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
 			case 114:
 				return -1
+			case 116:
+				return 3
+			case 117:
+				return -1
 			case 119:
 				return -1
 			}
@@ -30656,73 +35881,108 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 69:
+			case 9:
+				return -1
+			case 10:
+				return -1
+			case 12:
+				return -1
+			case 13:
+				return -1
+			case 32:
+				return -1
+			case 71:
 				return -1
 			case 72:
+				return 4
+			case 73:
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
 				return -1
 			case 82:
 				return -1
+			case 84:
+				return -1
+			case 85:
+				return -1
 			case 87:
 				return -1
-			case 101:
+			case 103:
 				return -1
 			case 104:
+				return 4
+			case 105:
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
 				return -1
 			case 114:
 				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
 			case 119:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
-
-	// [wW][hH][iI][lL][eE]
-	{[]bool{false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 69:
+			case 9:
 				return -1
-			case 72:
+			case 10:
 				return -1
-			case 73:
+			case 12:
 				return -1
-			case 76:
+			case 13:
 				return -1
-			case 87:
-				return 1
-			case 101:
+			case 32:
 				return -1
-			case 104:
+			case 71:
 				return -1
-			case 105:
+			case 72:
 				return -1
-			case 108:
+			case 73:
+				return 5
+			case 78:
 				return -1
-			case 119:
-				return 1
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
-			case 69:
+			case 79:
 				return -1
-			case 72:
-				return 2
-			case 73:
+			case 80:
 				return -1
-			case 76:
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 85:
 				return -1
 			case 87:
 				return -1
-			case 101:
+			case 103:
 				return -1
 			case 104:
-				return 2
+				return -1
 			case 105:
+				return 5
+			case 110:
 				return -1
-			case 108:
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 117:
 				return -1
 			case 119:
 				return -1
@@ -30731,23 +35991,53 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 69:
+			case 9:
+				return -1
+			case 10:
+				return -1
+			case 12:
+				return -1
+			case 13:
+				return -1
+			case 32:
+				return -1
+			case 71:
 				return -1
 			case 72:
 				return -1
 			case 73:
-				return 3
-			case 76:
+				return -1
+			case 78:
+				return 6
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 85:
 				return -1
 			case 87:
 				return -1
-			case 101:
+			case 103:
 				return -1
 			case 104:
 				return -1
 			case 105:
-				return 3
-			case 108:
+				return -1
+			case 110:
+				return 6
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 117:
 				return -1
 			case 119:
 				return -1
@@ -30756,24 +36046,54 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 69:
+			case 9:
+				return 7
+			case 10:
+				return 7
+			case 12:
+				return 7
+			case 13:
+				return 7
+			case 32:
+				return 7
+			case 71:
 				return -1
 			case 72:
 				return -1
 			case 73:
 				return -1
-			case 76:
-				return 4
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 85:
+				return -1
 			case 87:
 				return -1
-			case 101:
+			case 103:
 				return -1
 			case 104:
 				return -1
 			case 105:
 				return -1
-			case 108:
-				return 4
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 117:
+				return -1
 			case 119:
 				return -1
 			}
@@ -30781,23 +36101,53 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 69:
-				return 5
+			case 9:
+				return 7
+			case 10:
+				return 7
+			case 12:
+				return 7
+			case 13:
+				return 7
+			case 32:
+				return 7
+			case 71:
+				return 8
 			case 72:
 				return -1
 			case 73:
 				return -1
-			case 76:
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
+			case 84:
+				return -1
+			case 85:
 				return -1
 			case 87:
 				return -1
-			case 101:
-				return 5
+			case 103:
+				return 8
 			case 104:
 				return -1
 			case 105:
 				return -1
-			case 108:
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
+			case 116:
+				return -1
+			case 117:
 				return -1
 			case 119:
 				return -1
@@ -30806,70 +36156,109 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
-			case 69:
+			case 9:
+				return -1
+			case 10:
+				return -1
+			case 12:
+				return -1
+			case 13:
+				return -1
+			case 32:
+				return -1
+			case 71:
 				return -1
 			case 72:
 				return -1
 			case 73:
 				return -1
-			case 76:
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return 9
+			case 84:
+				return -1
+			case 85:
 				return -1
 			case 87:
 				return -1
-			case 101:
+			case 103:
 				return -1
 			case 104:
 				return -1
 			case 105:
 				return -1
-			case 108:
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return 9
+			case 116:
+				return -1
+			case 117:
 				return -1
 			case 119:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1}, nil},
-
-	// [wW][iI][tT][hH]
-	{[]bool{false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
 			switch r {
-			case 72:
+			case 9:
 				return -1
-			case 73:
+			case 10:
 				return -1
-			case 84:
+			case 12:
 				return -1
-			case 87:
-				return 1
-			case 104:
+			case 13:
 				return -1
-			case 105:
+			case 32:
 				return -1
-			case 116:
+			case 71:
 				return -1
-			case 119:
-				return 1
-			}
-			return -1
-		},
-		func(r rune) int {
-			switch r {
 			case 72:
 				return -1
 			case 73:
-				return 2
+				return -1
+			case 78:
+				return -1
+			case 79:
+				return 10
+			case 80:
+				return -1
+			case 82:
+				return -1
 			case 84:
 				return -1
+			case 85:
+				return -1
 			case 87:
 				return -1
+			case 103:
+				return -1
 			case 104:
 				return -1
 			case 105:
-				return 2
+				return -1
+			case 110:
+				return -1
+			case 111:
+				return 10
+			case 112:
+				return -1
+			case 114:
+				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			case 119:
 				return -1
 			}
@@ -30877,20 +36266,54 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
+			case 9:
+				return -1
+			case 10:
+				return -1
+			case 12:
+				return -1
+			case 13:
+				return -1
+			case 32:
+				return -1
+			case 71:
+				return -1
 			case 72:
 				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
 			case 84:
-				return 3
+				return -1
+			case 85:
+				return 11
 			case 87:
 				return -1
+			case 103:
+				return -1
 			case 104:
 				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
 			case 116:
-				return 3
+				return -1
+			case 117:
+				return 11
 			case 119:
 				return -1
 			}
@@ -30898,20 +36321,54 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
+			case 9:
+				return -1
+			case 10:
+				return -1
+			case 12:
+				return -1
+			case 13:
+				return -1
+			case 32:
+				return -1
+			case 71:
+				return -1
 			case 72:
-				return 4
+				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return 12
+			case 82:
+				return -1
 			case 84:
 				return -1
+			case 85:
+				return -1
 			case 87:
 				return -1
+			case 103:
+				return -1
 			case 104:
-				return 4
+				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return 12
+			case 114:
+				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			case 119:
 				return -1
 			}
@@ -30919,27 +36376,60 @@ var dfas = []dfa{
 		},
 		func(r rune) int {
 			switch r {
+			case 9:
+				return -1
+			case 10:
+				return -1
+			case 12:
+				return -1
+			case 13:
+				return -1
+			case 32:
+				return -1
+			case 71:
+				return -1
 			case 72:
 				return -1
 			case 73:
 				return -1
+			case 78:
+				return -1
+			case 79:
+				return -1
+			case 80:
+				return -1
+			case 82:
+				return -1
 			case 84:
 				return -1
+			case 85:
+				return -1
 			case 87:
 				return -1
+			case 103:
+				return -1
 			case 104:
 				return -1
 			case 105:
 				return -1
+			case 110:
+				return -1
+			case 111:
+				return -1
+			case 112:
+				return -1
+			case 114:
+				return -1
 			case 116:
 				return -1
+			case 117:
+				return -1
 			case 119:
 				return -1
 			}
 			return -1
 		},
-	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1}, nil},
-
+	}, []int{ /* Start-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, []int{ /* End-of-input transitions */ -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, nil},
 	// [wW][iI][tT][hH][iI][nN]
 	{[]bool{false, false, false, false, false, false, true}, []func(rune) int{ // Transitions
 		func(r rune) int {
@@ -31703,6 +37193,12 @@ OUTER0:
 			}
 		case 7:
 			{
+				// optimizer hints are written as a block comment beginning /*+
+				if len(yylex.Text()) > 2 && yylex.Text()[2] == '+' {
+					lval.s = yylex.Text()
+					yylex.logToken(yylex.Text(), "OPTIM_HINTS - %s", lval.s)
+					return OPTIM_HINTS
+				}
 				yylex.logToken(yylex.Text(), "BLOCK_COMMENT (length=%d)", len(yylex.Text())) /* eat up block comment */
 			}
 		case 8:
@@ -31844,916 +37340,1060 @@ OUTER0:
 				return NOT_A_TOKEN
 			}
 		case 36:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "ADVISE")
+				lval.tokOffset = yylex.curOffset
+				return ADVISE
+			}
+		case 37:
 			{
 				yylex.logToken(yylex.Text(), "ALL")
 				return ALL
 			}
-		case 37:
+		case 38:
 			{
 				yylex.logToken(yylex.Text(), "ALTER")
 				return ALTER
 			}
-		case 38:
+		case 39:
 			{
 				yylex.logToken(yylex.Text(), "ANALYZE")
 				return ANALYZE
 			}
-		case 39:
+		case 40:
 			{
 				yylex.logToken(yylex.Text(), "AND")
 				return AND
 			}
-		case 40:
+		case 41:
 			{
 				yylex.logToken(yylex.Text(), "ANY")
 				return ANY
 			}
-		case 41:
+		case 42:
 			{
 				yylex.logToken(yylex.Text(), "ARRAY")
 				return ARRAY
 			}
-		case 42:
+		case 43:
 			{
 				yylex.logToken(yylex.Text(), "AS")
 				lval.tokOffset = yylex.curOffset
 				return AS
 			}
-		case 43:
+		case 44:
 			{
 				yylex.logToken(yylex.Text(), "ASC")
 				return ASC
 			}
-		case 44:
+		case 45:
 			{
 				yylex.logToken(yylex.Text(), "BEGIN")
 				return BEGIN
 			}
-		case 45:
+		case 46:
 			{
 				yylex.logToken(yylex.Text(), "BETWEEN")
 				return BETWEEN
 			}
-		case 46:
+		case 47:
 			{
 				yylex.logToken(yylex.Text(), "BINARY")
 				return BINARY
 			}
-		case 47:
+		case 48:
 			{
 				yylex.logToken(yylex.Text(), "BOOLEAN")
 				return BOOLEAN
 			}
-		case 48:
+		case 49:
 			{
 				yylex.logToken(yylex.Text(), "BREAK")
 				return BREAK
 			}
-		case 49:
+		case 50:
 			{
 				yylex.logToken(yylex.Text(), "BUCKET")
 				return BUCKET
 			}
-		case 50:
+		case 51:
 			{
 				yylex.logToken(yylex.Text(), "BUILD")
 				return BUILD
 			}
-		case 51:
+		case 52:
 			{
 				yylex.logToken(yylex.Text(), "BY")
 				return BY
 			}
-		case 52:
+		case 53:
 			{
 				yylex.logToken(yylex.Text(), "CALL")
 				return CALL
 			}
-		case 53:
+		case 54:
 			{
 				yylex.logToken(yylex.Text(), "CASE")
 				return CASE
 			}
-		case 54:
+		case 55:
 			{
 				yylex.logToken(yylex.Text(), "CAST")
 				return CAST
 			}
-		case 55:
+		case 56:
 			{
 				yylex.logToken(yylex.Text(), "CLUSTER")
 				return CLUSTER
 			}
-		case 56:
+		case 57:
 			{
 				yylex.logToken(yylex.Text(), "COLLATE")
 				return COLLATE
 			}
-		case 57:
+		case 58:
 			{
 				yylex.logToken(yylex.Text(), "COLLECTION")
 				return COLLECTION
 			}
-		case 58:
+		case 59:
 			{
 				yylex.logToken(yylex.Text(), "COMMIT")
 				return COMMIT
 			}
-		case 59:
+		case 60:
 			{
 				yylex.logToken(yylex.Text(), "CONNECT")
 				return CONNECT
 			}
-		case 60:
+		case 61:
 			{
 				yylex.logToken(yylex.Text(), "CONTINUE")
 				return CONTINUE
 			}
-		case 61:
+		case 62:
 			{
 				yylex.logToken(yylex.Text(), "CORRELATED")
 				return CORRELATED
 			}
-		case 62:
+		case 63:
 			{
 				yylex.logToken(yylex.Text(), "COVER")
 				return COVER
 			}
-		case 63:
+		case 64:
 			{
 				yylex.logToken(yylex.Text(), "CREATE")
 				return CREATE
 			}
-		case 64:
+		case 65:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "CUBE")
+				return CUBE
+			}
+		case 66:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "CURRENT")
+				return CURRENT
+			}
+		case 67:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "CYCLE")
+				return CYCLE
+			}
+		case 68:
 			{
 				yylex.logToken(yylex.Text(), "DATABASE")
 				return DATABASE
 			}
-		case 65:
+		case 69:
 			{
 				yylex.logToken(yylex.Text(), "DATASET")
 				return DATASET
 			}
-		case 66:
+		case 70:
 			{
 				yylex.logToken(yylex.Text(), "DATASTORE")
 				return DATASTORE
 			}
-		case 67:
+		case 71:
 			{
 				yylex.logToken(yylex.Text(), "DECLARE")
 				return DECLARE
 			}
-		case 68:
+		case 72:
 			{
 				yylex.logToken(yylex.Text(), "DECREMENT")
 				return DECREMENT
 			}
-		case 69:
+		case 73:
 			{
 				yylex.logToken(yylex.Text(), "DELETE")
 				return DELETE
 			}
-		case 70:
+		case 74:
 			{
 				yylex.logToken(yylex.Text(), "DERIVED")
 				return DERIVED
 			}
-		case 71:
+		case 75:
 			{
 				yylex.logToken(yylex.Text(), "DESC")
 				return DESC
 			}
-		case 72:
+		case 76:
 			{
 				yylex.logToken(yylex.Text(), "DESCRIBE")
 				return DESCRIBE
 			}
-		case 73:
+		case 77:
 			{
 				yylex.logToken(yylex.Text(), "DISTINCT")
 				return DISTINCT
 			}
-		case 74:
+		case 78:
 			{
 				yylex.logToken(yylex.Text(), "DO")
 				return DO
 			}
-		case 75:
+		case 79:
 			{
 				yylex.logToken(yylex.Text(), "DROP")
 				return DROP
 			}
-		case 76:
+		case 80:
 			{
 				yylex.logToken(yylex.Text(), "EACH")
 				return EACH
 			}
-		case 77:
+		case 81:
 			{
 				yylex.logToken(yylex.Text(), "ELEMENT")
 				return ELEMENT
 			}
-		case 78:
+		case 82:
 			{
 				yylex.logToken(yylex.Text(), "ELSE")
 				return ELSE
 			}
-		case 79:
+		case 83:
 			{
 				yylex.logToken(yylex.Text(), "END")
 				return END
 			}
-		case 80:
+		case 84:
 			{
 				yylex.logToken(yylex.Text(), "EVERY")
 				return EVERY
 			}
-		case 81:
+		case 85:
 			{
 				yylex.logToken(yylex.Text(), "EXCEPT")
 				return EXCEPT
 			}
-		case 82:
+		case 86:
 			{
 				yylex.logToken(yylex.Text(), "EXCLUDE")
 				return EXCLUDE
 			}
-		case 83:
+		case 87:
 			{
 				yylex.logToken(yylex.Text(), "EXECUTE")
 				return EXECUTE
 			}
-		case 84:
+		case 88:
 			{
 				yylex.logToken(yylex.Text(), "EXISTS")
 				return EXISTS
 			}
-		case 85:
+		case 89:
 			{
 				yylex.logToken(yylex.Text(), "EXPLAIN")
 				lval.tokOffset = yylex.curOffset
 				return EXPLAIN
 			}
-		case 86:
+		case 90:
 			{
 				yylex.logToken(yylex.Text(), "FALSE")
 				return FALSE
 			}
-		case 87:
+		case 91:
 			{
 				yylex.logToken(yylex.Text(), "FETCH")
 				return FETCH
 			}
-		case 88:
+		case 92:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "FILTER")
+				return FILTER
+			}
+		case 93:
 			{
 				yylex.logToken(yylex.Text(), "FIRST")
 				return FIRST
 			}
-		case 89:
+		case 94:
 			{
 				yylex.logToken(yylex.Text(), "FLATTEN")
 				return FLATTEN
 			}
-		case 90:
+		case 95:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "FOLLOWING")
+				return FOLLOWING
+			}
+		case 96:
 			{
 				yylex.logToken(yylex.Text(), "FOR")
 				return FOR
 			}
-		case 91:
+		case 97:
 			{
 				yylex.logToken(yylex.Text(), "FORCE")
 				return FORCE
 			}
-		case 92:
+		case 98:
 			{
 				yylex.logToken(yylex.Text(), "FROM")
 				lval.tokOffset = yylex.curOffset
 				return FROM
 			}
-		case 93:
+		case 99:
 			{
 				yylex.logToken(yylex.Text(), "FTS")
 				return FTS
 			}
-		case 94:
+		case 100:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "FULL")
+				return FULL
+			}
+		case 101:
 			{
 				yylex.logToken(yylex.Text(), "FUNCTION")
 				return FUNCTION
 			}
-		case 95:
+		case 102:
 			{
 				yylex.logToken(yylex.Text(), "GRANT")
 				return GRANT
 			}
-		case 96:
+		case 103:
 			{
 				yylex.logToken(yylex.Text(), "GROUP")
 				return GROUP
 			}
-		case 97:
+		case 104:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "GROUPING")
+				return GROUPING
+			}
+		case 105:
 			{
 				yylex.logToken(yylex.Text(), "GSI")
 				return GSI
 			}
-		case 98:
+		case 106:
 			{
 				yylex.logToken(yylex.Text(), "HASH")
 				return HASH
 			}
-		case 99:
+		case 107:
 			{
 				yylex.logToken(yylex.Text(), "HAVING")
 				return HAVING
 			}
-		case 100:
+		case 108:
 			{
 				yylex.logToken(yylex.Text(), "IF")
 				return IF
 			}
-		case 101:
+		case 109:
 			{
 				yylex.logToken(yylex.Text(), "IGNORE")
 				return IGNORE
 			}
-		case 102:
+		case 110:
 			{
 				yylex.logToken(yylex.Text(), "ILIKE")
 				return ILIKE
 			}
-		case 103:
+		case 111:
 			{
 				yylex.logToken(yylex.Text(), "IN")
 				return IN
 			}
-		case 104:
+		case 112:
 			{
 				yylex.logToken(yylex.Text(), "INCLUDE")
 				return INCLUDE
 			}
-		case 105:
+		case 113:
 			{
 				yylex.logToken(yylex.Text(), "INCREMENT")
 				return INCREMENT
 			}
-		case 106:
+		case 114:
 			{
 				yylex.logToken(yylex.Text(), "INDEX")
 				return INDEX
 			}
-		case 107:
+		case 115:
 			{
 				yylex.logToken(yylex.Text(), "INFER")
 				return INFER
 			}
-		case 108:
+		case 116:
 			{
 				yylex.logToken(yylex.Text(), "INLINE")
 				return INLINE
 			}
-		case 109:
+		case 117:
 			{
 				yylex.logToken(yylex.Text(), "INNER")
 				return INNER
 			}
-		case 110:
+		case 118:
 			{
 				yylex.logToken(yylex.Text(), "INSERT")
 				return INSERT
 			}
-		case 111:
+		case 119:
 			{
 				yylex.logToken(yylex.Text(), "INTERSECT")
 				return INTERSECT
 			}
-		case 112:
+		case 120:
 			{
 				yylex.logToken(yylex.Text(), "INTO")
 				return INTO
 			}
-		case 113:
+		case 121:
 			{
 				yylex.logToken(yylex.Text(), "IS")
 				return IS
 			}
-		case 114:
+		case 122:
 			{
 				yylex.logToken(yylex.Text(), "JOIN")
 				return JOIN
 			}
-		case 115:
+		case 123:
 			{
 				yylex.logToken(yylex.Text(), "KEY")
 				return KEY
 			}
-		case 116:
+		case 124:
 			{
 				yylex.logToken(yylex.Text(), "KEYS")
 				return KEYS
 			}
-		case 117:
+		case 125:
 			{
 				yylex.logToken(yylex.Text(), "KEYSPACE")
 				return KEYSPACE
 			}
-		case 118:
+		case 126:
 			{
 				yylex.logToken(yylex.Text(), "KNOWN")
 				return KNOWN
 			}
-		case 119:
+		case 127:
 			{
 				yylex.logToken(yylex.Text(), "LAST")
 				return LAST
 			}
-		case 120:
+		case 128:
 			{
 				yylex.logToken(yylex.Text(), "LEFT")
 				return LEFT
 			}
-		case 121:
+		case 129:
 			{
 				yylex.logToken(yylex.Text(), "LET")
 				return LET
 			}
-		case 122:
+		case 130:
 			{
 				yylex.logToken(yylex.Text(), "LETTING")
 				return LETTING
 			}
-		case 123:
+		case 131:
 			{
 				yylex.logToken(yylex.Text(), "LIKE")
 				return LIKE
 			}
-		case 124:
+		case 132:
 			{
 				yylex.logToken(yylex.Text(), "LIMIT")
 				return LIMIT
 			}
-		case 125:
+		case 133:
 			{
 				yylex.logToken(yylex.Text(), "LSM")
 				return LSM
 			}
-		case 126:
+		case 134:
 			{
 				yylex.logToken(yylex.Text(), "MAP")
 				return MAP
 			}
-		case 127:
+		case 135:
 			{
 				yylex.logToken(yylex.Text(), "MAPPING")
 				return MAPPING
 			}
-		case 128:
+		case 136:
 			{
 				yylex.logToken(yylex.Text(), "MATCHED")
 				return MATCHED
 			}
-		case 129:
+		case 137:
 			{
 				yylex.logToken(yylex.Text(), "MATERIALIZED")
 				return MATERIALIZED
 			}
-		case 130:
+		case 138:
 			{
 				yylex.logToken(yylex.Text(), "MERGE")
 				return MERGE
 			}
-		case 131:
+		case 139:
 			{
 				yylex.logToken(yylex.Text(), "MINUS")
 				return MINUS
 			}
-		case 132:
+		case 140:
 			{
 				yylex.logToken(yylex.Text(), "MISSING")
 				return MISSING
 			}
-		case 133:
+		case 141:
 			{
 				yylex.logToken(yylex.Text(), "NAMESPACE")
 				return NAMESPACE
 			}
-		case 134:
+		case 142:
 			{
 				yylex.logToken(yylex.Text(), "NEST")
 				return NEST
 			}
-		case 135:
+		case 143:
 			{
 				yylex.logToken(yylex.Text(), "NL")
 				return NL
 			}
-		case 136:
+		case 144:
 			{
 				yylex.logToken(yylex.Text(), "NOT")
 				return NOT
 			}
-		case 137:
+		case 145:
 			{
 				yylex.logToken(yylex.Text(), "NULL")
 				return NULL
 			}
-		case 138:
+		case 146:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "NULLS")
+				return NULLS
+			}
+		case 147:
 			{
 				yylex.logToken(yylex.Text(), "NUMBER")
 				return NUMBER
 			}
-		case 139:
+		case 148:
 			{
 				yylex.logToken(yylex.Text(), "OBJECT")
 				return OBJECT
 			}
-		case 140:
+		case 149:
 			{
 				yylex.logToken(yylex.Text(), "OFFSET")
 				return OFFSET
 			}
-		case 141:
+		case 150:
 			{
 				yylex.logToken(yylex.Text(), "ON")
 				return ON
 			}
-		case 142:
+		case 151:
 			{
 				yylex.logToken(yylex.Text(), "OPTION")
 				return OPTION
 			}
-		case 143:
+		case 152:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "OPTIONS")
+				return OPTIONS
+			}
+		case 153:
 			{
 				yylex.logToken(yylex.Text(), "OR")
 				return OR
 			}
-		case 144:
+		case 154:
 			{
 				yylex.logToken(yylex.Text(), "ORDER")
 				return ORDER
 			}
-		case 145:
+		case 155:
 			{
 				yylex.logToken(yylex.Text(), "OUTER")
 				return OUTER
 			}
-		case 146:
+		case 156:
 			{
 				yylex.logToken(yylex.Text(), "OVER")
 				return OVER
 			}
-		case 147:
+		case 157:
 			{
 				yylex.logToken(yylex.Text(), "PARSE")
 				return PARSE
 			}
-		case 148:
+		case 158:
 			{
 				yylex.logToken(yylex.Text(), "PARTITION")
 				return PARTITION
 			}
-		case 149:
+		case 159:
 			{
 				yylex.logToken(yylex.Text(), "PASSWORD")
 				return PASSWORD
 			}
-		case 150:
+		case 160:
 			{
 				yylex.logToken(yylex.Text(), "PATH")
 				return PATH
 			}
-		case 151:
+		case 161:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "PERCENT")
+				return PERCENT
+			}
+		case 162:
 			{
 				yylex.logToken(yylex.Text(), "POOL")
 				return POOL
 			}
-		case 152:
+		case 163:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "PRECEDING")
+				return PRECEDING
+			}
+		case 164:
 			{
 				yylex.logToken(yylex.Text(), "PREPARE")
 				lval.tokOffset = yylex.curOffset
 				return PREPARE
 			}
-		case 153:
+		case 165:
 			{
 				yylex.logToken(yylex.Text(), "PRIMARY")
 				return PRIMARY
 			}
-		case 154:
+		case 166:
 			{
 				yylex.logToken(yylex.Text(), "PRIVATE")
 				return PRIVATE
 			}
-		case 155:
+		case 167:
 			{
 				yylex.logToken(yylex.Text(), "PRIVILEGE")
 				return PRIVILEGE
 			}
-		case 156:
+		case 168:
 			{
 				yylex.logToken(yylex.Text(), "PROCEDURE")
 				return PROCEDURE
 			}
-		case 157:
+		case 169:
 			{
 				yylex.logToken(yylex.Text(), "PROBE")
 				return PROBE
 			}
-		case 158:
+		case 170:
 			{
 				yylex.logToken(yylex.Text(), "PUBLIC")
 				return PUBLIC
 			}
-		case 159:
+		case 171:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "RANGE")
+				return RANGE
+			}
+		case 172:
 			{
 				yylex.logToken(yylex.Text(), "RAW")
 				return RAW
 			}
-		case 160:
+		case 173:
 			{
 				yylex.logToken(yylex.Text(), "REALM")
 				return REALM
 			}
-		case 161:
+		case 174:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "RECURSIVE")
+				return RECURSIVE
+			}
+		case 175:
 			{
 				yylex.logToken(yylex.Text(), "REDUCE")
 				return REDUCE
 			}
-		case 162:
+		case 176:
 			{
 				yylex.logToken(yylex.Text(), "RENAME")
 				return RENAME
 			}
-		case 163:
+		case 177:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "RESTRICT")
+				return RESTRICT
+			}
+		case 178:
 			{
 				yylex.logToken(yylex.Text(), "RETURN")
 				return RETURN
 			}
-		case 164:
+		case 179:
 			{
 				yylex.logToken(yylex.Text(), "RETURNING")
 				return RETURNING
 			}
-		case 165:
+		case 180:
 			{
 				yylex.logToken(yylex.Text(), "REVOKE")
 				return REVOKE
 			}
-		case 166:
+		case 181:
 			{
 				yylex.logToken(yylex.Text(), "RIGHT")
 				return RIGHT
 			}
-		case 167:
+		case 182:
 			{
 				yylex.logToken(yylex.Text(), "ROLE")
 				return ROLE
 			}
-		case 168:
+		case 183:
 			{
 				yylex.logToken(yylex.Text(), "ROLLBACK")
 				return ROLLBACK
 			}
-		case 169:
+		case 184:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "ROLLUP")
+				return ROLLUP
+			}
+		case 185:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "ROW")
+				return ROW
+			}
+		case 186:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "ROWS")
+				return ROWS
+			}
+		case 187:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "SAMPLE")
+				return SAMPLE
+			}
+		case 188:
 			{
 				yylex.logToken(yylex.Text(), "SATISFIES")
 				return SATISFIES
 			}
-		case 170:
+		case 189:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "SAVEPOINT")
+				return SAVEPOINT
+			}
+		case 190:
 			{
 				yylex.logToken(yylex.Text(), "SCHEMA")
 				return SCHEMA
 			}
-		case 171:
+		case 191:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "SEED")
+				return SEED
+			}
+		case 192:
 			{
 				yylex.logToken(yylex.Text(), "SELECT")
 				return SELECT
 			}
-		case 172:
+		case 193:
 			{
 				yylex.logToken(yylex.Text(), "SELF")
 				return SELF
 			}
-		case 173:
+		case 194:
 			{
 				yylex.logToken(yylex.Text(), "SET")
 				return SET
 			}
-		case 174:
+		case 195:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "SETS")
+				return SETS
+			}
+		case 196:
 			{
 				yylex.logToken(yylex.Text(), "SHOW")
 				return SHOW
 			}
-		case 175:
+		case 197:
 			{
 				yylex.logToken(yylex.Text(), "SOME")
 				return SOME
 			}
-		case 176:
+		case 198:
 			{
 				yylex.logToken(yylex.Text(), "START")
 				return START
 			}
-		case 177:
+		case 199:
 			{
 				yylex.logToken(yylex.Text(), "STATISTICS")
 				return STATISTICS
 			}
-		case 178:
+		case 200:
 			{
 				yylex.logToken(yylex.Text(), "STRING")
 				return STRING
 			}
-		case 179:
+		case 201:
 			{
 				yylex.logToken(yylex.Text(), "SYSTEM")
 				return SYSTEM
 			}
-		case 180:
+		case 202:
 			{
 				yylex.logToken(yylex.Text(), "THEN")
 				return THEN
 			}
-		case 181:
+		case 203:
 			{
 				yylex.logToken(yylex.Text(), "TO")
 				return TO
 			}
-		case 182:
+		case 204:
 			{
 				yylex.logToken(yylex.Text(), "TRANSACTION")
 				return TRANSACTION
 			}
-		case 183:
+		case 205:
 			{
 				yylex.logToken(yylex.Text(), "TRIGGER")
 				return TRIGGER
 			}
-		case 184:
+		case 206:
 			{
 				yylex.logToken(yylex.Text(), "TRUE")
 				return TRUE
 			}
-		case 185:
+		case 207:
 			{
 				yylex.logToken(yylex.Text(), "TRUNCATE")
 				return TRUNCATE
 			}
-		case 186:
+		case 208:
+			{
+				lval.s = yylex.Text()
+				yylex.logToken(yylex.Text(), "UNBOUNDED")
+				return UNBOUNDED
+			}
+		case 209:
 			{
 				yylex.logToken(yylex.Text(), "UNDER")
 				return UNDER
 			}
-		case 187:
+		case 210:
 			{
 				yylex.logToken(yylex.Text(), "UNION")
 				return UNION
 			}
-		case 188:
+		case 211:
 			{
 				yylex.logToken(yylex.Text(), "UNIQUE")
 				return UNIQUE
 			}
-		case 189:
+		case 212:
 			{
 				yylex.logToken(yylex.Text(), "UNKNOWN")
 				return UNKNOWN
 			}
-		case 190:
+		case 213:
 			{
 				yylex.logToken(yylex.Text(), "UNNEST")
 				return UNNEST
 			}
-		case 191:
+		case 214:
 			{
 				yylex.logToken(yylex.Text(), "UNSET")
 				return UNSET
 			}
-		case 192:
+		case 215:
 			{
 				yylex.logToken(yylex.Text(), "UPDATE")
 				return UPDATE
 			}
-		case 193:
+		case 216:
 			{
 				yylex.logToken(yylex.Text(), "UPSERT")
 				return UPSERT
 			}
-		case 194:
+		case 217:
 			{
 				yylex.logToken(yylex.Text(), "USE")
 				return USE
 			}
-		case 195:
+		case 218:
 			{
 				yylex.logToken(yylex.Text(), "USER")
 				return USER
 			}
-		case 196:
+		case 219:
 			{
 				yylex.logToken(yylex.Text(), "USING")
 				return USING
 			}
-		case 197:
+		case 220:
 			{
 				yylex.logToken(yylex.Text(), "VALIDATE")
 				return VALIDATE
 			}
-		case 198:
+		case 221:
 			{
 				yylex.logToken(yylex.Text(), "VALUE")
 				return VALUE
 			}
-		case 199:
+		case 222:
 			{
 				yylex.logToken(yylex.Text(), "VALUED")
 				return VALUED
 			}
-		case 200:
+		case 223:
 			{
 				yylex.logToken(yylex.Text(), "VALUES")
 				return VALUES
 			}
-		case 201:
+		case 224:
 			{
 				yylex.logToken(yylex.Text(), "VIA")
 				return VIA
 			}
-		case 202:
+		case 225:
 			{
 				yylex.logToken(yylex.Text(), "VIEW")
 				return VIEW
 			}
-		case 203:
+		case 226:
 			{
 				yylex.logToken(yylex.Text(), "WHEN")
 				return WHEN
 			}
-		case 204:
+		case 227:
 			{
 				yylex.logToken(yylex.Text(), "WHERE")
 				return WHERE
 			}
-		case 205:
+		case 228:
 			{
 				yylex.logToken(yylex.Text(), "WHILE")
 				return WHILE
 			}
-		case 206:
+		case 229:
 			{
 				yylex.logToken(yylex.Text(), "WITH")
 				return WITH
 			}
-		case 207:
+		case 230:
+			{
+				yylex.logToken(yylex.Text(), "WITHIN_GROUP")
+				return WITHIN_GROUP
+			}
+		case 231:
 			{
 				yylex.logToken(yylex.Text(), "WITHIN")
 				return WITHIN
 			}
-		case 208:
+		case 232:
 			{
 				yylex.logToken(yylex.Text(), "WORK")
 				return WORK
 			}
-		case 209:
+		case 233:
 			{
 				yylex.logToken(yylex.Text(), "XOR")
 				return XOR
 			}
-		case 210:
+		case 234:
 			{
 				lval.s = yylex.Text()
 				yylex.logToken(yylex.Text(), "IDENT - %s", lval.s)
 				return IDENT
 			}
-		case 211:
+		case 235:
 			{
 				lval.s = yylex.Text()[1:]
 				yylex.logToken(yylex.Text(), "NAMED_PARAM - %s", lval.s)
 				return NAMED_PARAM
 			}
-		case 212:
+		case 236:
 			{
 				lval.n, _ = strconv.ParseInt(yylex.Text()[1:], 10, 64)
 				yylex.logToken(yylex.Text(), "POSITIONAL_PARAM - %d", lval.n)
 				return POSITIONAL_PARAM
 			}
-		case 213:
+		case 237:
 			{
 				lval.n = 0 // Handled by parser
 				yylex.logToken(yylex.Text(), "NEXT_PARAM - ?")
 				return NEXT_PARAM
 			}
-		case 214:
+		case 238:
 			{
 				yylex.curOffset++
 			}
-		case 215:
+		case 239:
 			{
 				yylex.curOffset++
 			}
-		case 216:
+		case 240:
 			{
 				yylex.curOffset++
 			}
-		case 217:
+		case 241:
 			{
 				/* this we don't know what it is: we'll let
 				   the parser handle it (and most probably throw a syntax error
@@ -41,18 +41,25 @@ fromTerm         algebra.FromTerm
 simpleFromTerm   algebra.SimpleFromTerm
 keyspaceTerm     *algebra.KeyspaceTerm
 use              *algebra.Use
+sample           *algebra.Sample
 joinHint         algebra.JoinHint
 indexRefs        algebra.IndexRefs
 indexRef         *algebra.IndexRef
 subqueryTerm     *algebra.SubqueryTerm
 path             expression.Path
 group            *algebra.Group
+groupTerm        *algebra.GroupTerm
+groupTerms       algebra.GroupTerms
+exprsList        []expression.Expressions
 resultTerm       *algebra.ResultTerm
 resultTerms      algebra.ResultTerms
 projection       *algebra.Projection
 order            *algebra.Order
 sortTerm         *algebra.SortTerm
 sortTerms        algebra.SortTerms
+windowTerm       *algebra.WindowTerm
+windowFrame      *algebra.WindowFrame
+windowExtent     *algebra.WindowFrameExtent
 indexKeyTerm    *algebra.IndexKeyTerm
 indexKeyTerms    algebra.IndexKeyTerms
 partitionTerm   *algebra.IndexPartitionTerm
@@ -73,6 +80,8 @@ mergeUpdate      *algebra.MergeUpdate
 mergeDelete      *algebra.MergeDelete
 mergeInsert      *algebra.MergeInsert
 
+optimHints       *algebra.OptimHints
+
 indexType        datastore.IndexType
 inferenceType    datastore.InferenceType
 val              value.Value
@@ -82,6 +91,7 @@ tokOffset	 int
 }
 
 %token _ERROR_	// used by the scanner to flag errors
+%token ADVISE
 %token ALL
 %token ALTER
 %token ANALYZE
@@ -110,6 +120,9 @@ tokOffset	 int
 %token CORRELATED
 %token COVER
 %token CREATE
+%token CUBE
+%token CURRENT
+%token CYCLE
 %token DATABASE
 %token DATASET
 %token DATASTORE
@@ -134,15 +147,19 @@ tokOffset	 int
 %token EXPLAIN
 %token FALSE
 %token FETCH
+%token FILTER
 %token FIRST
 %token FLATTEN
+%token FOLLOWING
 %token FOR
 %token FORCE
 %token FROM
 %token FTS
+%token FULL
 %token FUNCTION
 %token GRANT
 %token GROUP
+%token GROUPING
 %token GSI
 %token HASH
 %token HAVING
@@ -185,11 +202,14 @@ tokOffset	 int
 %token NOT
 %token NOT_A_TOKEN
 %token NULL
+%token NULLS
 %token NUMBER
 %token OBJECT
 %token OFFSET
 %token ON
+%token OPTIM_HINTS
 %token OPTION
+%token OPTIONS
 %token OR
 %token ORDER
 %token OUTER
@@ -198,7 +218,9 @@ tokOffset	 int
 %token PARTITION
 %token PASSWORD
 %token PATH
+%token PERCENT
 %token POOL
+%token PRECEDING
 %token PREPARE
 %token PRIMARY
 %token PRIVATE
@@ -206,22 +228,32 @@ tokOffset	 int
 %token PROBE
 %token PROCEDURE
 %token PUBLIC
+%token RANGE
 %token RAW
 %token REALM
+%token RECURSIVE
 %token REDUCE
 %token RENAME
+%token RESTRICT
 %token RETURN
 %token RETURNING
 %token REVOKE
 %token RIGHT
 %token ROLE
+%token ROLLUP
 %token ROLLBACK
+%token ROW
+%token ROWS
+%token SAMPLE
 %token SATISFIES
+%token SAVEPOINT
 %token SCHEMA
+%token SEED
 %token SELECT
 %token SELF
 %token SEMI
 %token SET
+%token SETS
 %token SHOW
 %token SOME
 %token START
@@ -234,6 +266,7 @@ tokOffset	 int
 %token TRIGGER
 %token TRUE
 %token TRUNCATE
+%token UNBOUNDED
 %token UNDER
 %token UNION
 %token UNIQUE
@@ -256,6 +289,7 @@ tokOffset	 int
 %token WHILE
 %token WITH
 %token WITHIN
+%token WITHIN_GROUP
 %token WORK
 %token XOR
 
@@ -266,8 +300,10 @@ tokOffset	 int
 
 /* Precedence: lowest to highest */
 %left           ORDER
+%nonassoc       UNBOUNDED                       /* keywords used as identifiers yield to the clauses they begin */
+%nonassoc       PRECEDING FOLLOWING FILTER SAMPLE
 %left           UNION INTERESECT EXCEPT
-%left           JOIN NEST UNNEST FLATTEN INNER LEFT RIGHT
+%left           JOIN NEST UNNEST FLATTEN INNER LEFT RIGHT FULL
 %left           OR
 %left           AND
 %right          NOT
@@ -294,7 +330,11 @@ tokOffset	 int
 /* Types */
 %type <s>                STR
 %type <s>                IDENT IDENT_ICASE
+%type <s>                ADVISE CUBE CURRENT CYCLE FILTER FOLLOWING FULL GROUPING
+%type <s>                NULLS OPTIONS PERCENT PRECEDING RANGE RECURSIVE RESTRICT ROLLUP
+%type <s>                ROW ROWS SAMPLE SAVEPOINT SEED SETS UNBOUNDED
 %type <s>                NAMED_PARAM
+%type <s>                OPTIM_HINTS
 %type <f>                NUM
 %type <n>                INT
 %type <n>                POSITIONAL_PARAM NEXT_PARAM
@@ -309,6 +349,7 @@ tokOffset	 int
 %type <bindings>         bindings
 
 %type <s>                alias as_alias opt_as_alias variable opt_name
+%type <s>                ident nonreserved_keyword
 
 %type <expr>             case_expr simple_or_searched_case simple_case searched_case opt_else
 %type <whenTerms>        when_thens
@@ -321,6 +362,12 @@ tokOffset	 int
 
 %type <expr>             function_expr
 %type <s>                function_name
+%type <windowTerm>       opt_window_clause
+%type <expr>             opt_filter
+%type <exprs>            opt_window_partition
+%type <windowFrame>      opt_window_frame
+%type <n>                window_frame_units
+%type <windowExtent>     window_frame_extent
 
 %type <expr>             paren_expr
 %type <subquery>         subquery_expr
@@ -338,29 +385,45 @@ tokOffset	 int
 %type <s>                namespace_name keyspace_name namespace_term
 %type <use>              opt_use opt_use_del_upd opt_use_merge use_options use_keys use_index join_hint
 %type <joinHint>         use_hash_option
+%type <sample>           opt_sample
+%type <expr>             opt_sample_seed
 %type <expr>             on_keys on_key
 %type <indexRefs>        index_refs
 %type <indexRef>         index_ref
 %type <bindings>         opt_let let
+%type <bindings>         with with_list recursive_with_list
+%type <binding>          with_term recursive_with_term
+%type <exprs>            opt_cycle
+%type <expr>             opt_with_options
 %type <expr>             opt_where where
 %type <group>            opt_group group
+%type <groupTerm>        group_term
+%type <groupTerms>       group_terms
+%type <exprsList>        grouping_sets
+%type <exprs>            grouping_set
 %type <bindings>         opt_letting letting
 %type <expr>             opt_having having
 %type <resultTerm>       project
 %type <resultTerms>      projects
-%type <projection>       projection select_clause
+%type <projection>       projection
+%type <optimHints>       opt_optim_hints
 %type <order>            order_by opt_order_by
 %type <sortTerm>         sort_term
-%type <sortTerms>        sort_terms
+%type <sortTerms>        sort_terms opt_within_group
 %type <expr>             limit opt_limit
 %type <expr>             offset opt_offset
 %type <b>                dir opt_dir
+%type <n>                opt_nulls
 
-%type <statement>        stmt explain prepare execute select_stmt dml_stmt ddl_stmt
+%type <statement>        stmt explain advise prepare execute select_stmt dml_stmt ddl_stmt
 %type <statement>        infer infer_keyspace
-%type <statement>        insert upsert delete update merge
+%type <statement>        insert upsert delete update update_term merge truncate
 %type <statement>        index_stmt create_index drop_index alter_index build_index
 %type <statement>        role_stmt grant_role revoke_role
+%type <statement>        transaction_stmt start_transaction commit_transaction rollback_transaction savepoint
+%type <statement>        function_stmt create_function drop_function execute_function
+%type <statement>        update_statistics
+%type <ss>               opt_parameter_list parameter_list
 
 %type <keyspaceRef>      keyspace_ref
 %type <pairs>            values values_list next_values
@@ -435,13 +498,19 @@ ddl_stmt
 |
 explain
 |
+advise
+|
 prepare
 |
 execute
 |
+execute_function
+|
 infer
 |
 role_stmt
+|
+transaction_stmt
 ;
 
 explain:
@@ -451,6 +520,23 @@ EXPLAIN stmt
 }
 ;
 
+advise:
+ADVISE select_stmt
+{
+    $$ = algebra.NewAdvise($2, yylex.(*lexer).Remainder($<tokOffset>1))
+}
+|
+ADVISE dml_stmt
+{
+    $$ = algebra.NewAdvise($2, yylex.(*lexer).Remainder($<tokOffset>1))
+}
+|
+ADVISE ALL
+{
+    $$ = algebra.NewAdviseAll()
+}
+;
+
 prepare:
 PREPARE opt_name stmt
 {
@@ -538,6 +624,12 @@ fullselect
 {
     $$ = $1
 }
+|
+with fullselect
+{
+    $2.SetWith($1)
+    $$ = $2
+}
 ;
 
 dml_stmt:
@@ -550,10 +642,40 @@ delete
 update
 |
 merge
+|
+truncate
+|
+with insert
+{
+    $2.(*algebra.Insert).SetWith($1)
+    $$ = $2
+}
+|
+with upsert
+{
+    $2.(*algebra.Upsert).SetWith($1)
+    $$ = $2
+}
+|
+with delete
+{
+    $2.(*algebra.Delete).SetWith($1)
+    $$ = $2
+}
+|
+with update
+{
+    $2.(*algebra.Update).SetWith($1)
+    $$ = $2
+}
 ;
 
 ddl_stmt:
 index_stmt
+|
+function_stmt
+|
+update_statistics
 ;
 
 role_stmt:
@@ -681,34 +803,128 @@ select_from
 ;
 
 from_select:
-from opt_let opt_where opt_group select_clause
+from opt_let opt_where opt_group SELECT opt_optim_hints projection
 {
-    $$ = algebra.NewSubselect($1, $2, $3, $4, $5)
+    $$ = algebra.NewSubselect($1, $2, $3, $4, $7)
+    $$.SetOptimHints($6)
 }
 ;
 
 select_from:
-select_clause opt_from opt_let opt_where opt_group
+SELECT opt_optim_hints projection opt_from opt_let opt_where opt_group
 {
-    $$ = algebra.NewSubselect($2, $3, $4, $5, $1)
+    $$ = algebra.NewSubselect($4, $5, $6, $7, $3)
+    $$.SetOptimHints($2)
 }
 ;
 
 
 /*************************************************
  *
- * SELECT clause
+ * WITH clause
  *
  *************************************************/
 
-select_clause:
-SELECT
-projection
+with:
+WITH with_list
 {
     $$ = $2
 }
+|
+WITH RECURSIVE recursive_with_list
+{
+    $$ = $3
+}
 ;
 
+with_list:
+with_term
+{
+    $$ = expression.Bindings{$1}
+}
+|
+with_list COMMA with_term
+{
+    $$ = append($1, $3)
+}
+;
+
+with_term:
+alias AS LPAREN fullselect RPAREN
+{
+    $$ = expression.NewSimpleBinding($1, algebra.NewSubquery($4))
+}
+;
+
+recursive_with_list:
+recursive_with_term
+{
+    $$ = expression.Bindings{$1}
+}
+|
+recursive_with_list COMMA recursive_with_term
+{
+    $$ = append($1, $3)
+}
+;
+
+recursive_with_term:
+alias AS LPAREN fullselect RPAREN opt_cycle opt_with_options
+{
+    $$ = newRecursiveWith(yylex, $1, $4, $6, $7)
+}
+;
+
+opt_cycle:
+/* empty */
+{
+    $$ = nil
+}
+|
+CYCLE exprs RESTRICT
+{
+    $$ = $2
+}
+;
+
+opt_with_options:
+/* empty */
+{
+    $$ = nil
+}
+|
+OPTIONS object
+{
+    $$ = $2
+}
+;
+
+
+/*************************************************
+ *
+ * Optimizer hints
+ *
+ *************************************************/
+
+opt_optim_hints:
+/* empty */
+{
+    $$ = nil
+}
+|
+OPTIM_HINTS
+{
+    $$ = newOptimHints($1)
+}
+;
+
+
+/*************************************************
+ *
+ * SELECT clause
+ *
+ *************************************************/
+
 projection:
 projects
 {
@@ -774,7 +990,7 @@ expr opt_as_alias
 ;
 
 opt_as_alias:
-/* empty */
+/* empty */ %prec FULL
 {
     $$ = ""
 }
@@ -792,7 +1008,7 @@ AS alias
 ;
 
 alias:
-IDENT
+ident
 ;
 
 
@@ -814,6 +1030,9 @@ from
 from:
 FROM from_term
 {
+    if first, ok := $2.PrimaryTerm().(algebra.SimpleFromTerm); ok && first.JoinHint() != algebra.JOIN_HINT_NONE {
+        yylex.Error(fmt.Sprintf("Join hint (USE HASH or USE NL) cannot be specified on the first from term %s", first.Alias()))
+    }
     $$ = $2
 }
 ;
@@ -821,9 +1040,6 @@ FROM from_term
 from_term:
 simple_from_term
 {
-    if $1.JoinHint() != algebra.JOIN_HINT_NONE {
-        yylex.Error(fmt.Sprintf("Join hint (USE HASH or USE NL) cannot be specified on the first from term %s", $1.Alias()))
-    }
     $$ = $1
 }
 |
@@ -886,10 +1102,22 @@ from_term opt_join_type NEST simple_from_term ON expr
     $$ = algebra.NewAnsiNest($1, $2, $4, $6)
 }
 |
-simple_from_term RIGHT opt_outer JOIN simple_from_term ON expr
+from_term RIGHT opt_outer JOIN simple_from_term ON expr
 {
-    $1.SetAnsiJoin()
-    $$ = algebra.NewAnsiRightJoin($1, $5, $7)
+    switch left := $1.(type) {
+    case algebra.SimpleFromTerm:
+        left.SetAnsiJoin()
+        $$ = algebra.NewAnsiRightJoin(left, $5, $7)
+    default:
+        $5.SetAnsiJoin()
+        $$ = algebra.NewAnsiRightOuterJoin($1, false, $5, $7)
+    }
+}
+|
+from_term FULL opt_outer JOIN simple_from_term ON expr
+{
+    $5.SetAnsiJoin()
+    $$ = algebra.NewAnsiRightOuterJoin($1, true, $5, $7)
 }
 ;
 
@@ -899,7 +1127,7 @@ keyspace_term
     $$ = $1
 }
 |
-expr opt_as_alias opt_use
+expr opt_as_alias opt_use opt_sample
 {
      switch other := $1.(type) {
          case *algebra.Subquery:
@@ -909,14 +1137,21 @@ expr opt_as_alias opt_use
               if $3.Keys() != nil || $3.Indexes() != nil {
                    yylex.Error("FROM Subquery cannot have USE KEYS or USE INDEX.")
               }
+              if $4 != nil {
+                   yylex.Error("FROM Subquery cannot have SAMPLE.")
+              }
               $$ = algebra.NewSubqueryTerm(other.Select(), $2, $3.JoinHint())
          case *expression.Identifier:
               ksterm := algebra.NewKeyspaceTerm("", other.Alias(), $2, $3.Keys(), $3.Indexes())
+              ksterm.SetSample($4)
               $$ = algebra.NewExpressionTerm(other, $2, ksterm, other.Parenthesis() == false, $3.JoinHint())
          default:
               if $3.Keys() != nil || $3.Indexes() != nil {
                   yylex.Error("FROM Expression cannot have USE KEYS or USE INDEX.")
               }
+              if $4 != nil {
+                  yylex.Error("FROM Expression cannot have SAMPLE.")
+              }
               $$ = algebra.NewExpressionTerm(other, $2, nil, false, $3.JoinHint())
      }
 }
@@ -929,16 +1164,46 @@ FLATTEN
 ;
 
 keyspace_term:
-namespace_term COLON keyspace_name opt_as_alias opt_use
+namespace_term COLON keyspace_name opt_as_alias opt_use opt_sample
 {
     ksterm := algebra.NewKeyspaceTerm($1, $3, $4, $5.Keys(), $5.Indexes())
     if $5.JoinHint() != algebra.JOIN_HINT_NONE {
         ksterm.SetJoinHint($5.JoinHint())
     }
+    ksterm.SetSample($6)
     $$ = ksterm
 }
 ;
 
+opt_sample:
+/* empty */
+{
+    $$ = nil
+}
+|
+SAMPLE expr PERCENT opt_sample_seed
+{
+    $$ = algebra.NewSample($2, false, $4)
+}
+|
+SAMPLE expr ROWS opt_sample_seed
+{
+    $$ = algebra.NewSample($2, true, $4)
+}
+;
+
+opt_sample_seed:
+/* empty */
+{
+    $$ = nil
+}
+|
+SEED expr
+{
+    $$ = $2
+}
+;
+
 namespace_term:
 namespace_name
 |
@@ -953,7 +1218,13 @@ IDENT
 ;
 
 keyspace_name:
-IDENT
+ident
+|
+STATISTICS
+{
+    /* keyword allowed as a keyspace name, for system:statistics */
+    $$ = "statistics"
+}
 ;
 
 opt_use:
@@ -1193,9 +1464,9 @@ group
 ;
 
 group:
-GROUP BY exprs opt_letting opt_having
+GROUP BY group_terms opt_letting opt_having
 {
-    $$ = algebra.NewGroup($3, $4, $5)
+    $$ = algebra.NewGroupTerms($3, $4, $5)
 }
 |
 letting
@@ -1204,6 +1475,59 @@ letting
 }
 ;
 
+group_terms:
+group_term
+{
+    $$ = algebra.GroupTerms{$1}
+}
+|
+group_terms COMMA group_term
+{
+    $$ = append($1, $3)
+}
+;
+
+group_term:
+expr
+{
+    $$ = algebra.NewGroupTerm($1)
+}
+|
+ROLLUP LPAREN exprs RPAREN
+{
+    $$ = algebra.NewRollup($3)
+}
+|
+CUBE LPAREN exprs RPAREN
+{
+    $$ = algebra.NewCube($3)
+}
+|
+GROUPING SETS LPAREN grouping_sets RPAREN
+{
+    $$ = algebra.NewGroupingSets($4)
+}
+;
+
+grouping_sets:
+grouping_set
+{
+    $$ = []expression.Expressions{$1}
+}
+|
+grouping_sets COMMA grouping_set
+{
+    $$ = append($1, $3)
+}
+;
+
+grouping_set:
+LPAREN opt_exprs RPAREN
+{
+    $$ = $2
+}
+;
+
 exprs:
 expr
 {
@@ -1284,9 +1608,9 @@ sort_terms COMMA sort_term
 ;
 
 sort_term:
-expr opt_dir
+expr opt_dir opt_nulls
 {
-    $$ = algebra.NewSortTerm($1, $2)
+    $$ = algebra.NewSortTerm($1, $2, int($3))
 }
 ;
 
@@ -1311,6 +1635,23 @@ DESC
 }
 ;
 
+opt_nulls:
+/* empty */
+{
+    $$ = algebra.NULLS_DEFAULT
+}
+|
+NULLS FIRST
+{
+    $$ = algebra.NULLS_FIRST
+}
+|
+NULLS LAST
+{
+    $$ = algebra.NULLS_LAST
+}
+;
+
 
 /*************************************************
  *
@@ -1513,12 +1854,109 @@ UPSERT INTO keyspace_ref LPAREN key_expr opt_value_expr RPAREN fullselect opt_re
  *************************************************/
 
 delete:
-DELETE FROM keyspace_ref opt_use_del_upd opt_where opt_limit opt_returning
+DELETE opt_optim_hints FROM keyspace_ref opt_use_del_upd opt_where opt_limit opt_returning
+{
+    delete := algebra.NewDelete($4, $5.Keys(), $5.Indexes(), $6, $7, $8)
+    delete.SetOptimHints($2)
+    $$ = delete
+}
+;
+
+
+/*************************************************
+ *
+ * UPDATE STATISTICS
+ *
+ *************************************************/
+
+update_statistics:
+UPDATE STATISTICS FOR named_keyspace_ref LPAREN exprs RPAREN
+{
+    $$ = algebra.NewUpdateStatistics($4, $6)
+}
+|
+ANALYZE opt_keyspace named_keyspace_ref LPAREN exprs RPAREN
+{
+    $$ = algebra.NewUpdateStatistics($3, $5)
+}
+;
+
+/*************************************************
+ *
+ * TRUNCATE
+ *
+ *************************************************/
+
+truncate:
+TRUNCATE opt_keyspace named_keyspace_ref
+{
+    $$ = algebra.NewTruncate($3)
+}
+;
+
+
+/*************************************************
+ *
+ * TRANSACTIONS
+ *
+ *************************************************/
+
+transaction_stmt:
+start_transaction
+|
+commit_transaction
+|
+rollback_transaction
+|
+savepoint
+;
+
+start_transaction:
+START TRANSACTION
+{
+    $$ = algebra.NewStartTransaction()
+}
+|
+BEGIN opt_transaction
 {
-    $$ = algebra.NewDelete($3, $4.Keys(), $4.Indexes(), $5, $6, $7)
+    $$ = algebra.NewStartTransaction()
 }
 ;
 
+commit_transaction:
+COMMIT opt_transaction
+{
+    $$ = algebra.NewCommitTransaction()
+}
+;
+
+rollback_transaction:
+ROLLBACK opt_transaction
+{
+    $$ = algebra.NewRollbackTransaction("")
+}
+|
+ROLLBACK opt_transaction TO SAVEPOINT IDENT
+{
+    $$ = algebra.NewRollbackTransaction($5)
+}
+;
+
+savepoint:
+SAVEPOINT IDENT
+{
+    $$ = algebra.NewSavepoint($2)
+}
+;
+
+opt_transaction:
+/* empty */
+|
+WORK
+|
+TRANSACTION
+;
+
 
 /*************************************************
  *
@@ -1526,20 +1964,35 @@ DELETE FROM keyspace_ref opt_use_del_upd opt_where opt_limit opt_returning
  *
  *************************************************/
 
+/* OPTIM_HINTS is not optional here, so that UPDATE STATISTICS is told
+   apart from UPDATE statistics by the token after STATISTICS */
 update:
-UPDATE keyspace_ref opt_use_del_upd set unset opt_where opt_limit opt_returning
+UPDATE update_term
 {
-    $$ = algebra.NewUpdate($2, $3.Keys(), $3.Indexes(), $4, $5, $6, $7, $8)
+    $$ = $2
 }
 |
-UPDATE keyspace_ref opt_use_del_upd set opt_where opt_limit opt_returning
+UPDATE OPTIM_HINTS update_term
+{
+    $3.(*algebra.Update).SetOptimHints(newOptimHints($2))
+    $$ = $3
+}
+;
+
+update_term:
+keyspace_ref opt_use_del_upd set unset opt_where opt_limit opt_returning
 {
-    $$ = algebra.NewUpdate($2, $3.Keys(), $3.Indexes(), $4, nil, $5, $6, $7)
+    $$ = algebra.NewUpdate($1, $2.Keys(), $2.Indexes(), $3, $4, $5, $6, $7)
 }
 |
-UPDATE keyspace_ref opt_use_del_upd unset opt_where opt_limit opt_returning
+keyspace_ref opt_use_del_upd set opt_where opt_limit opt_returning
 {
-    $$ = algebra.NewUpdate($2, $3.Keys(), $3.Indexes(), nil, $4, $5, $6, $7)
+    $$ = algebra.NewUpdate($1, $2.Keys(), $2.Indexes(), $3, nil, $4, $5, $6)
+}
+|
+keyspace_ref opt_use_del_upd unset opt_where opt_limit opt_returning
+{
+    $$ = algebra.NewUpdate($1, $2.Keys(), $2.Indexes(), nil, $3, $4, $5, $6)
 }
 ;
 
@@ -1683,21 +2136,26 @@ path opt_update_for
  *************************************************/
 
 merge:
-MERGE INTO keyspace_ref opt_use_merge USING simple_from_term ON opt_key expr merge_actions opt_limit opt_returning
+MERGE opt_optim_hints INTO keyspace_ref opt_use_merge USING simple_from_term ON opt_key expr merge_actions opt_limit opt_returning
 {
-     switch other := $6.(type) {
+     var merge *algebra.Merge
+     switch other := $7.(type) {
          case *algebra.SubqueryTerm:
               source := algebra.NewMergeSourceSubquery(other)
-              $$ = algebra.NewMerge($3, $4.Indexes(), source, $8, $9, $10, $11, $12)
+              merge = algebra.NewMerge($4, $5.Indexes(), source, $9, $10, $11, $12, $13)
          case *algebra.ExpressionTerm:
               source := algebra.NewMergeSourceExpression(other)
-              $$ = algebra.NewMerge($3, $4.Indexes(), source, $8, $9, $10, $11, $12)
+              merge = algebra.NewMerge($4, $5.Indexes(), source, $9, $10, $11, $12, $13)
          case *algebra.KeyspaceTerm:
               source := algebra.NewMergeSourceFrom(other)
-              $$ = algebra.NewMerge($3, $4.Indexes(), source, $8, $9, $10, $11, $12)
+              merge = algebra.NewMerge($4, $5.Indexes(), source, $9, $10, $11, $12, $13)
          default:
 	      yylex.Error("MERGE source term is UNKNOWN.")
      }
+     if merge != nil {
+         merge.SetOptimHints($2)
+         $$ = merge
+     }
 }
 ;
 
@@ -2151,6 +2609,136 @@ index_names COMMA index_name
 ;
 
 
+/*************************************************
+ *
+ * CREATE FUNCTION
+ *
+ *************************************************/
+
+function_stmt:
+create_function
+|
+drop_function
+;
+
+create_function:
+CREATE FUNCTION function_name LPAREN opt_parameter_list RPAREN LBRACE expr RBRACE
+{
+    $$ = algebra.NewCreateFunction($3, $5, $8)
+}
+;
+
+opt_parameter_list:
+/* empty */
+{
+    $$ = nil
+}
+|
+parameter_list
+;
+
+parameter_list:
+IDENT
+{
+    $$ = []string{$1}
+}
+|
+parameter_list COMMA IDENT
+{
+    $$ = append($1, $3)
+}
+;
+
+/*************************************************
+ *
+ * DROP FUNCTION
+ *
+ *************************************************/
+
+drop_function:
+DROP FUNCTION function_name
+{
+    $$ = algebra.NewDropFunction($3)
+}
+;
+
+/*************************************************
+ *
+ * EXECUTE FUNCTION
+ *
+ *************************************************/
+
+execute_function:
+EXECUTE FUNCTION function_name LPAREN opt_exprs RPAREN
+{
+    $$ = newExecuteFunction(yylex, $3, $5)
+}
+;
+
+
+/*************************************************
+ *
+ * Identifier
+ *
+ *************************************************/
+
+/* Keywords added after the fact are not reserved, so that existing
+   field and keyspace names spelled like them continue to parse. */
+
+ident:
+IDENT
+|
+nonreserved_keyword
+;
+
+nonreserved_keyword:
+ADVISE
+|
+CUBE
+|
+CURRENT
+|
+CYCLE
+|
+FILTER
+|
+FOLLOWING
+|
+FULL
+|
+GROUPING
+|
+NULLS
+|
+OPTIONS
+|
+PERCENT
+|
+PRECEDING
+|
+RANGE
+|
+RECURSIVE
+|
+RESTRICT
+|
+ROLLUP
+|
+ROW
+|
+ROWS
+|
+SAMPLE
+|
+SAVEPOINT
+|
+SEED
+|
+SETS
+|
+UNBOUNDED
+;
+
 /*************************************************
  *
  * Path
@@ -2158,12 +2746,12 @@ index_names COMMA index_name
  *************************************************/
 
 path:
-IDENT
+ident
 {
     $$ = expression.NewIdentifier($1)
 }
 |
-path DOT IDENT
+path DOT ident
 {
     $$ = expression.NewField($1, expression.NewFieldName($3, false))
 }
@@ -2204,7 +2792,7 @@ expr:
 c_expr
 |
 /* Nested */
-expr DOT IDENT
+expr DOT ident
 {
     $$ = expression.NewField($1, expression.NewFieldName($3, false))
 }
@@ -2422,7 +3010,7 @@ literal
 construction_expr
 |
 /* Identifier */
-IDENT
+ident
 {
     $$ = expression.NewIdentifier($1)
 }
@@ -2479,7 +3067,7 @@ b_expr:
 c_expr
 |
 /* Nested */
-b_expr DOT IDENT
+b_expr DOT ident
 {
     $$ = expression.NewField($1, expression.NewFieldName($3, false))
 }
@@ -2771,48 +3359,175 @@ ELSE expr
  *************************************************/
 
 function_expr:
-function_name LPAREN opt_exprs RPAREN
+function_name LPAREN opt_exprs RPAREN opt_within_group opt_filter opt_window_clause
 {
     $$ = nil;
     f, ok := expression.GetFunction($1);
     if !ok {
         f, ok = algebra.GetAggregate($1, false);
     }
+    if !ok {
+        f, ok = algebra.GetWindowFunction($1);
+    }
 
     if ok {
         if len($3) < f.MinArgs() || len($3) > f.MaxArgs() {
             yylex.Error(fmt.Sprintf("Wrong number of arguments to function %s.", $1));
         } else {
-            $$ = f.Constructor()($3...);
+            $$ = setWindowTerm(yylex, setFilter(yylex, setWithinGroup(yylex, f.Constructor()($3...), $5, false), $6), $7);
         }
     } else {
         yylex.Error(fmt.Sprintf("Invalid function %s.", $1));
     }
 }
 |
-function_name LPAREN DISTINCT expr RPAREN
+function_name LPAREN exprs order_by RPAREN opt_filter opt_window_clause
+{
+    $$ = nil;
+    agg, ok := algebra.GetAggregate($1, false);
+    if ok {
+        if len($3) < agg.MinArgs() || len($3) > agg.MaxArgs() {
+            yylex.Error(fmt.Sprintf("Wrong number of arguments to function %s.", $1));
+        } else {
+            $$ = setWindowTerm(yylex, setFilter(yylex, setWithinGroup(yylex, agg.Constructor()($3...), $4.Terms(), true), $6), $7);
+        }
+    } else {
+        yylex.Error(fmt.Sprintf("Invalid aggregate function %s.", $1));
+    }
+}
+|
+function_name LPAREN DISTINCT expr RPAREN opt_filter opt_window_clause
 {
     agg, ok := algebra.GetAggregate($1, true);
     if ok {
-        $$ = agg.Constructor()($4);
+        $$ = setWindowTerm(yylex, setFilter(yylex, setWithinGroup(yylex, agg.Constructor()($4), nil, false), $6), $7);
     } else {
         yylex.Error(fmt.Sprintf("Invalid aggregate function %s.", $1));
     }
 }
 |
-function_name LPAREN STAR RPAREN
+function_name LPAREN STAR RPAREN opt_filter opt_window_clause
 {
     if strings.ToLower($1) != "count" {
         yylex.Error(fmt.Sprintf("Invalid aggregate function %s(*).", $1));
     } else {
         agg, ok := algebra.GetAggregate($1, false);
         if ok {
-            $$ = agg.Constructor()(nil);
+            $$ = setWindowTerm(yylex, setFilter(yylex, setWithinGroup(yylex, agg.Constructor()(nil), nil, false), $5), $6);
         } else {
             yylex.Error(fmt.Sprintf("Invalid aggregate function %s.", $1));
         }
     }
 }
+|
+GROUPING LPAREN exprs RPAREN
+{
+    $$ = algebra.NewGrouping($3...)
+}
+;
+
+opt_within_group:
+/* empty */
+{
+    $$ = nil
+}
+|
+WITHIN_GROUP LPAREN order_by RPAREN
+{
+    $$ = $3.Terms()
+}
+;
+
+opt_filter:
+/* empty */ %prec UNBOUNDED
+{
+    $$ = nil
+}
+|
+FILTER LPAREN WHERE expr RPAREN
+{
+    $$ = $4
+}
+;
+
+opt_window_clause:
+/* empty */
+{
+    $$ = nil
+}
+|
+OVER LPAREN opt_window_partition opt_order_by opt_window_frame RPAREN
+{
+    $$ = algebra.NewWindowTerm($3, $4, $5)
+}
+;
+
+opt_window_partition:
+/* empty */
+{
+    $$ = nil
+}
+|
+PARTITION BY exprs
+{
+    $$ = $3
+}
+;
+
+opt_window_frame:
+/* empty */
+{
+    $$ = nil
+}
+|
+window_frame_units window_frame_extent
+{
+    $$ = newWindowFrame(yylex, $1, $2, algebra.NewWindowFrameExtent(nil, algebra.WINDOW_CURRENT_ROW))
+}
+|
+window_frame_units BETWEEN window_frame_extent AND window_frame_extent
+{
+    $$ = newWindowFrame(yylex, $1, $3, $5)
+}
+;
+
+window_frame_units:
+ROWS
+{
+    $$ = algebra.WINDOW_FRAME_ROWS
+}
+|
+RANGE
+{
+    $$ = algebra.WINDOW_FRAME_RANGE
+}
+;
+
+window_frame_extent:
+UNBOUNDED PRECEDING
+{
+    $$ = algebra.NewWindowFrameExtent(nil, algebra.WINDOW_UNBOUNDED_PRECEDING)
+}
+|
+UNBOUNDED FOLLOWING
+{
+    $$ = algebra.NewWindowFrameExtent(nil, algebra.WINDOW_UNBOUNDED_FOLLOWING)
+}
+|
+CURRENT ROW
+{
+    $$ = algebra.NewWindowFrameExtent(nil, algebra.WINDOW_CURRENT_ROW)
+}
+|
+expr PRECEDING
+{
+    $$ = algebra.NewWindowFrameExtent($1, algebra.WINDOW_VALUE_PRECEDING)
+}
+|
+expr FOLLOWING
+{
+    $$ = algebra.NewWindowFrameExtent($1, algebra.WINDOW_VALUE_FOLLOWING)
+}
 ;
 
 function_name:
@@ -0,0 +1,119 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+)
+
+// The index advice for a statement, which is made by the planner
+type Advise struct {
+	readonly
+	advice map[string]interface{}
+}
+
+func NewAdvise(advice map[string]interface{}) *Advise {
+	return &Advise{
+		advice: advice,
+	}
+}
+
+func (this *Advise) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitAdvise(this)
+}
+
+func (this *Advise) New() Operator {
+	return &Advise{}
+}
+
+func (this *Advise) Advice() map[string]interface{} {
+	return this.advice
+}
+
+func (this *Advise) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *Advise) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "Advise"}
+	r["advice"] = this.advice
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *Advise) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_      string                 `json:"#operator"`
+		Advice map[string]interface{} `json:"advice"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	this.advice = _unmarshalled.Advice
+	return nil
+}
+
+// Advise on the statements of the completed requests, which are
+// read under the alias
+type AdviseAll struct {
+	readonly
+	alias string
+}
+
+func NewAdviseAll(alias string) *AdviseAll {
+	return &AdviseAll{
+		alias: alias,
+	}
+}
+
+func (this *AdviseAll) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitAdviseAll(this)
+}
+
+func (this *AdviseAll) New() Operator {
+	return &AdviseAll{}
+}
+
+func (this *AdviseAll) Alias() string {
+	return this.alias
+}
+
+func (this *AdviseAll) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *AdviseAll) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "AdviseAll"}
+	r["alias"] = this.alias
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *AdviseAll) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_     string `json:"#operator"`
+		Alias string `json:"alias"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	this.alias = _unmarshalled.Alias
+	return nil
+}
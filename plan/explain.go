@@ -15,14 +15,16 @@ import (
 
 type Explain struct {
 	readonly
-	op   Operator
-	text string
+	op         Operator
+	text       string
+	optimHints map[string]interface{}
 }
 
-func NewExplain(op Operator, text string) *Explain {
+func NewExplain(op Operator, text string, optimHints map[string]interface{}) *Explain {
 	return &Explain{
-		op:   op,
-		text: text,
+		op:         op,
+		text:       text,
+		optimHints: optimHints,
 	}
 }
 
@@ -38,6 +40,14 @@ func (this *Explain) Operator() Operator {
 	return this.op
 }
 
+/*
+Returns the optimizer hints that were followed, were not followed, and
+were invalid, or nil when the statement has no hints.
+*/
+func (this *Explain) OptimHints() map[string]interface{} {
+	return this.optimHints
+}
+
 func (this *Explain) MarshalJSON() ([]byte, error) {
 	return json.Marshal(this.MarshalBase(nil))
 }
@@ -46,6 +56,9 @@ func (this *Explain) MarshalBase(f func(map[string]interface{})) map[string]inte
 	r := make(map[string]interface{}, 2)
 	r["plan"] = this.op
 	r["text"] = this.text
+	if len(this.optimHints) > 0 {
+		r["optimizer_hints"] = this.optimHints
+	}
 	if f != nil {
 		f(r)
 	} else {
@@ -56,8 +69,9 @@ func (this *Explain) MarshalBase(f func(map[string]interface{})) map[string]inte
 
 func (this *Explain) UnmarshalJSON(body []byte) error {
 	var _unmarshalled struct {
-		Op   json.RawMessage `json:"plan"`
-		Text string          `json:"text"`
+		Op         json.RawMessage        `json:"plan"`
+		Text       string                 `json:"text"`
+		OptimHints map[string]interface{} `json:"optimizer_hints"`
 	}
 
 	var op_type struct {
@@ -75,6 +89,7 @@ func (this *Explain) UnmarshalJSON(body []byte) error {
 	}
 
 	this.text = _unmarshalled.Text
+	this.optimHints = _unmarshalled.OptimHints
 
 	err = json.Unmarshal(_unmarshalled.Op, &op_type)
 	if err != nil {
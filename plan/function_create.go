@@ -0,0 +1,79 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/expression/parser"
+)
+
+// Create function
+type CreateFunction struct {
+	readwrite
+	node *algebra.CreateFunction
+}
+
+func NewCreateFunction(node *algebra.CreateFunction) *CreateFunction {
+	return &CreateFunction{
+		node: node,
+	}
+}
+
+func (this *CreateFunction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCreateFunction(this)
+}
+
+func (this *CreateFunction) New() Operator {
+	return &CreateFunction{}
+}
+
+func (this *CreateFunction) Node() *algebra.CreateFunction {
+	return this.node
+}
+
+func (this *CreateFunction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *CreateFunction) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "CreateFunction"}
+	r["name"] = this.node.Name()
+	r["parameters"] = this.node.Parameters()
+	r["body"] = expression.NewStringer().Visit(this.node.Body())
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *CreateFunction) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_          string   `json:"#operator"`
+		Name       string   `json:"name"`
+		Parameters []string `json:"parameters"`
+		Body       string   `json:"body"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	expr, err := parser.Parse(_unmarshalled.Body)
+	if err != nil {
+		return err
+	}
+
+	this.node = algebra.NewCreateFunction(_unmarshalled.Name, _unmarshalled.Parameters, expr)
+	return nil
+}
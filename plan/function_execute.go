@@ -0,0 +1,75 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/expression/parser"
+)
+
+// Execute function
+type ExecuteFunction struct {
+	readonly
+	node *algebra.ExecuteFunction
+}
+
+func NewExecuteFunction(node *algebra.ExecuteFunction) *ExecuteFunction {
+	return &ExecuteFunction{
+		node: node,
+	}
+}
+
+func (this *ExecuteFunction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitExecuteFunction(this)
+}
+
+func (this *ExecuteFunction) New() Operator {
+	return &ExecuteFunction{}
+}
+
+func (this *ExecuteFunction) Node() *algebra.ExecuteFunction {
+	return this.node
+}
+
+func (this *ExecuteFunction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *ExecuteFunction) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "ExecuteFunction"}
+	r["call"] = expression.NewStringer().Visit(this.node.Call())
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *ExecuteFunction) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_    string `json:"#operator"`
+		Call string `json:"call"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	call, err := parser.Parse(_unmarshalled.Call)
+	if err != nil {
+		return err
+	}
+
+	this.node = algebra.NewExecuteFunction(call)
+	return nil
+}
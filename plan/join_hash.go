@@ -20,6 +20,7 @@ import (
 type HashJoin struct {
 	readonly
 	outer        bool
+	rightOuter   bool
 	onclause     expression.Expression
 	child        Operator
 	buildExprs   expression.Expressions
@@ -31,6 +32,7 @@ func NewHashJoin(join *algebra.AnsiJoin, child Operator, buildExprs, probeExprs
 	buildAliases []string) *HashJoin {
 	return &HashJoin{
 		outer:        join.Outer(),
+		rightOuter:   join.RightOuter(),
 		onclause:     join.Onclause(),
 		child:        child,
 		buildExprs:   buildExprs,
@@ -51,6 +53,10 @@ func (this *HashJoin) Outer() bool {
 	return this.outer
 }
 
+func (this *HashJoin) RightOuter() bool {
+	return this.rightOuter
+}
+
 func (this *HashJoin) Onclause() expression.Expression {
 	return this.onclause
 }
@@ -83,6 +89,10 @@ func (this *HashJoin) MarshalBase(f func(map[string]interface{})) map[string]int
 		r["outer"] = this.outer
 	}
 
+	if this.rightOuter {
+		r["right_outer"] = this.rightOuter
+	}
+
 	buildList := make([]string, 0, len(this.buildExprs))
 	for _, build := range this.buildExprs {
 		buildList = append(buildList, expression.NewStringer().Visit(build))
@@ -110,6 +120,7 @@ func (this *HashJoin) UnmarshalJSON(body []byte) error {
 		_            string          `json:"#operator"`
 		Onclause     string          `json:"on_clause"`
 		Outer        bool            `json:"outer"`
+		RightOuter   bool            `json:"right_outer"`
 		BuildExprs   []string        `json:"build_exprs"`
 		ProbeExprs   []string        `json:"probe_exprs"`
 		BuildAliases []string        `json:"build_aliases"`
@@ -129,6 +140,7 @@ func (this *HashJoin) UnmarshalJSON(body []byte) error {
 	}
 
 	this.outer = _unmarshalled.Outer
+	this.rightOuter = _unmarshalled.RightOuter
 
 	this.buildExprs = make(expression.Expressions, len(_unmarshalled.BuildExprs))
 	for i, build := range _unmarshalled.BuildExprs {
@@ -59,6 +59,9 @@ var _OPERATORS = map[string]Operator{
 	"DistinctScan":            &DistinctScan{},
 	"ExpressionScan":          &ExpressionScan{},
 
+	// Sample
+	"Sample": &Sample{},
+
 	// Fetch
 	"Fetch":      &Fetch{},
 	"DummyFetch": &DummyFetch{},
@@ -88,6 +91,9 @@ var _OPERATORS = map[string]Operator{
 	"IntermediateGroup": &IntermediateGroup{},
 	"FinalGroup":        &FinalGroup{},
 
+	// Window functions
+	"WindowAggregate": &WindowAggregate{},
+
 	// Project
 	"InitialProject":    &InitialProject{},
 	"FinalProject":      &FinalProject{},
@@ -97,9 +103,10 @@ var _OPERATORS = map[string]Operator{
 	"Distinct": &Distinct{},
 
 	// Set operators
-	"UnionAll":     &UnionAll{},
-	"IntersectAll": &IntersectAll{},
-	"ExceptAll":    &ExceptAll{},
+	"UnionAll":       &UnionAll{},
+	"RecursiveUnion": &RecursiveUnion{},
+	"IntersectAll":   &IntersectAll{},
+	"ExceptAll":      &ExceptAll{},
 
 	// Order
 	"Order": &Order{},
@@ -126,6 +133,9 @@ var _OPERATORS = map[string]Operator{
 	// Merge
 	"Merge": &Merge{},
 
+	// Truncate
+	"Truncate": &Truncate{},
+
 	// Framework
 	"Alias":     &Alias{},
 	"Authorize": &Authorize{},
@@ -142,13 +152,31 @@ var _OPERATORS = map[string]Operator{
 	"AlterIndex":         &AlterIndex{},
 	"BuildIndexes":       &BuildIndexes{},
 
+	// Optimizer statistics
+	"UpdateStatistics": &UpdateStatistics{},
+
 	// Roles
 	"GrantRole":  &GrantRole{},
 	"RevokeRole": &RevokeRole{},
 
+	// Functions
+	"CreateFunction":  &CreateFunction{},
+	"DropFunction":    &DropFunction{},
+	"ExecuteFunction": &ExecuteFunction{},
+
+	// Transactions
+	"StartTransaction":    &StartTransaction{},
+	"CommitTransaction":   &CommitTransaction{},
+	"RollbackTransaction": &RollbackTransaction{},
+	"Savepoint":           &Savepoint{},
+
 	// Explain
 	"Explain": &Explain{},
 
+	// Advise
+	"Advise":    &Advise{},
+	"AdviseAll": &AdviseAll{},
+
 	// Prepare
 	"Prepare": &Prepare{},
 }
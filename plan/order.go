@@ -62,6 +62,13 @@ func (this *Order) MarshalBase(f func(map[string]interface{})) map[string]interf
 			q["desc"] = term.Descending()
 		}
 
+		switch term.NullsPos() {
+		case algebra.NULLS_FIRST:
+			q["nulls_pos"] = "first"
+		case algebra.NULLS_LAST:
+			q["nulls_pos"] = "last"
+		}
+
 		s = append(s, q)
 	}
 	r["sort_terms"] = s
@@ -81,8 +88,9 @@ func (this *Order) UnmarshalJSON(body []byte) error {
 	var _unmarshalled struct {
 		_     string `json:"#operator"`
 		Terms []struct {
-			Expr string `json:"expr"`
-			Desc bool   `json:"desc"`
+			Expr     string `json:"expr"`
+			Desc     bool   `json:"desc"`
+			NullsPos string `json:"nulls_pos"`
 		} `json:"sort_terms"`
 		offsetExpr string `json:"offset"`
 		limitExpr  string `json:"limit"`
@@ -99,7 +107,14 @@ func (this *Order) UnmarshalJSON(body []byte) error {
 		if err != nil {
 			return err
 		}
-		this.terms[i] = algebra.NewSortTerm(expr, term.Desc)
+		nullsPos := algebra.NULLS_DEFAULT
+		switch term.NullsPos {
+		case "first":
+			nullsPos = algebra.NULLS_FIRST
+		case "last":
+			nullsPos = algebra.NULLS_LAST
+		}
+		this.terms[i] = algebra.NewSortTerm(expr, term.Desc, nullsPos)
 	}
 	if offsetExprStr := _unmarshalled.offsetExpr; offsetExprStr != "" {
 		offsetExpr, err := parser.Parse(offsetExprStr)
@@ -22,6 +22,7 @@ import (
 type Prepared struct {
 	Operator
 	signature       value.Value
+	columns         []string
 	name            string
 	encoded_plan    string
 	text            string
@@ -43,10 +44,11 @@ type nsVersion struct {
 	version   uint64
 }
 
-func NewPrepared(operator Operator, signature value.Value) *Prepared {
+func NewPrepared(operator Operator, signature value.Value, columns []string) *Prepared {
 	return &Prepared{
 		Operator:  operator,
 		signature: signature,
+		columns:   columns,
 	}
 }
 
@@ -58,6 +60,9 @@ func (this *Prepared) MarshalBase(f func(map[string]interface{})) map[string]int
 	r := make(map[string]interface{}, 5)
 	r["operator"] = this.Operator
 	r["signature"] = this.signature
+	if this.columns != nil {
+		r["columns"] = this.columns
+	}
 	r["name"] = this.name
 	r["encoded_plan"] = this.encoded_plan
 	r["text"] = this.text
@@ -74,6 +79,7 @@ func (this *Prepared) UnmarshalJSON(body []byte) error {
 	var _unmarshalled struct {
 		Operator        json.RawMessage `json:"operator"`
 		Signature       json.RawMessage `json:"signature"`
+		Columns         []string        `json:"columns"`
 		Name            string          `json:"name"`
 		EncodedPlan     string          `json:"encoded_plan"`
 		Text            string          `json:"text"`
@@ -102,6 +108,7 @@ func (this *Prepared) UnmarshalJSON(body []byte) error {
 		_unmarshalled.ApiVersion = datastore.INDEX_API_MAX
 	}
 	this.signature = value.NewValue(_unmarshalled.Signature)
+	this.columns = _unmarshalled.Columns
 	this.name = _unmarshalled.Name
 	this.encoded_plan = _unmarshalled.EncodedPlan
 	this.text = _unmarshalled.Text
@@ -117,6 +124,12 @@ func (this *Prepared) Signature() value.Value {
 	return this.signature
 }
 
+// Columns returns the result fields in projection order, or nil if
+// they are not known until execution
+func (this *Prepared) Columns() []string {
+	return this.columns
+}
+
 func (this *Prepared) Name() string {
 	return this.name
 }
@@ -0,0 +1,139 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/expression/parser"
+)
+
+// Sample the keys produced by the scan of a keyspace
+type Sample struct {
+	readonly
+	keyspace datastore.Keyspace
+	term     *algebra.KeyspaceTerm
+	sample   *algebra.Sample
+	random   bool
+}
+
+func NewSample(keyspace datastore.Keyspace, term *algebra.KeyspaceTerm, random bool) *Sample {
+	return &Sample{
+		keyspace: keyspace,
+		term:     term,
+		sample:   term.Sample(),
+		random:   random,
+	}
+}
+
+func (this *Sample) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitSample(this)
+}
+
+func (this *Sample) New() Operator {
+	return &Sample{}
+}
+
+func (this *Sample) Keyspace() datastore.Keyspace {
+	return this.keyspace
+}
+
+func (this *Sample) Term() *algebra.KeyspaceTerm {
+	return this.term
+}
+
+func (this *Sample) Size() expression.Expression {
+	return this.sample.Size()
+}
+
+func (this *Sample) Rows() bool {
+	return this.sample.Rows()
+}
+
+func (this *Sample) Seed() expression.Expression {
+	return this.sample.Seed()
+}
+
+/*
+Whether the rows may be read from the random entries of the keyspace,
+instead of from the scan.
+*/
+func (this *Sample) Random() bool {
+	return this.random
+}
+
+func (this *Sample) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *Sample) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "Sample"}
+	r["namespace"] = this.term.Namespace()
+	r["keyspace"] = this.term.Keyspace()
+	if this.term.As() != "" {
+		r["as"] = this.term.As()
+	}
+
+	r["size"] = expression.NewStringer().Visit(this.sample.Size())
+	if this.sample.Rows() {
+		r["rows"] = true
+	}
+	if this.sample.Seed() != nil {
+		r["seed"] = expression.NewStringer().Visit(this.sample.Seed())
+	}
+	if this.random {
+		r["random"] = true
+	}
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *Sample) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_      string `json:"#operator"`
+		Names  string `json:"namespace"`
+		Keys   string `json:"keyspace"`
+		As     string `json:"as"`
+		Size   string `json:"size"`
+		Rows   bool   `json:"rows"`
+		Seed   string `json:"seed"`
+		Random bool   `json:"random"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	size, err := parser.Parse(_unmarshalled.Size)
+	if err != nil {
+		return err
+	}
+
+	var seed expression.Expression
+	if _unmarshalled.Seed != "" {
+		seed, err = parser.Parse(_unmarshalled.Seed)
+		if err != nil {
+			return err
+		}
+	}
+
+	this.sample = algebra.NewSample(size, _unmarshalled.Rows, seed)
+	this.term = algebra.NewKeyspaceTerm(_unmarshalled.Names, _unmarshalled.Keys, _unmarshalled.As, nil, nil)
+	this.term.SetSample(this.sample)
+	this.random = _unmarshalled.Random
+	this.keyspace, err = datastore.GetKeyspace(_unmarshalled.Names, _unmarshalled.Keys)
+	return err
+}
@@ -0,0 +1,57 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/algebra"
+)
+
+// Commit transaction
+type CommitTransaction struct {
+	readwrite
+	node *algebra.CommitTransaction
+}
+
+func NewCommitTransaction(node *algebra.CommitTransaction) *CommitTransaction {
+	return &CommitTransaction{
+		node: node,
+	}
+}
+
+func (this *CommitTransaction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCommitTransaction(this)
+}
+
+func (this *CommitTransaction) New() Operator {
+	return &CommitTransaction{}
+}
+
+func (this *CommitTransaction) Node() *algebra.CommitTransaction {
+	return this.node
+}
+
+func (this *CommitTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *CommitTransaction) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "CommitTransaction"}
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *CommitTransaction) UnmarshalJSON(body []byte) error {
+	this.node = algebra.NewCommitTransaction()
+	return nil
+}
@@ -0,0 +1,70 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/algebra"
+)
+
+// Rollback transaction
+type RollbackTransaction struct {
+	readwrite
+	node *algebra.RollbackTransaction
+}
+
+func NewRollbackTransaction(node *algebra.RollbackTransaction) *RollbackTransaction {
+	return &RollbackTransaction{
+		node: node,
+	}
+}
+
+func (this *RollbackTransaction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitRollbackTransaction(this)
+}
+
+func (this *RollbackTransaction) New() Operator {
+	return &RollbackTransaction{}
+}
+
+func (this *RollbackTransaction) Node() *algebra.RollbackTransaction {
+	return this.node
+}
+
+func (this *RollbackTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *RollbackTransaction) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "RollbackTransaction"}
+	if this.node.Savepoint() != "" {
+		r["savepoint"] = this.node.Savepoint()
+	}
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *RollbackTransaction) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_         string `json:"#operator"`
+		Savepoint string `json:"savepoint"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	this.node = algebra.NewRollbackTransaction(_unmarshalled.Savepoint)
+	return nil
+}
@@ -0,0 +1,68 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/algebra"
+)
+
+// Savepoint
+type Savepoint struct {
+	readwrite
+	node *algebra.Savepoint
+}
+
+func NewSavepoint(node *algebra.Savepoint) *Savepoint {
+	return &Savepoint{
+		node: node,
+	}
+}
+
+func (this *Savepoint) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitSavepoint(this)
+}
+
+func (this *Savepoint) New() Operator {
+	return &Savepoint{}
+}
+
+func (this *Savepoint) Node() *algebra.Savepoint {
+	return this.node
+}
+
+func (this *Savepoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *Savepoint) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "Savepoint"}
+	r["savepoint"] = this.node.Savepoint()
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *Savepoint) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_         string `json:"#operator"`
+		Savepoint string `json:"savepoint"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	this.node = algebra.NewSavepoint(_unmarshalled.Savepoint)
+	return nil
+}
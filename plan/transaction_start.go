@@ -0,0 +1,57 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/algebra"
+)
+
+// Start transaction
+type StartTransaction struct {
+	readwrite
+	node *algebra.StartTransaction
+}
+
+func NewStartTransaction(node *algebra.StartTransaction) *StartTransaction {
+	return &StartTransaction{
+		node: node,
+	}
+}
+
+func (this *StartTransaction) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitStartTransaction(this)
+}
+
+func (this *StartTransaction) New() Operator {
+	return &StartTransaction{}
+}
+
+func (this *StartTransaction) Node() *algebra.StartTransaction {
+	return this.node
+}
+
+func (this *StartTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *StartTransaction) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "StartTransaction"}
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *StartTransaction) UnmarshalJSON(body []byte) error {
+	this.node = algebra.NewStartTransaction()
+	return nil
+}
@@ -0,0 +1,74 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/datastore"
+)
+
+// Truncate keyspace
+type Truncate struct {
+	readwrite
+	keyspace datastore.Keyspace
+}
+
+func NewTruncate(keyspace datastore.Keyspace) *Truncate {
+	return &Truncate{
+		keyspace: keyspace,
+	}
+}
+
+func (this *Truncate) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitTruncate(this)
+}
+
+func (this *Truncate) New() Operator {
+	return &Truncate{}
+}
+
+func (this *Truncate) Keyspace() datastore.Keyspace {
+	return this.keyspace
+}
+
+func (this *Truncate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *Truncate) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "Truncate"}
+	r["namespace"] = this.keyspace.NamespaceId()
+	r["keyspace"] = this.keyspace.Name()
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *Truncate) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_     string `json:"#operator"`
+		Names string `json:"namespace"`
+		Keys  string `json:"keyspace"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	this.keyspace, err = datastore.GetKeyspace(_unmarshalled.Names, _unmarshalled.Keys)
+	return err
+}
+
+func (this *Truncate) verify(prepared *Prepared) bool {
+	return verifyKeyspace(this.keyspace, prepared)
+}
@@ -0,0 +1,187 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/expression/parser"
+)
+
+/*
+Runs the anchor once, then runs the recursive member repeatedly over
+the rows produced by the previous iteration, until no new rows are
+produced or a limit is reached.
+*/
+type RecursiveUnion struct {
+	readonly
+	first      Operator
+	second     Operator
+	workingSet string
+	distinct   bool
+	cycle      expression.Expressions
+	levels     int64
+	documents  int64
+}
+
+func NewRecursiveUnion(node *algebra.RecursiveUnion, first, second Operator) *RecursiveUnion {
+	return &RecursiveUnion{
+		first:      first,
+		second:     second,
+		workingSet: node.WorkingSet(),
+		distinct:   node.Distinct(),
+		cycle:      node.Cycle(),
+		levels:     node.Levels(),
+		documents:  node.Documents(),
+	}
+}
+
+func (this *RecursiveUnion) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitRecursiveUnion(this)
+}
+
+func (this *RecursiveUnion) New() Operator {
+	return &RecursiveUnion{}
+}
+
+func (this *RecursiveUnion) First() Operator {
+	return this.first
+}
+
+func (this *RecursiveUnion) Second() Operator {
+	return this.second
+}
+
+func (this *RecursiveUnion) WorkingSet() string {
+	return this.workingSet
+}
+
+func (this *RecursiveUnion) Distinct() bool {
+	return this.distinct
+}
+
+func (this *RecursiveUnion) Cycle() expression.Expressions {
+	return this.cycle
+}
+
+func (this *RecursiveUnion) Levels() int64 {
+	return this.levels
+}
+
+func (this *RecursiveUnion) Documents() int64 {
+	return this.documents
+}
+
+func (this *RecursiveUnion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *RecursiveUnion) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "RecursiveUnion"}
+	r["working_set"] = this.workingSet
+
+	if this.distinct {
+		r["distinct"] = this.distinct
+	}
+
+	if len(this.cycle) > 0 {
+		cycle := make([]string, 0, len(this.cycle))
+		for _, expr := range this.cycle {
+			cycle = append(cycle, expression.NewStringer().Visit(expr))
+		}
+		r["cycle"] = cycle
+	}
+
+	if this.levels >= 0 {
+		r["levels"] = this.levels
+	}
+
+	if this.documents >= 0 {
+		r["documents"] = this.documents
+	}
+
+	if f != nil {
+		f(r)
+	} else {
+		r["first"] = this.first
+		r["second"] = this.second
+	}
+	return r
+}
+
+func (this *RecursiveUnion) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_          string          `json:"#operator"`
+		WorkingSet string          `json:"working_set"`
+		Distinct   bool            `json:"distinct"`
+		Cycle      []string        `json:"cycle"`
+		Levels     *int64          `json:"levels"`
+		Documents  *int64          `json:"documents"`
+		First      json.RawMessage `json:"first"`
+		Second     json.RawMessage `json:"second"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	this.workingSet = _unmarshalled.WorkingSet
+	this.distinct = _unmarshalled.Distinct
+
+	if len(_unmarshalled.Cycle) > 0 {
+		this.cycle = make(expression.Expressions, len(_unmarshalled.Cycle))
+		for i, s := range _unmarshalled.Cycle {
+			this.cycle[i], err = parser.Parse(s)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	this.levels = -1
+	if _unmarshalled.Levels != nil {
+		this.levels = *_unmarshalled.Levels
+	}
+
+	this.documents = -1
+	if _unmarshalled.Documents != nil {
+		this.documents = *_unmarshalled.Documents
+	}
+
+	for i, child := range []json.RawMessage{_unmarshalled.First, _unmarshalled.Second} {
+		var op_type struct {
+			Operator string `json:"#operator"`
+		}
+
+		err = json.Unmarshal(child, &op_type)
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			this.first, err = MakeOperator(op_type.Operator, child)
+		} else {
+			this.second, err = MakeOperator(op_type.Operator, child)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (this *RecursiveUnion) verify(prepared *Prepared) bool {
+	return this.first.verify(prepared) && this.second.verify(prepared)
+}
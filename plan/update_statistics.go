@@ -0,0 +1,108 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/expression/parser"
+)
+
+// Collect the statistics of the terms from the sampled documents
+type UpdateStatistics struct {
+	readwrite
+	keyspace datastore.Keyspace
+	alias    string
+	terms    expression.Expressions
+}
+
+func NewUpdateStatistics(keyspace datastore.Keyspace, alias string,
+	terms expression.Expressions) *UpdateStatistics {
+	return &UpdateStatistics{
+		keyspace: keyspace,
+		alias:    alias,
+		terms:    terms,
+	}
+}
+
+func (this *UpdateStatistics) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitUpdateStatistics(this)
+}
+
+func (this *UpdateStatistics) New() Operator {
+	return &UpdateStatistics{}
+}
+
+func (this *UpdateStatistics) Keyspace() datastore.Keyspace {
+	return this.keyspace
+}
+
+func (this *UpdateStatistics) Alias() string {
+	return this.alias
+}
+
+func (this *UpdateStatistics) Terms() expression.Expressions {
+	return this.terms
+}
+
+func (this *UpdateStatistics) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *UpdateStatistics) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "UpdateStatistics"}
+	r["namespace"] = this.keyspace.NamespaceId()
+	r["keyspace"] = this.keyspace.Name()
+	r["alias"] = this.alias
+
+	terms := make([]string, len(this.terms))
+	for i, term := range this.terms {
+		terms[i] = expression.NewStringer().Visit(term)
+	}
+	r["terms"] = terms
+
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *UpdateStatistics) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_     string   `json:"#operator"`
+		Names string   `json:"namespace"`
+		Keys  string   `json:"keyspace"`
+		Alias string   `json:"alias"`
+		Terms []string `json:"terms"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	this.alias = _unmarshalled.Alias
+	this.terms = make(expression.Expressions, len(_unmarshalled.Terms))
+	for i, term := range _unmarshalled.Terms {
+		this.terms[i], err = parser.Parse(term)
+		if err != nil {
+			return err
+		}
+	}
+
+	this.keyspace, err = datastore.GetKeyspace(_unmarshalled.Names, _unmarshalled.Keys)
+	return err
+}
+
+func (this *UpdateStatistics) verify(prepared *Prepared) bool {
+	return verifyKeyspace(this.keyspace, prepared)
+}
@@ -30,6 +30,9 @@ type Visitor interface {
 	VisitOrderedIntersectScan(op *OrderedIntersectScan) (interface{}, error)
 	VisitExpressionScan(op *ExpressionScan) (interface{}, error)
 
+	// Sample
+	VisitSample(op *Sample) (interface{}, error)
+
 	// Fetch
 	VisitFetch(op *Fetch) (interface{}, error)
 	VisitDummyFetch(op *DummyFetch) (interface{}, error)
@@ -56,6 +59,9 @@ type Visitor interface {
 	VisitIntermediateGroup(op *IntermediateGroup) (interface{}, error)
 	VisitFinalGroup(op *FinalGroup) (interface{}, error)
 
+	// Window functions
+	VisitWindowAggregate(op *WindowAggregate) (interface{}, error)
+
 	// Project
 	VisitInitialProject(op *InitialProject) (interface{}, error)
 	VisitFinalProject(op *FinalProject) (interface{}, error)
@@ -66,6 +72,7 @@ type Visitor interface {
 
 	// Set operators
 	VisitUnionAll(op *UnionAll) (interface{}, error)
+	VisitRecursiveUnion(op *RecursiveUnion) (interface{}, error)
 	VisitIntersectAll(op *IntersectAll) (interface{}, error)
 	VisitExceptAll(op *ExceptAll) (interface{}, error)
 
@@ -94,6 +101,9 @@ type Visitor interface {
 	// Merge
 	VisitMerge(op *Merge) (interface{}, error)
 
+	// Truncate
+	VisitTruncate(op *Truncate) (interface{}, error)
+
 	// Framework
 	VisitAlias(op *Alias) (interface{}, error)
 	VisitAuthorize(op *Authorize) (interface{}, error)
@@ -110,13 +120,31 @@ type Visitor interface {
 	VisitAlterIndex(op *AlterIndex) (interface{}, error)
 	VisitBuildIndexes(op *BuildIndexes) (interface{}, error)
 
+	// Optimizer statistics
+	VisitUpdateStatistics(op *UpdateStatistics) (interface{}, error)
+
 	// Roles
 	VisitGrantRole(op *GrantRole) (interface{}, error)
 	VisitRevokeRole(op *RevokeRole) (interface{}, error)
 
+	// Functions
+	VisitCreateFunction(op *CreateFunction) (interface{}, error)
+	VisitDropFunction(op *DropFunction) (interface{}, error)
+	VisitExecuteFunction(op *ExecuteFunction) (interface{}, error)
+
+	// Transactions
+	VisitStartTransaction(op *StartTransaction) (interface{}, error)
+	VisitCommitTransaction(op *CommitTransaction) (interface{}, error)
+	VisitRollbackTransaction(op *RollbackTransaction) (interface{}, error)
+	VisitSavepoint(op *Savepoint) (interface{}, error)
+
 	// Explain
 	VisitExplain(op *Explain) (interface{}, error)
 
+	// Advise
+	VisitAdvise(op *Advise) (interface{}, error)
+	VisitAdviseAll(op *AdviseAll) (interface{}, error)
+
 	// Prepare
 	VisitPrepare(op *Prepare) (interface{}, error)
 
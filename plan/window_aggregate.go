@@ -0,0 +1,96 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/expression/parser"
+)
+
+// Window functions sharing the same PARTITION BY and ORDER BY. Input
+// must be sorted on those terms. Serial.
+type WindowAggregate struct {
+	readonly
+	aggregates algebra.WindowFunctions
+}
+
+func NewWindowAggregate(aggregates algebra.WindowFunctions) *WindowAggregate {
+	return &WindowAggregate{
+		aggregates: aggregates,
+	}
+}
+
+func (this *WindowAggregate) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitWindowAggregate(this)
+}
+
+func (this *WindowAggregate) New() Operator {
+	return &WindowAggregate{}
+}
+
+func (this *WindowAggregate) Aggregates() algebra.WindowFunctions {
+	return this.aggregates
+}
+
+/*
+The OVER clause shared by all the window functions.
+*/
+func (this *WindowAggregate) WindowTerm() *algebra.WindowTerm {
+	return this.aggregates[0].WindowTerm()
+}
+
+func (this *WindowAggregate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.MarshalBase(nil))
+}
+
+func (this *WindowAggregate) MarshalBase(f func(map[string]interface{})) map[string]interface{} {
+	r := map[string]interface{}{"#operator": "WindowAggregate"}
+	s := make([]interface{}, 0, len(this.aggregates))
+	for _, agg := range this.aggregates {
+		s = append(s, expression.NewStringer().Visit(agg))
+	}
+	r["aggregates"] = s
+	if f != nil {
+		f(r)
+	}
+	return r
+}
+
+func (this *WindowAggregate) UnmarshalJSON(body []byte) error {
+	var _unmarshalled struct {
+		_    string   `json:"#operator"`
+		Aggs []string `json:"aggregates"`
+	}
+
+	err := json.Unmarshal(body, &_unmarshalled)
+	if err != nil {
+		return err
+	}
+
+	this.aggregates = make(algebra.WindowFunctions, len(_unmarshalled.Aggs))
+	for i, agg := range _unmarshalled.Aggs {
+		agg_expr, err := parser.Parse(agg)
+		if err != nil {
+			return err
+		}
+
+		wf, ok := agg_expr.(algebra.WindowFunction)
+		if !ok || wf.WindowTerm() == nil {
+			return fmt.Errorf("Invalid window function %s", agg)
+		}
+		this.aggregates[i] = wf
+	}
+
+	return nil
+}
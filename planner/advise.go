@@ -0,0 +1,1201 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/parser/n1ql"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/timestamp"
+	"github.com/couchbase/query/value"
+)
+
+// Maximum number of times a statement is planned with the hypothetical
+// indexes, each time reaching keyspace terms the previous plan did not
+const _ADVISE_PASSES = 8
+
+/*
+The index advice for a statement: the indexes its current plan reads,
+and the secondary indexes that would serve it better.
+*/
+type IndexAdvice struct {
+	query    string
+	current  []*adviseScan
+	planErr  error
+	primary  bool // the current plan reads a filtered keyspace with a primary scan
+	indexes  []*IndexRecommendation
+	covering []*IndexRecommendation
+}
+
+/*
+A recommended index, as the CREATE INDEX statement that creates it.
+*/
+type IndexRecommendation struct {
+	keyspace  string
+	alias     string
+	statement string
+}
+
+func (this *IndexRecommendation) Keyspace() string {
+	return this.keyspace
+}
+
+func (this *IndexRecommendation) Alias() string {
+	return this.alias
+}
+
+func (this *IndexRecommendation) Statement() string {
+	return this.statement
+}
+
+func (this *IndexAdvice) Query() string {
+	return this.query
+}
+
+func (this *IndexAdvice) Indexes() []*IndexRecommendation {
+	return this.indexes
+}
+
+func (this *IndexAdvice) CoveringIndexes() []*IndexRecommendation {
+	return this.covering
+}
+
+/*
+The current plan is optimal when the statement can be planned with
+the existing indexes, no keyspace that is filtered is read by a
+primary scan, and no index is recommended.
+*/
+func (this *IndexAdvice) Optimal() bool {
+	return this.planErr == nil && !this.primary && len(this.indexes) == 0 && len(this.covering) == 0
+}
+
+func (this *IndexAdvice) Report() map[string]interface{} {
+	current := make([]interface{}, 0, len(this.current))
+	seen := make(map[string]bool, len(this.current))
+	for _, scan := range this.current {
+		for _, index := range scan.indexes {
+			key := scan.keyspace + ":" + scan.alias + ":" + index.Name()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			current = append(current, map[string]interface{}{
+				"index":    index.Name(),
+				"keyspace": scan.keyspace,
+				"alias":    scan.alias,
+			})
+		}
+	}
+
+	rv := map[string]interface{}{
+		"query":           this.query,
+		"current_indexes": current,
+		"optimal":         this.Optimal(),
+	}
+	if this.planErr != nil {
+		rv["current_plan_error"] = this.planErr.Error()
+	}
+	if len(this.indexes) > 0 {
+		rv["recommended_indexes"] = recommendationsReport(this.indexes)
+	}
+	if len(this.covering) > 0 {
+		rv["recommended_covering_indexes"] = recommendationsReport(this.covering)
+	}
+	return rv
+}
+
+func recommendationsReport(recs []*IndexRecommendation) []interface{} {
+	rv := make([]interface{}, len(recs))
+	for i, rec := range recs {
+		rv[i] = map[string]interface{}{
+			"index":          rec.statement,
+			"keyspace_alias": rec.alias,
+		}
+	}
+	return rv
+}
+
+func (this *IndexAdvice) addScans(scans []*adviseScan, coveringOnly bool) {
+	for _, scan := range scans {
+		for _, index := range scan.indexes {
+			vindex, ok := index.(*virtualIndex)
+			if !ok {
+				continue
+			}
+
+			if scan.covering {
+				this.covering = addRecommendation(this.covering, vindex, scan.alias)
+			} else if !coveringOnly {
+				this.indexes = addRecommendation(this.indexes, vindex, scan.alias)
+			}
+		}
+	}
+}
+
+func addRecommendation(recs []*IndexRecommendation, index *virtualIndex, alias string) []*IndexRecommendation {
+	statement := index.createStatement()
+	for _, rec := range recs {
+		if rec.statement == statement {
+			return recs
+		}
+	}
+
+	return append(recs, &IndexRecommendation{
+		keyspace:  index.keyspaceName(),
+		alias:     alias,
+		statement: statement,
+	})
+}
+
+/*
+Advise on the indexes for a statement, given as its text. The
+statement is planned with the existing indexes, and then with
+hypothetical indexes on the sargable terms of every keyspace it reads;
+the hypothetical indexes the planner chooses are recommended.
+*/
+func AdviseIndexes(text string, datastore, systemstore datastore.Datastore, namespace string,
+	namedArgs map[string]value.Value, positionalArgs value.Values, indexApiVersion int,
+	featureControls uint64) (*IndexAdvice, error) {
+	builder := newBuilder(datastore, systemstore, namespace, false, namedArgs, positionalArgs,
+		indexApiVersion, featureControls)
+	return builder.adviseIndexes(text)
+}
+
+func (this *builder) adviseIndexes(text string) (*IndexAdvice, error) {
+	advisor := &indexAdvisor{}
+	advice := &IndexAdvice{query: strings.TrimSpace(text)}
+
+	// the plan with the existing indexes
+	ops, err := this.advisePlan(text, advisor)
+	if err != nil {
+		if len(advisor.terms) == 0 {
+			return nil, err
+		}
+		advice.planErr = err
+	} else {
+		advice.current = adviseScans(ops)
+		advice.primary = advisor.primaryScan(advice.current)
+	}
+
+	// plan with the hypothetical indexes, for as long as planning
+	// fails after reaching keyspace terms it had not reached before
+	advisor.virtual = true
+	for i := 0; i < _ADVISE_PASSES; i++ {
+		advisor.added = false
+		ops, err = this.advisePlan(text, advisor)
+		if err == nil || !advisor.added {
+			break
+		}
+	}
+	if err != nil {
+		if advice.planErr != nil {
+			return nil, advice.planErr
+		}
+		return advice, nil
+	}
+	advice.addScans(adviseScans(ops), false)
+
+	// and with the covering indexes in place of the others
+	if advisor.hasCovering() {
+		advisor.covering = true
+		ops, err = this.advisePlan(text, advisor)
+		if err == nil {
+			advice.addScans(adviseScans(ops), true)
+		}
+	}
+
+	return advice, nil
+}
+
+/*
+Plan the statement afresh, as planning changes the keyspace terms of
+the statement it plans, and plan its subqueries, which are otherwise
+planned as they are run.
+*/
+func (this *builder) advisePlan(text string, advisor *indexAdvisor) ([]plan.Operator, error) {
+	stmt, err := adviseStatement(text)
+	if err != nil {
+		return nil, err
+	}
+
+	subqueries, err := expression.ListSubqueries(stmt.Expressions(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]plan.Operator, 0, len(subqueries)+1)
+	op, err := this.advisePlanStatement(stmt, advisor, false)
+	if err != nil {
+		return nil, err
+	}
+	ops = append(ops, op)
+
+	for _, subquery := range subqueries {
+		sub, ok := subquery.(*algebra.Subquery)
+		if !ok {
+			continue
+		}
+
+		op, err = this.advisePlanStatement(sub.Select(), advisor, true)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+func (this *builder) advisePlanStatement(stmt algebra.Statement, advisor *indexAdvisor, subquery bool) (
+	plan.Operator, error) {
+
+	builder := newBuilder(this.datastore, this.systemstore, this.namespace, subquery, this.namedArgs,
+		this.positionalArgs, this.indexApiVersion, this.featureControls)
+	builder.advisor = advisor
+	op, err := stmt.Accept(builder)
+	if err != nil {
+		return nil, err
+	}
+	return op.(plan.Operator), nil
+}
+
+/*
+Parse the statement to advise on. The statement of a PREPARE or an
+EXPLAIN is advised on in its place.
+*/
+func adviseStatement(text string) (algebra.Statement, error) {
+	stmt, err := n1ql.ParseStatement(text)
+	if err != nil {
+		return nil, errors.NewParseSyntaxError(err, "")
+	}
+
+	for {
+		switch s := stmt.(type) {
+		case *algebra.Prepare:
+			stmt = s.Statement()
+		case *algebra.Explain:
+			stmt = s.Statement()
+		case *algebra.Select, *algebra.Update, *algebra.Delete, *algebra.Merge,
+			*algebra.Insert, *algebra.Upsert:
+			return stmt, nil
+		default:
+			return nil, errors.NewAdviseNotSupportedError(stmt.Type())
+		}
+	}
+}
+
+/*
+Keeps the keyspace terms reached while planning a statement, and the
+hypothetical indexes derived from them.
+*/
+type indexAdvisor struct {
+	terms    []*adviseTerm
+	virtual  bool // offer the hypothetical indexes to the planner
+	covering bool // offer the covering indexes in place of the others
+	added    bool // terms were added while planning
+}
+
+type adviseTerm struct {
+	keyspace  datastore.Keyspace
+	alias     string
+	pred      expression.Expression
+	index     *virtualIndex
+	covering  *virtualIndex
+	disjuncts []*virtualIndex // for a union scan over the disjuncts of an OR
+}
+
+/*
+Record a keyspace term the planner is choosing a scan for. Terms read
+by USE INDEX, and system keyspaces, are not advised on.
+*/
+func (this *builder) adviseTerm(keyspace datastore.Keyspace, node *algebra.KeyspaceTerm,
+	pred expression.Expression) error {
+
+	advisor := this.advisor
+	if advisor == nil || pred == nil || len(node.Indexes()) > 0 ||
+		strings.ToLower(keyspace.NamespaceId()) == "#system" {
+		return nil
+	}
+
+	alias := node.Alias()
+	for _, term := range advisor.terms {
+		if term.alias == alias && term.keyspace.Id() == keyspace.Id() && term.pred.EquivalentTo(pred) {
+			return nil
+		}
+	}
+
+	names := make(map[string]bool, len(this.baseKeyspaces))
+	for name := range this.baseKeyspaces {
+		names[name] = true
+	}
+
+	term := &adviseTerm{
+		keyspace: keyspace,
+		alias:    alias,
+		pred:     pred.Copy(),
+	}
+	advisor.terms = append(advisor.terms, term)
+	advisor.added = true
+
+	if or, ok := pred.(*expression.Or); ok {
+		return this.adviseDisjuncts(term, or, names)
+	}
+
+	keys := adviseKeys(pred, alias, names)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	existing, err := this.existingKeys(keyspace, alias)
+	if err != nil {
+		return err
+	}
+
+	if !redundantIndex(keys, nil, alias, existing) {
+		term.index, err = newVirtualIndex(keyspace, alias, keys, false)
+		if err != nil || term.index == nil {
+			return err
+		}
+	}
+
+	paths, ok := coverPaths(this.cover, keys, alias)
+	if !ok || len(paths) == 0 {
+		if ok && term.index != nil {
+			term.index.covering = true
+		}
+		return nil
+	}
+
+	if !redundantIndex(keys, paths, alias, existing) {
+		term.covering, err = newVirtualIndex(keyspace, alias, append(keys[:len(keys):len(keys)], paths...), true)
+	}
+	return err
+}
+
+/*
+An OR is served by a union scan, which needs an index for each of
+its disjuncts.
+*/
+func (this *builder) adviseDisjuncts(term *adviseTerm, or *expression.Or, names map[string]bool) error {
+	disjuncts := adviseDisjunctKeys(or, term.alias, names)
+	if len(disjuncts) == 0 {
+		return nil
+	}
+
+	existing, err := this.existingKeys(term.keyspace, term.alias)
+	if err != nil {
+		return err
+	}
+
+outer:
+	for _, keys := range disjuncts {
+		if redundantIndex(keys, nil, term.alias, existing) {
+			continue
+		}
+
+		index, err := newVirtualIndex(term.keyspace, term.alias, keys, false)
+		if err != nil || index == nil {
+			return err
+		}
+
+		for _, other := range term.disjuncts {
+			if other.name == index.name {
+				continue outer
+			}
+		}
+		term.disjuncts = append(term.disjuncts, index)
+	}
+	return nil
+}
+
+/*
+Returns the keys of an index for each disjunct of an OR, or nil when
+a disjunct has nothing to index.
+*/
+func adviseDisjunctKeys(or *expression.Or, alias string, names map[string]bool) []expression.Expressions {
+	rv := make([]expression.Expressions, 0, len(or.Operands()))
+	for _, disjunct := range or.Operands() {
+		keys := adviseKeys(disjunct, alias, names)
+		if len(keys) == 0 {
+			return nil
+		}
+		rv = append(rv, keys)
+	}
+	return rv
+}
+
+/*
+Returns the hypothetical indexes of a keyspace term, with the indexes
+the planner chooses from.
+*/
+func (this *builder) advisedIndexes(keyspace datastore.Keyspace, alias string,
+	indexes []datastore.Index) []datastore.Index {
+
+	advisor := this.advisor
+	if advisor == nil || !advisor.virtual {
+		return indexes
+	}
+
+	for _, term := range advisor.terms {
+		if term.alias != alias || term.keyspace.Id() != keyspace.Id() {
+			continue
+		}
+
+		index := term.index
+		if advisor.covering && term.covering != nil {
+			index = term.covering
+		}
+		if index != nil && !hasIndex(indexes, index) {
+			indexes = append(indexes, index)
+		}
+
+		for _, index := range term.disjuncts {
+			if !hasIndex(indexes, index) {
+				indexes = append(indexes, index)
+			}
+		}
+	}
+
+	return indexes
+}
+
+/*
+Returns true if a keyspace term that has a predicate is read by a
+primary scan.
+*/
+func (this *indexAdvisor) primaryScan(scans []*adviseScan) bool {
+	for _, scan := range scans {
+		primary := false
+		for _, index := range scan.indexes {
+			primary = primary || index.IsPrimary()
+		}
+		if !primary {
+			continue
+		}
+
+		for _, term := range this.terms {
+			if term.alias == scan.alias && term.keyspace.NamespaceId()+":"+term.keyspace.Name() == scan.keyspace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (this *indexAdvisor) hasCovering() bool {
+	for _, term := range this.terms {
+		if term.covering != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasIndex(indexes []datastore.Index, index *virtualIndex) bool {
+	for _, i := range indexes {
+		if vi, ok := i.(*virtualIndex); ok && vi.name == index.name {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+The keys of the existing secondary indexes of a keyspace that have no
+index condition, formalized as the keys of index scans are.
+*/
+func (this *builder) existingKeys(keyspace datastore.Keyspace, alias string) ([]expression.Expressions, error) {
+	indexes, err := allIndexes(keyspace, nil, nil, this.indexApiVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	formalizer := expression.NewSelfFormalizer(alias, nil)
+	rv := make([]expression.Expressions, 0, len(indexes))
+	for _, index := range indexes {
+		if index.IsPrimary() || index.Condition() != nil || len(index.RangeKey()) == 0 {
+			continue
+		}
+
+		keys := make(expression.Expressions, len(index.RangeKey()))
+		for i, key := range index.RangeKey() {
+			keys[i], err = formalizeKey(key.Copy(), formalizer)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rv = append(rv, keys)
+	}
+	return rv, nil
+}
+
+/*
+An index is redundant when an existing index leads with the same keys,
+in any order, and covers the paths of a covering index.
+*/
+func redundantIndex(keys, paths expression.Expressions, alias string, existing []expression.Expressions) bool {
+outer:
+	for _, ekeys := range existing {
+		if len(ekeys) < len(keys) {
+			continue
+		}
+
+		for _, key := range keys {
+			if !containsExpr(ekeys[:len(keys)], key) {
+				continue outer
+			}
+		}
+
+		for _, path := range paths {
+			if !expression.IsCovered(path, alias, ekeys) {
+				continue outer
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func containsExpr(exprs expression.Expressions, expr expression.Expression) bool {
+	for _, e := range exprs {
+		if e.EquivalentTo(expr) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Derive the keys of an index for the predicate of a keyspace term:
+equality keys first, then IN keys, then range keys. A predicate on
+the elements of an array gives an array key.
+*/
+func adviseKeys(pred expression.Expression, alias string, names map[string]bool) expression.Expressions {
+	var eqs, ins, ranges expression.Expressions
+
+	terms := expression.Expressions{pred}
+	if and, ok := pred.(*expression.And); ok {
+		terms = and.Operands()
+	}
+
+	for _, term := range terms {
+		switch term := term.(type) {
+		case *expression.Eq:
+			if key := adviseOperand(term.First(), term.Second(), alias, names); key != nil {
+				eqs = append(eqs, key)
+			} else if key := adviseOperand(term.Second(), term.First(), alias, names); key != nil {
+				eqs = append(eqs, key)
+			}
+		case *expression.LT, *expression.LE:
+			first := term.(expression.BinaryFunction).First()
+			second := term.(expression.BinaryFunction).Second()
+			if key := adviseOperand(first, second, alias, names); key != nil {
+				ranges = append(ranges, key)
+			} else if key := adviseOperand(second, first, alias, names); key != nil {
+				ranges = append(ranges, key)
+			}
+		case *expression.In:
+			if key := adviseOperand(term.First(), term.Second(), alias, names); key != nil {
+				ins = append(ins, key)
+			}
+		case *expression.Between:
+			if adviseKey(term.First(), alias, names) && adviseValue(term.Second(), alias, names) &&
+				adviseValue(term.Third(), alias, names) {
+				ranges = append(ranges, term.First())
+			}
+		case *expression.Like:
+			if adviseKey(term.First(), alias, names) && likePrefix(term.Second()) {
+				ranges = append(ranges, term.First())
+			}
+		case *expression.IsNull:
+			if adviseKey(term.Operand(), alias, names) {
+				eqs = append(eqs, term.Operand())
+			}
+		case *expression.IsNotNull, *expression.IsValued, *expression.IsNotMissing:
+			operand := term.(expression.UnaryFunction).Operand()
+			if adviseKey(operand, alias, names) {
+				ranges = append(ranges, operand)
+			}
+		case *expression.Any:
+			if key, eq := adviseArrayKey(term.Bindings(), term.Satisfies(), alias, names); key != nil {
+				if eq {
+					eqs = append(eqs, key)
+				} else {
+					ranges = append(ranges, key)
+				}
+			}
+		case *expression.AnyEvery:
+			if key, eq := adviseArrayKey(term.Bindings(), term.Satisfies(), alias, names); key != nil {
+				if eq {
+					eqs = append(eqs, key)
+				} else {
+					ranges = append(ranges, key)
+				}
+			}
+		}
+	}
+
+	var keys expression.Expressions
+	for _, key := range append(append(eqs, ins...), ranges...) {
+		if !containsExpr(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	// an index has a single array key
+	rv := keys[:0]
+	array := false
+	for _, key := range keys {
+		if _, ok := key.(*expression.All); ok {
+			if array {
+				continue
+			}
+			array = true
+		}
+		rv = append(rv, key)
+	}
+	return rv
+}
+
+/*
+Returns the key when it is compared with a value that does not depend
+on the keyspace.
+*/
+func adviseOperand(key, other expression.Expression, alias string, names map[string]bool) expression.Expression {
+	if adviseKey(key, alias, names) && adviseValue(other, alias, names) {
+		return key
+	}
+	return nil
+}
+
+/*
+An expression can be an index key when it depends on the keyspace and
+on no other keyspace, and is not the whole document.
+*/
+func adviseKey(expr expression.Expression, alias string, names map[string]bool) bool {
+	if expr.Value() != nil {
+		return false
+	}
+
+	if id, ok := expr.(*expression.Identifier); ok && id.Identifier() == alias {
+		return false
+	}
+
+	keyspaces, err := expression.CountKeySpaces(expr, names)
+	return err == nil && len(keyspaces) == 1 && keyspaces[alias]
+}
+
+func adviseValue(expr expression.Expression, alias string, names map[string]bool) bool {
+	keyspaces, err := expression.CountKeySpaces(expr, names)
+	return err == nil && !keyspaces[alias]
+}
+
+func likePrefix(pattern expression.Expression) bool {
+	val := pattern.Value()
+	if val == nil || val.Type() != value.STRING {
+		return false
+	}
+
+	s := val.Actual().(string)
+	return s != "" && s[0] != '%' && s[0] != '_'
+}
+
+/*
+An ANY over a single array of the keyspace gives an array key on the
+elements compared in the SATISFIES clause, and whether they are
+compared for equality.
+*/
+func adviseArrayKey(bindings expression.Bindings, satisfies expression.Expression, alias string,
+	names map[string]bool) (expression.Expression, bool) {
+
+	if len(bindings) != 1 || bindings[0].Descend() || bindings[0].NameVariable() != "" ||
+		!adviseKey(bindings[0].Expression(), alias, names) {
+		return nil, false
+	}
+
+	variable := expression.NewIdentifier(bindings[0].Variable())
+	terms := expression.Expressions{satisfies}
+	if and, ok := satisfies.(*expression.And); ok {
+		terms = and.Operands()
+	}
+
+	var mapping expression.Expression
+	eq := false
+	for _, term := range terms {
+		var key expression.Expression
+		switch term := term.(type) {
+		case *expression.Eq:
+			key = arrayOperand(term.First(), term.Second(), variable, alias, names)
+			if key == nil {
+				key = arrayOperand(term.Second(), term.First(), variable, alias, names)
+			}
+			if key != nil {
+				mapping, eq = key, true
+			}
+		case *expression.LT, *expression.LE, *expression.In, *expression.Like:
+			first := term.(expression.BinaryFunction).First()
+			second := term.(expression.BinaryFunction).Second()
+			key = arrayOperand(first, second, variable, alias, names)
+			if key == nil {
+				if _, ok := term.(*expression.In); !ok {
+					key = arrayOperand(second, first, variable, alias, names)
+				}
+			}
+			if key != nil && mapping == nil {
+				mapping = key
+			}
+		}
+
+		if eq {
+			break
+		}
+	}
+
+	if mapping == nil {
+		return nil, false
+	}
+
+	return expression.NewAll(expression.NewArray(mapping.Copy(), expression.Bindings{bindings[0].Copy()}, nil), true), eq
+}
+
+func arrayOperand(key, other, variable expression.Expression, alias string,
+	names map[string]bool) expression.Expression {
+
+	if key.Value() != nil || !key.DependsOn(variable) || other.DependsOn(variable) {
+		return nil
+	}
+
+	keyspaces, err := expression.CountKeySpaces(key, names)
+	if err != nil || len(keyspaces) > 0 {
+		return nil
+	}
+
+	if !adviseValue(other, alias, names) {
+		return nil
+	}
+	return key
+}
+
+/*
+The paths of the keyspace a covering index must add to the keys. The
+query cannot be covered when it reads the whole document, metadata
+other than the document key, or runs a subquery.
+*/
+func coverPaths(cover expression.HasExpressions, keys expression.Expressions, alias string) (
+	expression.Expressions, bool) {
+
+	if cover == nil {
+		return nil, false
+	}
+
+	for _, key := range keys {
+		if _, ok := key.(*expression.All); ok {
+			return nil, false
+		}
+	}
+
+	var paths expression.Expressions
+	var ok bool
+	for _, expr := range cover.Expressions() {
+		paths, ok = appendPaths(expr, alias, paths)
+		if !ok {
+			return nil, false
+		}
+	}
+
+	id := expression.NewField(expression.NewMeta(expression.NewIdentifier(alias)),
+		expression.NewFieldName("id", false))
+	rv := paths[:0]
+	for _, path := range paths {
+		if !expression.IsCovered(path, alias, append(keys[:len(keys):len(keys)], id)) {
+			rv = append(rv, path)
+		}
+	}
+	return rv, true
+}
+
+func appendPaths(expr expression.Expression, alias string, paths expression.Expressions) (
+	expression.Expressions, bool) {
+
+	if root, ok := pathRoot(expr); ok {
+		if root == alias && !containsExpr(paths, expr) {
+			paths = append(paths, expr)
+		}
+		return paths, true
+	}
+
+	switch expr := expr.(type) {
+	case *expression.Identifier:
+		return paths, expr.Identifier() != alias
+	case *expression.Field:
+		if _, ok := expr.First().(*expression.Meta); ok {
+			name, ok := expr.Second().(*expression.FieldName)
+			if ok && name.Alias() == "id" {
+				return paths, true
+			}
+		}
+	case *expression.Meta:
+		operands := expr.Operands()
+		if len(operands) == 0 {
+			return paths, true
+		}
+		if id, ok := operands[0].(*expression.Identifier); ok && id.Identifier() != alias {
+			return paths, true
+		}
+		return paths, false
+	case expression.Subquery:
+		return paths, false
+	}
+
+	var ok bool
+	for _, child := range expr.Children() {
+		paths, ok = appendPaths(child, alias, paths)
+		if !ok {
+			return nil, false
+		}
+	}
+	return paths, true
+}
+
+/*
+Returns the identifier a path of fields and constant elements is read
+from.
+*/
+func pathRoot(expr expression.Expression) (string, bool) {
+	path := false
+	for {
+		switch e := expr.(type) {
+		case *expression.Field:
+			if _, ok := e.Second().(*expression.FieldName); !ok {
+				return "", false
+			}
+			expr = e.First()
+		case *expression.Element:
+			if e.Second().Value() == nil {
+				return "", false
+			}
+			expr = e.First()
+		case *expression.Identifier:
+			return e.Identifier(), path
+		default:
+			return "", false
+		}
+		path = true
+	}
+}
+
+/*
+A hypothetical index on the keys derived for a keyspace term. It is
+offered to the planner as an online index without statistics, and is
+never scanned.
+*/
+type virtualIndex struct {
+	keyspace datastore.Keyspace
+	indexer  datastore.Indexer
+	name     string
+	keys     expression.Expressions // keys of the index
+	covering bool                   // covers the query
+}
+
+func newVirtualIndex(keyspace datastore.Keyspace, alias string, keys expression.Expressions,
+	covering bool) (*virtualIndex, error) {
+
+	indexers, err := keyspace.Indexers()
+	if err != nil || len(indexers) == 0 {
+		return nil, err
+	}
+
+	mapper := newIndexKeyMapper(alias)
+	ikeys := make(expression.Expressions, len(keys))
+	for i, key := range keys {
+		var er error
+		ikeys[i], er = mapper.Map(key.Copy())
+		if er != nil {
+			// the key cannot be indexed
+			return nil, nil
+		}
+	}
+
+	return &virtualIndex{
+		keyspace: keyspace,
+		indexer:  indexers[0],
+		name:     adviseIndexName(ikeys),
+		keys:     ikeys,
+		covering: covering,
+	}, nil
+}
+
+func (this *virtualIndex) KeyspaceId() string {
+	return this.keyspace.Id()
+}
+
+func (this *virtualIndex) Id() string {
+	return this.name
+}
+
+func (this *virtualIndex) Name() string {
+	return this.name
+}
+
+func (this *virtualIndex) Type() datastore.IndexType {
+	return this.indexer.Name()
+}
+
+func (this *virtualIndex) Indexer() datastore.Indexer {
+	return this.indexer
+}
+
+func (this *virtualIndex) SeekKey() expression.Expressions {
+	return nil
+}
+
+func (this *virtualIndex) RangeKey() expression.Expressions {
+	return this.keys
+}
+
+func (this *virtualIndex) Condition() expression.Expression {
+	return nil
+}
+
+func (this *virtualIndex) IsPrimary() bool {
+	return false
+}
+
+func (this *virtualIndex) State() (datastore.IndexState, string, errors.Error) {
+	return datastore.ONLINE, "", nil
+}
+
+func (this *virtualIndex) Statistics(requestId string, span *datastore.Span) (datastore.Statistics, errors.Error) {
+	return nil, nil
+}
+
+func (this *virtualIndex) Drop(requestId string) errors.Error {
+	return errors.NewPlanInternalError(fmt.Sprintf("index %s is hypothetical", this.name))
+}
+
+func (this *virtualIndex) Scan(requestId string, span *datastore.Span, distinct bool, limit int64,
+	cons datastore.ScanConsistency, vector timestamp.Vector, conn *datastore.IndexConnection) {
+	defer close(conn.EntryChannel())
+	conn.Error(errors.NewPlanInternalError(fmt.Sprintf("index %s is hypothetical", this.name)))
+}
+
+func (this *virtualIndex) keyspaceName() string {
+	return this.keyspace.NamespaceId() + ":" + this.keyspace.Name()
+}
+
+func (this *virtualIndex) createStatement() string {
+	var buf bytes.Buffer
+	buf.WriteString("CREATE INDEX ")
+	buf.WriteString(this.name)
+	buf.WriteString(" ON `")
+	buf.WriteString(this.keyspace.NamespaceId())
+	buf.WriteString("`:`")
+	buf.WriteString(this.keyspace.Name())
+	buf.WriteString("`(")
+	for i, key := range this.keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(indexKeyText(key))
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+func indexKeyText(key expression.Expression) string {
+	all, ok := key.(*expression.All)
+	if !ok {
+		return expression.NewStringer().Visit(key)
+	}
+
+	s := "ALL "
+	if all.Distinct() {
+		s = "DISTINCT "
+	}
+	return s + expression.NewStringer().Visit(all.Array())
+}
+
+/*
+Name an index after its keys, and an array key after the array and
+the elements indexed.
+*/
+func adviseIndexName(keys expression.Expressions) string {
+	var buf bytes.Buffer
+	buf.WriteString("adv")
+	for _, key := range keys {
+		if all, ok := key.(*expression.All); ok {
+			if array, ok := all.Array().(*expression.Array); ok && len(array.Bindings()) == 1 {
+				buf.WriteString("_")
+				buf.WriteString(nameText(array.Bindings()[0].Expression()))
+				key = array.ValueMapping()
+			}
+		}
+		buf.WriteString("_")
+		buf.WriteString(nameText(key))
+	}
+	return buf.String()
+}
+
+func nameText(expr expression.Expression) string {
+	s := expression.NewStringer().Visit(expr)
+	name := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			name = append(name, c)
+		} else if len(name) > 0 && name[len(name)-1] != '_' {
+			name = append(name, '_')
+		}
+	}
+	return strings.TrimRight(string(name), "_")
+}
+
+/*
+Map a key of the keyspace to a key of an index on it, which reads
+the fields of the document directly.
+*/
+type indexKeyMapper struct {
+	expression.MapperBase
+
+	alias string
+}
+
+func newIndexKeyMapper(alias string) *indexKeyMapper {
+	rv := &indexKeyMapper{
+		alias: alias,
+	}
+
+	rv.SetMapper(rv)
+	rv.SetMapFunc(func(expr expression.Expression) (expression.Expression, error) {
+		switch expr := expr.(type) {
+		case *expression.Field:
+			if id, ok := expr.First().(*expression.Identifier); ok && id.Identifier() == rv.alias {
+				if name, ok := expr.Second().(*expression.FieldName); ok {
+					return expression.NewIdentifier(name.Alias()), nil
+				}
+			}
+		case *expression.Meta:
+			if len(expr.Operands()) == 1 {
+				if id, ok := expr.Operands()[0].(*expression.Identifier); ok && id.Identifier() == rv.alias {
+					return expression.NewMeta(), nil
+				}
+			}
+		case *expression.Identifier:
+			if expr.Identifier() == rv.alias {
+				return nil, errors.NewPlanInternalError("index key reads the whole document")
+			}
+		}
+
+		return expr, expr.MapChildren(rv)
+	})
+
+	return rv
+}
+
+/*
+A scan of the plan, with the indexes it reads, and whether it covers
+the query.
+*/
+type adviseScan struct {
+	keyspace string
+	alias    string
+	indexes  []datastore.Index
+	covering bool
+}
+
+/*
+Returns the index scans of the plans of a statement and its subqueries.
+*/
+func adviseScans(ops []plan.Operator) []*adviseScan {
+	var scans []*adviseScan
+	for _, op := range ops {
+		scans = appendScans(op, scans)
+	}
+	return scans
+}
+
+func appendScans(op plan.Operator, scans []*adviseScan) []*adviseScan {
+	switch op := op.(type) {
+	case *plan.Sequence:
+		for _, child := range op.Children() {
+			scans = appendScans(child, scans)
+		}
+	case *plan.Parallel:
+		scans = appendScans(op.Child(), scans)
+	case *plan.Authorize:
+		scans = appendScans(op.Child(), scans)
+	case *plan.NLJoin:
+		scans = appendScans(op.Child(), scans)
+	case *plan.NLNest:
+		scans = appendScans(op.Child(), scans)
+	case *plan.HashJoin:
+		scans = appendScans(op.Child(), scans)
+	case *plan.HashNest:
+		scans = appendScans(op.Child(), scans)
+	case *plan.UnionAll:
+		for _, child := range op.Children() {
+			scans = appendScans(child, scans)
+		}
+	case *plan.IntersectAll:
+		scans = appendScans(op.Second(), appendScans(op.First(), scans))
+	case *plan.ExceptAll:
+		scans = appendScans(op.Second(), appendScans(op.First(), scans))
+	default:
+		indexes := scanIndexes(op, nil)
+		term := scanTerm(op)
+		if len(indexes) == 0 || term == nil {
+			break
+		}
+
+		covering := false
+		if cop, ok := op.(plan.CoveringOperator); ok {
+			covering = cop.Covering()
+		}
+
+		scans = append(scans, &adviseScan{
+			keyspace: term.Namespace() + ":" + term.Keyspace(),
+			alias:    term.Alias(),
+			indexes:  indexes,
+			covering: covering,
+		})
+	}
+	return scans
+}
+
+func scanTerm(op plan.Operator) *algebra.KeyspaceTerm {
+	switch op := op.(type) {
+	case *plan.DistinctScan:
+		return scanTerm(op.Scan())
+	case *plan.IntersectScan:
+		if len(op.Scans()) > 0 {
+			return scanTerm(op.Scans()[0])
+		}
+	case *plan.OrderedIntersectScan:
+		if len(op.Scans()) > 0 {
+			return scanTerm(op.Scans()[0])
+		}
+	case *plan.UnionScan:
+		if len(op.Scans()) > 0 {
+			return scanTerm(op.Scans()[0])
+		}
+	case interface {
+		Term() *algebra.KeyspaceTerm
+	}:
+		return op.Term()
+	}
+	return nil
+}
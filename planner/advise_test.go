@@ -0,0 +1,145 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"testing"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/parser/n1ql"
+)
+
+func whereOf(t *testing.T, text string) expression.Expression {
+	stmt, err := n1ql.ParseStatement(text)
+	if err != nil {
+		t.Fatalf("unable to parse %s: %v", text, err)
+	}
+
+	where := stmt.(*algebra.Select).Subresult().(*algebra.Subselect).Where()
+	dnf := NewDNF(where, true, true)
+	where, err = dnf.Map(where)
+	if err != nil {
+		t.Fatalf("unable to normalize %s: %v", text, err)
+	}
+	return where
+}
+
+func TestAdviseKeys(t *testing.T) {
+	names := map[string]bool{"o": true, "c": true}
+	cases := []struct {
+		where string
+		name  string
+	}{
+		// equality keys first, then IN keys, then range keys
+		{"o.qty > 3 AND o.type = 'a'", "adv_type_qty"},
+		{"o.qty < 3 AND o.cid IN [1, 2] AND o.type = c.type", "adv_type_cid_qty"},
+		{"o.name LIKE 'ab%' AND o.city LIKE '%b'", "adv_name"},
+		{"o.a.b BETWEEN 1 AND 2 AND o.c IS NULL", "adv_c_a_b"},
+		{"ANY v IN o.items SATISFIES v.iid = 5 END", "adv_items_v_iid"},
+		{"LOWER(o.name) = 'x' AND META(o).id LIKE 'k%'", "adv_lower_name_meta_id"},
+
+		// nothing to index
+		{"o.qty = o.cid", ""},
+		{"o.qty = 1 OR o.cid = 2", ""},
+		{"c.cid = 3", ""},
+	}
+
+	for _, c := range cases {
+		where := whereOf(t, "SELECT * FROM ord o JOIN cust c ON o.cid = c.cid WHERE "+c.where)
+		keys := adviseKeys(where, "o", names)
+		if len(keys) == 0 {
+			if c.name != "" {
+				t.Errorf("%s: expected %s, got no keys", c.where, c.name)
+			}
+			continue
+		}
+
+		mapper := newIndexKeyMapper("o")
+		for i, key := range keys {
+			var err error
+			keys[i], err = mapper.Map(key.Copy())
+			if err != nil {
+				t.Fatalf("%s: unable to map key %v: %v", c.where, key, err)
+			}
+		}
+
+		if name := adviseIndexName(keys); name != c.name {
+			t.Errorf("%s: expected %s, got %s", c.where, c.name, name)
+		}
+	}
+}
+
+func TestAdviseDisjunctKeys(t *testing.T) {
+	names := map[string]bool{"o": true}
+	cases := []struct {
+		where string
+		names []string
+	}{
+		{"o.qty = 1 OR o.cid = 2", []string{"adv_qty", "adv_cid"}},
+		{"(o.type = 'a' AND o.qty > 3) OR o.cid IN [1, 2]", []string{"adv_type_qty", "adv_cid"}},
+
+		// a union scan needs an index for every disjunct
+		{"o.qty = 1 OR o.cid = o.qty", nil},
+	}
+
+	for _, c := range cases {
+		where := whereOf(t, "SELECT * FROM ord o WHERE "+c.where)
+		or, ok := where.(*expression.Or)
+		if !ok {
+			t.Fatalf("%s: expected an OR, got %v", c.where, where)
+		}
+
+		disjuncts := adviseDisjunctKeys(or, "o", names)
+		if len(disjuncts) != len(c.names) {
+			t.Errorf("%s: expected %v, got %d disjuncts", c.where, c.names, len(disjuncts))
+			continue
+		}
+
+		mapper := newIndexKeyMapper("o")
+		for i, keys := range disjuncts {
+			for j, key := range keys {
+				var err error
+				keys[j], err = mapper.Map(key.Copy())
+				if err != nil {
+					t.Fatalf("%s: unable to map key %v: %v", c.where, key, err)
+				}
+			}
+
+			if name := adviseIndexName(keys); name != c.names[i] {
+				t.Errorf("%s: expected %s, got %s", c.where, c.names[i], name)
+			}
+		}
+	}
+}
+
+func TestAdviseCoverPaths(t *testing.T) {
+	stmt, err := n1ql.ParseStatement("SELECT o.oid, o.qty + 1 AS q, META(o).id FROM ord o WHERE o.cid = 5")
+	if err != nil {
+		t.Fatalf("unable to parse: %v", err)
+	}
+
+	sub := stmt.(*algebra.Select).Subresult().(*algebra.Subselect)
+	keys := adviseKeys(sub.Where(), "o", map[string]bool{"o": true})
+	paths, ok := coverPaths(sub, keys, "o")
+	if !ok || len(paths) != 2 {
+		t.Fatalf("expected the paths oid and qty, got %v", paths)
+	}
+
+	stmt, err = n1ql.ParseStatement("SELECT o FROM ord o WHERE o.cid = 5")
+	if err != nil {
+		t.Fatalf("unable to parse: %v", err)
+	}
+
+	sub = stmt.(*algebra.Select).Subresult().(*algebra.Subselect)
+	if _, ok = coverPaths(sub, keys, "o"); ok {
+		t.Errorf("expected the whole document not to be covered")
+	}
+}
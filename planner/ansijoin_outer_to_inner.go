@@ -135,13 +135,73 @@ func (this *ansijoinOuterToInner) VisitAnsiJoin(node *algebra.AnsiJoin) (interfa
 	if err != nil {
 		return nil, err
 	}
+
+	if !node.RightOuter() {
+		if aoj2aij {
+			this.addOnclause(node.Onclause())
+			node.SetOuter(false)
+		}
+		return nil, nil
+	}
+
+	// a RIGHT or FULL OUTER JOIN becomes an INNER or LEFT OUTER JOIN
+	// when its unmatched right-hand side rows are rejected
+	roj2loj, err := this.nullRejLeft(node.Left())
+	if err != nil {
+		return nil, err
+	}
+
 	if aoj2aij {
-		this.addOnclause(node.Onclause())
 		node.SetOuter(false)
 	}
+	if roj2loj {
+		node.SetRightOuter(false)
+	}
+	if (aoj2aij || roj2loj) && !node.Outer() && !node.RightOuter() {
+		this.addOnclause(node.Onclause())
+	}
 	return nil, nil
 }
 
+/*
+Check whether the WHERE clause rejects the rows in which all keyspaces
+of the left-hand side of a join are MISSING. Pushable ON-clauses are not
+considered, since those of the inner joins on the left-hand side itself
+say nothing about the rows with no match.
+*/
+func (this *ansijoinOuterToInner) nullRejLeft(left algebra.FromTerm) (bool, error) {
+	aliases := make(map[string]*baseKeyspace, len(this.baseKeyspaces))
+	_, err := left.Accept(newKeyspaceFinder(aliases))
+	if err != nil {
+		return false, err
+	}
+
+	chkNullRej := newChkNullRej()
+
+	for a, _ := range aliases {
+		baseKeyspace, ok := this.baseKeyspaces[a]
+		if !ok {
+			return false, errors.NewPlanInternalError(fmt.Sprintf("ansijoinOuterToInner: missing baseKeyspace for %s", a))
+		}
+
+		chkNullRej.setAlias(a)
+
+		for _, fl := range baseKeyspace.filters {
+			if !fl.isOnclause() && nullRejExpr(chkNullRej, fl.fltrExpr) {
+				return true, nil
+			}
+		}
+
+		for _, jfl := range baseKeyspace.joinfilters {
+			if !jfl.isOnclause() && nullRejExpr(chkNullRej, jfl.fltrExpr) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func (this *ansijoinOuterToInner) VisitNest(node *algebra.Nest) (interface{}, error) {
 	// no mixing of lookup nest and ANSI JOIN/NEST
 	return nil, nil
@@ -176,6 +236,10 @@ func (this *ansijoinOuterToInner) VisitUnionAll(node *algebra.UnionAll) (interfa
 	return nil, this.visitSetop(node.First(), node.Second())
 }
 
+func (this *ansijoinOuterToInner) VisitRecursiveUnion(node *algebra.RecursiveUnion) (interface{}, error) {
+	return nil, this.visitSetop(node.First(), node.Second())
+}
+
 func (this *ansijoinOuterToInner) VisitIntersect(node *algebra.Intersect) (interface{}, error) {
 	return nil, this.visitSetop(node.First(), node.Second())
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/couchbase/query/datastore"
 	"github.com/couchbase/query/expression"
 	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/util"
 	"github.com/couchbase/query/value"
 )
 
@@ -94,6 +95,9 @@ type builder struct {
 	baseKeyspaces     map[string]*baseKeyspace
 	pushableOnclause  expression.Expression // combined ON-clause from all inner joins
 	builderFlags      uint32
+	optimHints        *algebra.OptimHints   // optimizer hints of the query block
+	hintBlocks        []*algebra.OptimHints // optimizer hints of all the query blocks, for EXPLAIN
+	advisor           *indexAdvisor         // keyspace terms and hypothetical indexes, for ADVISE
 }
 
 type indexPushDowns struct {
@@ -152,6 +156,15 @@ func newBuilder(datastore, systemstore datastore.Datastore, namespace string, su
 	return rv
 }
 
+/*
+Covering answers queries from indexes and keyspace counts without
+fetching documents, so it is turned off for requests in a transaction,
+which must see their own changes.
+*/
+func (this *builder) covering() bool {
+	return this.cover != nil && util.IsFeatureEnabled(this.featureControls, util.N1QL_INDEX_COVERING)
+}
+
 func (this *builder) trueWhereClause() bool {
 	return (this.builderFlags & BUILDER_WHERE_IS_TRUE) != 0
 }
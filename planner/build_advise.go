@@ -0,0 +1,57 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/plan"
+)
+
+/*
+The advice on a statement is made as it is planned. ADVISE ALL reads
+the completed requests, and advises on their statements as it runs.
+*/
+func (this *builder) VisitAdvise(stmt *algebra.Advise) (interface{}, error) {
+	if stmt.Statement() == nil {
+		return this.buildAdviseAll()
+	}
+
+	if _, ok := stmt.Statement().(*algebra.Truncate); ok {
+		return nil, errors.NewAdviseNotSupportedError(stmt.Statement().Type())
+	}
+
+	advice, err := this.adviseIndexes(stmt.Text())
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.NewAdvise(advice.Report()), nil
+}
+
+func (this *builder) buildAdviseAll() (interface{}, error) {
+	keyspace, err := this.getNameKeyspace("#system", "completed_requests")
+	if err != nil {
+		return nil, err
+	}
+
+	primary, err := buildPrimaryIndex(keyspace, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	alias := "completed_requests"
+	term := algebra.NewKeyspaceTerm(keyspace.NamespaceId(), keyspace.Name(), alias, nil, nil)
+	return plan.NewSequence(
+		plan.NewPrimaryScan(primary, keyspace, term, nil),
+		plan.NewFetch(keyspace, term, nil),
+		plan.NewAdviseAll(alias),
+	), nil
+}
@@ -15,6 +15,7 @@ import (
 )
 
 func (this *builder) VisitDelete(stmt *algebra.Delete) (interface{}, error) {
+	this.setOptimHints(stmt.OptimHints())
 	this.cover = stmt
 	this.node = stmt
 	this.where = stmt.Where()
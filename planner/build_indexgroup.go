@@ -136,3 +136,49 @@ func (this *FullAggCoverer) VisitNamedParameter(expr expression.NamedParameter)
 func (this *FullAggCoverer) VisitPositionalParameter(expr expression.PositionalParameter) (interface{}, error) {
 	return expr, nil
 }
+
+/*
+Maps the group keys to their covers, without descending into group
+aggregates, which are computed from the group's input.
+*/
+type GroupingKeyCoverer struct {
+	expression.MapperBase
+
+	covers []*expression.Cover
+}
+
+func NewGroupingKeyCoverer(covers []*expression.Cover) *GroupingKeyCoverer {
+	rv := &GroupingKeyCoverer{
+		covers: covers,
+	}
+
+	rv.SetMapper(rv)
+	rv.SetMapFunc(func(expr expression.Expression) (expression.Expression, error) {
+
+		if _, ok := expr.(*expression.Cover); ok {
+			return expr, nil
+		}
+
+		if agg, ok := expr.(algebra.Aggregate); ok && agg.WindowTerm() == nil {
+			return expr, nil
+		}
+
+		for _, c := range covers {
+			if c.Covered().EquivalentTo(expr) {
+				return c, nil
+			}
+		}
+
+		return expr, expr.MapChildren(rv)
+	})
+
+	return rv
+}
+
+func (this *GroupingKeyCoverer) VisitNamedParameter(expr expression.NamedParameter) (interface{}, error) {
+	return expr, nil
+}
+
+func (this *GroupingKeyCoverer) VisitPositionalParameter(expr expression.PositionalParameter) (interface{}, error) {
+	return expr, nil
+}
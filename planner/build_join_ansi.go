@@ -20,6 +20,10 @@ import (
 )
 
 func (this *builder) buildAnsiJoin(node *algebra.AnsiJoin) (op plan.Operator, err error) {
+	if node.RightOuter() {
+		return this.buildAnsiRightOuterJoin(node)
+	}
+
 	right := node.Right()
 
 	if ksterm := algebra.GetKeyspaceTerm(right); ksterm != nil {
@@ -34,9 +38,10 @@ func (this *builder) buildAnsiJoin(node *algebra.AnsiJoin) (op plan.Operator, er
 		}
 
 		if util.IsFeatureEnabled(this.featureControls, util.N1QL_HASH_JOIN) {
-			// currently only consider hash join when USE HASH join hint is specified
+			// currently only consider hash join when USE HASH join hint is specified,
+			// or when the right-hand side is sampled
 			var hjoin *plan.HashJoin
-			if right.PreferHash() {
+			if right.PreferHash() || (right.Sample() != nil && !right.PreferNL()) {
 				hjoin, err = this.buildHashJoin(node)
 				if hjoin != nil || err != nil {
 					return hjoin, err
@@ -44,6 +49,11 @@ func (this *builder) buildAnsiJoin(node *algebra.AnsiJoin) (op plan.Operator, er
 			}
 		}
 
+		// a nested-loop join scans the right-hand side once per left-hand row
+		if right.Sample() != nil {
+			return nil, errors.NewSampleNestedLoopError(right.Alias())
+		}
+
 		right.SetUnderNL()
 		scans, primaryJoinKeys, newOnclause, err := this.buildAnsiJoinScan(right, node.Onclause())
 		if err != nil {
@@ -109,6 +119,213 @@ func (this *builder) buildAnsiJoin(node *algebra.AnsiJoin) (op plan.Operator, er
 	}
 }
 
+/*
+RIGHT and FULL OUTER JOINs keep the rows of the right-hand side that
+have no match, so the right-hand side is planned independently of the
+left-hand side, and without using the ON-clause to select its rows. It
+is then either built into the hash table of a hash join, or read once by
+a nested-loop join.
+*/
+func (this *builder) buildAnsiRightOuterJoin(node *algebra.AnsiJoin) (op plan.Operator, err error) {
+	right := node.Right()
+
+	if ksterm := algebra.GetKeyspaceTerm(right); ksterm != nil {
+		right = ksterm
+	}
+
+	switch right := right.(type) {
+	case *algebra.KeyspaceTerm:
+		if right.Keys() != nil && right.Keys().Static() == nil {
+			return nil, errors.NewRightOuterJoinCorrelatedError(right.Alias())
+		}
+	case *algebra.ExpressionTerm:
+		correlated := right.IsCorrelated()
+		if !correlated {
+			correlated, err = dependsOnLeft(right.ExpressionTerm(), node.Left())
+			if err != nil {
+				return nil, err
+			}
+		}
+		if correlated {
+			return nil, errors.NewRightOuterJoinCorrelatedError(right.Alias())
+		}
+	case *algebra.SubqueryTerm:
+		if right.Subquery().IsCorrelated() {
+			return nil, errors.NewRightOuterJoinCorrelatedError(right.Alias())
+		}
+	default:
+		return nil, errors.NewPlanInternalError(fmt.Sprintf("buildAnsiRightOuterJoin: Unexpected right-hand side node type"))
+	}
+
+	baseKeyspace, ok := this.baseKeyspaces[right.Alias()]
+	if !ok {
+		return nil, errors.NewPlanInternalError(fmt.Sprintf("buildAnsiRightOuterJoin: missing baseKeyspace %s", right.Alias()))
+	}
+
+	// the join filters of the ON-clause give the hash join its keys, but
+	// the ON-clause filters are left out of the index selection
+	_, err = this.processPredicate(node.Onclause(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = combineFilters(baseKeyspace, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if util.IsFeatureEnabled(this.featureControls, util.N1QL_HASH_JOIN) && !right.PreferNL() {
+		hjoin, err := this.buildHashJoin(node)
+		if hjoin != nil || err != nil {
+			return hjoin, err
+		}
+	}
+
+	child, err := this.buildAnsiRightOuterScan(right)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.NewNLJoin(node, child), nil
+}
+
+/*
+Plan the right-hand side of a RIGHT or FULL OUTER JOIN on its own, for
+a nested-loop join to read once.
+*/
+func (this *builder) buildAnsiRightOuterScan(right algebra.SimpleFromTerm) (child plan.Operator, err error) {
+	// left hand side is already built
+	if len(this.subChildren) > 0 {
+		parallel := plan.NewParallel(plan.NewSequence(this.subChildren...), this.maxParallelism)
+		this.children = append(this.children, parallel)
+		this.subChildren = make([]plan.Operator, 0, 16)
+	}
+
+	children := this.children
+	subChildren := this.subChildren
+	coveringScans := this.coveringScans
+	countScan := this.countScan
+	orderScan := this.orderScan
+	indexPushDowns := this.storeIndexPushDowns()
+	defer func() {
+		this.children = children
+		this.subChildren = subChildren
+		this.countScan = countScan
+		this.orderScan = orderScan
+		this.restoreIndexPushDowns(indexPushDowns, true)
+
+		if len(this.coveringScans) > 0 {
+			this.coveringScans = append(coveringScans, this.coveringScans...)
+		} else {
+			this.coveringScans = coveringScans
+		}
+	}()
+
+	this.children = make([]plan.Operator, 0, 16)
+	this.subChildren = make([]plan.Operator, 0, 16)
+	this.coveringScans = nil
+	this.countScan = nil
+	this.order = nil
+	this.orderScan = nil
+	this.limit = nil
+	this.offset = nil
+
+	// as for the build side of a hash join, join filters cannot be
+	// used for index selection
+	if ksterm, ok := right.(*algebra.KeyspaceTerm); ok {
+		ksterm.SetUnderHash()
+	}
+
+	_, err = right.Accept(this)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(this.subChildren) > 0 {
+		this.children = append(this.children, this.subChildren...)
+	}
+
+	if len(this.children) == 0 {
+		return nil, errors.NewPlanInternalError(fmt.Sprintf("buildAnsiRightOuterScan: no plan built for %s", right.Alias()))
+	}
+
+	return plan.NewSequence(this.children...), nil
+}
+
+// Check whether an expression refers to the left-hand side of a join
+func dependsOnLeft(expr expression.Expression, left algebra.FromTerm) (bool, error) {
+	leftKeyspaces := make(map[string]*baseKeyspace, 8)
+	_, err := left.Accept(newKeyspaceFinder(leftKeyspaces))
+	if err != nil {
+		return false, err
+	}
+
+	for alias, _ := range leftKeyspaces {
+		if expr.DependsOn(expression.NewIdentifier(alias)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+/*
+The left-hand side of a RIGHT or FULL OUTER JOIN supplies MISSING for
+the right-hand side rows with no match, so the WHERE clause, and the ON-
+clauses of the inner joins above it, cannot select its rows: a row they
+reject could be the match that keeps a right-hand side row from being
+sent on its own. Only the ON-clauses of the inner joins within it still
+apply. Filters that reject MISSING have already turned such joins into
+inner or left outer joins.
+*/
+func (this *builder) processRightOuterJoin(from algebra.FromTerm) error {
+	var left algebra.FromTerm
+
+	// the outermost RIGHT or FULL OUTER JOIN covers all the others
+	for term := from; term != nil && left == nil; {
+		switch t := term.(type) {
+		case *algebra.AnsiJoin:
+			if t.RightOuter() {
+				left = t.Left()
+			}
+			term = t.Left()
+		case algebra.JoinTerm:
+			term = t.Left()
+		default:
+			term = nil
+		}
+	}
+
+	if left == nil {
+		return nil
+	}
+
+	leftKeyspaces := make(map[string]*baseKeyspace, len(this.baseKeyspaces))
+	keyspaceFinder := newKeyspaceFinder(leftKeyspaces)
+	_, err := left.Accept(keyspaceFinder)
+	if err != nil {
+		return err
+	}
+
+	for alias, _ := range leftKeyspaces {
+		baseKeyspace, ok := this.baseKeyspaces[alias]
+		if !ok {
+			return errors.NewPlanInternalError(fmt.Sprintf("processRightOuterJoin: missing baseKeyspace %s", alias))
+		}
+		baseKeyspace.filters = nil
+		baseKeyspace.joinfilters = nil
+	}
+
+	if keyspaceFinder.pushableOnclause != nil {
+		_, err = this.processPredicate(keyspaceFinder.pushableOnclause, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (this *builder) buildAnsiNest(node *algebra.AnsiNest) (op plan.Operator, err error) {
 	right := node.Right()
 
@@ -124,9 +341,10 @@ func (this *builder) buildAnsiNest(node *algebra.AnsiNest) (op plan.Operator, er
 		}
 
 		if util.IsFeatureEnabled(this.featureControls, util.N1QL_HASH_JOIN) {
-			// currently only consider hash nest when USE HASH join hint is specified
+			// currently only consider hash nest when USE HASH join hint is specified,
+			// or when the right-hand side is sampled
 			var hnest *plan.HashNest
-			if right.PreferHash() {
+			if right.PreferHash() || (right.Sample() != nil && !right.PreferNL()) {
 				hnest, err = this.buildHashNest(node)
 				if hnest != nil || err != nil {
 					return hnest, err
@@ -134,6 +352,11 @@ func (this *builder) buildAnsiNest(node *algebra.AnsiNest) (op plan.Operator, er
 			}
 		}
 
+		// a nested-loop nest scans the right-hand side once per left-hand row
+		if right.Sample() != nil {
+			return nil, errors.NewSampleNestedLoopError(right.Alias())
+		}
+
 		right.SetUnderNL()
 		scans, primaryJoinKeys, newOnclause, err := this.buildAnsiJoinScan(right, node.Onclause())
 		if err != nil {
@@ -364,7 +587,12 @@ func (this *builder) buildAnsiJoinScan(node *algebra.KeyspaceTerm, onclause expr
 }
 
 func (this *builder) buildHashJoin(node *algebra.AnsiJoin) (hjoin *plan.HashJoin, err error) {
-	child, buildExprs, probeExprs, aliases, err := this.buildHashJoinScan(node.Right(), node.Outer(), "join")
+	var onclause expression.Expression
+	if node.RightOuter() {
+		onclause = node.Onclause()
+	}
+
+	child, buildExprs, probeExprs, aliases, err := this.buildHashJoinScan(node.Right(), node.Outer(), onclause, "join")
 	if err != nil || child == nil {
 		// cannot do hash join
 		return nil, err
@@ -373,7 +601,7 @@ func (this *builder) buildHashJoin(node *algebra.AnsiJoin) (hjoin *plan.HashJoin
 }
 
 func (this *builder) buildHashNest(node *algebra.AnsiNest) (hnest *plan.HashNest, err error) {
-	child, buildExprs, probeExprs, aliases, err := this.buildHashJoinScan(node.Right(), node.Outer(), "nest")
+	child, buildExprs, probeExprs, aliases, err := this.buildHashJoinScan(node.Right(), node.Outer(), nil, "nest")
 	if err != nil || child == nil {
 		// cannot do hash nest
 		return nil, err
@@ -384,7 +612,12 @@ func (this *builder) buildHashNest(node *algebra.AnsiNest) (hnest *plan.HashNest
 	return plan.NewHashNest(node, child, buildExprs, probeExprs, aliases[0]), nil
 }
 
-func (this *builder) buildHashJoinScan(right algebra.SimpleFromTerm, outer bool, op string) (
+/*
+For RIGHT and FULL OUTER JOINs, the ON-clause is passed in. The right-hand
+side is then always the build side, and the hash keys only come from the
+ON-clause, since the rows with no match must be sent whatever else holds.
+*/
+func (this *builder) buildHashJoinScan(right algebra.SimpleFromTerm, outer bool, onclause expression.Expression, op string) (
 	child plan.Operator, buildExprs expression.Expressions, probeExprs expression.Expressions, buildAliases []string, err error) {
 
 	var ksterm *algebra.KeyspaceTerm
@@ -422,7 +655,10 @@ func (this *builder) buildHashJoinScan(right algebra.SimpleFromTerm, outer bool,
 
 	buildRight := false
 	joinHint := right.JoinHint()
-	if joinHint == algebra.USE_HASH_BUILD {
+	if onclause != nil {
+		// the unmatched rows of the right-hand side are tracked in the hash table
+		buildRight = true
+	} else if joinHint == algebra.USE_HASH_BUILD {
 		buildRight = true
 	} else if joinHint == algebra.USE_HASH_PROBE {
 		// in case of outer join, cannot build on dominant side
@@ -448,13 +684,23 @@ func (this *builder) buildHashJoinScan(right algebra.SimpleFromTerm, outer bool,
 	leftExprs := make(expression.Expressions, 0, 4)
 	rightExprs := make(expression.Expressions, 0, 4)
 
-	// look for equality join predicates
-	for _, fltr := range baseKeyspace.filters {
-		if !fltr.isJoin() {
-			continue
+	var joinPreds expression.Expressions
+	if and, ok := onclause.(*expression.And); ok {
+		and, _ = flattenAnd(and)
+		joinPreds = and.Operands()
+	} else if onclause != nil {
+		joinPreds = expression.Expressions{onclause}
+	} else {
+		for _, fltr := range baseKeyspace.filters {
+			if fltr.isJoin() {
+				joinPreds = append(joinPreds, fltr.fltrExpr)
+			}
 		}
+	}
 
-		if eqFltr, ok := fltr.fltrExpr.(*expression.Eq); ok {
+	// look for equality join predicates
+	for _, pred := range joinPreds {
+		if eqFltr, ok := pred.(*expression.Eq); ok {
 			if !eqFltr.First().Indexable() || !eqFltr.Second().Indexable() {
 				continue
 			}
@@ -137,7 +137,7 @@ func (this *builder) buildCoveringJoinScan(secondaries map[datastore.Index]*inde
 	node *algebra.KeyspaceTerm, op string, pred expression.Expression) (
 	datastore.Index, expression.Covers, map[*expression.Cover]value.Value, error) {
 
-	if this.cover != nil && op == "join" {
+	if this.covering() && op == "join" {
 		alias := node.Alias()
 		id := expression.NewField(
 			expression.NewMeta(expression.NewIdentifier(alias)),
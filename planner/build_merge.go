@@ -17,6 +17,7 @@ import (
 )
 
 func (this *builder) VisitMerge(stmt *algebra.Merge) (interface{}, error) {
+	this.setOptimHints(stmt.OptimHints())
 	this.children = make([]plan.Operator, 0, 8)
 	this.subChildren = make([]plan.Operator, 0, 8)
 	source := stmt.Source()
@@ -126,6 +127,7 @@ func (this *builder) VisitMerge(stmt *algebra.Merge) (interface{}, error) {
 		right := algebra.NewKeyspaceTerm(ksref.Namespace(), ksref.Keyspace(), ksref.As(), nil, stmt.Indexes())
 		right.SetAnsiJoin()
 		algebra.TransferJoinHint(right, left)
+		this.setJoinHint(right)
 
 		targetKeyspace := newBaseKeyspace(right.Alias())
 		this.baseKeyspaces[targetKeyspace.name] = targetKeyspace
@@ -146,6 +148,7 @@ func (this *builder) VisitMerge(stmt *algebra.Merge) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
+		this.markJoinHints(right.Alias(), join)
 
 		switch join := join.(type) {
 		case *plan.NLJoin:
@@ -26,5 +26,33 @@ func BuildPrepared(stmt algebra.Statement, datastore, systemstore datastore.Data
 	}
 
 	signature := stmt.Signature()
-	return plan.NewPrepared(operator, signature), nil
+	return plan.NewPrepared(operator, signature, resultColumns(stmt)), nil
+}
+
+/*
+Returns the names of the result fields of a statement in projection
+order, or nil if they are not known until execution.
+*/
+func resultColumns(stmt algebra.Statement) []string {
+	var returning *algebra.Projection
+
+	switch stmt := stmt.(type) {
+	case *algebra.Select:
+		return stmt.Columns()
+	case *algebra.Insert:
+		returning = stmt.Returning()
+	case *algebra.Upsert:
+		returning = stmt.Returning()
+	case *algebra.Update:
+		returning = stmt.Returning()
+	case *algebra.Delete:
+		returning = stmt.Returning()
+	case *algebra.Merge:
+		returning = stmt.Returning()
+	}
+
+	if returning == nil {
+		return nil
+	}
+	return returning.Columns()
 }
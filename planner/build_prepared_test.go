@@ -0,0 +1,48 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/couchbase/query/parser/n1ql"
+)
+
+func TestResultColumns(t *testing.T) {
+	cases := []struct {
+		text    string
+		columns []string
+	}{
+		{"SELECT 1 AS one, 's' AS s, null AS n", []string{"one", "s", "n"}},
+		{"SELECT c.z, c.a, c.m FROM contacts c", []string{"z", "a", "m"}},
+		{"SELECT c.z, 1 FROM contacts c", []string{"z", "$1"}},
+		{"SELECT * FROM contacts c", nil},
+		{"SELECT c.z, c.* FROM contacts c", nil},
+		{"SELECT RAW c.z FROM contacts c", nil},
+		{"SELECT c.z, c.a FROM contacts c UNION SELECT d.b, d.a FROM contacts d", []string{"z", "a", "b"}},
+		{"SELECT c.z, c.a FROM contacts c UNION SELECT * FROM contacts d", nil},
+		{"SELECT c.z, c.a FROM contacts c INTERSECT SELECT d.a, d.z FROM contacts d", []string{"z", "a"}},
+		{"DELETE FROM contacts c RETURNING c.z, META(c).id", []string{"z", "id"}},
+		{"DELETE FROM contacts c", nil},
+	}
+
+	for _, c := range cases {
+		stmt, err := n1ql.ParseStatement(c.text)
+		if err != nil {
+			t.Fatalf("Unable to parse %s: %v", c.text, err)
+		}
+
+		columns := resultColumns(stmt)
+		if !reflect.DeepEqual(columns, c.columns) {
+			t.Errorf("%s: expected columns %v, got %v", c.text, c.columns, columns)
+		}
+	}
+}
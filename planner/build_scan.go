@@ -33,7 +33,17 @@ func (this *builder) selectScan(keyspace datastore.Keyspace, node *algebra.Keysp
 			this.maxParallelism = 1
 		}
 
-		return plan.NewKeyScan(keys), nil
+		op = plan.NewKeyScan(keys)
+		err = this.markIndexHints(keyspace, node, op)
+		if err != nil {
+			return nil, err
+		}
+
+		if !node.IsAnsiJoinOp() {
+			this.parallelHint()
+		}
+
+		return op, nil
 	}
 
 	secondary, primary, err := this.buildScan(keyspace, node)
@@ -42,12 +52,23 @@ func (this *builder) selectScan(keyspace datastore.Keyspace, node *algebra.Keysp
 	}
 
 	if secondary != nil {
-		return secondary, nil
+		op = secondary
 	} else if primary != nil {
-		return primary, nil
+		op = primary
 	} else {
 		return nil, nil
 	}
+
+	err = this.markIndexHints(keyspace, node, op)
+	if err != nil {
+		return nil, err
+	}
+
+	if !node.IsAnsiJoinOp() {
+		this.parallelHint()
+	}
+
+	return op, nil
 }
 
 func (this *builder) buildScan(keyspace datastore.Keyspace, node *algebra.KeyspaceTerm) (
@@ -66,6 +87,21 @@ func (this *builder) buildScan(keyspace datastore.Keyspace, node *algebra.Keyspa
 		}
 	}
 
+	// INDEX and NO_INDEX optimizer hints
+	hinted, avoid, err := this.indexHints(keyspace, node)
+	if err != nil {
+		return
+	}
+	if len(hinted) > 0 {
+		hints = hinted
+	}
+
+	skip := hints
+	if len(avoid) > 0 {
+		skip = make([]datastore.Index, 0, len(hints)+len(avoid))
+		skip = append(append(skip, hints...), avoid...)
+	}
+
 	baseKeyspace, ok := this.baseKeyspaces[node.Alias()]
 	if !ok {
 		return nil, nil, errors.NewPlanInternalError(fmt.Sprintf("buildScan: cannot find keyspace %s", node.Alias()))
@@ -86,6 +122,13 @@ func (this *builder) buildScan(keyspace datastore.Keyspace, node *algebra.Keyspa
 		}
 	}
 
+	// SAMPLE n ROWS is taken before the filters are applied, so the
+	// whole keyspace is scanned
+	if node.Sample() != nil && node.Sample().Rows() {
+		pred = nil
+		pred2 = nil
+	}
+
 	id := expression.NewField(
 		expression.NewMeta(expression.NewIdentifier(node.Alias())),
 		expression.NewFieldName("id", false))
@@ -109,10 +152,15 @@ func (this *builder) buildScan(keyspace datastore.Keyspace, node *algebra.Keyspa
 		}
 
 		if baseKeyspace.dnfPred != nil {
+			err = this.adviseTerm(keyspace, node, baseKeyspace.dnfPred)
+			if err != nil {
+				return nil, nil, err
+			}
+
 			if baseKeyspace.origPred == nil {
 				return nil, nil, errors.NewPlanInternalError("buildScan: NULL origPred")
 			}
-			return this.buildPredicateScan(keyspace, node, baseKeyspace, id, hints)
+			return this.buildPredicateScan(keyspace, node, baseKeyspace, id, hints, skip)
 		}
 	}
 
@@ -122,7 +170,7 @@ func (this *builder) buildScan(keyspace datastore.Keyspace, node *algebra.Keyspa
 			op = "nest"
 		}
 		return nil, nil, errors.NewNoAnsiJoinError(node.Alias(), op)
-	} else if this.cover != nil && baseKeyspace.dnfPred == nil {
+	} else if this.covering() && baseKeyspace.dnfPred == nil {
 		// Handle covering primary scan
 		scan, err := this.buildCoveringPrimaryScan(keyspace, node, id, hints)
 		if scan != nil || err != nil {
@@ -135,7 +183,7 @@ func (this *builder) buildScan(keyspace datastore.Keyspace, node *algebra.Keyspa
 }
 
 func (this *builder) buildPredicateScan(keyspace datastore.Keyspace, node *algebra.KeyspaceTerm,
-	baseKeyspace *baseKeyspace, id expression.Expression, hints []datastore.Index) (
+	baseKeyspace *baseKeyspace, id expression.Expression, hints, skip []datastore.Index) (
 	secondary plan.Operator, primary plan.Operator, err error) {
 
 	// Handle constant FALSE predicate
@@ -162,10 +210,11 @@ func (this *builder) buildPredicateScan(keyspace datastore.Keyspace, node *algeb
 
 	others := _INDEX_POOL.Get()
 	defer _INDEX_POOL.Put(others)
-	others, err = allIndexes(keyspace, hints, others, this.indexApiVersion)
+	others, err = allIndexes(keyspace, skip, others, this.indexApiVersion)
 	if err != nil {
 		return
 	}
+	others = this.advisedIndexes(keyspace, node.Alias(), others)
 
 	secondary, primary, err = this.buildSubsetScan(keyspace, node, baseKeyspace, id, others, primaryKey, formalizer, false)
 
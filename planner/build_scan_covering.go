@@ -23,7 +23,7 @@ func (this *builder) buildCoveringScan(indexes map[datastore.Index]*indexEntry,
 	node *algebra.KeyspaceTerm, baseKeyspace *baseKeyspace,
 	id expression.Expression) (plan.SecondaryScan, int, error) {
 
-	if this.cover == nil {
+	if !this.covering() {
 		return nil, 0, nil
 	}
 
@@ -53,6 +53,11 @@ func checkAndAdd(ids []int, id int) []int {
 }
 
 func aggToIndexAgg(agg algebra.Aggregate) *indexGroupAggProperties {
+	// Index aggregates cannot filter or order their input
+	if agg.Filter() != nil || agg.WithinGroup() != nil {
+		return nil
+	}
+
 	name := agg.Name()
 	switch agg.(type) {
 	case *algebra.ArrayAggDistinct, *algebra.CountDistinct, *algebra.CountnDistinct, *algebra.AvgDistinct, *algebra.SumDistinct:
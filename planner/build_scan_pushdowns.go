@@ -446,6 +446,7 @@ outer:
 		for {
 			projexpr, projalias := hashProj[orderTerm.Expression().Alias()]
 			if indexKeyIsDescCollation(i, indexKeys) == orderTerm.Descending() &&
+				indexKeyNullsMatch(i, orderTerm, entry) &&
 				(orderTerm.Expression().EquivalentTo(keys[i]) ||
 					(projalias && expression.Equivalent(projexpr, keys[i]))) {
 				// orderTerm matched with index key
@@ -484,3 +485,16 @@ func equalConditionFilter(filters map[string]value.Value, str string) bool {
 func indexKeyIsDescCollation(keypos int, indexKeys datastore.IndexKeys) bool {
 	return len(indexKeys) > 0 && keypos < len(indexKeys) && indexKeys[keypos].Desc
 }
+
+/*
+Index keys hold NULL and MISSING values first in ascending collation,
+and last in descending collation. Any other position only matches
+when the scan returns no such values.
+*/
+func indexKeyNullsMatch(keypos int, orderTerm *algebra.SortTerm, entry *indexEntry) bool {
+	if orderTerm.NullsFirst() != orderTerm.Descending() || entry.index.IsPrimary() {
+		return true
+	}
+
+	return keypos == 0 && !orderTerm.Descending() && entry.spans.SkipsLeadingNulls()
+}
@@ -25,7 +25,7 @@ func (this *builder) buildSecondaryScan(indexes map[datastore.Index]*indexEntry,
 	node *algebra.KeyspaceTerm, baseKeyspace *baseKeyspace, id expression.Expression) (
 	plan.SecondaryScan, int, error) {
 
-	if this.cover != nil && !node.IsAnsiNest() {
+	if this.covering() && !node.IsAnsiNest() {
 		scan, sargLength, err := this.buildCoveringScan(indexes, node, baseKeyspace, id)
 		if scan != nil || err != nil {
 			return scan, sargLength, err
@@ -47,6 +47,11 @@ func (this *builder) buildSecondaryScan(indexes map[datastore.Index]*indexEntry,
 		return nil, 0, err
 	}
 
+	indexes, err = this.costIndexes(indexes, node)
+	if err != nil || len(indexes) == 0 {
+		return nil, 0, err
+	}
+
 	var orderIndex datastore.Index
 	var limit expression.Expression
 	pushDown := false
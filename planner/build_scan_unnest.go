@@ -210,6 +210,12 @@ func collectInnerUnnests(from algebra.FromTerm, buf []*algebra.Unnest) []*algebr
 		return buf
 	}
 
+	// UNNESTs on the left-hand side of a RIGHT or FULL OUTER JOIN
+	// can be MISSING in its result
+	if ansiJoin, ok := joinTerm.(*algebra.AnsiJoin); ok && ansiJoin.RightOuter() {
+		return buf
+	}
+
 	buf = collectInnerUnnests(joinTerm.Left(), buf)
 
 	unnest, ok := joinTerm.(*algebra.Unnest)
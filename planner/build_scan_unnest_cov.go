@@ -22,7 +22,7 @@ func (this *builder) buildCoveringUnnestScan(node *algebra.KeyspaceTerm, pred ex
 	plan.SecondaryScan, int, error) {
 
 	// Statement to be covered
-	if this.cover == nil {
+	if !this.covering() {
 		return nil, 0, nil
 	}
 
@@ -13,9 +13,11 @@ import (
 	"strings"
 
 	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/expression"
 	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/util"
 	"github.com/couchbase/query/value"
 )
 
@@ -97,8 +99,25 @@ func (this *builder) visitFrom(node *algebra.Subselect, group *algebra.Group) er
 			}
 		}
 
+		err = this.processRightOuterJoin(node.From())
+		if err != nil {
+			return err
+		}
+
+		this.joinHints(node.From())
+
+		// Order inner joins by estimated cost, unless ORDERED is hinted
+		from := node.From()
+		if !this.orderedHint(from) {
+			from, err = this.orderJoins(from)
+			if err != nil {
+				return err
+			}
+		}
+		this.from = from
+
 		// Use FROM clause in index selection
-		_, err = node.From().Accept(this)
+		_, err = from.Accept(this)
 		if err != nil {
 			return err
 		}
@@ -142,6 +161,11 @@ func (this *builder) VisitKeyspaceTerm(node *algebra.KeyspaceTerm) (interface{},
 		return nil, errors.NewSubqueryMissingKeysError(node.Keyspace())
 	}
 
+	// the sample must see the whole scan, so nothing is pushed down
+	if node.Sample() != nil {
+		this.resetPushDowns()
+	}
+
 	scan, err := this.selectScan(keyspace, node)
 	if err != nil {
 		return nil, err
@@ -160,6 +184,14 @@ func (this *builder) VisitKeyspaceTerm(node *algebra.KeyspaceTerm) (interface{},
 	}
 	this.children = append(this.children, scan)
 
+	if node.Sample() != nil {
+		// random entries can only be drawn when the documents are fetched
+		_, random := keyspace.(datastore.RandomEntryProvider)
+		random = random && len(this.coveringScans) == 0 &&
+			node.Sample().Rows() && node.Sample().Seed() == nil
+		this.children = append(this.children, plan.NewSample(keyspace, node, random))
+	}
+
 	if len(this.coveringScans) == 0 && this.countScan == nil {
 		names, err := this.GetSubPaths(node.Alias())
 		if err != nil {
@@ -322,11 +354,14 @@ func (this *builder) VisitAnsiJoin(node *algebra.AnsiJoin) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+	this.markJoinHints(node.Alias(), join)
 
-	switch join := join.(type) {
-	case *plan.NLJoin:
+	// RIGHT and FULL OUTER JOINs only know which rows of the right-hand side
+	// have no match once they have seen all of the left-hand side, so even
+	// nested-loop joins run serially for them
+	if nljoin, ok := join.(*plan.NLJoin); ok && !nljoin.RightOuter() {
 		this.subChildren = append(this.subChildren, join)
-	case *plan.Join, *plan.HashJoin:
+	} else {
 		if len(this.subChildren) > 0 {
 			parallel := plan.NewParallel(plan.NewSequence(this.subChildren...), this.maxParallelism)
 			this.children = append(this.children, parallel)
@@ -444,6 +479,7 @@ func (this *builder) VisitAnsiNest(node *algebra.AnsiNest) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+	this.markJoinHints(node.Alias(), nest)
 
 	switch nest := nest.(type) {
 	case *plan.NLNest:
@@ -495,7 +531,8 @@ func (this *builder) VisitUnnest(node *algebra.Unnest) (interface{}, error) {
 func (this *builder) fastCount(node *algebra.Subselect) (bool, error) {
 	if node.From() == nil ||
 		(node.Where() != nil && (node.Where().Value() == nil || !node.Where().Value().Truth())) ||
-		node.Group() != nil {
+		node.Group() != nil ||
+		!util.IsFeatureEnabled(this.featureControls, util.N1QL_INDEX_COVERING) {
 		return false, nil
 	}
 
@@ -513,7 +550,7 @@ func (this *builder) fastCount(node *algebra.Subselect) (bool, error) {
 		return false, nil
 	}
 
-	if from == nil || from.Keys() != nil {
+	if from == nil || from.Keys() != nil || from.Sample() != nil {
 		return false, nil
 	}
 
@@ -525,7 +562,7 @@ func (this *builder) fastCount(node *algebra.Subselect) (bool, error) {
 
 	for _, term := range node.Projection().Terms() {
 		count, ok := term.Expression().(*algebra.Count)
-		if !ok {
+		if !ok || count.Filter() != nil {
 			return false, nil
 		}
 
@@ -31,6 +31,7 @@ func (this *builder) VisitSubselect(node *algebra.Subselect) (interface{}, error
 	prevPushableOnclause := this.pushableOnclause
 	prevBuilderFlags := this.builderFlags
 	prevMaxParallelism := this.maxParallelism
+	prevOptimHints := this.optimHints
 
 	indexPushDowns := this.storeIndexPushDowns()
 
@@ -45,6 +46,7 @@ func (this *builder) VisitSubselect(node *algebra.Subselect) (interface{}, error
 		this.pushableOnclause = prevPushableOnclause
 		this.builderFlags = prevBuilderFlags
 		this.maxParallelism = prevMaxParallelism
+		this.optimHints = prevOptimHints
 		this.restoreIndexPushDowns(indexPushDowns, false)
 	}()
 
@@ -56,6 +58,7 @@ func (this *builder) VisitSubselect(node *algebra.Subselect) (interface{}, error
 	this.pushableOnclause = nil
 	this.builderFlags = 0
 	this.maxParallelism = 0
+	this.setOptimHints(node.OptimHints())
 
 	this.projection = node.Projection()
 	this.resetIndexGroupAggs()
@@ -88,6 +91,11 @@ func (this *builder) VisitSubselect(node *algebra.Subselect) (interface{}, error
 		return nil, err
 	}
 
+	windowAggs, err := allWindowAggregates(node, this.order, aggs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Infer WHERE clause from aggregates
 	group := node.Group()
 	if group == nil && len(aggs) > 0 {
@@ -162,6 +170,10 @@ func (this *builder) VisitSubselect(node *algebra.Subselect) (interface{}, error
 		for _, term := range node.Projection().Terms() {
 			switch expr := term.Expression().(type) {
 			case *algebra.Count, *algebra.CountDistinct, *algebra.Min, *algebra.Max:
+				if expr.(algebra.Aggregate).Filter() != nil {
+					this.oldAggregates = false
+					break loop
+				}
 				this.oldAggregates = true
 			default:
 				if expr.Value() == nil {
@@ -172,6 +184,11 @@ func (this *builder) VisitSubselect(node *algebra.Subselect) (interface{}, error
 		}
 	}
 
+	// Window functions are computed over all the qualifying rows
+	if len(windowAggs) > 0 {
+		this.resetPushDowns()
+	}
+
 	this.children = make([]plan.Operator, 0, 16)    // top-level children, executed sequentially
 	this.subChildren = make([]plan.Operator, 0, 16) // sub-children, executed across data-parallel streams
 
@@ -189,7 +206,9 @@ func (this *builder) VisitSubselect(node *algebra.Subselect) (interface{}, error
 		}
 	}
 
-	this.setIndexGroupAggs(group, aggs, node.Let())
+	if len(windowAggs) == 0 {
+		this.setIndexGroupAggs(group, aggs, node.Let())
+	}
 
 	err = this.visitFrom(node, group)
 	if err != nil {
@@ -214,9 +233,18 @@ func (this *builder) VisitSubselect(node *algebra.Subselect) (interface{}, error
 		}
 
 		if group != nil {
+			if group.GroupingSets() != nil {
+				err = this.coverGroupingKeys(node, group)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			this.visitGroup(group, aggs)
 		}
 
+		this.visitWindowAggregates(windowAggs)
+
 		projection := node.Projection()
 		this.subChildren = append(this.subChildren, plan.NewInitialProject(projection))
 
@@ -287,17 +315,49 @@ func (this *builder) visitGroup(group *algebra.Group, aggs algebra.Aggregates) {
 
 	if partial {
 		aggv := sortAggregatesSlice(aggs)
-		this.subChildren = append(this.subChildren, plan.NewInitialGroup(group.By(), aggv))
+		sets := group.GroupingSets()
+		this.subChildren = append(this.subChildren, plan.NewInitialGroup(group.By(), aggv, sets))
 		this.children = append(this.children,
 			plan.NewParallel(plan.NewSequence(this.subChildren...), this.maxParallelism))
-		this.children = append(this.children, plan.NewIntermediateGroup(group.By(), aggv))
-		this.children = append(this.children, plan.NewFinalGroup(group.By(), aggv))
+		this.children = append(this.children, plan.NewIntermediateGroup(group.By(), aggv, sets))
+		this.children = append(this.children, plan.NewFinalGroup(group.By(), aggv, sets))
 		this.subChildren = make([]plan.Operator, 0, 8)
 	}
 
 	this.addLetAndPredicate(group.Letting(), group.Having())
 }
 
+/*
+With grouping sets, the group keys rolled up in a group are NULL
+rather than their value in the group's first item. Expressions
+evaluated on the groups refer to the group keys through their covers,
+which the group operators set accordingly.
+*/
+func (this *builder) coverGroupingKeys(node *algebra.Subselect, group *algebra.Group) error {
+	covers := make(expression.Covers, 0, len(group.By()))
+	for _, key := range group.By() {
+		covers = append(covers, expression.NewCover(key))
+	}
+
+	coverer := NewGroupingKeyCoverer(covers)
+	err := node.Projection().MapExpressions(coverer)
+	if err != nil {
+		return err
+	}
+
+	err = group.MapGrouped(coverer)
+	if err != nil {
+		return err
+	}
+
+	// ORDER BY of the enclosing statement
+	if stmt, ok := this.cover.(*algebra.Select); ok && stmt.Subresult() == node && stmt.Order() != nil {
+		err = stmt.Order().MapExpressions(coverer)
+	}
+
+	return err
+}
+
 func (this *builder) coverExpressions() error {
 	for _, op := range this.coveringScans {
 		coverer := expression.NewCoverer(op.Covers(), op.FilterCovers())
@@ -529,7 +589,7 @@ func allAggregates(node *algebra.Subselect, order *algebra.Order) (algebra.Aggre
 		// Disallow nested aggregates
 		subAggs := make(map[string]algebra.Aggregate)
 		for _, agg := range aggs {
-			collectAggregates(subAggs, agg.Operand())
+			collectAggregates(subAggs, agg.Children()...)
 			if len(subAggs) > 0 {
 				return nil, fmt.Errorf("Nested aggregates are not allowed.")
 			}
@@ -571,7 +631,7 @@ func collectAggregates(aggs map[string]algebra.Aggregate, exprs ...expression.Ex
 			continue
 		}
 		agg, ok := expr.(algebra.Aggregate)
-		if ok {
+		if ok && agg.WindowTerm() == nil {
 			str := stringer.Visit(agg)
 			aggs[str] = agg
 		}
@@ -680,6 +740,12 @@ func skipFixedOrderTerms(order *algebra.Order, pred expression.Expression) *alge
 func (this *builder) setIndexGroupAggs(group *algebra.Group, aggs algebra.Aggregates, let expression.Bindings) {
 
 	if group != nil {
+		// Grouping sets are not pushed down to the index
+		if group.GroupingSets() != nil {
+			this.resetPushDowns()
+			return
+		}
+
 		// Group or Aggregates Depends on LET disable pushdowns
 		for _, expr := range group.By() {
 			if !expr.IndexAggregatable() || dependsOnLet(expr, let) {
@@ -690,7 +756,7 @@ func (this *builder) setIndexGroupAggs(group *algebra.Group, aggs algebra.Aggreg
 
 		for _, agg := range aggs {
 			aggIndexProperties := aggToIndexAgg(agg)
-			if !aggIndexProperties.supported {
+			if aggIndexProperties == nil || !aggIndexProperties.supported {
 				this.resetPushDowns()
 				return
 			}
@@ -0,0 +1,181 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/plan"
+)
+
+/*
+Window functions are computed after grouping, LETTING and HAVING, and
+before projection. Each set of window functions sharing a PARTITION
+BY and ORDER BY is computed serially, on input sorted on those terms.
+*/
+func (this *builder) visitWindowAggregates(windowAggs algebra.WindowFunctions) {
+	if len(windowAggs) == 0 {
+		return
+	}
+
+	if len(this.subChildren) > 0 {
+		this.children = append(this.children,
+			plan.NewParallel(plan.NewSequence(this.subChildren...), this.maxParallelism))
+		this.subChildren = make([]plan.Operator, 0, 8)
+	}
+
+	for _, aggs := range windowAggregateSets(windowAggs) {
+		sortTerms := aggs[0].WindowTerm().SortTerms()
+		if len(sortTerms) > 0 {
+			this.children = append(this.children, plan.NewOrder(algebra.NewOrder(sortTerms), nil, nil))
+		}
+
+		this.children = append(this.children, plan.NewWindowAggregate(aggs))
+	}
+}
+
+/*
+Split window functions into sets sharing the same PARTITION BY and
+ORDER BY, preserving their order.
+*/
+func windowAggregateSets(windowAggs algebra.WindowFunctions) []algebra.WindowFunctions {
+	sets := make([]algebra.WindowFunctions, 0, len(windowAggs))
+	index := make(map[string]int, len(windowAggs))
+
+	for _, agg := range windowAggs {
+		wTerm := agg.WindowTerm()
+		key := algebra.NewWindowTerm(wTerm.PartitionBy(), wTerm.OrderBy(), nil).String()
+
+		i, ok := index[key]
+		if !ok {
+			i = len(sets)
+			index[key] = i
+			sets = append(sets, nil)
+		}
+
+		sets[i] = append(sets[i], agg)
+	}
+
+	return sets
+}
+
+/*
+Window functions are only allowed in the projection and ORDER BY, and
+cannot be nested.
+*/
+func allWindowAggregates(node *algebra.Subselect, order *algebra.Order, aggs algebra.Aggregates) (
+	algebra.WindowFunctions, error) {
+	windowAggs := make(map[string]algebra.WindowFunction)
+
+	for _, binding := range node.Let() {
+		collectWindowAggregates(windowAggs, binding.Expression())
+		if len(windowAggs) > 0 {
+			return nil, fmt.Errorf("Window functions are not allowed in LET.")
+		}
+	}
+
+	if node.Where() != nil {
+		collectWindowAggregates(windowAggs, node.Where())
+		if len(windowAggs) > 0 {
+			return nil, fmt.Errorf("Window functions are not allowed in WHERE.")
+		}
+	}
+
+	group := node.Group()
+	if group != nil {
+		collectWindowAggregates(windowAggs, group.By()...)
+		if len(windowAggs) > 0 {
+			return nil, fmt.Errorf("Window functions are not allowed in GROUP BY.")
+		}
+
+		for _, binding := range group.Letting() {
+			collectWindowAggregates(windowAggs, binding.Expression())
+			if len(windowAggs) > 0 {
+				return nil, fmt.Errorf("Window functions are not allowed in LETTING.")
+			}
+		}
+
+		if group.Having() != nil {
+			collectWindowAggregates(windowAggs, group.Having())
+			if len(windowAggs) > 0 {
+				return nil, fmt.Errorf("Window functions are not allowed in HAVING.")
+			}
+		}
+	}
+
+	for _, term := range node.Projection().Terms() {
+		if term.Expression() != nil {
+			collectWindowAggregates(windowAggs, term.Expression())
+		}
+	}
+
+	if order != nil {
+		for _, term := range order.Terms() {
+			collectWindowAggregates(windowAggs, term.Expression())
+		}
+	}
+
+	if len(windowAggs) == 0 {
+		return nil, nil
+	}
+
+	// Disallow nested window functions
+	subAggs := make(map[string]algebra.WindowFunction)
+	for _, agg := range windowAggs {
+		collectWindowAggregates(subAggs, agg.Children()...)
+		if len(subAggs) > 0 {
+			return nil, fmt.Errorf("Nested window functions are not allowed.")
+		}
+	}
+
+	for _, agg := range aggs {
+		collectWindowAggregates(subAggs, agg.Children()...)
+		if len(subAggs) > 0 {
+			return nil, fmt.Errorf("Window functions are not allowed in aggregates.")
+		}
+	}
+
+	names := make(sort.StringSlice, 0, len(windowAggs))
+	for name, _ := range windowAggs {
+		names = append(names, name)
+	}
+
+	names.Sort()
+	rv := make(algebra.WindowFunctions, len(names))
+	for i, name := range names {
+		rv[i] = windowAggs[name]
+	}
+
+	return rv, nil
+}
+
+func collectWindowAggregates(windowAggs map[string]algebra.WindowFunction, exprs ...expression.Expression) {
+	stringer := expression.NewStringer()
+
+	for _, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+
+		if agg, ok := expr.(algebra.WindowFunction); ok && agg.WindowTerm() != nil {
+			windowAggs[stringer.Visit(agg)] = agg
+		}
+
+		if _, ok := expr.(*algebra.Subquery); !ok {
+			children := expr.Children()
+			if len(children) > 0 {
+				collectWindowAggregates(windowAggs, children...)
+			}
+		}
+	}
+}
@@ -186,3 +186,45 @@ func (this *builder) VisitExceptAll(node *algebra.ExceptAll) (interface{}, error
 	this.maxParallelism = 0
 	return plan.NewExceptAll(first.(plan.Operator), second.(plan.Operator)), nil
 }
+
+func (this *builder) VisitRecursiveUnion(node *algebra.RecursiveUnion) (interface{}, error) {
+	// Without a reference to the WITH alias, this is a regular UNION
+	if !node.IsRecursive() {
+		if node.Distinct() {
+			return algebra.NewUnion(node.First(), node.Second()).Accept(this)
+		}
+
+		return algebra.NewUnionAll(node.First(), node.Second()).Accept(this)
+	}
+
+	// Inject DISTINCT into both terms of UNION
+	setOpDistinct := this.setOpDistinct
+	this.setOpDistinct = node.Distinct()
+	prevCover := this.cover
+	defer func() {
+		this.cover = prevCover
+		this.setOpDistinct = setOpDistinct
+	}()
+	this.cover = node.First()
+	this.resetOrderOffsetLimit()
+	this.delayProjection = false // Disable ORDER BY non-projected expressions
+
+	first, err := node.First().Accept(this)
+	if err != nil {
+		return nil, err
+	}
+
+	// The recursive member is correlated to the working set only, and
+	// is evaluated once per iteration rather than once per outer row
+	subquery := this.subquery
+	this.subquery = subquery && node.IsCorrelated()
+	this.cover = node.Second()
+	second, err := node.Second().Accept(this)
+	this.subquery = subquery
+	if err != nil {
+		return nil, err
+	}
+
+	this.maxParallelism = 0
+	return plan.NewRecursiveUnion(node, first.(plan.Operator), second.(plan.Operator)), nil
+}
@@ -15,6 +15,7 @@ import (
 )
 
 func (this *builder) VisitUpdate(stmt *algebra.Update) (interface{}, error) {
+	this.setOptimHints(stmt.OptimHints())
 	this.where = stmt.Where()
 	this.node = stmt
 
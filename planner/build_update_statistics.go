@@ -0,0 +1,53 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/plan"
+)
+
+// Number of documents sampled by UPDATE STATISTICS
+const _STATISTICS_SAMPLE_SIZE = 10000
+
+/*
+UPDATE STATISTICS samples the documents of the keyspace from its
+primary index, and collects the values of the terms from the sample.
+*/
+func (this *builder) VisitUpdateStatistics(stmt *algebra.UpdateStatistics) (interface{}, error) {
+	ksref := stmt.Keyspace()
+	keyspace, err := this.getNameKeyspace(ksref.Namespace(), ksref.Keyspace())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := keyspace.(datastore.StatisticsKeyspace); !ok {
+		return nil, errors.NewUpdateStatisticsNotSupportedError(keyspace.Name())
+	}
+
+	primary, err := buildPrimaryIndex(keyspace, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	term := algebra.NewKeyspaceTerm(keyspace.NamespaceId(), ksref.Keyspace(), ksref.As(), nil, nil)
+	term.SetSample(algebra.NewSample(expression.NewConstant(_STATISTICS_SAMPLE_SIZE), true, nil))
+	_, random := keyspace.(datastore.RandomEntryProvider)
+
+	return plan.NewSequence(
+		plan.NewPrimaryScan(primary, keyspace, term, nil),
+		plan.NewSample(keyspace, term, random),
+		plan.NewFetch(keyspace, term, nil),
+		plan.NewUpdateStatistics(keyspace, ksref.Alias(), stmt.Terms()),
+	), nil
+}
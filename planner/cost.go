@@ -0,0 +1,205 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"sort"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/value"
+)
+
+// Relative costs of reading a primary index entry, a secondary index
+// entry, of fetching a document, and of adding a row to and probing the
+// hash table of a hash join
+const (
+	_COST_PRIMARY_ENTRY = 0.5
+	_COST_INDEX_ENTRY   = 1.0
+	_COST_FETCH         = 4.0
+	_COST_HASH_BUILD    = 2.0
+	_COST_HASH_PROBE    = 1.0
+)
+
+type indexCost struct {
+	entry *indexEntry
+	count float64 // estimated number of index entries scanned
+}
+
+/*
+Choose the secondary scans of least estimated cost, from the statistics
+of the indexes and the number of documents in the keyspace. Indexes are
+added to an IntersectScan, most selective first, for as long as they
+lower the cost, and no index is returned when a PrimaryScan costs less.
+When any estimate is unavailable, the indexes are returned as they are,
+and are chosen by the rules.
+*/
+func (this *builder) costIndexes(indexes map[datastore.Index]*indexEntry, node *algebra.KeyspaceTerm) (
+	map[datastore.Index]*indexEntry, error) {
+
+	if len(indexes) == 0 || this.order != nil || node.IsPrimaryJoin() {
+		return indexes, nil
+	}
+
+	costs := make([]*indexCost, 0, len(indexes))
+	for _, entry := range indexes {
+		n, ok := estimateEntries(entry.index, entry.spans, len(entry.keys))
+		if !ok {
+			return indexes, nil
+		}
+		costs = append(costs, &indexCost{entry, n})
+	}
+
+	keyspace, err := this.getTermKeyspace(node)
+	if err != nil {
+		return nil, err
+	}
+
+	count, er := keyspace.Count(datastore.NULL_QUERY_CONTEXT)
+	if er != nil || count <= 0 {
+		return indexes, nil
+	}
+	card := float64(count)
+
+	chosen, cost := cheapestScans(costs, card)
+	rv := make(map[datastore.Index]*indexEntry, len(chosen))
+
+	// A primary scan reads every document, but is cheaper per entry
+	if (!node.IsAnsiJoinOp() || node.IsUnderHash()) && len(node.Indexes()) == 0 &&
+		len(this.optimHints.Find(algebra.OPTIM_HINT_INDEX, node.Alias())) == 0 &&
+		card*(_COST_PRIMARY_ENTRY+_COST_FETCH) < cost {
+		primary, err := buildPrimaryIndex(keyspace, nil, false)
+		if err == nil && primary != nil {
+			return rv, nil
+		}
+	}
+
+	for _, c := range chosen {
+		rv[c.entry.index] = c.entry
+	}
+	return rv, nil
+}
+
+/*
+Choose the indexes to scan, and return the estimated cost of the scan.
+*/
+func cheapestScans(costs []*indexCost, card float64) ([]*indexCost, float64) {
+	sort.Slice(costs, func(i, j int) bool {
+		return costs[i].count < costs[j].count ||
+			(costs[i].count == costs[j].count && costs[i].entry.index.Name() < costs[j].entry.index.Name())
+	})
+
+	// Predicates on the keys of different indexes are taken to be
+	// independent, so that the selectivities of an intersection multiply
+	chosen := []*indexCost{costs[0]}
+	entries := costs[0].count
+	selectivity := fraction(costs[0].count, card)
+	cost := scanCost(entries, selectivity, card)
+	for _, c := range costs[1:] {
+		s := selectivity * fraction(c.count, card)
+		if ic := scanCost(entries+c.count, s, card); ic < cost {
+			chosen = append(chosen, c)
+			entries += c.count
+			selectivity = s
+			cost = ic
+		}
+	}
+
+	return chosen, cost
+}
+
+func scanCost(entries, selectivity, card float64) float64 {
+	return entries*_COST_INDEX_ENTRY + selectivity*card*_COST_FETCH
+}
+
+func fraction(count, card float64) float64 {
+	if count >= card {
+		return 1.0
+	}
+	return count / card
+}
+
+/*
+Estimate the number of index entries in the spans, from the statistics
+of the index. The estimate is only available when the index provides
+statistics, and the bounds of all the spans are constants.
+*/
+func estimateEntries(index datastore.Index, spans SargSpans, total int) (float64, bool) {
+	switch spans := spans.(type) {
+	case *TermSpans:
+		spans1, _ := ConvertSpans2ToSpan(spans.Spans(), total)
+		rv := 0.0
+		for _, span := range spans1 {
+			dspan := &datastore.Span{}
+			var ok bool
+			dspan.Range.Low, ok = spanBound(span.Range.Low)
+			if !ok {
+				return 0.0, false
+			}
+			dspan.Range.High, ok = spanBound(span.Range.High)
+			if !ok {
+				return 0.0, false
+			}
+			dspan.Range.Inclusion = span.Range.Inclusion
+
+			stats, err := index.Statistics("", dspan)
+			if err != nil || stats == nil {
+				return 0.0, false
+			}
+
+			n, err := stats.Count()
+			if err != nil || n < 0 {
+				return 0.0, false
+			}
+			rv += float64(n)
+		}
+		return rv, true
+	case *UnionSpans:
+		rv := 0.0
+		for _, s := range spans.spans {
+			n, ok := estimateEntries(index, s, total)
+			if !ok {
+				return 0.0, false
+			}
+			rv += n
+		}
+		return rv, true
+	case *IntersectSpans:
+		rv := -1.0
+		for _, s := range spans.spans {
+			n, ok := estimateEntries(index, s, total)
+			if !ok {
+				return 0.0, false
+			}
+			if rv < 0.0 || n < rv {
+				rv = n
+			}
+		}
+		return rv, rv >= 0.0
+	default:
+		return 0.0, false
+	}
+}
+
+func spanBound(bound []expression.Expression) (value.Values, bool) {
+	if len(bound) == 0 {
+		return nil, true
+	}
+
+	rv := make(value.Values, len(bound))
+	for i, b := range bound {
+		rv[i] = b.Value()
+		if rv[i] == nil {
+			return nil, false
+		}
+	}
+	return rv, true
+}
@@ -0,0 +1,144 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"testing"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/value"
+)
+
+// statsIndex provides statistics over a list of leading key values
+type statsIndex struct {
+	datastore.Index
+	name string
+	keys []value.Value
+}
+
+func (this *statsIndex) Name() string {
+	return this.name
+}
+
+func (this *statsIndex) Statistics(requestId string, span *datastore.Span) (datastore.Statistics, errors.Error) {
+	if this.keys == nil {
+		return nil, nil
+	}
+
+	var count int64
+	for _, k := range this.keys {
+		if len(span.Range.Low) > 0 {
+			c := k.Collate(span.Range.Low[0])
+			if c < 0 || (c == 0 && span.Range.Inclusion&datastore.LOW == 0) {
+				continue
+			}
+		}
+		if len(span.Range.High) > 0 {
+			c := k.Collate(span.Range.High[0])
+			if c > 0 || (c == 0 && span.Range.Inclusion&datastore.HIGH == 0) {
+				continue
+			}
+		}
+		count++
+	}
+	return countStats(count), nil
+}
+
+type countStats int64
+
+func (this countStats) Count() (int64, errors.Error)                 { return int64(this), nil }
+func (this countStats) Min() (value.Values, errors.Error)            { return nil, nil }
+func (this countStats) Max() (value.Values, errors.Error)            { return nil, nil }
+func (this countStats) DistinctCount() (int64, errors.Error)         { return int64(this), nil }
+func (this countStats) Bins() ([]datastore.Statistics, errors.Error) { return nil, nil }
+
+func newStatsIndex(name string, n int) *statsIndex {
+	keys := make([]value.Value, n)
+	for i := range keys {
+		keys[i] = value.NewValue(i)
+	}
+	return &statsIndex{name: name, keys: keys}
+}
+
+func eqSpans(v interface{}) SargSpans {
+	c := expression.NewConstant(v)
+	return NewTermSpans(plan.NewSpan2(nil, plan.Ranges2{plan.NewRange2(c, c, datastore.BOTH)}, true))
+}
+
+func TestEstimateEntries(t *testing.T) {
+	index := newStatsIndex("ix", 100)
+
+	n, ok := estimateEntries(index, eqSpans(10), 1)
+	if !ok || n != 1 {
+		t.Errorf("expected 1 entry, got %v %v", n, ok)
+	}
+
+	low := expression.NewConstant(10)
+	high := expression.NewConstant(20)
+	spans := NewTermSpans(plan.NewSpan2(nil, plan.Ranges2{plan.NewRange2(low, high, datastore.LOW)}, true))
+	n, ok = estimateEntries(index, spans, 1)
+	if !ok || n != 10 {
+		t.Errorf("expected 10 entries, got %v %v", n, ok)
+	}
+
+	n, ok = estimateEntries(index, NewUnionSpans(eqSpans(10), spans), 1)
+	if !ok || n != 11 {
+		t.Errorf("expected 11 entries, got %v %v", n, ok)
+	}
+
+	n, ok = estimateEntries(index, NewIntersectSpans(eqSpans(10), spans), 1)
+	if !ok || n != 1 {
+		t.Errorf("expected 1 entry, got %v %v", n, ok)
+	}
+
+	// no estimate for parameters, or without statistics
+	param := algebra.NewPositionalParameter(1)
+	spans = NewTermSpans(plan.NewSpan2(nil, plan.Ranges2{plan.NewRange2(param, param, datastore.BOTH)}, true))
+	if _, ok = estimateEntries(index, spans, 1); ok {
+		t.Errorf("expected no estimate for a parameter")
+	}
+	if _, ok = estimateEntries(&statsIndex{name: "nostats"}, eqSpans(10), 1); ok {
+		t.Errorf("expected no estimate without statistics")
+	}
+}
+
+func TestCheapestScans(t *testing.T) {
+	card := 1000.0
+	narrow := &indexCost{&indexEntry{index: &statsIndex{name: "narrow"}}, 5}
+	wide := &indexCost{&indexEntry{index: &statsIndex{name: "wide"}}, 600}
+	medium := &indexCost{&indexEntry{index: &statsIndex{name: "medium"}}, 100}
+
+	// the selective index alone beats intersecting it with a wide one
+	chosen, cost := cheapestScans([]*indexCost{wide, narrow}, card)
+	if len(chosen) != 1 || chosen[0] != narrow {
+		t.Errorf("expected narrow index only, got %v indexes", len(chosen))
+	}
+	if cost != 25 {
+		t.Errorf("expected cost 25, got %v", cost)
+	}
+
+	// two moderately selective indexes are intersected
+	other := &indexCost{&indexEntry{index: &statsIndex{name: "other"}}, 100}
+	chosen, _ = cheapestScans([]*indexCost{medium, wide, other}, card)
+	if len(chosen) != 2 || chosen[0] != medium || chosen[1] != other {
+		t.Errorf("expected intersection of medium and other indexes, got %v indexes", len(chosen))
+	}
+
+	// an unselective index costs more than a primary scan
+	all := &indexCost{&indexEntry{index: &statsIndex{name: "all"}}, 1000}
+	_, cost = cheapestScans([]*indexCost{all}, card)
+	if cost <= card*(_COST_PRIMARY_ENTRY+_COST_FETCH) {
+		t.Errorf("expected cost %v to exceed a primary scan", cost)
+	}
+}
@@ -0,0 +1,785 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"math"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/expression"
+	"github.com/couchbase/query/expression/parser"
+	"github.com/couchbase/query/util"
+	"github.com/couchbase/query/value"
+)
+
+// Selectivities of the predicates that have no statistics
+const (
+	_SEL_EQ      = 0.1
+	_SEL_RANGE   = 0.3
+	_SEL_DEFAULT = 0.5
+	_SEL_JOIN    = 0.3 // join predicates other than equalities
+)
+
+// Joins of up to this many terms are ordered by dynamic programming
+// over all the subsets of the terms, and longer ones greedily
+const _JOIN_DP_TERMS = 6
+
+// A term can only be ordered if it can be a bit of a uint64
+const _JOIN_MAX_TERMS = 64
+
+type joinTerm struct {
+	term    *algebra.KeyspaceTerm
+	count   float64 // documents in the keyspace
+	card    float64 // documents that pass the filters on the term alone
+	indexed bool    // an index is sargable for the filters on the term alone
+	primary bool    // the keyspace has a primary index
+	joinKey bool    // an index leads with a key of a join predicate
+}
+
+/*
+The cost of scanning the term on its own, as the first term of the
+joins or the build side of a hash join.
+*/
+func (this *joinTerm) scanCost() float64 {
+	if this.indexed {
+		return this.card * (_COST_INDEX_ENTRY + _COST_FETCH)
+	}
+	return this.count * (_COST_PRIMARY_ENTRY + _COST_FETCH)
+}
+
+type joinPred struct {
+	terms  uint64  // terms referenced
+	sel    float64 // selectivity
+	equi   bool    // equality of expressions of two terms, usable as hash keys
+	lookup uint64  // terms that an index scan, or a fetch, can look up by the predicate
+}
+
+type joinStep struct {
+	prev uint64           // terms joined before
+	term int              // term joined by this step
+	hint algebra.JoinHint // join method
+	cost float64          // total cost of the joins up to this step
+	rows float64          // rows produced by the joins up to this step
+}
+
+type joinOrder struct {
+	terms []*joinTerm
+	preds []*joinPred
+	hash  bool // hash joins are enabled
+}
+
+func termBit(t int) uint64 {
+	return uint64(1) << uint(t)
+}
+
+/*
+Find the join order of least estimated cost. Only orders that join
+every term to the terms before it by some predicate are considered,
+so that no cartesian products are formed. Returns nil when there is
+no such order.
+*/
+func (this *joinOrder) best() []*joinStep {
+	if len(this.terms) <= _JOIN_DP_TERMS {
+		return this.dynamic()
+	}
+	return this.greedy()
+}
+
+func (this *joinOrder) first(t int) *joinStep {
+	term := this.terms[t]
+	if !term.indexed && !term.primary && !term.joinKey {
+		return nil
+	}
+	return &joinStep{term: t, cost: term.scanCost(), rows: term.card}
+}
+
+/*
+Build the cheapest left-deep join of every subset of the terms from the
+cheapest joins of its subsets. Every subset is numerically greater than
+its subsets, so they are all built before it.
+*/
+func (this *joinOrder) dynamic() []*joinStep {
+	n := len(this.terms)
+	all := termBit(n) - 1
+	best := make(map[uint64]*joinStep, 1<<uint(n))
+
+	for t := range this.terms {
+		if step := this.first(t); step != nil {
+			best[termBit(t)] = step
+		}
+	}
+
+	for s := uint64(1); s < all; s++ {
+		prev, ok := best[s]
+		if !ok {
+			continue
+		}
+
+		for t := range this.terms {
+			if s&termBit(t) != 0 {
+				continue
+			}
+
+			step := this.join(prev, s, t)
+			if step == nil {
+				continue
+			}
+
+			if next, ok := best[s|termBit(t)]; !ok || step.cost < next.cost {
+				best[s|termBit(t)] = step
+			}
+		}
+	}
+
+	if _, ok := best[all]; !ok {
+		return nil
+	}
+
+	steps := make([]*joinStep, n)
+	for i, s := n-1, all; i >= 0; i-- {
+		steps[i] = best[s]
+		s = steps[i].prev
+	}
+	return steps
+}
+
+/*
+Starting from every term in turn, join the term of least cost next,
+and keep the cheapest of the complete orders.
+*/
+func (this *joinOrder) greedy() []*joinStep {
+	var rv []*joinStep
+
+	for t := range this.terms {
+		step := this.first(t)
+		if step == nil {
+			continue
+		}
+
+		steps := []*joinStep{step}
+		s := termBit(t)
+		for len(steps) < len(this.terms) {
+			var next *joinStep
+			for t := range this.terms {
+				if s&termBit(t) != 0 {
+					continue
+				}
+
+				step := this.join(steps[len(steps)-1], s, t)
+				if step != nil && (next == nil || step.cost < next.cost) {
+					next = step
+				}
+			}
+
+			if next == nil {
+				break
+			}
+
+			steps = append(steps, next)
+			s |= termBit(next.term)
+		}
+
+		if len(steps) == len(this.terms) &&
+			(rv == nil || steps[len(steps)-1].cost < rv[len(rv)-1].cost) {
+			rv = steps
+		}
+	}
+
+	return rv
+}
+
+/*
+Join the terms in the given order, choosing the cheapest method of each
+join. Returns nil when the order cannot be joined.
+*/
+func (this *joinOrder) fixed(order []int) []*joinStep {
+	steps := make([]*joinStep, 0, len(order))
+	step := this.first(order[0])
+	if step == nil {
+		return nil
+	}
+
+	steps = append(steps, step)
+	s := termBit(order[0])
+	for _, t := range order[1:] {
+		step = this.join(step, s, t)
+		if step == nil {
+			return nil
+		}
+
+		steps = append(steps, step)
+		s |= termBit(t)
+	}
+
+	return steps
+}
+
+/*
+Join term t to the joins of the terms in s, by the cheapest method.
+A nested-loop join scans an index of the term once per row, and needs
+an index to look the term up by the join predicates, or by its own
+filters. A hash join scans the term once, and needs an equality join
+predicate for its keys; the smaller side is built into the hash table.
+*/
+func (this *joinOrder) join(prev *joinStep, s uint64, t int) *joinStep {
+	term := this.terms[t]
+	bit := termBit(t)
+
+	sel, lookupSel := 1.0, 1.0
+	connected, lookup, equi := false, false, false
+	for _, pred := range this.preds {
+		if pred.terms&bit == 0 || pred.terms&s == 0 || pred.terms&^(s|bit) != 0 {
+			continue
+		}
+
+		connected = true
+		sel *= pred.sel
+		equi = equi || pred.equi
+		if pred.lookup&bit != 0 {
+			lookup = true
+			lookupSel *= pred.sel
+		}
+	}
+
+	if !connected {
+		return nil
+	}
+
+	hint := algebra.JoinHint(algebra.JOIN_HINT_NONE)
+	cost := math.Inf(1)
+	if lookup {
+		cost = prev.rows * (_COST_INDEX_ENTRY + term.count*lookupSel*(_COST_INDEX_ENTRY+_COST_FETCH))
+	} else if term.indexed {
+		cost = prev.rows * (_COST_INDEX_ENTRY + term.card*(_COST_INDEX_ENTRY+_COST_FETCH))
+	}
+
+	if this.hash && equi && (term.indexed || term.primary) {
+		scan := term.scanCost()
+		if c := scan + term.card*_COST_HASH_BUILD + prev.rows*_COST_HASH_PROBE; c < cost {
+			hint, cost = algebra.USE_HASH_BUILD, c
+		}
+		if c := scan + prev.rows*_COST_HASH_BUILD + term.card*_COST_HASH_PROBE; c < cost {
+			hint, cost = algebra.USE_HASH_PROBE, c
+		}
+	}
+
+	if math.IsInf(cost, 1) {
+		return nil
+	}
+
+	return &joinStep{
+		prev: s,
+		term: t,
+		hint: hint,
+		cost: prev.cost + cost,
+		rows: prev.rows * term.card * sel,
+	}
+}
+
+/*
+Reorder a FROM clause of inner ANSI JOINs of keyspaces by estimated
+cost, and choose the method of each join. The FROM clause is returned
+as it is when any of its terms has a join hint, USE KEYS, USE INDEX or
+SAMPLE, when the estimates are unavailable, or when the order and
+methods of the joins are unchanged. The terms are copied, so that the
+statement can be planned again.
+*/
+func (this *builder) orderJoins(from algebra.FromTerm) (algebra.FromTerm, error) {
+	terms, onclauses := joinTerms(from)
+	if len(terms) < 2 || len(terms) > _JOIN_MAX_TERMS || this.falseWhereClause() {
+		return from, nil
+	}
+
+	aliases := make(map[string]bool, len(terms))
+	positions := make(map[string]int, len(terms))
+	for i, term := range terms {
+		aliases[term.Alias()] = true
+		positions[term.Alias()] = i
+	}
+
+	order := &joinOrder{
+		terms: make([]*joinTerm, len(terms)),
+		hash:  util.IsFeatureEnabled(this.featureControls, util.N1QL_HASH_JOIN),
+	}
+
+	keys := make([]expression.Expressions, len(terms))
+	stats := make([][]*exprStatistics, len(terms))
+	for i, term := range terms {
+		baseKeyspace, ok := this.baseKeyspaces[term.Alias()]
+		if !ok {
+			return from, nil
+		}
+
+		keyspace, err := this.getTermKeyspace(term)
+		if err != nil {
+			return from, nil
+		}
+
+		count, er := keyspace.Count(datastore.NULL_QUERY_CONTEXT)
+		if er != nil || count < 0 {
+			return from, nil
+		}
+
+		jt := &joinTerm{term: term, count: float64(count)}
+		keys[i], jt.primary, err = this.leadingKeys(keyspace, term.Alias())
+		if err != nil {
+			return from, nil
+		}
+		stats[i] = termStatistics(keyspace, term.Alias())
+
+		sel := 1.0
+		var pred expression.Expression
+		for _, fltr := range baseKeyspace.filters {
+			if fltr.isJoin() || fltr.isDerived() {
+				continue
+			}
+
+			sel *= filterSelectivity(fltr.fltrExpr, stats[i], jt.count)
+			if pred == nil {
+				pred = fltr.fltrExpr
+			} else {
+				pred = expression.NewAnd(pred, fltr.fltrExpr)
+			}
+		}
+
+		jt.card = math.Max(jt.count*sel, 1.0)
+		jt.indexed = pred != nil && sargableFor(pred, keys[i])
+		order.terms[i] = jt
+	}
+
+	// every keyspace a join filter references has a copy of it
+	seen := make(map[expression.Expression]bool, len(terms))
+	for _, term := range terms {
+		for _, fltr := range this.baseKeyspaces[term.Alias()].joinfilters {
+			if seen[fltr.fltrExpr] {
+				continue
+			}
+			seen[fltr.fltrExpr] = true
+
+			pred := &joinPred{sel: _SEL_JOIN}
+			for alias, _ := range fltr.keyspaces {
+				t, ok := positions[alias]
+				if !ok {
+					return from, nil
+				}
+				pred.terms |= termBit(t)
+			}
+
+			err := order.estimateJoin(pred, fltr.fltrExpr, aliases, positions, keys, stats)
+			if err != nil {
+				return from, nil
+			}
+			order.preds = append(order.preds, pred)
+		}
+	}
+
+	original := make([]int, len(terms))
+	for i := range original {
+		original[i] = i
+	}
+
+	steps := order.best()
+	written := order.fixed(original)
+	if steps == nil || (written != nil && written[len(written)-1].cost <= steps[len(steps)-1].cost) {
+		steps = written
+	}
+
+	if steps == nil {
+		return from, nil
+	}
+
+	changed := false
+	for i, step := range steps {
+		if step.term != i || step.hint != algebra.JOIN_HINT_NONE {
+			changed = true
+			break
+		}
+	}
+
+	if !changed {
+		return from, nil
+	}
+
+	return rebuildJoins(terms, steps, onclauses, aliases, positions)
+}
+
+/*
+Estimate the selectivity of a join predicate, whether it can be the key
+of a hash join, and the terms that it can look up. An equality of
+expressions of two terms selects one in the larger number of distinct
+values of the two expressions.
+*/
+func (this *joinOrder) estimateJoin(pred *joinPred, expr expression.Expression, aliases map[string]bool,
+	positions map[string]int, keys []expression.Expressions, stats [][]*exprStatistics) error {
+
+	if eq, ok := expr.(*expression.Eq); ok {
+		first, err := singleTerm(eq.First(), aliases, positions)
+		if err != nil {
+			return err
+		}
+
+		second, err := singleTerm(eq.Second(), aliases, positions)
+		if err != nil {
+			return err
+		}
+
+		if first >= 0 && second >= 0 && first != second {
+			pred.equi = eq.First().Indexable() && eq.Second().Indexable()
+
+			ndv := math.Max(this.distinct(eq.First(), first, stats[first]),
+				this.distinct(eq.Second(), second, stats[second]))
+			if ndv <= 0.0 {
+				ndv = math.Max(this.terms[first].count, this.terms[second].count)
+			}
+			pred.sel = 1.0 / math.Max(ndv, 1.0)
+		}
+	}
+
+	for t, term := range this.terms {
+		if pred.terms&termBit(t) == 0 {
+			continue
+		}
+
+		if primaryJoin(expr, term.term.Alias()) {
+			pred.lookup |= termBit(t)
+		} else if sargableFor(expr, keys[t]) {
+			pred.lookup |= termBit(t)
+			term.joinKey = true
+		}
+	}
+
+	return nil
+}
+
+/*
+The number of distinct values of an expression of a term, from the
+statistics of the expression, or the count of the document keys. Zero
+when it is unknown.
+*/
+func (this *joinOrder) distinct(expr expression.Expression, t int, stats []*exprStatistics) float64 {
+	if expr.EquivalentTo(metaId(this.terms[t].term.Alias())) {
+		return this.terms[t].count
+	}
+
+	if s := findStatistics(expr, stats); s != nil {
+		return float64(s.DistinctCount)
+	}
+
+	return 0.0
+}
+
+/*
+Collect the keyspace terms of a FROM clause of inner ANSI JOINs, in the
+order written, and the ON-clauses of the joins. Returns nil for any
+other FROM clause, or when any term cannot be reordered.
+*/
+func joinTerms(from algebra.FromTerm) ([]*algebra.KeyspaceTerm, expression.Expressions) {
+	var terms []*algebra.KeyspaceTerm
+	var onclauses expression.Expressions
+
+	for {
+		var term *algebra.KeyspaceTerm
+		switch node := from.(type) {
+		case *algebra.AnsiJoin:
+			if node.Outer() || node.RightOuter() {
+				return nil, nil
+			}
+			term = algebra.GetKeyspaceTerm(node.Right())
+			onclauses = append(onclauses, node.Onclause())
+			from = node.Left()
+		case algebra.SimpleFromTerm:
+			term = algebra.GetKeyspaceTerm(node)
+			from = nil
+		default:
+			return nil, nil
+		}
+
+		if term == nil || term.Keys() != nil || len(term.Indexes()) > 0 ||
+			term.Sample() != nil || term.JoinHint() != algebra.JOIN_HINT_NONE {
+			return nil, nil
+		}
+
+		terms = append(terms, term)
+		if from == nil {
+			break
+		}
+	}
+
+	for i, j := 0, len(terms)-1; i < j; i, j = i+1, j-1 {
+		terms[i], terms[j] = terms[j], terms[i]
+	}
+	return terms, onclauses
+}
+
+/*
+Build the joins of the terms in the order of the steps. Each conjunct
+of the ON-clauses goes to the first join where all the keyspaces it
+references are available.
+*/
+func rebuildJoins(terms []*algebra.KeyspaceTerm, steps []*joinStep, onclauses expression.Expressions,
+	aliases map[string]bool, positions map[string]int) (algebra.FromTerm, error) {
+
+	var conjuncts expression.Expressions
+	for _, onclause := range onclauses {
+		if and, ok := onclause.(*expression.And); ok {
+			and, _ = flattenAnd(and)
+			conjuncts = append(conjuncts, and.Operands()...)
+		} else {
+			conjuncts = append(conjuncts, onclause)
+		}
+	}
+
+	masks := make([]uint64, len(conjuncts))
+	for i, conjunct := range conjuncts {
+		keyspaces, err := expression.CountKeySpaces(conjunct, aliases)
+		if err != nil {
+			return nil, err
+		}
+
+		for alias, _ := range keyspaces {
+			masks[i] |= termBit(positions[alias])
+		}
+	}
+
+	var from algebra.FromTerm = copyJoinTerm(terms[steps[0].term])
+	available := termBit(steps[0].term)
+	placed := make([]bool, len(conjuncts))
+	for _, step := range steps[1:] {
+		available |= termBit(step.term)
+
+		var operands expression.Expressions
+		for i, conjunct := range conjuncts {
+			if !placed[i] && masks[i]&^available == 0 {
+				operands = append(operands, conjunct)
+				placed[i] = true
+			}
+		}
+
+		var onclause expression.Expression
+		switch len(operands) {
+		case 0:
+			onclause = expression.TRUE_EXPR
+		case 1:
+			onclause = operands[0]
+		default:
+			onclause = expression.NewAnd(operands...)
+		}
+
+		right := copyJoinTerm(terms[step.term])
+		right.SetAnsiJoin()
+		right.SetJoinHint(step.hint)
+		from = algebra.NewAnsiJoin(from, false, right, onclause)
+	}
+
+	return from, nil
+}
+
+func copyJoinTerm(term *algebra.KeyspaceTerm) *algebra.KeyspaceTerm {
+	return algebra.NewKeyspaceTerm(term.Namespace(), term.Keyspace(), term.As(), nil, nil)
+}
+
+/*
+The leading keys of the online secondary indexes of a keyspace that
+have no index condition, formalized as the keys of index scans are,
+and whether the keyspace has a primary index.
+*/
+func (this *builder) leadingKeys(keyspace datastore.Keyspace, alias string) (
+	keys expression.Expressions, primary bool, err error) {
+
+	indexes := _INDEX_POOL.Get()
+	defer _INDEX_POOL.Put(indexes)
+	indexes, err = allIndexes(keyspace, nil, indexes, this.indexApiVersion)
+	if err != nil {
+		return nil, false, err
+	}
+	indexes = this.advisedIndexes(keyspace, alias, indexes)
+
+	formalizer := expression.NewSelfFormalizer(alias, nil)
+	for _, index := range indexes {
+		if index.IsPrimary() {
+			primary = true
+			continue
+		}
+
+		rangeKey := index.RangeKey()
+		if len(rangeKey) == 0 || index.Condition() != nil {
+			continue
+		}
+
+		key, err := formalizeKey(rangeKey[0].Copy(), formalizer)
+		if err != nil {
+			return nil, false, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, primary, nil
+}
+
+func formalizeKey(key expression.Expression, formalizer *expression.Formalizer) (expression.Expression, error) {
+	formalizer.SetIndexScope()
+	key, err := formalizer.Map(key)
+	formalizer.ClearIndexScope()
+	if err != nil {
+		return nil, err
+	}
+
+	dnf := NewDNF(key, true, true)
+	return dnf.Map(key)
+}
+
+func sargableFor(pred expression.Expression, keys expression.Expressions) bool {
+	for _, key := range keys {
+		if min, _ := SargableFor(pred, expression.Expressions{key}); min > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func metaId(alias string) expression.Expression {
+	return expression.NewField(
+		expression.NewMeta(expression.NewIdentifier(alias)),
+		expression.NewFieldName("id", false))
+}
+
+// whether the predicate looks up the documents of the alias by their keys
+func primaryJoin(pred expression.Expression, alias string) bool {
+	id := metaId(alias)
+	switch pred := pred.(type) {
+	case *expression.Eq:
+		return pred.First().EquivalentTo(id) || pred.Second().EquivalentTo(id)
+	case *expression.In:
+		return pred.First().EquivalentTo(id)
+	default:
+		return false
+	}
+}
+
+// the position of the only term an expression references, or -1
+func singleTerm(expr expression.Expression, aliases map[string]bool, positions map[string]int) (int, error) {
+	keyspaces, err := expression.CountKeySpaces(expr, aliases)
+	if err != nil || len(keyspaces) != 1 {
+		return -1, err
+	}
+
+	for alias, _ := range keyspaces {
+		return positions[alias], nil
+	}
+	return -1, nil
+}
+
+type exprStatistics struct {
+	expr  expression.Expression
+	stats *datastore.ExpressionStatistics
+}
+
+/*
+The statistics stored by UPDATE STATISTICS for a keyspace, with their
+expressions formalized by the alias. Nil when the keyspace stores none.
+*/
+func termStatistics(keyspace datastore.Keyspace, alias string) []*exprStatistics {
+	sk, ok := keyspace.(datastore.StatisticsKeyspace)
+	if !ok {
+		return nil
+	}
+
+	stats, err := sk.KeyspaceStatistics()
+	if err != nil {
+		return nil
+	}
+
+	rv := make([]*exprStatistics, 0, len(stats))
+	formalizer := expression.NewSelfFormalizer(alias, nil)
+	for _, s := range stats {
+		expr, err := parser.Parse(s.Expression)
+		if err != nil {
+			continue
+		}
+
+		expr, err = formalizeKey(expr, formalizer)
+		if err != nil {
+			continue
+		}
+		rv = append(rv, &exprStatistics{expr, s})
+	}
+
+	return rv
+}
+
+func findStatistics(expr expression.Expression, stats []*exprStatistics) *datastore.ExpressionStatistics {
+	for _, s := range stats {
+		if s.expr.EquivalentTo(expr) {
+			return s.stats
+		}
+	}
+	return nil
+}
+
+/*
+Estimate the selectivity of a filter on a single keyspace of count
+documents, from the histogram of the expression it compares with a
+constant, or else from defaults.
+*/
+func filterSelectivity(fltr expression.Expression, stats []*exprStatistics, count float64) float64 {
+	var expr expression.Expression
+	var span *datastore.Span
+	sel := _SEL_DEFAULT
+
+	switch fltr := fltr.(type) {
+	case *expression.Eq:
+		sel = _SEL_EQ
+		if v := fltr.Second().Value(); v != nil {
+			expr, span = fltr.First(), &datastore.Span{Seek: value.Values{v}}
+		} else if v := fltr.First().Value(); v != nil {
+			expr, span = fltr.Second(), &datastore.Span{Seek: value.Values{v}}
+		}
+	case *expression.LT:
+		sel = _SEL_RANGE
+		expr, span = rangeSpan(fltr.First(), fltr.Second(), datastore.NEITHER)
+	case *expression.LE:
+		sel = _SEL_RANGE
+		expr, span = rangeSpan(fltr.First(), fltr.Second(), datastore.BOTH)
+	}
+
+	if expr == nil || count <= 0.0 {
+		return sel
+	}
+
+	s := findStatistics(expr, stats)
+	if s == nil {
+		return sel
+	}
+
+	n, err := s.SpanStatistics(span).Count()
+	if err != nil {
+		return sel
+	}
+	return math.Min(float64(n)/count, 1.0)
+}
+
+// the span of first < second, or first <= second, when either is a constant
+func rangeSpan(first, second expression.Expression, inclusion datastore.Inclusion) (
+	expression.Expression, *datastore.Span) {
+
+	if v := second.Value(); v != nil {
+		span := &datastore.Span{}
+		span.Range.High = value.Values{v}
+		span.Range.Inclusion = inclusion & datastore.HIGH
+		return first, span
+	} else if v := first.Value(); v != nil {
+		span := &datastore.Span{}
+		span.Range.Low = value.Values{v}
+		span.Range.Inclusion = inclusion & datastore.LOW
+		return second, span
+	}
+	return nil, nil
+}
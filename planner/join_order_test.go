@@ -0,0 +1,210 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/expression"
+)
+
+func newJoinTerm(alias string, count, card float64, indexed bool) *joinTerm {
+	return &joinTerm{
+		term:    algebra.NewKeyspaceTerm("default", alias, alias, nil, nil),
+		count:   count,
+		card:    card,
+		indexed: indexed,
+		primary: true,
+	}
+}
+
+func joinOrderOf(steps []*joinStep) []int {
+	rv := make([]int, len(steps))
+	for i, step := range steps {
+		rv[i] = step.term
+	}
+	return rv
+}
+
+func field(alias, name string) expression.Expression {
+	return expression.NewField(expression.NewIdentifier(alias), expression.NewFieldName(name, false))
+}
+
+func TestJoinOrderDynamic(t *testing.T) {
+	// orders JOIN customers JOIN items, with customers filtered to 2 of 20
+	order := &joinOrder{
+		terms: []*joinTerm{
+			newJoinTerm("o", 300, 300, false),
+			newJoinTerm("c", 20, 2, true),
+			newJoinTerm("i", 50, 50, false),
+		},
+		preds: []*joinPred{
+			{terms: 3, sel: 1.0 / 20, equi: true, lookup: 1},
+			{terms: 5, sel: 1.0 / 50, equi: true, lookup: 4},
+		},
+	}
+
+	steps := order.best()
+	if fmt.Sprint(joinOrderOf(steps)) != "[1 0 2]" {
+		t.Errorf("expected customers, orders, items, got %v", joinOrderOf(steps))
+	}
+
+	for _, step := range steps[1:] {
+		if step.hint != algebra.JOIN_HINT_NONE {
+			t.Errorf("expected nested-loop joins without hash joins, got %v", step.hint)
+		}
+	}
+
+	written := order.fixed([]int{0, 1, 2})
+	if written == nil || written[2].cost <= steps[2].cost {
+		t.Errorf("expected the order written to cost more")
+	}
+
+	if steps[2].rows != 30 {
+		t.Errorf("expected 30 rows, got %v", steps[2].rows)
+	}
+}
+
+func TestJoinOrderHash(t *testing.T) {
+	// no index looks either term up by the join predicate
+	order := &joinOrder{
+		terms: []*joinTerm{
+			newJoinTerm("o", 300, 300, false),
+			newJoinTerm("c", 20, 2, true),
+		},
+		preds: []*joinPred{
+			{terms: 3, sel: 1.0 / 20, equi: true},
+		},
+		hash: true,
+	}
+
+	steps := order.fixed([]int{0, 1})
+	if steps == nil || steps[1].hint != algebra.USE_HASH_BUILD {
+		t.Errorf("expected the right-hand side to be built")
+	}
+
+	steps = order.fixed([]int{1, 0})
+	if steps == nil || steps[1].hint != algebra.USE_HASH_PROBE {
+		t.Errorf("expected the left-hand side to be built")
+	}
+
+	// the nested-loop join needs an index on the filters of orders
+	order.hash = false
+	if steps = order.fixed([]int{1, 0}); steps != nil {
+		t.Errorf("expected no nested-loop join without an index")
+	}
+
+	if steps = order.best(); fmt.Sprint(joinOrderOf(steps)) != "[0 1]" {
+		t.Errorf("expected orders, customers, got %v", joinOrderOf(steps))
+	}
+}
+
+func TestJoinOrderDisconnected(t *testing.T) {
+	order := &joinOrder{
+		terms: []*joinTerm{
+			newJoinTerm("a", 10, 10, true),
+			newJoinTerm("b", 10, 10, true),
+		},
+	}
+
+	if steps := order.best(); steps != nil {
+		t.Errorf("expected no order without join predicates, got %v", joinOrderOf(steps))
+	}
+}
+
+func TestJoinOrderGreedy(t *testing.T) {
+	// a chain of terms, each joined to the next, of decreasing size
+	order := &joinOrder{}
+	for i := 0; i < _JOIN_DP_TERMS+2; i++ {
+		count := float64(1000 - 100*i)
+		order.terms = append(order.terms, newJoinTerm(fmt.Sprintf("t%d", i), count, count, false))
+		if i > 0 {
+			order.preds = append(order.preds, &joinPred{
+				terms:  termBit(i-1) | termBit(i),
+				sel:    1.0 / count,
+				equi:   true,
+				lookup: termBit(i-1) | termBit(i),
+			})
+		}
+	}
+
+	steps := order.best()
+	if len(steps) != len(order.terms) {
+		t.Fatalf("expected %d steps, got %d", len(order.terms), len(steps))
+	}
+
+	// every term is joined to the terms before it
+	s := termBit(steps[0].term)
+	for _, step := range steps[1:] {
+		if step.prev != s {
+			t.Errorf("expected term %d to follow %b, got %b", step.term, s, step.prev)
+		}
+		s |= termBit(step.term)
+	}
+
+	// dynamic programming finds an order at least as cheap
+	order.terms = order.terms[:_JOIN_DP_TERMS]
+	order.preds = order.preds[:_JOIN_DP_TERMS-1]
+	dynamic := order.dynamic()
+	greedy := order.greedy()
+	if dynamic[len(dynamic)-1].cost > greedy[len(greedy)-1].cost {
+		t.Errorf("expected dynamic programming to cost no more than greedy, got %v and %v",
+			dynamic[len(dynamic)-1].cost, greedy[len(greedy)-1].cost)
+	}
+}
+
+func TestRebuildJoins(t *testing.T) {
+	terms := []*algebra.KeyspaceTerm{
+		algebra.NewKeyspaceTerm("default", "a", "a", nil, nil),
+		algebra.NewKeyspaceTerm("default", "b", "b", nil, nil),
+		algebra.NewKeyspaceTerm("default", "c", "c", nil, nil),
+	}
+
+	onclauses := expression.Expressions{
+		expression.NewAnd(
+			expression.NewEq(field("b", "x"), field("a", "x")),
+			expression.NewEq(field("b", "y"), expression.NewConstant(1))),
+		expression.NewEq(field("c", "x"), field("b", "x")),
+	}
+
+	aliases := map[string]bool{"a": true, "b": true, "c": true}
+	positions := map[string]int{"a": 0, "b": 1, "c": 2}
+	steps := []*joinStep{
+		{term: 2},
+		{term: 1, hint: algebra.USE_HASH_BUILD},
+		{term: 0},
+	}
+
+	from, err := rebuildJoins(terms, steps, onclauses, aliases, positions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	top, ok := from.(*algebra.AnsiJoin)
+	if !ok || top.Alias() != "a" || top.Onclause().String() != "((`b`.`x`) = (`a`.`x`))" {
+		t.Fatalf("expected a joined last on b.x = a.x, got %v", from)
+	}
+
+	bottom, ok := top.Left().(*algebra.AnsiJoin)
+	if !ok || bottom.Alias() != "b" || bottom.Left().Alias() != "c" {
+		t.Fatalf("expected c JOIN b, got %v", top.Left())
+	}
+
+	if bottom.Onclause().String() != "(((`b`.`y`) = 1) and ((`c`.`x`) = (`b`.`x`)))" {
+		t.Errorf("unexpected ON-clause %v", bottom.Onclause())
+	}
+
+	right := bottom.Right().(*algebra.KeyspaceTerm)
+	if right == terms[1] || !right.IsAnsiJoin() || right.JoinHint() != algebra.USE_HASH_BUILD {
+		t.Errorf("expected a copy of b with the hash join hint")
+	}
+}
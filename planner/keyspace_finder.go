@@ -104,7 +104,7 @@ func (this *keyspaceFinder) VisitIndexJoin(node *algebra.IndexJoin) (interface{}
 
 func (this *keyspaceFinder) VisitAnsiJoin(node *algebra.AnsiJoin) (interface{}, error) {
 	// if this is inner join, gather ON-clause
-	if !node.Outer() {
+	if !node.Outer() && !node.RightOuter() {
 		this.addOnclause(node.Onclause())
 	}
 	return nil, this.visitJoin(node.Left(), node.Right())
@@ -142,6 +142,10 @@ func (this *keyspaceFinder) VisitUnionAll(node *algebra.UnionAll) (interface{},
 	return nil, this.visitSetop(node.First(), node.Second())
 }
 
+func (this *keyspaceFinder) VisitRecursiveUnion(node *algebra.RecursiveUnion) (interface{}, error) {
+	return nil, this.visitSetop(node.First(), node.Second())
+}
+
 func (this *keyspaceFinder) VisitIntersect(node *algebra.Intersect) (interface{}, error) {
 	return nil, this.visitSetop(node.First(), node.Second())
 }
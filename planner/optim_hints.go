@@ -0,0 +1,364 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package planner
+
+import (
+	"fmt"
+
+	"github.com/couchbase/query/algebra"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/plan"
+	"github.com/couchbase/query/util"
+)
+
+/*
+Set the optimizer hints of the query block being planned. The hints
+of every block are kept, so that EXPLAIN can report which of them
+were followed.
+*/
+func (this *builder) setOptimHints(hints *algebra.OptimHints) {
+	this.optimHints = hints
+	if hints == nil {
+		return
+	}
+
+	for _, block := range this.hintBlocks {
+		if block == hints {
+			return
+		}
+	}
+
+	hints.Reset()
+	this.hintBlocks = append(this.hintBlocks, hints)
+}
+
+/*
+Resolve the INDEX and NO_INDEX hints of the keyspace to the indexes to
+try first, and to the indexes not to consider. USE INDEX in the FROM
+clause takes precedence over the hints.
+*/
+func (this *builder) indexHints(keyspace datastore.Keyspace, node *algebra.KeyspaceTerm) (
+	use, avoid []datastore.Index, err error) {
+
+	hints := this.optimHints.Find(algebra.OPTIM_HINT_INDEX, node.Alias())
+	noHints := this.optimHints.Find(algebra.OPTIM_HINT_NO_INDEX, node.Alias())
+	if len(hints) == 0 && len(noHints) == 0 || len(node.Indexes()) > 0 {
+		return
+	}
+
+	indexes, err := allIndexes(keyspace, nil, nil, this.indexApiVersion)
+	if err != nil {
+		return
+	}
+
+	for _, hint := range noHints {
+		avoid = append(avoid, hintedIndexes(hint, indexes)...)
+	}
+
+	for _, hint := range hints {
+	outer:
+		for _, index := range hintedIndexes(hint, indexes) {
+			for _, a := range avoid {
+				if a == index {
+					continue outer
+				}
+			}
+			use = append(use, index)
+		}
+	}
+
+	return
+}
+
+/*
+Returns the indexes named by an INDEX or NO_INDEX hint, or all the
+secondary indexes when the hint names none.
+*/
+func hintedIndexes(hint *algebra.OptimHint, indexes []datastore.Index) []datastore.Index {
+	var rv []datastore.Index
+	for _, index := range indexes {
+		if hintNamesIndex(hint, index) {
+			rv = append(rv, index)
+		}
+	}
+	return rv
+}
+
+func hintNamesIndex(hint *algebra.OptimHint, index datastore.Index) bool {
+	if len(hint.Indexes()) == 0 {
+		return !index.IsPrimary()
+	}
+
+	for _, name := range hint.Indexes() {
+		if index.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Record whether the INDEX and NO_INDEX hints of the keyspace were
+followed by the scan chosen for it.
+*/
+func (this *builder) markIndexHints(keyspace datastore.Keyspace, node *algebra.KeyspaceTerm, scan plan.Operator) error {
+	hints := this.optimHints.Find(algebra.OPTIM_HINT_INDEX, node.Alias())
+	noHints := this.optimHints.Find(algebra.OPTIM_HINT_NO_INDEX, node.Alias())
+	if len(hints) == 0 && len(noHints) == 0 {
+		return nil
+	}
+
+	if len(node.Indexes()) > 0 {
+		for _, hint := range append(hints, noHints...) {
+			hint.SetNotFollowed("USE INDEX is given for the keyspace")
+		}
+		return nil
+	}
+
+	var indexes []datastore.Index
+	if len(hints) > 0 {
+		var err error
+		indexes, err = allIndexes(keyspace, nil, nil, this.indexApiVersion)
+		if err != nil {
+			return err
+		}
+	}
+
+	used := scanIndexes(scan, nil)
+	for _, hint := range hints {
+		if node.Keys() != nil {
+			hint.SetNotFollowed("the keyspace is read by USE KEYS")
+		} else if len(hintedIndexes(hint, indexes)) == 0 {
+			hint.SetNotFollowed("no hinted index exists on the keyspace")
+		} else if !usesHintedIndex(hint, used) {
+			hint.SetNotFollowed("no hinted index can be used for the keyspace")
+		} else {
+			hint.SetFollowed()
+		}
+	}
+
+	for _, hint := range noHints {
+		if usesHintedIndex(hint, used) {
+			hint.SetNotFollowed("no other index can be used for the keyspace")
+		} else {
+			hint.SetFollowed()
+		}
+	}
+
+	return nil
+}
+
+func usesHintedIndex(hint *algebra.OptimHint, used []datastore.Index) bool {
+	for _, index := range used {
+		if hintNamesIndex(hint, index) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Returns the indexes read by a scan.
+*/
+func scanIndexes(op plan.Operator, indexes []datastore.Index) []datastore.Index {
+	switch op := op.(type) {
+	case *plan.PrimaryScan:
+		indexes = append(indexes, op.Index())
+	case *plan.PrimaryScan3:
+		indexes = append(indexes, op.Index())
+	case *plan.IndexScan:
+		indexes = append(indexes, op.Index())
+	case *plan.IndexScan2:
+		indexes = append(indexes, op.Index())
+	case *plan.IndexScan3:
+		indexes = append(indexes, op.Index())
+	case *plan.IndexCountScan:
+		indexes = append(indexes, op.Index())
+	case *plan.IndexCountScan2:
+		indexes = append(indexes, op.Index())
+	case *plan.IndexCountDistinctScan2:
+		indexes = append(indexes, op.Index())
+	case *plan.DistinctScan:
+		indexes = scanIndexes(op.Scan(), indexes)
+	case *plan.IntersectScan:
+		for _, scan := range op.Scans() {
+			indexes = scanIndexes(scan, indexes)
+		}
+	case *plan.OrderedIntersectScan:
+		for _, scan := range op.Scans() {
+			indexes = scanIndexes(scan, indexes)
+		}
+	case *plan.UnionScan:
+		for _, scan := range op.Scans() {
+			indexes = scanIndexes(scan, indexes)
+		}
+	}
+	return indexes
+}
+
+/*
+Apply the PARALLEL hint to the scan that drives the query block. The
+parallelism of the request still limits the number of streams.
+*/
+func (this *builder) parallelHint() {
+	for _, hint := range this.optimHints.Find(algebra.OPTIM_HINT_PARALLEL, "") {
+		if this.maxParallelism == 1 {
+			hint.SetNotFollowed("the plan must be run in a single stream")
+		} else {
+			this.maxParallelism = hint.Parallel()
+			hint.SetFollowed()
+		}
+	}
+}
+
+/*
+Apply the USE_HASH and USE_NL hints to the terms of the ANSI joins and
+nests of the FROM clause. A join hint in the FROM clause takes
+precedence over a different hint.
+*/
+func (this *builder) joinHints(from algebra.FromTerm) {
+	if this.optimHints == nil {
+		return
+	}
+
+	switch from := from.(type) {
+	case *algebra.AnsiJoin:
+		this.joinHints(from.Left())
+		this.setJoinHint(from.Right())
+	case *algebra.AnsiNest:
+		this.joinHints(from.Left())
+		this.setJoinHint(from.Right())
+	case algebra.JoinTerm:
+		this.joinHints(from.Left())
+	case algebra.SimpleFromTerm:
+		for _, hint := range this.keyspaceJoinHints(from.Alias()) {
+			hint.SetNotFollowed("the first term of the FROM clause is not joined")
+		}
+	}
+}
+
+func (this *builder) setJoinHint(right algebra.SimpleFromTerm) {
+	if ksterm := algebra.GetKeyspaceTerm(right); ksterm != nil {
+		right = ksterm
+	}
+
+	for _, hint := range this.keyspaceJoinHints(right.Alias()) {
+		if right.JoinHint() == algebra.JOIN_HINT_NONE {
+			right.SetJoinHint(hint.JoinHint())
+		} else if right.JoinHint() != hint.JoinHint() {
+			hint.SetNotFollowed("a different join hint is given in the FROM clause")
+		}
+	}
+}
+
+func (this *builder) keyspaceJoinHints(alias string) []*algebra.OptimHint {
+	return append(this.optimHints.Find(algebra.OPTIM_HINT_USE_HASH, alias),
+		this.optimHints.Find(algebra.OPTIM_HINT_USE_NL, alias)...)
+}
+
+/*
+Record whether the USE_HASH and USE_NL hints of the keyspace were
+followed by the join or nest built for it.
+*/
+func (this *builder) markJoinHints(alias string, op plan.Operator) {
+	if this.optimHints == nil {
+		return
+	}
+
+	hash := false
+	switch op.(type) {
+	case *plan.HashJoin, *plan.HashNest:
+		hash = true
+	}
+
+	for _, hint := range this.keyspaceJoinHints(alias) {
+		if hint.State() == algebra.OPTIM_HINT_NOT_FOLLOWED {
+			continue
+		}
+
+		switch {
+		case hint.Type() == algebra.OPTIM_HINT_USE_HASH && !hash:
+			if util.IsFeatureEnabled(this.featureControls, util.N1QL_HASH_JOIN) {
+				hint.SetNotFollowed("no hash join can be built for the ON clause")
+			} else {
+				hint.SetNotFollowed("hash join is not enabled")
+			}
+		case hint.Type() == algebra.OPTIM_HINT_USE_NL && hash:
+			hint.SetNotFollowed("no nested-loop join can be built for the ON clause")
+		default:
+			hint.SetFollowed()
+		}
+	}
+}
+
+/*
+ORDERED keeps the joins in the order of the FROM clause.
+*/
+func (this *builder) orderedHint(from algebra.FromTerm) bool {
+	hints := this.optimHints.Find(algebra.OPTIM_HINT_ORDERED, "")
+	for _, hint := range hints {
+		if _, ok := from.(algebra.JoinTerm); ok {
+			hint.SetFollowed()
+		} else {
+			hint.SetNotFollowed("the FROM clause has no joins")
+		}
+	}
+	return len(hints) > 0
+}
+
+/*
+Report the optimizer hints of the statement, for EXPLAIN. Hints left
+undecided did not apply to any part of the plan.
+*/
+func optimHintsReport(blocks []*algebra.OptimHints) map[string]interface{} {
+	var followed, notFollowed, invalid []interface{}
+	for _, block := range blocks {
+		for _, hint := range block.Hints() {
+			switch {
+			case hint.Type() == algebra.OPTIM_HINT_INVALID:
+				invalid = append(invalid, map[string]interface{}{
+					"hint":  hint.String(),
+					"error": hint.Reason(),
+				})
+			case hint.State() == algebra.OPTIM_HINT_FOLLOWED:
+				followed = append(followed, hint.String())
+			default:
+				reason := hint.Reason()
+				if hint.State() == algebra.OPTIM_HINT_UNKNOWN {
+					if hint.Keyspace() != "" {
+						reason = fmt.Sprintf("keyspace %s is not scanned or joined in the query block", hint.Keyspace())
+					} else {
+						reason = "the hint does not apply to the query block"
+					}
+				}
+				notFollowed = append(notFollowed, map[string]interface{}{
+					"hint":   hint.String(),
+					"reason": reason,
+				})
+			}
+		}
+	}
+
+	if len(followed) == 0 && len(notFollowed) == 0 && len(invalid) == 0 {
+		return nil
+	}
+
+	rv := make(map[string]interface{}, 3)
+	if len(followed) > 0 {
+		rv["hints_followed"] = followed
+	}
+	if len(notFollowed) > 0 {
+		rv["hints_not_followed"] = notFollowed
+	}
+	if len(invalid) > 0 {
+		rv["invalid_hints"] = invalid
+	}
+	return rv
+}
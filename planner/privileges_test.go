@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/functions"
 	"github.com/couchbase/query/parser/n1ql"
 )
 
@@ -292,9 +293,78 @@ func TestStatementPrivileges(t *testing.T) {
 			expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
 				auth.PrivilegePair{Target: ":testbucket", Priv: auth.PRIV_QUERY_DROP_INDEX},
 			}}},
+		//
+		// TRUNCATE
+		//
+		testCase{id: "Truncate",
+			text: "truncate keyspace testbucket",
+			expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
+				auth.PrivilegePair{Target: ":testbucket", Priv: auth.PRIV_QUERY_TRUNCATE},
+			}}},
+		//
+		// UPDATE STATISTICS
+		//
+		testCase{id: "Update Statistics",
+			text: "update statistics for testbucket(foo)",
+			expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
+				auth.PrivilegePair{Target: ":testbucket", Priv: auth.PRIV_QUERY_SELECT},
+				auth.PrivilegePair{Target: ":testbucket", Priv: auth.PRIV_QUERY_CREATE_INDEX},
+			}}},
+		testCase{id: "Analyze Keyspace",
+			text: "analyze keyspace testbucket(foo, bar)",
+			expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
+				auth.PrivilegePair{Target: ":testbucket", Priv: auth.PRIV_QUERY_SELECT},
+				auth.PrivilegePair{Target: ":testbucket", Priv: auth.PRIV_QUERY_CREATE_INDEX},
+			}}},
+		//
+		// ADVISE
+		//
+		testCase{id: "Advise Select",
+			text: "advise select * from testbucket where foo = 1",
+			expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
+				auth.PrivilegePair{Target: ":testbucket", Priv: auth.PRIV_QUERY_SELECT},
+			}}},
+		testCase{id: "Advise Update",
+			text: "advise update testbucket set foo = 1 where bar = 2",
+			expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
+				auth.PrivilegePair{Target: ":testbucket", Priv: auth.PRIV_QUERY_UPDATE},
+			}}},
+		testCase{id: "Advise All",
+			text: "advise all",
+			expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
+				auth.PrivilegePair{Target: "#system:completed_requests", Priv: auth.PRIV_SYSTEM_READ},
+			}}},
+		//
+		// USER DEFINED FUNCTIONS
+		//
+		testCase{id: "Create Function with CURL",
+			text: "create function get_url(u) { CURL(u) }",
+			expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
+				auth.PrivilegePair{Target: "", Priv: auth.PRIV_QUERY_MANAGE_FUNCTIONS},
+				auth.PrivilegePair{Target: "", Priv: auth.PRIV_QUERY_EXTERNAL_ACCESS},
+			}}},
 	}
 
 	for _, testCase := range testCases {
 		runCase(t, &testCase)
 	}
 }
+
+func TestUserFunctionPrivileges(t *testing.T) {
+	body, err := n1ql.ParseExpression("CURL(u)")
+	if err != nil {
+		t.Fatalf("Unable to parse function body: %v", err)
+	}
+
+	if err := functions.AddFunction(functions.NewFunction("get_url", []string{"u"}, body)); err != nil {
+		t.Fatalf("Unable to add function: %v", err)
+	}
+	defer functions.DeleteFunction("get_url")
+
+	runCase(t, &testCase{id: "Select calling CURL through a function",
+		text: "select get_url('http://ip.jsontest.com') as res",
+		expectedPrivs: &auth.Privileges{List: []auth.PrivilegePair{
+			auth.PrivilegePair{Target: "", Priv: auth.PRIV_QUERY_EXECUTE_FUNCTIONS},
+			auth.PrivilegePair{Target: "", Priv: auth.PRIV_QUERY_EXTERNAL_ACCESS},
+		}}})
+}
@@ -408,7 +408,7 @@ func DecodePrepared(prepared_name string, prepared_stmt string, track bool, dist
 }
 
 func unmarshalPrepared(bytes []byte, phaseTime *time.Duration) (*plan.Prepared, errors.Error) {
-	prepared := plan.NewPrepared(nil, nil)
+	prepared := plan.NewPrepared(nil, nil, nil)
 	err := prepared.UnmarshalJSON(bytes)
 	if err != nil {
 
@@ -441,7 +441,25 @@ func distributePrepared(name, plan string) {
 		}, distributed.NO_CREDS, "")
 }
 
+/*
+Get a plan of a prepared statement built with the given feature
+controls, as for a request in a transaction, which may not use index
+covering. The plan is rebuilt, but not cached, if the controls differ.
+*/
+func GetPreparedWithControls(prepared *plan.Prepared, featureControls uint64,
+	phaseTime *time.Duration) (*plan.Prepared, errors.Error) {
+	if prepared.FeatureControls() == featureControls {
+		return prepared, nil
+	}
+	return build(prepared, featureControls, phaseTime)
+}
+
 func reprepare(prepared *plan.Prepared, phaseTime *time.Duration) (*plan.Prepared, errors.Error) {
+	return build(prepared, prepared.FeatureControls(), phaseTime)
+}
+
+func build(prepared *plan.Prepared, featureControls uint64,
+	phaseTime *time.Duration) (*plan.Prepared, errors.Error) {
 	parse := time.Now()
 	stmt, err := n1ql.ParseStatement(prepared.Text())
 	if phaseTime != nil {
@@ -459,7 +477,7 @@ func reprepare(prepared *plan.Prepared, phaseTime *time.Duration) (*plan.Prepare
 	pl, err := planner.BuildPrepared(stmt.(*algebra.Prepare).Statement(), store, systemstore, namespace, false,
 
 		// building prepared statements should not depend on args
-		nil, nil, prepared.IndexApiVersion(), prepared.FeatureControls())
+		nil, nil, prepared.IndexApiVersion(), featureControls)
 	if phaseTime != nil {
 		*phaseTime += time.Since(prep)
 	}
@@ -471,7 +489,7 @@ func reprepare(prepared *plan.Prepared, phaseTime *time.Duration) (*plan.Prepare
 	pl.SetText(prepared.Text())
 	pl.SetType(prepared.Type())
 	pl.SetIndexApiVersion(prepared.IndexApiVersion())
-	pl.SetFeatureControls(prepared.FeatureControls())
+	pl.SetFeatureControls(featureControls)
 
 	json_bytes, err := pl.MarshalJSON()
 	if err != nil {
@@ -46,6 +46,9 @@ func (this *SemChecker) VisitJoin(node *algebra.Join) (interface{}, error) {
 	if right.Indexes() != nil {
 		return nil, errors.NewJoinNestNoUseIndexError("JOIN", right.Alias(), "semantics.visit_join.no_use_index")
 	}
+	if right.Sample() != nil {
+		return nil, errors.NewJoinNestNoSampleError("JOIN", right.Alias(), "semantics.visit_join.no_sample")
+	}
 
 	return nil, this.visitJoin(node.Left(), node.Right())
 }
@@ -70,6 +73,9 @@ func (this *SemChecker) VisitIndexJoin(node *algebra.IndexJoin) (interface{}, er
 	if right.Indexes() != nil {
 		return nil, errors.NewJoinNestNoUseIndexError("JOIN", right.Alias(), "semantics.visit_index_join.no_use_index")
 	}
+	if right.Sample() != nil {
+		return nil, errors.NewJoinNestNoSampleError("JOIN", right.Alias(), "semantics.visit_index_join.no_sample")
+	}
 
 	return nil, this.visitJoin(node.Left(), node.Right())
 }
@@ -107,6 +113,9 @@ func (this *SemChecker) VisitNest(node *algebra.Nest) (interface{}, error) {
 	if right.Indexes() != nil {
 		return nil, errors.NewJoinNestNoUseIndexError("NEST", right.Alias(), "semantics.visit_nest.no_use_index")
 	}
+	if right.Sample() != nil {
+		return nil, errors.NewJoinNestNoSampleError("NEST", right.Alias(), "semantics.visit_nest.no_sample")
+	}
 
 	return nil, this.visitJoin(node.Left(), node.Right())
 }
@@ -131,6 +140,9 @@ func (this *SemChecker) VisitIndexNest(node *algebra.IndexNest) (interface{}, er
 	if right.Indexes() != nil {
 		return nil, errors.NewJoinNestNoUseIndexError("NEST", right.Alias(), "semantics.visit_index_nest.no_use_index")
 	}
+	if right.Sample() != nil {
+		return nil, errors.NewJoinNestNoSampleError("NEST", right.Alias(), "semantics.visit_index_nest.no_sample")
+	}
 
 	return nil, this.visitJoin(node.Left(), node.Right())
 }
@@ -29,6 +29,10 @@ func (this *SemChecker) VisitUnionAll(node *algebra.UnionAll) (interface{}, erro
 	return this.visitSetop(node.First(), node.Second())
 }
 
+func (this *SemChecker) VisitRecursiveUnion(node *algebra.RecursiveUnion) (interface{}, error) {
+	return this.visitSetop(node.First(), node.Second())
+}
+
 func (this *SemChecker) VisitIntersect(node *algebra.Intersect) (interface{}, error) {
 	return this.visitSetop(node.First(), node.Second())
 }
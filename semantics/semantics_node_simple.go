@@ -15,6 +15,13 @@ import (
 )
 
 func (this *SemChecker) VisitKeyspaceTerm(node *algebra.KeyspaceTerm) (interface{}, error) {
+	if sample := node.Sample(); sample != nil {
+		for _, expr := range sample.Expressions() {
+			if expr.Static() == nil {
+				return nil, errors.NewSampleNotConstantError(node.Alias())
+			}
+		}
+	}
 	return nil, nil
 }
 
@@ -23,6 +30,10 @@ func (this *SemChecker) VisitExpressionTerm(node *algebra.ExpressionTerm) (inter
 		return node.KeyspaceTerm().Accept(this)
 	}
 
+	if ksterm := node.KeyspaceTerm(); ksterm != nil && ksterm.Sample() != nil {
+		return nil, errors.NewSampleKeyspaceOnlyError(node.Alias())
+	}
+
 	return nil, nil
 }
 
@@ -33,6 +33,10 @@ func (this *SemChecker) VisitBuildIndexes(stmt *algebra.BuildIndexes) (interface
 	return nil, nil
 }
 
+func (this *SemChecker) VisitUpdateStatistics(stmt *algebra.UpdateStatistics) (interface{}, error) {
+	return nil, nil
+}
+
 func (this *SemChecker) VisitGrantRole(stmt *algebra.GrantRole) (interface{}, error) {
 	return nil, nil
 }
@@ -40,3 +44,31 @@ func (this *SemChecker) VisitGrantRole(stmt *algebra.GrantRole) (interface{}, er
 func (this *SemChecker) VisitRevokeRole(stmt *algebra.RevokeRole) (interface{}, error) {
 	return nil, nil
 }
+
+func (this *SemChecker) VisitCreateFunction(stmt *algebra.CreateFunction) (interface{}, error) {
+	return nil, nil
+}
+
+func (this *SemChecker) VisitDropFunction(stmt *algebra.DropFunction) (interface{}, error) {
+	return nil, nil
+}
+
+func (this *SemChecker) VisitExecuteFunction(stmt *algebra.ExecuteFunction) (interface{}, error) {
+	return nil, nil
+}
+
+func (this *SemChecker) VisitStartTransaction(stmt *algebra.StartTransaction) (interface{}, error) {
+	return nil, nil
+}
+
+func (this *SemChecker) VisitCommitTransaction(stmt *algebra.CommitTransaction) (interface{}, error) {
+	return nil, nil
+}
+
+func (this *SemChecker) VisitRollbackTransaction(stmt *algebra.RollbackTransaction) (interface{}, error) {
+	return nil, nil
+}
+
+func (this *SemChecker) VisitSavepoint(stmt *algebra.Savepoint) (interface{}, error) {
+	return nil, nil
+}
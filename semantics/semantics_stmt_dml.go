@@ -86,10 +86,21 @@ func (this *SemChecker) VisitMerge(stmt *algebra.Merge) (interface{}, error) {
 	return nil, nil
 }
 
+func (this *SemChecker) VisitTruncate(stmt *algebra.Truncate) (interface{}, error) {
+	return nil, nil
+}
+
 func (this *SemChecker) VisitExplain(stmt *algebra.Explain) (interface{}, error) {
 	return stmt.Statement().Accept(this)
 }
 
+func (this *SemChecker) VisitAdvise(stmt *algebra.Advise) (interface{}, error) {
+	if stmt.Statement() == nil {
+		return nil, nil
+	}
+	return stmt.Statement().Accept(this)
+}
+
 func (this *SemChecker) VisitPrepare(stmt *algebra.Prepare) (interface{}, error) {
 	return nil, nil
 }
@@ -30,6 +30,7 @@ import (
 	datastore_package "github.com/couchbase/query/datastore"
 	"github.com/couchbase/query/datastore/resolver"
 	"github.com/couchbase/query/datastore/system"
+	"github.com/couchbase/query/functions"
 	"github.com/couchbase/query/logging"
 	log_resolver "github.com/couchbase/query/logging/resolver"
 	"github.com/couchbase/query/prepareds"
@@ -72,6 +73,9 @@ var DEBUG = flag.Bool("debug", false, "Debug mode")
 var KEEP_ALIVE_LENGTH = flag.Int("keep-alive-length", server.KEEP_ALIVE_DEFAULT, "maximum size of buffered result")
 var STATIC_PATH = flag.String("static-path", "static", "Path to static content")
 var PIPELINE_CAP = flag.Int64("pipeline-cap", 512, "Maximum number of items each execution operator can buffer")
+var SPILL_THRESHOLD = flag.Int64("spill-threshold", 512*1024*1024, "Estimated bytes of sort state a request can hold before spilling to disk; use zero or negative value to disable")
+var SPILL_DIR = flag.String("spill-dir", "", "Directory for spilled sort state; defaults to the system temporary directory")
+var MEMORY_QUOTA = flag.Int64("memory-quota", 0, "Estimated bytes of operator state a request can hold; use zero or negative value for no quota")
 var PIPELINE_BATCH = flag.Int("pipeline-batch", 16, "Number of items execution operators can batch")
 var ENTERPRISE = flag.Bool("enterprise", true, "Enterprise mode")
 var MAX_INDEX_API = flag.Int("max-index-api", datastore_package.INDEX_API_MAX, "Max Index API")
@@ -152,6 +156,13 @@ func main() {
 	}
 	datastore_package.SetDatastore(datastore)
 
+	err = functions.Init(datastore)
+	if err != nil {
+		logging.Errorp("Could not load user defined functions",
+			logging.Pair{"error", err},
+		)
+	}
+
 	// configstore should be set before the system datastore
 	configstore, err := config_resolver.NewConfigstore(*CONFIGSTORE)
 	if err != nil {
@@ -237,6 +248,9 @@ func main() {
 	server.SetScanCap(*SCAN_CAP)
 	server.SetPipelineCap(*PIPELINE_CAP)
 	server.SetPipelineBatch(*PIPELINE_BATCH)
+	server.SetSpillThreshold(*SPILL_THRESHOLD)
+	server.SetSpillDir(*SPILL_DIR)
+	server.SetMemoryQuota(*MEMORY_QUOTA)
 	server.SetRequestSizeCap(*REQUEST_SIZE_CAP)
 	server.SetScanCap(*SCAN_CAP)
 	server.SetMaxIndexAPI(*MAX_INDEX_API)
@@ -261,6 +275,9 @@ func main() {
 		logging.Pair{"scan-cap", server.ScanCap()},
 		logging.Pair{"pipeline-cap", server.PipelineCap()},
 		logging.Pair{"pipeline-batch", server.PipelineBatch()},
+		logging.Pair{"spill-threshold", server.SpillThreshold()},
+		logging.Pair{"spill-dir", server.SpillDir()},
+		logging.Pair{"memory-quota", server.MemoryQuota()},
 		logging.Pair{"request-cap", *REQUEST_CAP},
 		logging.Pair{"request-size-cap", server.RequestSizeCap()},
 		logging.Pair{"max-index-api", server.MaxIndexAPI()},
@@ -43,6 +43,7 @@ type RequestLogEntry struct {
 	ResultCount     int
 	ResultSize      int
 	ErrorCount      int
+	UsedMemory      uint64
 	PreparedName    string
 	PreparedText    string
 	Time            time.Time
@@ -268,6 +269,7 @@ func LogRequest(request_time time.Duration, service_time time.Duration,
 		ResultCount:     result_count,
 		ResultSize:      result_size,
 		ErrorCount:      error_count,
+		UsedMemory:      request.UsedMemory(),
 		Time:            time.Now(),
 		ScanConsistency: string(request.ScanConsistency()),
 	}
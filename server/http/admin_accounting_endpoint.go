@@ -609,6 +609,9 @@ func completedRequestWorkHorse(requestId string, profiling bool) map[string]inte
 		if request.PhaseOperators != nil {
 			reqMap["phaseOperators"] = request.PhaseOperators
 		}
+		if request.UsedMemory > 0 {
+			reqMap["usedMemory"] = request.UsedMemory
+		}
 		if profiling {
 			if request.PhaseTimes != nil {
 				reqMap["phaseTimes"] = request.PhaseTimes
@@ -679,6 +682,9 @@ func doCompletedRequests(endpoint *HttpEndpoint, w http.ResponseWriter, req *htt
 		if request.PhaseOperators != nil {
 			requests[i]["phaseOperators"] = request.PhaseOperators
 		}
+		if request.UsedMemory > 0 {
+			requests[i]["usedMemory"] = request.UsedMemory
+		}
 		if request.Users != "" {
 			requests[i]["users"] = request.Users
 		}
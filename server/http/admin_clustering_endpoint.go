@@ -393,6 +393,9 @@ func fillSettings(settings map[string]interface{}, srvr *server.Server) map[stri
 	settings[paramSettings.DEBUG] = srvr.Debug()
 	settings[paramSettings.PIPELINEBATCH] = srvr.PipelineBatch()
 	settings[paramSettings.PIPELINECAP] = srvr.PipelineCap()
+	settings[paramSettings.SPILLTHRESHOLD] = srvr.SpillThreshold()
+	settings[paramSettings.SPILLDIR] = srvr.SpillDir()
+	settings[paramSettings.MEMORYQUOTA] = srvr.MemoryQuota()
 	settings[paramSettings.MAXPARALLELISM] = srvr.MaxParallelism()
 	settings[paramSettings.TIMEOUTSETTING] = srvr.Timeout()
 	settings[paramSettings.KEEPALIVELENGTH] = srvr.KeepAlive()
@@ -0,0 +1,136 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package http
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/couchbase/query/errors"
+)
+
+// Compression of request and response bodies.
+//
+// Responses are compressed with gzip or deflate when requested through
+// the compression parameter or, in its absence, the Accept-Encoding
+// header. As per HTTP, deflate is the zlib format. Request bodies are
+// decompressed according to their Content-Encoding header.
+
+// compressor is implemented by gzip.Writer and zlib.Writer
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+func (c Compression) contentEncoding() string {
+	switch c {
+	case GZIP:
+		return "gzip"
+	case DEFLATE:
+		return "deflate"
+	default:
+		return ""
+	}
+}
+
+func (c Compression) newCompressor(w io.Writer) compressor {
+	switch c {
+	case GZIP:
+		return gzip.NewWriter(w)
+	case DEFLATE:
+		return zlib.NewWriter(w)
+	default:
+		return nil
+	}
+}
+
+// negotiateCompression returns the compression with the highest
+// quality in an Accept-Encoding header, preferring gzip on ties
+func negotiateCompression(acceptEncoding string) Compression {
+	rv := NONE
+	best := 0.0
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		quality := 1.0
+		fields := strings.Split(coding, ";")
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(param[2:], 64)
+				if err != nil {
+					q = 0.0
+				}
+				quality = q
+			}
+		}
+
+		var compression Compression
+		switch strings.ToLower(strings.TrimSpace(fields[0])) {
+		case "gzip", "x-gzip", "*":
+			compression = GZIP
+		case "deflate":
+			compression = DEFLATE
+		default:
+			continue
+		}
+
+		if quality > best || (quality == best && quality > 0.0 && compression == GZIP) {
+			rv = compression
+			best = quality
+		}
+	}
+	return rv
+}
+
+// requestBody decompresses a request body and closes both the
+// decompressor and the original body
+type requestBody struct {
+	io.Reader
+	body   io.ReadCloser
+	closer io.Closer
+}
+
+func (this *requestBody) Close() error {
+	this.closer.Close()
+	return this.body.Close()
+}
+
+// newRequestBody returns a reader for the decompressed request body.
+// The body is returned unchanged if it is not compressed, or cannot be
+// decompressed
+func newRequestBody(req *http.Request) (io.ReadCloser, errors.Error) {
+	contentEncoding := strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding")))
+
+	var decompressor io.ReadCloser
+	var err error
+	switch contentEncoding {
+	case "", "identity":
+		return req.Body, nil
+	case "gzip", "x-gzip":
+		decompressor, err = gzip.NewReader(req.Body)
+	case "deflate":
+		decompressor, err = zlib.NewReader(req.Body)
+	default:
+		return req.Body, errors.NewServiceErrorUnrecognizedValue("Content-Encoding", contentEncoding)
+	}
+
+	if err != nil {
+		return req.Body, errors.NewServiceErrorBadValue(err, "request body")
+	}
+
+	return &requestBody{
+		Reader: decompressor,
+		body:   req.Body,
+		closer: decompressor,
+	}, nil
+}
@@ -46,6 +46,9 @@ type httpRequest struct {
 	resultSize      int
 	errorCount      int
 	warningCount    int
+	format          Format
+	delimited       *delimitedWriter
+	compression     Compression
 
 	elapsedTime   time.Duration
 	executionTime time.Duration
@@ -63,6 +66,11 @@ func newHttpRequest(resp http.ResponseWriter, req *http.Request, bp BufferPool,
 	// This is literally when we become aware of the request
 	reqTime := time.Now()
 
+	// Decompress the body before limiting its size, so that the limit
+	// applies to what we actually parse
+	body, bodyErr := newRequestBody(req)
+	req.Body = body
+
 	// Limit body size in case of denial-of-service attack
 	req.Body = http.MaxBytesReader(resp, req.Body, int64(size))
 
@@ -76,6 +84,9 @@ func newHttpRequest(resp http.ResponseWriter, req *http.Request, bp BufferPool,
 	}
 
 	err = contentNegotiation(resp, req)
+	if err == nil {
+		err = bodyErr
+	}
 
 	if err == nil {
 		httpArgs, err = getRequestParams(req)
@@ -200,6 +211,23 @@ func newHttpRequest(resp http.ResponseWriter, req *http.Request, bp BufferPool,
 		}
 	}
 
+	var memory_quota int64
+	if err == nil {
+		param, err = httpArgs.getString(MEMORY_QUOTA, "")
+		if err == nil && param != "" {
+			var e error
+			memory_quota, e = strconv.ParseInt(param, 10, 64)
+			if e != nil {
+				err = errors.NewServiceErrorBadValue(go_errors.New("memory_quota is invalid"), "memory quota")
+			}
+		}
+	}
+
+	var txid string
+	if err == nil {
+		txid, err = httpArgs.getString(TXID, "")
+	}
+
 	var readonly value.Tristate
 	if err == nil {
 		readonly, err = getReadonly(httpArgs, req.Method == "GET")
@@ -215,10 +243,6 @@ func newHttpRequest(resp http.ResponseWriter, req *http.Request, bp BufferPool,
 		format, err = getFormat(httpArgs)
 	}
 
-	if err == nil && format != JSON {
-		err = errors.NewServiceErrorNotImplemented("format", format.String())
-	}
-
 	var signature value.Tristate
 	if err == nil {
 		signature, err = httpArgs.getTristate(SIGNATURE)
@@ -226,10 +250,10 @@ func newHttpRequest(resp http.ResponseWriter, req *http.Request, bp BufferPool,
 
 	var compression Compression
 	if err == nil {
-		compression, err = getCompression(httpArgs)
+		compression, err = getCompression(httpArgs, req.Header.Get("Accept-Encoding"))
 	}
 
-	if err == nil && compression != NONE {
+	if err == nil && compression != NONE && compression != GZIP && compression != DEFLATE {
 		err = errors.NewServiceErrorNotImplemented("compression", compression.String())
 	}
 
@@ -284,6 +308,17 @@ func newHttpRequest(resp http.ResponseWriter, req *http.Request, bp BufferPool,
 		req.RemoteAddr, userAgent)
 
 	rv.SetRequestTime(reqTime)
+	rv.SetMemoryQuota(memory_quota)
+	rv.SetTxId(txid)
+	if err == nil && format != JSON {
+		rv.format = format
+		resp.Header().Set("Content-Type", format.contentType())
+	}
+	if err == nil && compression != NONE {
+		rv.compression = compression
+		resp.Header().Set("Content-Encoding", compression.contentEncoding())
+	}
+	resp.Header().Add("Vary", "Accept-Encoding")
 	if phaseTime != 0 {
 		rv.Output().AddPhaseTime(execution.REPREPARE, phaseTime)
 	}
@@ -389,6 +424,8 @@ const ( // Request argument names
 	SCAN_CAP          = "scan_cap"
 	PIPELINE_CAP      = "pipeline_cap"
 	PIPELINE_BATCH    = "pipeline_batch"
+	MEMORY_QUOTA      = "memory_quota"
+	TXID              = "txid"
 	READONLY          = "readonly"
 	METRICS           = "metrics"
 	NAMESPACE         = "namespace"
@@ -429,6 +466,8 @@ var _PARAMETERS = []string{
 	SCAN_CAP,
 	PIPELINE_CAP,
 	PIPELINE_BATCH,
+	MEMORY_QUOTA,
+	TXID,
 	READONLY,
 	METRICS,
 	NAMESPACE,
@@ -484,15 +523,19 @@ func getEncodedPlan(a httpRequestArgs) (string, errors.Error) {
 	return a.getString(ENCODED_PLAN, "")
 }
 
-func getCompression(a httpRequestArgs) (Compression, errors.Error) {
+// getCompression returns the requested compression, or, if none was
+// requested, the preferred one of those accepted by the client
+func getCompression(a httpRequestArgs, acceptEncoding string) (Compression, errors.Error) {
 	var compression Compression
 
-	compression_field, err := a.getString(COMPRESSION, "NONE")
+	compression_field, err := a.getString(COMPRESSION, "")
 	if err == nil && compression_field != "" {
 		compression = newCompression(compression_field)
 		if compression == UNDEFINED_COMPRESSION {
 			err = errors.NewServiceErrorUnrecognizedValue(COMPRESSION, compression_field)
 		}
+	} else if err == nil {
+		compression = negotiateCompression(acceptEncoding)
 	}
 	return compression, err
 }
@@ -1312,6 +1355,8 @@ const (
 	RLE
 	LZMA
 	LZO
+	GZIP
+	DEFLATE
 	UNDEFINED_COMPRESSION
 )
 
@@ -1327,6 +1372,10 @@ func newCompression(s string) Compression {
 		return LZMA
 	case "LZO":
 		return LZO
+	case "GZIP":
+		return GZIP
+	case "DEFLATE":
+		return DEFLATE
 	default:
 		return UNDEFINED_COMPRESSION
 	}
@@ -1345,6 +1394,10 @@ func (c Compression) String() string {
 		s = "LZMA"
 	case LZO:
 		s = "LZO"
+	case GZIP:
+		s = "GZIP"
+	case DEFLATE:
+		s = "DEFLATE"
 	default:
 		s = "UNDEFINED_COMPRESSION"
 	}
@@ -11,8 +11,12 @@ package http
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -258,6 +262,139 @@ func TestRequestWithTimeout(t *testing.T) {
 	}
 }
 
+func TestRequestFormats(t *testing.T) {
+	statement := "select 1 as one, 'a,b' as s, {\"x\": [1, null]} as o, null as n"
+
+	doFormat(t, statement, "CSV", "text/csv; charset=utf-8",
+		"one,s,o,n\n1,\"a,b\",\"{\"\"x\"\":[1,null]}\",\n")
+	doFormat(t, statement, "TSV", "text/tab-separated-values; charset=utf-8",
+		"one\ts\to\tn\n1\ta,b\t\"{\"\"x\"\":[1,null]}\"\t\n")
+	doFormat(t, "select raw 'x<y'", "CSV", "text/csv; charset=utf-8",
+		"$1\nx<y\n")
+	doFormat(t, statement, "XML", "application/xml; charset=utf-8",
+		"<results><result><n null=\"true\"/><o><x><item>1</item><item null=\"true\"/></x></o>"+
+			"<one>1</one><s>a,b</s></result></results>")
+	doFormat(t, "select raw 'x<y'", "XML", "application/xml; charset=utf-8",
+		"<results><result>x&lt;y</result></results>")
+}
+
+func doFormat(t *testing.T, statement, format, contentType, expected string) {
+	res, err := doUrlEncodedPost(url.Values{
+		"statement": []string{statement},
+		"format":    []string{format},
+		"pretty":    []string{"false"},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error in HTTP request: %v", err)
+		return
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Errorf("Unexpected error reading HTTP response: %v", err)
+		return
+	}
+
+	if res.Header.Get("Content-Type") != contentType {
+		t.Errorf("Expected %v content type: %v, actual: %v\n", format, contentType, res.Header.Get("Content-Type"))
+	}
+
+	if !strings.Contains(string(body), expected) {
+		t.Errorf("Expected %v response containing: %v, actual: %v\n", format, expected, string(body))
+	}
+}
+
+func TestRequestCompression(t *testing.T) {
+	// large enough to be streamed in several chunks
+	statement := "select array_repeat('abcdefgh', 1000) as a"
+
+	doCompression(t, url.Values{"statement": []string{statement}, "compression": []string{"GZIP"}}, "", "gzip")
+	doCompression(t, url.Values{"statement": []string{statement}}, "deflate, gzip;q=0.5", "deflate")
+	doCompression(t, url.Values{"statement": []string{statement}}, "gzip, deflate", "gzip")
+	doCompression(t, url.Values{"statement": []string{statement}, "compression": []string{"NONE"}}, "gzip", "")
+
+	_, err := doUrlEncodedPost(url.Values{"statement": []string{statement}, "compression": []string{"LZO"}})
+	if err != nil {
+		t.Errorf("Unexpected error in HTTP request: %v", err)
+	}
+
+	select {
+	case err := <-test_server.request().Errors():
+		if err.Code() != 1020 {
+			t.Errorf("Expected error condition: not implemented. Received: %v", err)
+		}
+	default:
+		t.Errorf("Expected error: LZO compression not implemented")
+	}
+
+	var body bytes.Buffer
+	writer := gzip.NewWriter(&body)
+	writer.Write([]byte(`{"statement": "select 'compressed' as c"}`))
+	writer.Close()
+
+	u, _ := url.ParseRequestURI(test_server.URL())
+	req, _ := http.NewRequest("POST", u.String()+"/", &body)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Encoding", "gzip")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("Unexpected error in HTTP request: %v", err)
+		return
+	}
+
+	defer res.Body.Close()
+	response, _ := ioutil.ReadAll(res.Body)
+	if !strings.Contains(string(response), "\"c\": \"compressed\"") {
+		t.Errorf("Expected result of compressed request, actual: %v\n", string(response))
+	}
+}
+
+func doCompression(t *testing.T, payload url.Values, acceptEncoding, contentEncoding string) {
+	u, _ := url.ParseRequestURI(test_server.URL())
+	req, _ := http.NewRequest("POST", u.String()+"/", bytes.NewBufferString(payload.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	if acceptEncoding != "" {
+		req.Header.Add("Accept-Encoding", acceptEncoding)
+	}
+
+	// keep the client from negotiating and decompressing by itself
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Errorf("Unexpected error in HTTP request: %v", err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.Header.Get("Content-Encoding") != contentEncoding {
+		t.Errorf("Expected content encoding: %v, actual: %v\n", contentEncoding, res.Header.Get("Content-Encoding"))
+		return
+	}
+
+	var reader io.Reader = res.Body
+	switch contentEncoding {
+	case "gzip":
+		reader, err = gzip.NewReader(res.Body)
+	case "deflate":
+		reader, err = zlib.NewReader(res.Body)
+	}
+	if err != nil {
+		t.Errorf("Unexpected error decompressing response: %v", err)
+		return
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Errorf("Unexpected error reading response: %v", err)
+		return
+	}
+
+	if strings.Count(string(body), "abcdefgh") != 1000 || !strings.Contains(string(body), "\"status\": \"success\"") {
+		t.Errorf("Expected complete %v response, actual: %v\n", contentEncoding, string(body))
+	}
+}
+
 func TestPrepareStatements(t *testing.T) {
 	preparedSequence(t, "doSelect", "SELECT b FROM p0:b0 LIMIT 5")
 	preparedSequence(t, "doInsert", "INSERT INTO p0:b0 VALUES ($1, $2)")
@@ -101,6 +101,17 @@ func (this *httpRequest) Failed(srvr *server.Server) {
 	defer this.stopAndClose(server.FATAL)
 
 	prefix, indent := this.prettyStrings(srvr.Pretty(), false)
+	switch this.format {
+	case CSV, TSV:
+		this.failedDelimited()
+		this.writer.noMoreData()
+		return
+	case XML:
+		this.failedXML(srvr, prefix, indent)
+		this.writer.noMoreData()
+		return
+	}
+
 	this.writeString("{\n")
 	this.writeRequestID(prefix)
 	this.writeClientContextID(prefix)
@@ -122,13 +133,14 @@ func (this *httpRequest) markTimeOfCompletion() {
 	this.elapsedTime = time.Since(this.RequestTime())
 }
 
-func (this *httpRequest) Execute(srvr *server.Server, signature value.Value, stopNotify execution.Operator) {
+func (this *httpRequest) Execute(srvr *server.Server, signature value.Value, columns []string,
+	stopNotify execution.Operator) {
 	this.NotifyStop(stopNotify)
 
 	prefix, indent := this.prettyStrings(srvr.Pretty(), false)
 
 	this.setHttpCode(http.StatusOK)
-	this.writePrefix(srvr, signature, prefix, indent)
+	this.writePrefix(srvr, signature, columns, prefix, indent)
 	stopped := this.writeResults(srvr.Pretty())
 	this.Output().AddPhaseTime(execution.RUN, time.Since(this.ExecTime()))
 
@@ -154,7 +166,15 @@ func (this *httpRequest) stopAndClose(state server.State) {
 	this.Close()
 }
 
-func (this *httpRequest) writePrefix(srvr *server.Server, signature value.Value, prefix, indent string) bool {
+func (this *httpRequest) writePrefix(srvr *server.Server, signature value.Value, columns []string,
+	prefix, indent string) bool {
+	switch this.format {
+	case CSV, TSV:
+		return this.writeDelimitedPrefix(signature, columns)
+	case XML:
+		return this.writeXMLPrefix(srvr, signature, prefix, indent)
+	}
+
 	return this.writeString("{\n") &&
 		this.writeRequestID(prefix) &&
 		this.writeClientContextID(prefix) &&
@@ -237,6 +257,13 @@ func (this *httpRequest) writeResults(pretty bool) bool {
 }
 
 func (this *httpRequest) writeResult(item value.Value, buf *bytes.Buffer, prefix, indent string) bool {
+	switch this.format {
+	case CSV, TSV:
+		return this.writeDelimitedResult(item, buf)
+	case XML:
+		return this.writeXMLResult(item, buf, prefix, indent)
+	}
+
 	var success bool
 
 	buf.Reset()
@@ -287,6 +314,13 @@ func (this *httpRequest) writeValue(item value.Value, prefix, indent string) boo
 }
 
 func (this *httpRequest) writeSuffix(srvr *server.Server, state server.State, prefix, indent string) bool {
+	switch this.format {
+	case CSV, TSV:
+		return this.writeDelimitedSuffix()
+	case XML:
+		return this.writeXMLSuffix(srvr, state, prefix, indent)
+	}
+
 	return this.writeString("\n") && this.writeString(prefix) && this.writeString("]") &&
 		this.writeErrors(prefix, indent) &&
 		this.writeWarnings(prefix, indent) &&
@@ -302,6 +336,10 @@ func (this *httpRequest) writeString(s string) bool {
 }
 
 func (this *httpRequest) writeState(state server.State, prefix string) bool {
+	return this.writeString(fmt.Sprintf(",\n%s\"status\": \"%s\"", prefix, this.completedState(state)))
+}
+
+func (this *httpRequest) completedState(state server.State) server.State {
 	if state == "" {
 		state = this.State()
 	}
@@ -314,10 +352,33 @@ func (this *httpRequest) writeState(state server.State, prefix string) bool {
 		}
 	}
 
-	return this.writeString(fmt.Sprintf(",\n%s\"status\": \"%s\"", prefix, state))
+	return state
 }
 
 func (this *httpRequest) writeErrors(prefix string, indent string) bool {
+	ok := this.drainErrors(func(err errors.Error) bool {
+		if this.errorCount == 0 {
+			this.writeString(",\n")
+			this.writeString(prefix)
+			this.writeString("\"errors\": [")
+		}
+		rv := this.writeError(err, this.errorCount, prefix, indent)
+		this.errorCount++
+		return rv
+	})
+
+	if this.errorCount == 0 {
+		return ok
+	}
+
+	if prefix != "" && !(this.writeString("\n") && this.writeString(prefix)) {
+		return false
+	}
+	return this.writeString("]")
+}
+
+// drainErrors passes each pending error to write, until write fails
+func (this *httpRequest) drainErrors(write func(errors.Error) bool) bool {
 	var err errors.Error
 	ok := true
 loop:
@@ -326,9 +387,6 @@ loop:
 		case err, ok = <-this.Errors():
 			if ok {
 				if this.errorCount == 0 {
-					this.writeString(",\n")
-					this.writeString(prefix)
-					this.writeString("\"errors\": [")
 
 					// MB-19307: please check the comments
 					// in mapErrortoHttpResponse().
@@ -340,16 +398,32 @@ loop:
 						this.setHttpCode(mapErrorToHttpResponse(err, http.StatusOK))
 					}
 				}
-				ok = this.writeError(err, this.errorCount, prefix, indent)
-				this.errorCount++
+				ok = write(err)
+				if !ok {
+					return false
+				}
 			}
 		default:
 			break loop
 		}
 	}
+	return true
+}
 
-	if this.errorCount == 0 {
-		return true
+func (this *httpRequest) writeWarnings(prefix, indent string) bool {
+	ok := this.drainWarnings(func(err errors.Error) bool {
+		if this.warningCount == 0 {
+			this.writeString(",\n")
+			this.writeString(prefix)
+			this.writeString("\"warnings\": [")
+		}
+		rv := this.writeError(err, this.warningCount, prefix, indent)
+		this.warningCount++
+		return rv
+	})
+
+	if this.warningCount == 0 {
+		return ok
 	}
 
 	if prefix != "" && !(this.writeString("\n") && this.writeString(prefix)) {
@@ -358,7 +432,9 @@ loop:
 	return this.writeString("]")
 }
 
-func (this *httpRequest) writeWarnings(prefix, indent string) bool {
+// drainWarnings passes each pending warning to write, until write fails.
+// Warnings that are only to be reported once are skipped if repeated
+func (this *httpRequest) drainWarnings(write func(errors.Error) bool) bool {
 	var err errors.Error
 	ok := true
 	alreadySeen := make(map[string]bool)
@@ -371,28 +447,17 @@ loop:
 					// do nothing for this warning
 					continue loop
 				}
-				if this.warningCount == 0 {
-					this.writeString(",\n")
-					this.writeString(prefix)
-					this.writeString("\"warnings\": [")
-				}
-				ok = this.writeError(err, this.warningCount, prefix, indent)
-				this.warningCount++
 				alreadySeen[err.Error()] = true
+				ok = write(err)
+				if !ok {
+					return false
+				}
 			}
 		default:
 			break loop
 		}
 	}
-
-	if this.warningCount == 0 {
-		return true
-	}
-
-	if prefix != "" && !(this.writeString("\n") && this.writeString(prefix)) {
-		return false
-	}
-	return this.writeString("]")
+	return true
 }
 
 func (this *httpRequest) writeError(err errors.Error, count int, prefix, indent string) bool {
@@ -454,6 +519,10 @@ func (this *httpRequest) writeMetrics(metrics bool, prefix, indent string) bool
 		return false
 	}
 
+	if this.UsedMemory() > 0 && !this.writeString(fmt.Sprintf(",%s\"usedMemory\": %d", newPrefix, this.UsedMemory())) {
+		return false
+	}
+
 	if this.errorCount > 0 && !this.writeString(fmt.Sprintf(",%s\"errorCount\": %d", newPrefix, this.errorCount)) {
 		return false
 	}
@@ -623,10 +692,11 @@ type bufferedWriter struct {
 	closed      bool
 	header      bool // headers required
 	lastFlush   time.Time
+	compressor  compressor // compresses data into our buffer, if compression was requested
 }
 
 func NewBufferedWriter(r *httpRequest, bp BufferPool) *bufferedWriter {
-	return &bufferedWriter{
+	rv := &bufferedWriter{
 		req:         r,
 		buffer:      bp.GetBuffer(),
 		buffer_pool: bp,
@@ -634,6 +704,10 @@ func NewBufferedWriter(r *httpRequest, bp BufferPool) *bufferedWriter {
 		header:      true,
 		lastFlush:   time.Now(),
 	}
+	if r.compression != NONE {
+		rv.compressor = r.compression.newCompressor(rv.buffer)
+	}
+	return rv
 }
 
 func (this *bufferedWriter) writeString(s string) bool {
@@ -648,6 +722,11 @@ func (this *bufferedWriter) writeString(s string) bool {
 		(!this.header && time.Since(this.lastFlush) > 100*time.Millisecond) { // time exceeded
 		w := this.req.resp // our request's response writer
 
+		// push out whatever the compressor is holding on to
+		if this.compressor != nil {
+			this.compressor.Flush()
+		}
+
 		// write response header and data buffered so far using request's response writer:
 		if this.header {
 			w.WriteHeader(this.req.httpCode())
@@ -663,7 +742,12 @@ func (this *bufferedWriter) writeString(s string) bool {
 		w.(http.Flusher).Flush()
 	}
 	// under threshold - write the string to our buffer
-	_, err := this.buffer.Write([]byte(s))
+	var err error
+	if this.compressor != nil {
+		_, err = this.compressor.Write([]byte(s))
+	} else {
+		_, err = this.buffer.Write([]byte(s))
+	}
 	return err == nil
 }
 
@@ -678,6 +762,10 @@ func (this *bufferedWriter) noMoreData() {
 	w := this.req.resp // our request's response writer
 	r := this.req.req  // our request's http request
 
+	if this.compressor != nil {
+		this.compressor.Close()
+	}
+
 	if this.header {
 		// calculate and set the Content-Length header:
 		content_len := strconv.Itoa(len(this.buffer.Bytes()))
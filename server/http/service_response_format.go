@@ -0,0 +1,482 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/server"
+	"github.com/couchbase/query/value"
+)
+
+// Response formats other than JSON.
+//
+// CSV and TSV responses consist of a header row followed by one row
+// per result. The columns are the projection terms, in the order of
+// the projection, or, for SELECT * and raw projections, the fields of
+// the first result in sorted order, or a single $1 column if it is not
+// an object. Nested objects
+// and arrays are JSON encoded, and NULL and MISSING values are empty.
+// Errors and warnings follow as rows of the form: error,code,message,
+// separated from any preceding rows by an empty row.
+//
+// XML responses mirror the JSON response. An object becomes an element
+// per field, an array becomes an <item> element per element, and NULL
+// is an empty element with a null="true" attribute. Fields whose names
+// are not valid XML names become <field name="..."> elements.
+
+func (f Format) contentType() string {
+	switch f {
+	case XML:
+		return "application/xml; charset=utf-8"
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case TSV:
+		return "text/tab-separated-values; charset=utf-8"
+	default:
+		return version
+	}
+}
+
+// decodeResult returns the result as plain maps, slices and scalars,
+// preserving the representation of numbers
+func decodeResult(item value.Value, buf *bytes.Buffer) (interface{}, error) {
+	buf.Reset()
+	err := item.WriteJSON(buf, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var rv interface{}
+	decoder := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+	decoder.UseNumber()
+	err = decoder.Decode(&rv)
+	return rv, err
+}
+
+func sortedFields(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for name, _ := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+/////////////////////////////////////////////////////////////////
+//
+// CSV and TSV
+//
+/////////////////////////////////////////////////////////////////
+
+type delimitedWriter struct {
+	buf     bytes.Buffer
+	writer  *csv.Writer
+	columns []string
+	raw     bool
+	written bool
+}
+
+func newDelimitedWriter(format Format) *delimitedWriter {
+	rv := &delimitedWriter{}
+	rv.writer = csv.NewWriter(&rv.buf)
+	if format == TSV {
+		rv.writer.Comma = '\t'
+	}
+	return rv
+}
+
+// flush returns the rows written since the last flush
+func (this *delimitedWriter) flush() (string, error) {
+	this.buf.Reset()
+	this.writer.Flush()
+	return this.buf.String(), this.writer.Error()
+}
+
+func (this *httpRequest) writeDelimitedPrefix(signature value.Value, columns []string) bool {
+	this.delimited = newDelimitedWriter(this.format)
+
+	if columns != nil {
+		this.delimited.columns = columns
+		return this.writeDelimitedRecord(this.delimited.columns, false)
+	}
+
+	if signature == nil || signature.Type() != value.OBJECT {
+		return true
+	}
+
+	fields := signature.Fields()
+	if _, ok := fields["*"]; ok {
+		return true
+	}
+
+	this.delimited.columns = sortedFields(fields)
+	return this.writeDelimitedRecord(this.delimited.columns, false)
+}
+
+func (this *httpRequest) writeDelimitedResult(item value.Value, buf *bytes.Buffer) bool {
+	result, err := decodeResult(item, buf)
+
+	// item won't be used past this point
+	item.Recycle()
+
+	if err != nil {
+		this.Errors() <- errors.NewServiceErrorInvalidJSON(err)
+		this.SetState(server.FATAL)
+		return false
+	}
+
+	fields, isObject := result.(map[string]interface{})
+	w := this.delimited
+
+	// The first result determines the columns, if the signature did not
+	if w.columns == nil {
+		if isObject {
+			w.columns = sortedFields(fields)
+		} else {
+			w.columns = []string{"$1"}
+			w.raw = true
+		}
+
+		if !this.writeDelimitedRecord(w.columns, false) {
+			return false
+		}
+	}
+
+	record := make([]string, len(w.columns))
+	if w.raw {
+		record[0] = delimitedField(result)
+	} else if isObject {
+		for i, column := range w.columns {
+			record[i] = delimitedField(fields[column])
+		}
+	}
+
+	success := this.writeDelimitedRecord(record, true)
+	if success {
+		this.resultCount++
+	}
+	return success
+}
+
+func delimitedField(val interface{}) string {
+	switch val := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		bytes, err := json.Marshal(val)
+		if err != nil {
+			return ""
+		}
+		return string(bytes)
+	}
+}
+
+func (this *httpRequest) writeDelimitedRecord(record []string, result bool) bool {
+	w := this.delimited
+	w.writer.Write(record)
+	s, err := w.flush()
+	if err != nil {
+		this.SetState(server.CLOSED)
+		return false
+	}
+
+	w.written = true
+	if result {
+		this.resultSize += len(s)
+	}
+	if !this.writeString(s) {
+		this.SetState(server.CLOSED)
+		return false
+	}
+	return true
+}
+
+func (this *httpRequest) writeDelimitedSuffix() bool {
+	first := this.delimited.written
+	writeError := func(kind string, err errors.Error) bool {
+		if first {
+			this.delimited.writer.Write([]string{})
+			first = false
+		}
+		return this.delimited.writer.Write([]string{kind, strconv.Itoa(int(err.Code())), err.Error()}) == nil
+	}
+
+	this.drainErrors(func(err errors.Error) bool {
+		this.errorCount++
+		return writeError("error", err)
+	})
+	this.drainWarnings(func(err errors.Error) bool {
+		this.warningCount++
+		return writeError("warning", err)
+	})
+
+	s, err := this.delimited.flush()
+	if err != nil {
+		return false
+	}
+	return this.writeString(s)
+}
+
+func (this *httpRequest) failedDelimited() {
+	if this.delimited == nil {
+		this.delimited = newDelimitedWriter(this.format)
+	}
+	this.writeDelimitedSuffix()
+}
+
+/////////////////////////////////////////////////////////////////
+//
+// XML
+//
+/////////////////////////////////////////////////////////////////
+
+func (this *httpRequest) writeXMLPrefix(srvr *server.Server, signature value.Value, prefix, indent string) bool {
+	newline := xmlNewline(prefix)
+	if !this.writeString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<response>") ||
+		!this.writeXMLElement("requestID", this.Id().String(), prefix) {
+		return false
+	}
+
+	if this.ClientID().IsValid() && !this.writeXMLElement("clientContextID", this.ClientID().String(), prefix) {
+		return false
+	}
+
+	s := this.Signature()
+	if signature != nil && s != value.FALSE && (s != value.NONE || srvr.Signature()) {
+		var buf bytes.Buffer
+		fields, err := decodeResult(signature, &buf)
+		if err != nil {
+			return false
+		}
+
+		buf.Reset()
+		writeXMLValue(&buf, "signature", fields, prefix, indent)
+		if !this.writeString(newline) || !this.writeString(buf.String()) {
+			return false
+		}
+	}
+
+	return this.writeString(newline) && this.writeString("<results>")
+}
+
+func (this *httpRequest) writeXMLResult(item value.Value, buf *bytes.Buffer, prefix, indent string) bool {
+	result, err := decodeResult(item, buf)
+
+	// item won't be used past this point
+	item.Recycle()
+
+	if err != nil {
+		this.Errors() <- errors.NewServiceErrorInvalidJSON(err)
+		this.SetState(server.FATAL)
+		return false
+	}
+
+	buf.Reset()
+	writeXMLValue(buf, "result", result, prefix, indent)
+
+	success := this.writeString(xmlNewline(prefix)) && this.writeString(buf.String())
+	if success {
+		this.resultSize += len(buf.Bytes())
+		this.resultCount++
+	} else {
+		this.SetState(server.CLOSED)
+	}
+	return success
+}
+
+func (this *httpRequest) writeXMLSuffix(srvr *server.Server, state server.State, prefix, indent string) bool {
+	newline := xmlNewline(prefix)
+	return this.writeString(newline) && this.writeString("</results>") &&
+		this.writeXMLErrors(prefix, indent) &&
+		this.writeXMLElement("status", string(this.completedState(state)), prefix) &&
+		this.writeXMLMetrics(srvr.Metrics(), prefix, indent) &&
+		this.writeString("\n</response>\n")
+}
+
+func (this *httpRequest) failedXML(srvr *server.Server, prefix, indent string) {
+	this.writeString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<response>")
+	this.writeXMLElement("requestID", this.Id().String(), prefix)
+	if this.ClientID().IsValid() {
+		this.writeXMLElement("clientContextID", this.ClientID().String(), prefix)
+	}
+	this.writeXMLErrors(prefix, indent)
+	this.writeXMLElement("status", string(this.completedState("")), prefix)
+
+	this.markTimeOfCompletion()
+
+	this.writeXMLMetrics(srvr.Metrics(), prefix, indent)
+	this.writeString("\n</response>\n")
+}
+
+func (this *httpRequest) writeXMLErrors(prefix, indent string) bool {
+	var errs, warnings bytes.Buffer
+	newPrefix := prefix + indent
+
+	writeError := func(buf *bytes.Buffer, name string, err errors.Error) {
+		buf.WriteString(xmlNewline(newPrefix))
+		writeXMLValue(buf, name, map[string]interface{}{
+			"code": json.Number(strconv.Itoa(int(err.Code()))),
+			"msg":  err.Error(),
+		}, newPrefix, indent)
+	}
+
+	this.drainErrors(func(err errors.Error) bool {
+		writeError(&errs, "error", err)
+		this.errorCount++
+		return true
+	})
+	this.drainWarnings(func(err errors.Error) bool {
+		writeError(&warnings, "warning", err)
+		this.warningCount++
+		return true
+	})
+
+	newline := xmlNewline(prefix)
+	if errs.Len() > 0 && !(this.writeString(newline) && this.writeString("<errors>") &&
+		this.writeString(errs.String()) && this.writeString(newline) && this.writeString("</errors>")) {
+		return false
+	}
+
+	if warnings.Len() > 0 && !(this.writeString(newline) && this.writeString("<warnings>") &&
+		this.writeString(warnings.String()) && this.writeString(newline) && this.writeString("</warnings>")) {
+		return false
+	}
+
+	return true
+}
+
+func (this *httpRequest) writeXMLMetrics(metrics bool, prefix, indent string) bool {
+	m := this.Metrics()
+	if m == value.FALSE || (m == value.NONE && !metrics) {
+		return true
+	}
+
+	fields := map[string]interface{}{
+		"elapsedTime":   this.elapsedTime.String(),
+		"executionTime": this.executionTime.String(),
+		"resultCount":   json.Number(strconv.Itoa(this.resultCount)),
+		"resultSize":    json.Number(strconv.Itoa(this.resultSize)),
+	}
+
+	if this.MutationCount() > 0 {
+		fields["mutationCount"] = json.Number(strconv.FormatUint(this.MutationCount(), 10))
+	}
+
+	if this.SortCount() > 0 {
+		fields["sortCount"] = json.Number(strconv.FormatUint(this.SortCount(), 10))
+	}
+
+	if this.UsedMemory() > 0 {
+		fields["usedMemory"] = json.Number(strconv.FormatUint(this.UsedMemory(), 10))
+	}
+
+	if this.errorCount > 0 {
+		fields["errorCount"] = json.Number(strconv.Itoa(this.errorCount))
+	}
+
+	if this.warningCount > 0 {
+		fields["warningCount"] = json.Number(strconv.Itoa(this.warningCount))
+	}
+
+	var buf bytes.Buffer
+	writeXMLValue(&buf, "metrics", fields, prefix, indent)
+	return this.writeString(xmlNewline(prefix)) && this.writeString(buf.String())
+}
+
+func (this *httpRequest) writeXMLElement(name, text, prefix string) bool {
+	var buf bytes.Buffer
+	writeXMLValue(&buf, name, text, prefix, "")
+	return this.writeString(xmlNewline(prefix)) && this.writeString(buf.String())
+}
+
+func xmlNewline(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return "\n" + prefix
+}
+
+// writeXMLValue writes val as an element called name. The opening tag
+// is not indented; nested elements are indented from prefix
+func writeXMLValue(buf *bytes.Buffer, name string, val interface{}, prefix, indent string) {
+	tag := name
+	if !isXMLName(name) {
+		var attr bytes.Buffer
+		xml.EscapeText(&attr, []byte(name))
+		tag = "field"
+		name = "field name=\"" + attr.String() + "\""
+	}
+
+	newPrefix := prefix + indent
+	newline := xmlNewline(newPrefix)
+
+	switch val := val.(type) {
+	case nil:
+		fmt.Fprintf(buf, "<%s null=\"true\"/>", name)
+	case map[string]interface{}:
+		fmt.Fprintf(buf, "<%s>", name)
+		for _, field := range sortedFields(val) {
+			buf.WriteString(newline)
+			writeXMLValue(buf, field, val[field], newPrefix, indent)
+		}
+		if len(val) > 0 {
+			buf.WriteString(xmlNewline(prefix))
+		}
+		fmt.Fprintf(buf, "</%s>", tag)
+	case []interface{}:
+		fmt.Fprintf(buf, "<%s>", name)
+		for _, elem := range val {
+			buf.WriteString(newline)
+			writeXMLValue(buf, "item", elem, newPrefix, indent)
+		}
+		if len(val) > 0 {
+			buf.WriteString(xmlNewline(prefix))
+		}
+		fmt.Fprintf(buf, "</%s>", tag)
+	default:
+		fmt.Fprintf(buf, "<%s>", name)
+		xml.EscapeText(buf, []byte(delimitedField(val)))
+		fmt.Fprintf(buf, "</%s>", tag)
+	}
+}
+
+// isXMLName returns true if name can be used as an element name
+func isXMLName(name string) bool {
+	if name == "" || (len(name) >= 3 && (name[0]|0x20) == 'x' && (name[1]|0x20) == 'm' && (name[2]|0x20) == 'l') {
+		return false
+	}
+
+	for i, r := range name {
+		if unicode.IsLetter(r) || r == '_' {
+			continue
+		}
+		if i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.') {
+			continue
+		}
+		return false
+	}
+	return true
+}
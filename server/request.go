@@ -63,6 +63,8 @@ type Request interface {
 	ScanCap() int64
 	PipelineCap() int64
 	PipelineBatch() int
+	MemoryQuota() int64
+	TxId() string
 	Readonly() value.Tristate
 	Metrics() value.Tristate
 	Signature() value.Tristate
@@ -78,10 +80,11 @@ type Request interface {
 	CloseNotify() chan bool
 	Servicing()
 	Fail(err errors.Error)
-	Execute(server *Server, signature value.Value, notifyStop execution.Operator)
+	Execute(server *Server, signature value.Value, columns []string, notifyStop execution.Operator)
 	Failed(server *Server)
 	Expire(state State, timeout time.Duration)
 	SortCount() uint64
+	UsedMemory() uint64
 	State() State
 	Halted() bool
 	Credentials() auth.Credentials
@@ -169,6 +172,7 @@ type BaseRequest struct {
 	// of the struct to avoid alignment issues on x86 platforms
 	mutationCount atomic.AlignedUint64
 	sortCount     atomic.AlignedUint64
+	usedMemory    atomic.AlignedUint64
 	phaseStats    [execution.PHASES]phaseStat
 
 	sync.RWMutex
@@ -187,6 +191,8 @@ type BaseRequest struct {
 	scanCap         int64
 	pipelineCap     int64
 	pipelineBatch   int
+	memoryQuota     int64
+	txId            string
 	readonly        value.Tristate
 	signature       value.Tristate
 	metrics         value.Tristate
@@ -361,6 +367,27 @@ func (this *BaseRequest) PipelineBatch() int {
 	return this.pipelineBatch
 }
 
+func (this *BaseRequest) MemoryQuota() int64 {
+	return this.memoryQuota
+}
+
+func (this *BaseRequest) SetMemoryQuota(memoryQuota int64) {
+	this.memoryQuota = memoryQuota
+}
+
+func (this *BaseRequest) TxId() string {
+	return this.txId
+}
+
+func (this *BaseRequest) SetTxId(txId string) {
+	this.txId = txId
+
+	// statements in a transaction must see its changes, which indexes do not
+	if txId != "" {
+		this.SetFeatureControls(util.N1QL_INDEX_COVERING)
+	}
+}
+
 func (this *BaseRequest) Readonly() value.Tristate {
 	return this.readonly
 }
@@ -526,6 +553,20 @@ func (this *BaseRequest) SortCount() uint64 {
 	return atomic.LoadUint64(&this.sortCount)
 }
 
+// Only the highest memory usage is kept
+func (this *BaseRequest) SetUsedMemory(i uint64) {
+	for {
+		used := atomic.LoadUint64(&this.usedMemory)
+		if i <= used || atomic.CompareAndSwapUint64(&this.usedMemory, used, i) {
+			return
+		}
+	}
+}
+
+func (this *BaseRequest) UsedMemory() uint64 {
+	return atomic.LoadUint64(&this.usedMemory)
+}
+
 func (this *BaseRequest) AddPhaseCount(p execution.Phases, c uint64) {
 	atomic.AddUint64(&this.phaseStats[p].count, c)
 }
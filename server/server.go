@@ -34,6 +34,7 @@ import (
 	"github.com/couchbase/query/prepareds"
 	"github.com/couchbase/query/semantics"
 	queryMetakv "github.com/couchbase/query/server/settings/couchbase"
+	"github.com/couchbase/query/transactions"
 	"github.com/couchbase/query/util"
 	"github.com/couchbase/query/value"
 )
@@ -295,6 +296,30 @@ func (this *Server) SetPipelineCap(pipeline_cap int64) {
 	execution.SetPipelineCap(pipeline_cap)
 }
 
+func (this *Server) SpillThreshold() int64 {
+	return execution.GetSpillThreshold()
+}
+
+func (this *Server) SetSpillThreshold(spill_threshold int64) {
+	execution.SetSpillThreshold(spill_threshold)
+}
+
+func (this *Server) SpillDir() string {
+	return execution.GetSpillDir()
+}
+
+func (this *Server) SetSpillDir(spill_dir string) {
+	execution.SetSpillDir(spill_dir)
+}
+
+func (this *Server) MemoryQuota() int64 {
+	return execution.GetMemoryQuota()
+}
+
+func (this *Server) SetMemoryQuota(memory_quota int64) {
+	execution.SetMemoryQuota(memory_quota)
+}
+
 func (this *Server) PipelineBatch() int {
 	return execution.PipelineBatchSize()
 }
@@ -543,6 +568,17 @@ func (this *Server) serviceRequest(request Request) {
 		prepared, request.IndexApiVersion(), request.FeatureControls())
 
 	context.SetWhitelist(this.whitelist)
+	context.SetMemoryQuota(request.MemoryQuota())
+
+	if txid := request.TxId(); txid != "" {
+		tx, err := transactions.Get(txid, transactions.Owner(request.Credentials(), request.OriginalHttpRequest()))
+		if err != nil {
+			request.Fail(err)
+			request.Failed(this)
+			return
+		}
+		context.SetTransaction(tx)
+	}
 
 	build := time.Now()
 	operator, er := execution.Build(prepared, context)
@@ -581,7 +617,7 @@ func (this *Server) serviceRequest(request Request) {
 	go operator.RunOnce(context, nil)
 
 	request.SetExecTime(time.Now())
-	request.Execute(this, prepared.Signature(), operator)
+	request.Execute(this, prepared.Signature(), prepared.Columns(), operator)
 }
 
 func (this *Server) getPrepared(request Request, namespace string) (*plan.Prepared, errors.Error) {
@@ -638,6 +674,10 @@ func (this *Server) getPrepared(request Request, namespace string) (*plan.Prepar
 				if err != nil {
 					return nil, err
 				}
+				prepared, err = transactionPrepared(request, prepared)
+				if err != nil {
+					return nil, err
+				}
 				request.SetPrepared(prepared)
 
 				// when executing prepared statements, we set the type to that
@@ -665,6 +705,12 @@ func (this *Server) getPrepared(request Request, namespace string) (*plan.Prepar
 			prepared.SetText(request.Statement())
 		}
 	} else {
+		var err errors.Error
+
+		prepared, err = transactionPrepared(request, prepared)
+		if err != nil {
+			return nil, err
+		}
 
 		// ditto
 		request.SetType(prepared.Type())
@@ -678,6 +724,22 @@ func (this *Server) getPrepared(request Request, namespace string) (*plan.Prepar
 	return prepared, nil
 }
 
+// prepared statements in a transaction are planned without index covering,
+// since indexes do not see the changes of the transaction
+func transactionPrepared(request Request, prepared *plan.Prepared) (*plan.Prepared, errors.Error) {
+	if request.TxId() == "" || !util.IsFeatureEnabled(prepared.FeatureControls(), util.N1QL_INDEX_COVERING) {
+		return prepared, nil
+	}
+
+	var reprepTime time.Duration
+	prepared, err := prepareds.GetPreparedWithControls(prepared,
+		prepared.FeatureControls()|util.N1QL_INDEX_COVERING, &reprepTime)
+	if reprepTime > 0 {
+		request.Output().AddPhaseTime(execution.REPREPARE, reprepTime)
+	}
+	return prepared, err
+}
+
 func logExplain(prepared *plan.Prepared) {
 	var pl plan.Operator = prepared
 	explain, err := json.MarshalIndent(pl, "", "    ")
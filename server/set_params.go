@@ -65,6 +65,18 @@ var _SETTERS = map[string]Setter{
 		value, _ := o.(float64)
 		s.SetScanCap(int64(value))
 	},
+	paramSettings.SPILLTHRESHOLD: func(s *Server, o interface{}) {
+		value, _ := o.(float64)
+		s.SetSpillThreshold(int64(value))
+	},
+	paramSettings.SPILLDIR: func(s *Server, o interface{}) {
+		value, _ := o.(string)
+		s.SetSpillDir(value)
+	},
+	paramSettings.MEMORYQUOTA: func(s *Server, o interface{}) {
+		value, _ := o.(float64)
+		s.SetMemoryQuota(int64(value))
+	},
 	paramSettings.SERVICERS: func(s *Server, o interface{}) {
 		value, _ := o.(float64)
 		s.SetServicers(int(value))
@@ -25,6 +25,9 @@ const (
 	PIPELINEBATCH   = "pipeline-batch"
 	PIPELINECAP     = "pipeline-cap"
 	SCANCAP         = "scan-cap"
+	SPILLTHRESHOLD  = "spill-threshold"
+	SPILLDIR        = "spill-dir"
+	MEMORYQUOTA     = "memory-quota"
 	SERVICERS       = "servicers"
 	TIMEOUTSETTING  = "timeout"
 	CMPTHRESHOLD    = "completed-threshold"
@@ -50,6 +53,9 @@ var CHECKERS = map[string]Checker{
 	PIPELINEBATCH:   checkNumber,
 	PIPELINECAP:     checkNumber,
 	SCANCAP:         checkNumber,
+	SPILLTHRESHOLD:  checkNumber,
+	SPILLDIR:        checkString,
+	MEMORYQUOTA:     checkNumber,
 	SERVICERS:       checkNumber,
 	TIMEOUTSETTING:  checkNumber,
 	CMPTHRESHOLD:    checkNumber,
@@ -24,6 +24,7 @@ import (
 	"github.com/couchbase/query/datastore/system"
 	"github.com/couchbase/query/errors"
 	"github.com/couchbase/query/execution"
+	"github.com/couchbase/query/functions"
 	"github.com/couchbase/query/logging"
 	log_resolver "github.com/couchbase/query/logging/resolver"
 	"github.com/couchbase/query/prepareds"
@@ -65,7 +66,8 @@ func (this *MockQuery) Fail(err errors.Error) {
 	close(this.response.done)
 }
 
-func (this *MockQuery) Execute(srvr *server.Server, signature value.Value, stopNotify execution.Operator) {
+func (this *MockQuery) Execute(srvr *server.Server, signature value.Value, columns []string,
+	stopNotify execution.Operator) {
 	defer this.stopAndClose(server.COMPLETED)
 
 	this.NotifyStop(stopNotify)
@@ -167,6 +169,11 @@ func (this *MockServer) doStats(request *MockQuery) {
 }
 
 func Run(mockServer *MockServer, p bool, q string) ([]interface{}, []errors.Error, errors.Error) {
+	return RunTx(mockServer, p, q, "")
+}
+
+// RunTx runs a statement in the transaction of the given txid
+func RunTx(mockServer *MockServer, p bool, q, txid string) ([]interface{}, []errors.Error, errors.Error) {
 	var metrics value.Tristate
 	scanConfiguration := &scanConfigImpl{}
 
@@ -183,6 +190,7 @@ func Run(mockServer *MockServer, p bool, q string) ([]interface{}, []errors.Erro
 	}
 	server.NewBaseRequest(&query.BaseRequest, q, nil, nil, nil, "json", 0, 0, 0, 0,
 		value.FALSE, metrics, value.TRUE, pretty, scanConfiguration, "", nil, "", "")
+	query.SetTxId(txid)
 
 	defer mockServer.doStats(query)
 
@@ -197,9 +205,31 @@ func Run(mockServer *MockServer, p bool, q string) ([]interface{}, []errors.Erro
 
 	// wait till all the results are ready
 	<-mr.done
+
+	if mr.err == nil {
+		mr.err = query.executionError()
+	}
 	return mr.results, mr.warnings, mr.err
 }
 
+/*
+Errors raised while a statement executes, such as an expression that
+fails to evaluate or a request exceeding its memory quota, are not
+failures of the request: they are queued on the request's error
+channel, and the results produced so far are still returned. The
+server reports them in the errors of the response, so the harness
+reports the first of them too, rather than passing a statement whose
+execution failed on the results it produced before failing.
+*/
+func (this *MockQuery) executionError() errors.Error {
+	select {
+	case err := <-this.Errors():
+		return err
+	default:
+		return nil
+	}
+}
+
 func Start(site, pool string) *MockServer {
 
 	mockServer := &MockServer{}
@@ -210,6 +240,12 @@ func Start(site, pool string) *MockServer {
 	}
 	datastore.SetDatastore(ds)
 
+	err = functions.Init(ds)
+	if err != nil {
+		logging.Errorp(err.Error())
+		os.Exit(1)
+	}
+
 	sys, err := system.NewDatastore(ds)
 	if err != nil {
 		logging.Errorp(err.Error())
@@ -13,11 +13,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/execution"
+	"github.com/couchbase/query/util"
 
 	// For now we can't use go_json for unmarshalling
 	// as it returns a map in a different order than
@@ -206,6 +209,154 @@ func TestSimpleSelect(t *testing.T) {
 	}
 }
 
+func TestSpilledOrder(t *testing.T) {
+	qc := start()
+
+	_, _, err := Run(qc, true, "CREATE INDEX ix_spill ON orders(custId)")
+	if err != nil {
+		t.Fatalf("Unable to create index: %v", err)
+	}
+	defer Run(qc, true, "DROP INDEX orders.ix_spill")
+
+	testSpilling(t, qc, []string{
+		"SELECT META(o).id, o.custId FROM orders o ORDER BY o.custId DESC, META(o).id",
+		"SELECT custId, META().id FROM orders WHERE custId IS NOT NULL ORDER BY META().id DESC",
+		"SELECT ol.productId, ol.qty, UNNEST_POSITION(ol) AS pos FROM orders o UNNEST o.orderlines ol ORDER BY ol.productId, ol.qty, META(o).id",
+		"SELECT custId, COUNT(*) AS c FROM orders GROUP BY custId ORDER BY c DESC, custId",
+		"SELECT id, ROW_NUMBER() OVER (ORDER BY score DESC, id) AS rn FROM game ORDER BY rn",
+		"SELECT RAW name FROM contacts ORDER BY name OFFSET 1",
+		// enough runs to be merged in several passes
+		"SELECT c.name, r FROM contacts c UNNEST ARRAY_RANGE(0, 400) AS r ORDER BY r DESC, c.name",
+	})
+}
+
+func TestSpilledHashJoinAndGroup(t *testing.T) {
+	qc := start()
+
+	// hash joins are not available by default in the test server
+	control := util.GetN1qlFeatureControl()
+	defer util.SetN1qlFeatureControl(control)
+	util.SetN1qlFeatureControl(util.DEF_N1QL_FEAT_CTRL)
+
+	testSpilling(t, qc, []string{
+		"SELECT o1.id AS a, o2.id AS b FROM orders o1 JOIN orders o2 USE HASH(probe) ON o1.custId = o2.custId ORDER BY a, b",
+		"SELECT o1.id AS a, o2.id AS b FROM orders o1 LEFT JOIN orders o2 USE HASH(build) ON o1.custId = o2.custId AND o1.id != o2.id ORDER BY a, b",
+		"SELECT o1.id, ARRAY_SORT(ARRAY o.id FOR o IN o2 END) AS ids FROM orders o1 NEST orders o2 USE HASH(build) ON o1.custId = o2.custId ORDER BY o1.id",
+		"SELECT o1.id AS a, o2.id AS b FROM orders o1 JOIN orders o2 USE HASH(build) ON o1.id = o2.id RIGHT JOIN orders o3 ON o2.custId = o3.custId AND o3.id > \"1234\" ORDER BY a, b",
+		"SELECT o1.id AS a, c FROM orders o1 JOIN orders o2 USE HASH(probe) ON o1.id = o2.id FULL JOIN [\"aaa\", \"ccc\", \"bbb\"] AS c ON o2.custId = c ORDER BY a, c",
+		"SELECT custId, COUNT(*) AS c, COUNT(DISTINCT id) AS d, ARRAY_SORT(ARRAY_AGG(id)) AS ids FROM orders GROUP BY custId ORDER BY custId",
+		"SELECT g.id, COUNT(*) AS c FROM game g JOIN game g2 USE HASH(build) ON g.id = g2.id GROUP BY g.id ORDER BY g.id",
+		"SELECT o.custId, ol.productId, SUM(ol.qty) AS q, GROUPING(o.custId, ol.productId) AS g FROM orders o UNNEST o.orderlines ol GROUP BY CUBE(o.custId, ol.productId) ORDER BY g, o.custId, ol.productId",
+		"SELECT o.custId, ROUND(STDDEV_POP(ol.qty), 6) AS s, MEDIAN(ol.qty) AS m, PERCENTILE_DISC(0.5) WITHIN GROUP (ORDER BY ol.productId) AS p FROM orders o UNNEST o.orderlines ol GROUP BY o.custId ORDER BY o.custId",
+		"SELECT o.custId, APPROX_COUNT_DISTINCT(ol.productId) AS c, APPROX_PERCENTILE(0.5) WITHIN GROUP (ORDER BY ol.qty) AS q FROM orders o UNNEST o.orderlines ol GROUP BY o.custId ORDER BY o.custId",
+		"SELECT o.custId, OBJECT_AGG(ol.productId, ol.qty) AS q, STRING_AGG(o.id, ',' ORDER BY o.id) AS ids, ARRAY_AGG(ol.productId ORDER BY ol.productId DESC) AS p FROM orders o UNNEST o.orderlines ol GROUP BY o.custId ORDER BY o.custId",
+	})
+}
+
+// testSpilling runs each query with and without spilling to disk and
+// checks that the results are the same
+func testSpilling(t *testing.T, qc *MockServer, queries []string) {
+	dir, err := ioutil.TempDir("", "spill")
+	if err != nil {
+		t.Fatalf("Unable to create spill directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	threshold := execution.GetSpillThreshold()
+	spillDir := execution.GetSpillDir()
+	defer execution.SetSpillThreshold(threshold)
+	defer execution.SetSpillDir(spillDir)
+	execution.SetSpillDir(dir)
+
+	for _, q := range queries {
+		execution.SetSpillThreshold(0)
+		expected, _, err := Run(qc, true, q)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", q, err)
+		}
+
+		// spill every few items
+		execution.SetSpillThreshold(1024)
+		actual, _, err := Run(qc, true, q)
+		if err != nil {
+			t.Fatalf("Unexpected error spilling %s: %v", q, err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Spilled results for %s do not match: %v, expected %v", q, actual, expected)
+		}
+	}
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 0 {
+		t.Errorf("Spill files left behind: %v", len(files))
+	}
+}
+
+func TestMemoryQuota(t *testing.T) {
+	qc := start()
+
+	dir, err := ioutil.TempDir("", "spill")
+	if err != nil {
+		t.Fatalf("Unable to create spill directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	quota := execution.GetMemoryQuota()
+	threshold := execution.GetSpillThreshold()
+	spillDir := execution.GetSpillDir()
+	defer execution.SetMemoryQuota(quota)
+	defer execution.SetSpillThreshold(threshold)
+	defer execution.SetSpillDir(spillDir)
+	execution.SetSpillDir(dir)
+
+	q := "SELECT META(o).id, o.custId FROM orders o ORDER BY o.custId, META(o).id"
+	expected, _, err := Run(qc, true, q)
+	if err != nil {
+		t.Fatalf("Unexpected error for %s: %v", q, err)
+	}
+
+	// without spilling, the request cannot stay within its quota
+	execution.SetSpillThreshold(0)
+	execution.SetMemoryQuota(1024)
+	_, _, qerr := Run(qc, true, q)
+	if qerr == nil || qerr.Code() != 5390 {
+		t.Errorf("Expected memory quota error for %s, got %v", q, qerr)
+	}
+
+	// spilling keeps it within its quota
+	execution.SetSpillThreshold(threshold)
+	actual, _, err := Run(qc, true, q)
+	if err != nil {
+		t.Fatalf("Unexpected error spilling %s: %v", q, err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Results for %s do not match: %v, expected %v", q, actual, expected)
+	}
+
+	// requests without a quota report the memory they used
+	execution.SetMemoryQuota(0)
+	q = "SELECT DISTINCT custId FROM orders"
+	_, _, err = Run(qc, true, q)
+	if err != nil {
+		t.Fatalf("Unexpected error for %s: %v", q, err)
+	}
+
+	r, _, err := Run(qc, true, "SELECT usedMemory FROM system:completed_requests WHERE statement = \""+q+"\"")
+	if err != nil {
+		t.Fatalf("Unexpected error querying completed requests: %v", err)
+	}
+	if len(r) == 0 {
+		t.Fatalf("Completed request for %s not found", q)
+	}
+	for _, entry := range r {
+		used := entry.(map[string]interface{})["usedMemory"]
+		if n, ok := used.(float64); !ok || n <= 0 {
+			t.Errorf("Unexpected used memory for %s: %v", q, used)
+		}
+	}
+}
+
 func TestAllCaseFiles(t *testing.T) {
 	qc := start()
 	matches, err := filepath.Glob("json/default/cases/case_*.json")
@@ -279,10 +430,22 @@ func testCaseFile(t *testing.T, fname string, qc *MockServer) {
 			pretty = p.(bool)
 		}
 
+		// preStatements and statements of a transaction case run in a
+		// transaction, which is rolled back after them
+		txid := ""
+		if tx, ok := c["transaction"]; ok && tx.(bool) {
+			results, _, err := Run(qc, pretty, "BEGIN WORK")
+			if err != nil || len(results) != 1 {
+				t.Errorf("BEGIN WORK resulted in error: %v, for case file: %v, index: %v", err, fname, i)
+				return
+			}
+			txid = results[0].(map[string]interface{})["txid"].(string)
+		}
+
 		v, ok := c["preStatements"]
 		if ok {
 			preStatements := v.(string)
-			_, _, err := Run(qc, pretty, preStatements)
+			_, _, err := RunTx(qc, pretty, preStatements, txid)
 			if err != nil {
 				t.Errorf("preStatements resulted in error: %v, for case file: %v, index: %v", err, fname, i)
 			}
@@ -295,7 +458,14 @@ func testCaseFile(t *testing.T, fname string, qc *MockServer) {
 		}
 		statements := v.(string)
 		t.Logf("  %d: %v\n", i, statements)
-		resultsActual, _, errActual := Run(qc, pretty, statements)
+		resultsActual, _, errActual := RunTx(qc, pretty, statements, txid)
+
+		if txid != "" {
+			_, _, err := RunTx(qc, pretty, "ROLLBACK", txid)
+			if err != nil {
+				t.Errorf("ROLLBACK resulted in error: %v, for case file: %v, index: %v", err, fname, i)
+			}
+		}
 
 		v, ok = c["postStatements"]
 		if ok {
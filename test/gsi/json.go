@@ -97,7 +97,8 @@ func (this *MockQuery) Error(err errors.Error) {
 	}
 }
 
-func (this *MockQuery) Execute(srvr *server.Server, signature value.Value, stopNotify execution.Operator) {
+func (this *MockQuery) Execute(srvr *server.Server, signature value.Value, columns []string,
+	stopNotify execution.Operator) {
 	defer this.stopAndClose(server.COMPLETED)
 
 	this.NotifyStop(stopNotify)
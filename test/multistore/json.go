@@ -84,7 +84,8 @@ func (this *MockQuery) Fail(err errors.Error) {
 	close(this.response.done)
 }
 
-func (this *MockQuery) Execute(srvr *server.Server, signature value.Value, stopNotify execution.Operator) {
+func (this *MockQuery) Execute(srvr *server.Server, signature value.Value, columns []string,
+	stopNotify execution.Operator) {
 	defer this.stopAndClose(server.COMPLETED)
 
 	this.NotifyStop(stopNotify)
@@ -0,0 +1,237 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package transactions
+
+import (
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/value"
+)
+
+/*
+keyspace reads and mutates the documents of a keyspace through a
+transaction. Mutations are buffered in the transaction, and fetches,
+counts and index scans see them over the committed documents.
+*/
+type keyspace struct {
+	datastore.Keyspace
+	tx *Transaction
+}
+
+/*
+Returns the keyspace as seen from the transaction.
+*/
+func (this *Transaction) Keyspace(ks datastore.Keyspace) datastore.Keyspace {
+	if tks, ok := ks.(*keyspace); ok {
+		ks = tks.Keyspace
+	}
+
+	return &keyspace{
+		Keyspace: ks,
+		tx:       this,
+	}
+}
+
+func (this *keyspace) Fetch(keys []string, keysMap map[string]value.AnnotatedValue,
+	context datastore.QueryContext, subPaths []string) []errors.Error {
+
+	this.tx.Lock()
+	defer this.tx.Unlock()
+
+	committed := make([]string, 0, len(keys))
+	for _, k := range keys {
+		found, val := this.tx.lookup(this.Keyspace, k)
+		if !found {
+			committed = append(committed, k)
+		} else if val != nil {
+			item := value.NewAnnotatedValue(val.CopyForUpdate())
+			item.SetAttachment("meta", map[string]interface{}{
+				"id": k,
+			})
+			item.SetId(k)
+			keysMap[k] = item
+		}
+	}
+
+	if len(committed) == 0 {
+		return nil
+	}
+
+	if err := this.tx.read(this.Keyspace, committed); err != nil {
+		return []errors.Error{err}
+	}
+
+	return this.Keyspace.Fetch(committed, keysMap, context, subPaths)
+}
+
+/*
+Count the documents of the keyspace as seen from the transaction: the
+committed count, less the documents the transaction deleted, plus
+those it created.
+*/
+func (this *keyspace) Count(context datastore.QueryContext) (int64, errors.Error) {
+	count, err := this.Keyspace.Count(context)
+	if err != nil {
+		return 0, err
+	}
+
+	this.tx.Lock()
+	defer this.tx.Unlock()
+
+	keys := this.tx.mutatedKeys(this.Keyspace)
+	if len(keys) == 0 {
+		return count, nil
+	}
+
+	committed := make(map[string]value.AnnotatedValue, len(keys))
+	errs := this.Keyspace.Fetch(keys, committed, context, nil)
+	if len(errs) > 0 {
+		return 0, errs[0]
+	}
+
+	for _, k := range keys {
+		_, val := this.tx.lookup(this.Keyspace, k)
+		_, existed := committed[k]
+		switch {
+		case existed && val == nil:
+			count--
+		case !existed && val != nil:
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+/*
+Find which of the keys name existing documents, in the transaction.
+Must be called with the transaction locked.
+*/
+func (this *keyspace) exist(keys []string) (map[string]bool, errors.Error) {
+	rv := make(map[string]bool, len(keys))
+	committed := make([]string, 0, len(keys))
+	for _, k := range keys {
+		found, val := this.tx.lookup(this.Keyspace, k)
+		if found {
+			rv[k] = val != nil
+		} else {
+			committed = append(committed, k)
+		}
+	}
+
+	if len(committed) > 0 {
+		if err := this.tx.read(this.Keyspace, committed); err != nil {
+			return nil, err
+		}
+
+		fetched := make(map[string]value.AnnotatedValue, len(committed))
+		errs := this.Keyspace.Fetch(committed, fetched, datastore.NULL_QUERY_CONTEXT, nil)
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+
+		for _, k := range committed {
+			_, rv[k] = fetched[k]
+		}
+	}
+
+	return rv, nil
+}
+
+func (this *keyspace) pairKeys(pairs []value.Pair) []string {
+	keys := make([]string, len(pairs))
+	for i, p := range pairs {
+		keys[i] = p.Name
+	}
+	return keys
+}
+
+func (this *keyspace) Insert(inserts []value.Pair) ([]value.Pair, errors.Error) {
+	this.tx.Lock()
+	defer this.tx.Unlock()
+
+	exist, err := this.exist(this.pairKeys(inserts))
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make([]value.Pair, 0, len(inserts))
+	for _, p := range inserts {
+		if exist[p.Name] {
+			err = errors.NewTransactionKeyExistsError(p.Name, this.Name())
+			continue
+		}
+
+		exist[p.Name] = true
+		this.tx.add(datastore.MUTATE_INSERT, this.Keyspace, p.Name, p.Value)
+		rv = append(rv, p)
+	}
+
+	return rv, err
+}
+
+func (this *keyspace) Update(updates []value.Pair) ([]value.Pair, errors.Error) {
+	this.tx.Lock()
+	defer this.tx.Unlock()
+
+	exist, err := this.exist(this.pairKeys(updates))
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make([]value.Pair, 0, len(updates))
+	for _, p := range updates {
+		if !exist[p.Name] {
+			err = errors.NewTransactionKeyNotFoundError(p.Name, this.Name())
+			continue
+		}
+
+		this.tx.add(datastore.MUTATE_UPDATE, this.Keyspace, p.Name, p.Value)
+		rv = append(rv, p)
+	}
+
+	return rv, err
+}
+
+func (this *keyspace) Upsert(upserts []value.Pair) ([]value.Pair, errors.Error) {
+	this.tx.Lock()
+	defer this.tx.Unlock()
+
+	if err := this.tx.read(this.Keyspace, this.pairKeys(upserts)); err != nil {
+		return nil, err
+	}
+
+	for _, p := range upserts {
+		this.tx.add(datastore.MUTATE_UPSERT, this.Keyspace, p.Name, p.Value)
+	}
+
+	return upserts, nil
+}
+
+func (this *keyspace) Delete(deletes []string, context datastore.QueryContext) ([]string, errors.Error) {
+	this.tx.Lock()
+	defer this.tx.Unlock()
+
+	exist, err := this.exist(deletes)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make([]string, 0, len(deletes))
+	for _, k := range deletes {
+		if exist[k] {
+			exist[k] = false
+			this.tx.add(datastore.MUTATE_DELETE, this.Keyspace, k, nil)
+			rv = append(rv, k)
+		}
+	}
+
+	return rv, nil
+}
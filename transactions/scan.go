@@ -0,0 +1,217 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package transactions
+
+import (
+	"math"
+	"sort"
+
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/value"
+)
+
+/*
+IndexScan is a scan of an index of a keyspace, to be run through a
+transaction. Matches tells whether an index key is within the spans
+of the scan, and is nil if all keys are; the keys of primary indexes
+are the document keys. Scan runs the scan of the committed entries on
+a connection, with the given offset, limit and projection.
+*/
+type IndexScan struct {
+	Keyspace   datastore.Keyspace
+	Index      datastore.Index
+	Matches    func(key value.Values) bool
+	Reverse    bool
+	Distinct   bool
+	Offset     int64
+	Limit      int64
+	Projection *datastore.IndexProjection
+	Scan       func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection)
+}
+
+/*
+Run an index scan through the transaction. The committed entries of
+the documents mutated by the transaction are dropped, and the keys of
+their values in the transaction are matched against the spans in
+their place. The entries are merged in index order, and the offset,
+limit and projection of the scan apply to the merged entries. The
+committed entries are scanned on the committed connection.
+*/
+func (this *Transaction) ScanIndex(scan *IndexScan, committed, conn *datastore.IndexConnection) {
+	mutated, entries := this.indexEntries(scan)
+	if len(mutated) == 0 {
+		scan.Scan(scan.Offset, scan.Limit, scan.Projection, conn)
+		return
+	}
+
+	defer close(conn.EntryChannel())
+	defer notifyStop(committed)
+
+	go scan.Scan(0, math.MaxInt64, nil, committed)
+
+	offset, sent := scan.Offset, int64(0)
+	send := func(entry *datastore.IndexEntry) bool {
+		if offset > 0 {
+			offset--
+			return true
+		}
+		if scan.Limit > 0 && sent >= scan.Limit {
+			return false
+		}
+		sent++
+		return sendEntry(conn, project(entry, scan.Projection))
+	}
+
+	rangeKey := datastore.IndexRangeKeys(scan.Index)
+	next := 0
+	for {
+		var entry *datastore.IndexEntry
+		ok := true
+		select {
+		case entry, ok = <-committed.EntryChannel():
+		case <-conn.StopChannel():
+			return
+		}
+
+		if !ok {
+			break
+		}
+
+		if mutated[entry.PrimaryKey] {
+			continue
+		}
+
+		for ; next < len(entries) && compareEntries(entries[next], entry, rangeKey, scan) < 0; next++ {
+			if !send(entries[next]) {
+				return
+			}
+		}
+
+		if !send(entry) {
+			return
+		}
+	}
+
+	for ; next < len(entries); next++ {
+		if !send(entries[next]) {
+			return
+		}
+	}
+}
+
+/*
+Returns the keys of the documents of the keyspace of the scan that
+the transaction mutated, and the entries of those it did not delete
+that are within the spans, in index order.
+*/
+func (this *Transaction) indexEntries(scan *IndexScan) (map[string]bool, []*datastore.IndexEntry) {
+	this.Lock()
+	defer this.Unlock()
+
+	keys := this.mutatedKeys(scan.Keyspace)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	rangeKey := datastore.IndexRangeKeys(scan.Index)
+	mutated := make(map[string]bool, len(keys))
+	var entries []*datastore.IndexEntry
+	for _, k := range keys {
+		mutated[k] = true
+		_, val := this.lookup(scan.Keyspace, k)
+		if val == nil {
+			continue
+		}
+
+		if scan.Index.IsPrimary() {
+			if scan.Matches == nil || scan.Matches(value.Values{value.NewValue(k)}) {
+				entries = append(entries, &datastore.IndexEntry{PrimaryKey: k})
+			}
+			continue
+		}
+
+		doc := value.NewAnnotatedValue(val)
+		doc.SetAttachment("meta", map[string]interface{}{"id": k})
+		doc.SetId(k)
+
+		// documents whose keys cannot be evaluated are not indexed
+		indexKeys, err := datastore.EvaluateIndexKeys(rangeKey, scan.Index.Condition(), doc)
+		if err != nil {
+			continue
+		}
+
+		for _, key := range indexKeys {
+			if scan.Matches == nil || scan.Matches(key) {
+				entries = append(entries, &datastore.IndexEntry{EntryKey: key, PrimaryKey: k})
+				if scan.Distinct {
+					break
+				}
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return compareEntries(entries[i], entries[j], rangeKey, scan) < 0
+	})
+
+	return mutated, entries
+}
+
+// compareEntries orders entries in the order of the scan
+func compareEntries(a, b *datastore.IndexEntry, rangeKey datastore.IndexKeys, scan *IndexScan) int {
+	c := 0
+	if !scan.Index.IsPrimary() {
+		c = datastore.CompareIndexKeys(a.EntryKey, b.EntryKey, rangeKey)
+	}
+
+	if c == 0 {
+		switch {
+		case a.PrimaryKey < b.PrimaryKey:
+			c = -1
+		case a.PrimaryKey > b.PrimaryKey:
+			c = 1
+		}
+	}
+
+	if scan.Reverse {
+		return -c
+	}
+	return c
+}
+
+func project(entry *datastore.IndexEntry, projection *datastore.IndexProjection) *datastore.IndexEntry {
+	if projection == nil || len(entry.EntryKey) == 0 {
+		return entry
+	}
+
+	key := make(value.Values, 0, len(projection.EntryKeys))
+	for _, pos := range projection.EntryKeys {
+		if pos >= 0 && pos < len(entry.EntryKey) {
+			key = append(key, entry.EntryKey[pos])
+		}
+	}
+	return &datastore.IndexEntry{EntryKey: key, PrimaryKey: entry.PrimaryKey}
+}
+
+func sendEntry(conn *datastore.IndexConnection, entry *datastore.IndexEntry) bool {
+	select {
+	case conn.EntryChannel() <- entry:
+		return true
+	case <-conn.StopChannel():
+		return false
+	}
+}
+
+func notifyStop(conn *datastore.IndexConnection) {
+	select {
+	case conn.StopChannel() <- false:
+	default:
+	}
+}
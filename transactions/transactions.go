@@ -0,0 +1,386 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+/*
+
+Package transactions implements multi-statement transactions.
+
+A transaction is started by BEGIN WORK, and is identified by a txid,
+which later requests pass to run in the transaction. The mutations of
+the DML statements run in a transaction are buffered, and are seen by
+the reads of later statements in the same transaction through Fetch.
+At COMMIT, the mutations are applied atomically by the datastore, which
+must implement datastore.TransactionDatastore. The version of each
+document is recorded when the transaction first reads it, and the
+commit fails with a conflict if any of them has changed since.
+
+Index scans and counts run through the transaction too: the committed
+index entries of the documents the transaction mutated are replaced by
+entries for their values in the transaction. Statements in a
+transaction are planned without index covering, so that the documents
+they return are always fetched through the transaction.
+
+*/
+package transactions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/errors"
+	"github.com/couchbase/query/util"
+	"github.com/couchbase/query/value"
+)
+
+// Transactions that are idle for longer than this are rolled back
+const TRANSACTION_TIMEOUT = 15 * time.Minute
+
+type docKey struct {
+	namespace string
+	keyspace  string
+	key       string
+}
+
+type Transaction struct {
+	sync.Mutex
+	id         string
+	owner      string
+	store      datastore.TransactionDatastore
+	log        []*datastore.Mutation
+	latest     map[docKey]int             // position in the log of the last mutation of each document
+	reads      map[docKey]*datastore.Read // version of each document when first read
+	savepoints map[string]int             // length of the log at each savepoint
+	lastUse    time.Time
+}
+
+var transactions = struct {
+	sync.Mutex
+	active map[string]*Transaction
+}{active: make(map[string]*Transaction)}
+
+/*
+The identity of the user of a request, as a digest of the credentials
+it passes, so that a transaction can only be used by the user that
+started it.
+*/
+func Owner(credentials auth.Credentials, req *http.Request) string {
+	pairs := make([]string, 0, len(credentials)+1)
+	for user, password := range credentials {
+		pairs = append(pairs, user+":"+password)
+	}
+
+	if req != nil {
+		if user, password, ok := req.BasicAuth(); ok {
+			pairs = append(pairs, user+":"+password)
+		}
+	}
+
+	sort.Strings(pairs)
+	digest := sha256.New()
+	for _, pair := range pairs {
+		digest.Write([]byte(pair))
+		digest.Write([]byte{0})
+	}
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+/*
+Start a transaction on a datastore, which must support transactions.
+The transaction belongs to the given owner.
+*/
+func Start(store datastore.Datastore, owner string) (*Transaction, errors.Error) {
+	txstore, ok := store.(datastore.TransactionDatastore)
+	if !ok {
+		return nil, errors.NewTransactionNotSupportedError(store.URL())
+	}
+
+	id, err := util.UUID()
+	if err != nil {
+		return nil, errors.NewError(err, "")
+	}
+
+	rv := &Transaction{
+		id:         id,
+		owner:      owner,
+		store:      txstore,
+		latest:     make(map[docKey]int),
+		reads:      make(map[docKey]*datastore.Read),
+		savepoints: make(map[string]int),
+		lastUse:    time.Now(),
+	}
+
+	transactions.Lock()
+	defer transactions.Unlock()
+	expire()
+	transactions.active[id] = rv
+	return rv, nil
+}
+
+/*
+Get the active transaction with the given txid, which must belong to
+the given owner.
+*/
+func Get(txid string, owner string) (*Transaction, errors.Error) {
+	transactions.Lock()
+	defer transactions.Unlock()
+	expire()
+
+	rv, ok := transactions.active[txid]
+	if !ok {
+		return nil, errors.NewNoSuchTransactionError(txid)
+	}
+
+	if rv.owner != owner {
+		return nil, errors.NewTransactionOwnerError(txid)
+	}
+
+	rv.lastUse = time.Now()
+	return rv, nil
+}
+
+/*
+Number of active transactions.
+*/
+func Count() int {
+	transactions.Lock()
+	defer transactions.Unlock()
+	return len(transactions.active)
+}
+
+// Drop idle transactions. Must be called with the registry locked.
+func expire() {
+	now := time.Now()
+	for id, tx := range transactions.active {
+		if now.Sub(tx.lastUse) > TRANSACTION_TIMEOUT {
+			delete(transactions.active, id)
+		}
+	}
+}
+
+func end(txid string) {
+	transactions.Lock()
+	defer transactions.Unlock()
+	delete(transactions.active, txid)
+}
+
+func (this *Transaction) Id() string {
+	return this.id
+}
+
+/*
+Apply the mutations of the transaction, and end it. The transaction
+ends whether the commit succeeds or not.
+*/
+func (this *Transaction) Commit() errors.Error {
+	end(this.id)
+
+	this.Lock()
+	defer this.Unlock()
+
+	mutations := this.mutations()
+	reads := make([]*datastore.Read, 0, len(this.reads))
+	for _, read := range this.reads {
+		reads = append(reads, read)
+	}
+	this.reset(0)
+	this.reads = make(map[docKey]*datastore.Read)
+	if len(mutations) == 0 {
+		return nil
+	}
+
+	err := this.store.CommitTransaction(this.id, mutations, reads)
+	if err != nil {
+		return errors.NewTransactionCommitError(err, this.id)
+	}
+	return nil
+}
+
+/*
+Discard the mutations of the transaction, and end it.
+*/
+func (this *Transaction) Rollback() errors.Error {
+	end(this.id)
+
+	this.Lock()
+	defer this.Unlock()
+	this.reset(0)
+	this.reads = make(map[docKey]*datastore.Read)
+	return nil
+}
+
+/*
+Mark the current point of the transaction, to which it can later be
+rolled back. An existing savepoint with the same name is moved.
+*/
+func (this *Transaction) Savepoint(name string) errors.Error {
+	this.Lock()
+	defer this.Unlock()
+	this.savepoints[name] = len(this.log)
+	return nil
+}
+
+/*
+Discard the mutations made after a savepoint. The savepoint itself is
+kept, and those set after it are dropped.
+*/
+func (this *Transaction) RollbackToSavepoint(name string) errors.Error {
+	this.Lock()
+	defer this.Unlock()
+
+	pos, ok := this.savepoints[name]
+	if !ok {
+		return errors.NewNoSuchSavepointError(name)
+	}
+
+	this.reset(pos)
+	return nil
+}
+
+// Truncate the log, and drop the savepoints past its end
+func (this *Transaction) reset(pos int) {
+	for i := pos; i < len(this.log); i++ {
+		this.log[i] = nil
+	}
+	this.log = this.log[:pos]
+
+	this.latest = make(map[docKey]int, len(this.log))
+	for i, m := range this.log {
+		this.latest[newDocKey(m.Keyspace, m.Key)] = i
+	}
+
+	for name, p := range this.savepoints {
+		if p > pos {
+			delete(this.savepoints, name)
+		}
+	}
+}
+
+func newDocKey(keyspace datastore.Keyspace, key string) docKey {
+	return docKey{keyspace.NamespaceId(), keyspace.Name(), key}
+}
+
+/*
+Record the versions of the documents that the transaction has not read
+before. Versions are taken before the documents are fetched, so that a
+change in between fails the commit rather than being lost. Must be
+called with the transaction locked.
+*/
+func (this *Transaction) read(keyspace datastore.Keyspace, keys []string) errors.Error {
+	unread := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if _, ok := this.reads[newDocKey(keyspace, k)]; !ok {
+			unread = append(unread, k)
+		}
+	}
+
+	if len(unread) == 0 {
+		return nil
+	}
+
+	versions, err := this.store.DocumentVersions(keyspace, unread)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range unread {
+		this.reads[newDocKey(keyspace, k)] = &datastore.Read{Keyspace: keyspace, Key: k, Version: versions[k]}
+	}
+	return nil
+}
+
+// Record a mutation. Must be called with the transaction locked.
+func (this *Transaction) add(op datastore.MutateOp, keyspace datastore.Keyspace, key string, val value.Value) {
+	this.latest[newDocKey(keyspace, key)] = len(this.log)
+	this.log = append(this.log, &datastore.Mutation{
+		Op:       op,
+		Keyspace: keyspace,
+		Key:      key,
+		Value:    val,
+	})
+}
+
+/*
+Look up the buffered state of a document. It returns whether the
+transaction mutated the document, and its value, which is nil if the
+document was deleted. Must be called with the transaction locked.
+*/
+func (this *Transaction) lookup(keyspace datastore.Keyspace, key string) (bool, value.Value) {
+	pos, ok := this.latest[newDocKey(keyspace, key)]
+	if !ok {
+		return false, nil
+	}
+
+	m := this.log[pos]
+	if m.Op == datastore.MUTATE_DELETE {
+		return true, nil
+	}
+	return true, m.Value
+}
+
+/*
+The keys of the documents of a keyspace that the transaction mutated,
+in key order. Must be called with the transaction locked.
+*/
+func (this *Transaction) mutatedKeys(keyspace datastore.Keyspace) []string {
+	var rv []string
+	for dk, _ := range this.latest {
+		if dk.namespace == keyspace.NamespaceId() && dk.keyspace == keyspace.Name() {
+			rv = append(rv, dk.key)
+		}
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+/*
+Combine the mutations of each document into one, in the order the
+documents were first mutated. The first mutation tells what must
+hold of the document at COMMIT, and the last one its final value.
+Must be called with the transaction locked.
+*/
+func (this *Transaction) mutations() []*datastore.Mutation {
+	first := make(map[docKey]int, len(this.latest))
+	rv := make([]*datastore.Mutation, 0, len(this.latest))
+
+	for i, m := range this.log {
+		dk := newDocKey(m.Keyspace, m.Key)
+		if _, ok := first[dk]; ok {
+			continue
+		}
+		first[dk] = i
+
+		last := this.log[this.latest[dk]]
+		op := m.Op
+		switch {
+		case op == datastore.MUTATE_INSERT && last.Op == datastore.MUTATE_DELETE:
+			continue
+		case op == datastore.MUTATE_INSERT:
+		case last.Op == datastore.MUTATE_DELETE:
+			op = datastore.MUTATE_DELETE
+		case op == datastore.MUTATE_UPSERT:
+		default:
+			op = datastore.MUTATE_UPDATE
+		}
+
+		rv = append(rv, &datastore.Mutation{
+			Op:       op,
+			Keyspace: m.Keyspace,
+			Key:      m.Key,
+			Value:    last.Value,
+		})
+	}
+
+	return rv
+}
@@ -0,0 +1,262 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package transactions
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/couchbase/query/auth"
+	"github.com/couchbase/query/datastore"
+	"github.com/couchbase/query/datastore/file"
+	"github.com/couchbase/query/value"
+)
+
+func TestTransaction(t *testing.T) {
+	dir, store, keyspace := openStore(t)
+	defer os.RemoveAll(dir)
+
+	tx, err := Start(store, "owner")
+	if err != nil {
+		t.Fatalf("failed to start transaction: %v", err)
+	}
+
+	if got, _ := Get(tx.Id(), "owner"); got != tx {
+		t.Errorf("expected transaction %v to be active", tx.Id())
+	}
+
+	if _, err := Get(tx.Id(), "other"); err == nil {
+		t.Errorf("expected transaction %v to be denied to another user", tx.Id())
+	}
+
+	txks := tx.Keyspace(keyspace)
+	_, err = txks.Insert([]value.Pair{newPair("o3", 30)})
+	if err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+	_, err = txks.Insert([]value.Pair{newPair("o1", 15)})
+	if err == nil {
+		t.Errorf("expected insert of existing key to fail")
+	}
+	_, err = txks.Update([]value.Pair{newPair("o1", 11)})
+	if err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	_, err = txks.Delete([]string{"o2"}, datastore.NULL_QUERY_CONTEXT)
+	if err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+
+	// the transaction sees its own mutations, others do not
+	verifyFetch(t, txks, map[string]interface{}{"o1": 11.0, "o3": 30.0})
+	verifyFetch(t, keyspace, map[string]interface{}{"o1": 10.0, "o2": 20.0})
+
+	_, err = txks.Update([]value.Pair{newPair("o2", 21)})
+	if err == nil {
+		t.Errorf("expected update of deleted key to fail")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	verifyFetch(t, keyspace, map[string]interface{}{"o1": 11.0, "o3": 30.0})
+	if _, err = Get(tx.Id(), "owner"); err == nil {
+		t.Errorf("expected transaction %v to have ended", tx.Id())
+	}
+}
+
+func TestSavepoint(t *testing.T) {
+	dir, store, keyspace := openStore(t)
+	defer os.RemoveAll(dir)
+
+	tx, _ := Start(store, "owner")
+	txks := tx.Keyspace(keyspace)
+	txks.Update([]value.Pair{newPair("o1", 11)})
+	tx.Savepoint("s1")
+	txks.Insert([]value.Pair{newPair("o3", 30)})
+	txks.Delete([]string{"o1"}, datastore.NULL_QUERY_CONTEXT)
+
+	err := tx.RollbackToSavepoint("s1")
+	if err != nil {
+		t.Fatalf("failed to roll back to savepoint: %v", err)
+	}
+	verifyFetch(t, txks, map[string]interface{}{"o1": 11.0, "o2": 20.0})
+
+	if err = tx.RollbackToSavepoint("s2"); err == nil {
+		t.Errorf("expected rollback to unknown savepoint to fail")
+	}
+
+	tx.Commit()
+	verifyFetch(t, keyspace, map[string]interface{}{"o1": 11.0, "o2": 20.0})
+
+	tx, _ = Start(store, "owner")
+	tx.Keyspace(keyspace).Upsert([]value.Pair{newPair("o4", 40)})
+	tx.Rollback()
+	verifyFetch(t, keyspace, map[string]interface{}{"o1": 11.0, "o2": 20.0})
+}
+
+func TestConflict(t *testing.T) {
+	dir, store, keyspace := openStore(t)
+	defer os.RemoveAll(dir)
+
+	tx1, _ := Start(store, "owner")
+	tx2, _ := Start(store, "owner")
+	tx1.Keyspace(keyspace).Insert([]value.Pair{newPair("o3", 30)})
+	tx1.Keyspace(keyspace).Update([]value.Pair{newPair("o1", 11)})
+	tx2.Keyspace(keyspace).Insert([]value.Pair{newPair("o3", 31)})
+
+	if err := tx2.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	// the insert of o3 now conflicts, and the update of o1 must not apply
+	if err := tx1.Commit(); err == nil {
+		t.Errorf("expected conflicting commit to fail")
+	}
+	verifyFetch(t, keyspace, map[string]interface{}{"o1": 10.0, "o2": 20.0, "o3": 31.0})
+}
+
+func TestLostUpdate(t *testing.T) {
+	dir, store, keyspace := openStore(t)
+	defer os.RemoveAll(dir)
+
+	tx, _ := Start(store, "owner")
+	txks := tx.Keyspace(keyspace)
+	txks.Fetch([]string{"o1"}, make(map[string]value.AnnotatedValue), datastore.NULL_QUERY_CONTEXT, nil)
+
+	// a write outside the transaction after the read
+	if _, err := keyspace.Update([]value.Pair{newPair("o1", 12)}); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	txks.Update([]value.Pair{newPair("o1", 11)})
+	if err := tx.Commit(); err == nil {
+		t.Errorf("expected commit of a stale read to fail")
+	}
+	verifyFetch(t, keyspace, map[string]interface{}{"o1": 12.0, "o2": 20.0})
+
+	// writes to documents the transaction did not read do not conflict
+	tx, _ = Start(store, "owner")
+	txks = tx.Keyspace(keyspace)
+	txks.Update([]value.Pair{newPair("o1", 13)})
+	keyspace.Update([]value.Pair{newPair("o2", 21)})
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	verifyFetch(t, keyspace, map[string]interface{}{"o1": 13.0, "o2": 21.0})
+}
+
+func TestOwner(t *testing.T) {
+	alice := Owner(auth.Credentials{"alice": "secret"}, nil)
+	if alice != Owner(auth.Credentials{"alice": "secret"}, nil) {
+		t.Errorf("expected the same credentials to have the same owner")
+	}
+	if alice == Owner(auth.Credentials{"bob": "secret"}, nil) {
+		t.Errorf("expected different users to have different owners")
+	}
+	if alice == Owner(auth.Credentials{"alice": "guess"}, nil) {
+		t.Errorf("expected different passwords to have different owners")
+	}
+}
+
+func TestScanAndCount(t *testing.T) {
+	dir, store, keyspace := openStore(t)
+	defer os.RemoveAll(dir)
+
+	tx, _ := Start(store, "owner")
+	txks := tx.Keyspace(keyspace)
+	txks.Insert([]value.Pair{newPair("o3", 30)})
+	txks.Delete([]string{"o1"}, datastore.NULL_QUERY_CONTEXT)
+
+	if count, _ := txks.Count(datastore.NULL_QUERY_CONTEXT); count != 2 {
+		t.Errorf("expected 2 documents in transaction, got %v", count)
+	}
+
+	indexer, _ := keyspace.Indexer(datastore.DEFAULT)
+	primary, _ := indexer.IndexByName("#primary")
+	index := primary.(datastore.PrimaryIndex)
+
+	conn := datastore.NewIndexConnection(datastore.NULL_CONTEXT)
+	go tx.ScanIndex(&IndexScan{
+		Keyspace: keyspace,
+		Index:    index,
+		Scan: func(offset, limit int64, projection *datastore.IndexProjection, conn *datastore.IndexConnection) {
+			index.ScanEntries("", limit, datastore.UNBOUNDED, nil, conn)
+		},
+	}, datastore.NewIndexConnection(datastore.NULL_CONTEXT), conn)
+
+	var keys []string
+	for entry := range conn.EntryChannel() {
+		keys = append(keys, entry.PrimaryKey)
+	}
+	if len(keys) != 2 || keys[0] != "o2" || keys[1] != "o3" {
+		t.Errorf("expected o2 and o3 in transaction, got %v", keys)
+	}
+
+	tx.Rollback()
+	if count, _ := keyspace.Count(datastore.NULL_QUERY_CONTEXT); count != 2 {
+		t.Errorf("expected 2 committed documents, got %v", count)
+	}
+}
+
+func openStore(t *testing.T) (string, datastore.Datastore, datastore.Keyspace) {
+	dir, er := ioutil.TempDir("", "transactions")
+	if er != nil {
+		t.Fatalf("failed to create directory: %v", er)
+	}
+
+	path := filepath.Join(dir, "default", "orders")
+	os.MkdirAll(path, 0755)
+	ioutil.WriteFile(filepath.Join(path, "o1.json"), []byte(`{"total": 10}`), 0666)
+	ioutil.WriteFile(filepath.Join(path, "o2.json"), []byte(`{"total": 20}`), 0666)
+
+	store, err := file.NewDatastore(dir)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	namespace, _ := store.NamespaceByName("default")
+	keyspace, err := namespace.KeyspaceByName("orders")
+	if err != nil {
+		t.Fatalf("failed to get keyspace: %v", err)
+	}
+	return dir, store, keyspace
+}
+
+func newPair(key string, total int) value.Pair {
+	return value.Pair{Name: key, Value: value.NewValue(map[string]interface{}{"total": total})}
+}
+
+func verifyFetch(t *testing.T, keyspace datastore.Keyspace, expected map[string]interface{}) {
+	keys := []string{"o1", "o2", "o3", "o4"}
+	fetched := make(map[string]value.AnnotatedValue, len(keys))
+	errs := keyspace.Fetch(keys, fetched, datastore.NULL_QUERY_CONTEXT, nil)
+	if len(errs) > 0 {
+		t.Fatalf("failed to fetch: %v", errs)
+	}
+
+	if len(fetched) != len(expected) {
+		t.Errorf("expected %v documents, got %v", len(expected), len(fetched))
+	}
+	for key, total := range expected {
+		doc, ok := fetched[key]
+		if !ok {
+			t.Errorf("expected document %v", key)
+			continue
+		}
+		if val, _ := doc.Field("total"); val.Actual() != total {
+			t.Errorf("expected document %v total %v, got %v", key, total, val.Actual())
+		}
+	}
+}
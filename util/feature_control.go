@@ -27,7 +27,8 @@ func GetMaxIndexAPI() int {
 const (
 	N1QL_GROUPAGG_PUSHDOWN uint64 = 1 << iota
 	N1QL_HASH_JOIN
-	N1QL_ALL_BITS // Add anything above this. This needs to be last one
+	N1QL_INDEX_COVERING // set for requests in a transaction, whose changes indexes do not see
+	N1QL_ALL_BITS       // Add anything above this. This needs to be last one
 )
 
 const DEF_N1QL_FEAT_CTRL = 0
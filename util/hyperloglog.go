@@ -0,0 +1,125 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// HyperLogLog estimates the number of distinct 64 bit hashes added to it,
+// in a fixed amount of memory, see Flajolet et al., "HyperLogLog: the
+// analysis of a near-optimal cardinality estimation algorithm"
+//
+// The first precision bits of a hash pick a register, which keeps the
+// longest run of leading zeros seen in the remaining bits. The standard
+// error of the estimate is 1.04 / sqrt(2^precision).
+
+const (
+	HLL_MIN_PRECISION     = 4
+	HLL_MAX_PRECISION     = 16
+	HLL_DEFAULT_PRECISION = 14 // 16KB of registers, 0.81% standard error
+)
+
+type HyperLogLog struct {
+	precision uint
+	registers []uint8
+}
+
+func NewHyperLogLog(precision uint) *HyperLogLog {
+	if precision < HLL_MIN_PRECISION {
+		precision = HLL_MIN_PRECISION
+	} else if precision > HLL_MAX_PRECISION {
+		precision = HLL_MAX_PRECISION
+	}
+
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+func (this *HyperLogLog) Precision() uint {
+	return this.precision
+}
+
+func (this *HyperLogLog) Add(hash uint64) {
+	idx := hash >> (64 - this.precision)
+	rank := uint8(bits.LeadingZeros64(hash<<this.precision|1<<(this.precision-1)) + 1)
+	if rank > this.registers[idx] {
+		this.registers[idx] = rank
+	}
+}
+
+// Merge another sketch of the same precision into this one
+func (this *HyperLogLog) Merge(other *HyperLogLog) error {
+	if this.precision != other.precision {
+		return fmt.Errorf("Cannot merge HyperLogLog of precision %d into precision %d",
+			other.precision, this.precision)
+	}
+
+	for i, r := range other.registers {
+		if r > this.registers[i] {
+			this.registers[i] = r
+		}
+	}
+	return nil
+}
+
+func (this *HyperLogLog) Estimate() uint64 {
+	m := float64(len(this.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range this.registers {
+		sum += 1.0 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch len(this.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1.0 + 1.079/m)
+	}
+
+	estimate := alpha * m * m / sum
+
+	// small range correction, using linear counting
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(estimate + 0.5)
+}
+
+// The registers, for spilling and transport
+func (this *HyperLogLog) Registers() []byte {
+	return this.registers
+}
+
+func NewHyperLogLogFromRegisters(registers []byte) (*HyperLogLog, error) {
+	precision := uint(bits.TrailingZeros(uint(len(registers))))
+	if len(registers) != 1<<precision || precision < HLL_MIN_PRECISION || precision > HLL_MAX_PRECISION {
+		return nil, fmt.Errorf("Invalid HyperLogLog of %d registers", len(registers))
+	}
+
+	return &HyperLogLog{
+		precision: precision,
+		registers: registers,
+	}, nil
+}
@@ -0,0 +1,63 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package util
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 1000, 100000} {
+		hll := NewHyperLogLog(HLL_DEFAULT_PRECISION)
+
+		// add every value twice
+		for i := 0; i < 2*n; i++ {
+			hll.Add(SeaHashSum64([]byte(strconv.Itoa(i % n))))
+		}
+
+		estimate := float64(hll.Estimate())
+		if math.Abs(estimate-float64(n)) > 0.03*float64(n) {
+			t.Errorf("Expected about %d distinct values, got %v", n, estimate)
+		}
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	hll1 := NewHyperLogLog(HLL_DEFAULT_PRECISION)
+	hll2 := NewHyperLogLog(HLL_DEFAULT_PRECISION)
+
+	// overlapping halves
+	for i := 0; i < 60000; i++ {
+		hll1.Add(SeaHashSum64([]byte(strconv.Itoa(i))))
+		hll2.Add(SeaHashSum64([]byte(strconv.Itoa(i + 40000))))
+	}
+
+	err := hll1.Merge(hll2)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	estimate := float64(hll1.Estimate())
+	if math.Abs(estimate-100000.0) > 3000.0 {
+		t.Errorf("Expected about 100000 distinct values, got %v", estimate)
+	}
+
+	copy, err := NewHyperLogLogFromRegisters(hll1.Registers())
+	if err != nil || copy.Estimate() != hll1.Estimate() {
+		t.Errorf("Expected the same estimate from registers, got %v, %v", copy, err)
+	}
+
+	err = hll1.Merge(NewHyperLogLog(HLL_MIN_PRECISION))
+	if err == nil {
+		t.Errorf("Expected error merging different precisions")
+	}
+}
@@ -0,0 +1,205 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package util
+
+import (
+	"math"
+	"sort"
+)
+
+// TDigest estimates quantiles of a stream of numbers in bounded memory,
+// using the merging t-digest of Dunning and Ertl, "Computing extremely
+// accurate quantiles using t-digests"
+//
+// Numbers are clustered into centroids, which are small near the tails
+// and larger in the middle, so that extreme quantiles stay accurate. The
+// number of centroids is bounded by about the compression.
+
+const TDIGEST_DEFAULT_COMPRESSION = 100
+
+type Centroid struct {
+	Mean  float64 `json:"mean"`
+	Count float64 `json:"count"`
+}
+
+type TDigest struct {
+	compression float64
+	centroids   []Centroid
+	buffer      []Centroid
+	count       float64
+	min         float64
+	max         float64
+}
+
+func NewTDigest(compression float64) *TDigest {
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+func (this *TDigest) Compression() float64 {
+	return this.compression
+}
+
+func (this *TDigest) Count() float64 {
+	return this.count + this.buffered()
+}
+
+func (this *TDigest) Add(x float64) {
+	this.addCentroid(Centroid{Mean: x, Count: 1.0})
+}
+
+// Merge another digest into this one
+func (this *TDigest) Merge(other *TDigest) {
+	for _, c := range other.centroids {
+		this.addCentroid(c)
+	}
+
+	for _, c := range other.buffer {
+		this.addCentroid(c)
+	}
+
+	this.min = math.Min(this.min, other.min)
+	this.max = math.Max(this.max, other.max)
+}
+
+func (this *TDigest) addCentroid(c Centroid) {
+	if math.IsNaN(c.Mean) || c.Count <= 0.0 {
+		return
+	}
+
+	this.min = math.Min(this.min, c.Mean)
+	this.max = math.Max(this.max, c.Mean)
+	this.buffer = append(this.buffer, c)
+	if len(this.buffer) >= this.bufferSize() {
+		this.compress()
+	}
+}
+
+func (this *TDigest) bufferSize() int {
+	return 5 * int(math.Ceil(this.compression))
+}
+
+func (this *TDigest) buffered() float64 {
+	count := 0.0
+	for _, c := range this.buffer {
+		count += c.Count
+	}
+	return count
+}
+
+// The scale function, which bounds the size of centroids by quantile
+func (this *TDigest) scale(q float64) float64 {
+	return this.compression / (2.0 * math.Pi) * math.Asin(2.0*q-1.0)
+}
+
+// Merge the buffered numbers into the centroids
+func (this *TDigest) compress() {
+	if len(this.buffer) == 0 {
+		return
+	}
+
+	all := append(this.centroids, this.buffer...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Mean < all[j].Mean })
+
+	total := 0.0
+	for _, c := range all {
+		total += c.Count
+	}
+
+	merged := make([]Centroid, 0, len(all))
+	cur := all[0]
+	sofar := 0.0
+	kLow := this.scale(0.0)
+	for _, c := range all[1:] {
+		q := (sofar + cur.Count + c.Count) / total
+		if this.scale(q)-kLow <= 1.0 {
+			cur.Mean += (c.Mean - cur.Mean) * c.Count / (cur.Count + c.Count)
+			cur.Count += c.Count
+			continue
+		}
+
+		sofar += cur.Count
+		kLow = this.scale(sofar / total)
+		merged = append(merged, cur)
+		cur = c
+	}
+
+	this.centroids = append(merged, cur)
+	this.buffer = this.buffer[:0]
+	this.count = total
+}
+
+// Estimate the q quantile, for q between 0 and 1. It returns NaN if no
+// numbers were added
+func (this *TDigest) Quantile(q float64) float64 {
+	this.compress()
+
+	n := len(this.centroids)
+	if n == 0 {
+		return math.NaN()
+	} else if q <= 0.0 {
+		return this.min
+	} else if q >= 1.0 {
+		return this.max
+	}
+
+	// each centroid is centered on the middle of the numbers it holds
+	target := q * this.count
+	first, last := this.centroids[0], this.centroids[n-1]
+	if target < first.Count/2.0 {
+		return this.min + (first.Mean-this.min)*target/(first.Count/2.0)
+	}
+
+	if target > this.count-last.Count/2.0 {
+		return last.Mean + (this.max-last.Mean)*(target-this.count+last.Count/2.0)/(last.Count/2.0)
+	}
+
+	sofar := first.Count / 2.0
+	for i := 1; i < n; i++ {
+		prev, c := this.centroids[i-1], this.centroids[i]
+		gap := (prev.Count + c.Count) / 2.0
+		if target <= sofar+gap {
+			return prev.Mean + (c.Mean-prev.Mean)*(target-sofar)/gap
+		}
+		sofar += gap
+	}
+
+	return last.Mean
+}
+
+func (this *TDigest) Min() float64 {
+	return this.min
+}
+
+func (this *TDigest) Max() float64 {
+	return this.max
+}
+
+// The centroids, for spilling and transport
+func (this *TDigest) Centroids() []Centroid {
+	this.compress()
+	return this.centroids
+}
+
+func NewTDigestFromCentroids(compression, min, max float64, centroids []Centroid) *TDigest {
+	rv := NewTDigest(compression)
+	for _, c := range centroids {
+		rv.addCentroid(c)
+	}
+	rv.compress()
+	if len(rv.centroids) > 0 {
+		rv.min = min
+		rv.max = max
+	}
+	return rv
+}
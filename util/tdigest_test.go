@@ -0,0 +1,74 @@
+//  Copyright (c) 2018 Couchbase, Inc.
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+//  except in compliance with the License. You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+//  Unless required by applicable law or agreed to in writing, software distributed under the
+//  License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+//  either express or implied. See the License for the specific language governing permissions
+//  and limitations under the License.
+
+package util
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTDigestSmall(t *testing.T) {
+	td := NewTDigest(TDIGEST_DEFAULT_COMPRESSION)
+	if !math.IsNaN(td.Quantile(0.5)) {
+		t.Errorf("Expected NaN for an empty digest")
+	}
+
+	// small inputs are exact
+	for _, x := range []float64{10, 1, 100, 8, 10} {
+		td.Add(x)
+	}
+
+	for q, expected := range map[float64]float64{0.0: 1, 0.3: 8, 0.5: 10, 1.0: 100} {
+		actual := td.Quantile(q)
+		if actual != expected {
+			t.Errorf("Expected quantile %v to be %v, got %v", q, expected, actual)
+		}
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	parts := make([]*TDigest, 4)
+	for i := range parts {
+		parts[i] = NewTDigest(TDIGEST_DEFAULT_COMPRESSION)
+	}
+
+	// a uniform distribution over [0, 1000), spread over the parts
+	n := 100000
+	for i := 0; i < n; i++ {
+		parts[i%len(parts)].Add(r.Float64() * 1000.0)
+	}
+
+	td := NewTDigest(TDIGEST_DEFAULT_COMPRESSION)
+	for _, part := range parts {
+		td.Merge(part)
+	}
+
+	if td.Count() != float64(n) {
+		t.Errorf("Expected count %v, got %v", n, td.Count())
+	}
+
+	if len(td.Centroids()) > 2*TDIGEST_DEFAULT_COMPRESSION {
+		t.Errorf("Expected at most %v centroids, got %v", 2*TDIGEST_DEFAULT_COMPRESSION, len(td.Centroids()))
+	}
+
+	for _, q := range []float64{0.01, 0.25, 0.5, 0.75, 0.99} {
+		actual := td.Quantile(q)
+		if math.Abs(actual-q*1000.0) > 10.0 {
+			t.Errorf("Expected quantile %v to be about %v, got %v", q, q*1000.0, actual)
+		}
+	}
+
+	copy := NewTDigestFromCentroids(td.Compression(), td.Min(), td.Max(), td.Centroids())
+	if copy.Quantile(0.5) != td.Quantile(0.5) || copy.Quantile(1.0) != td.Quantile(1.0) {
+		t.Errorf("Expected the same quantiles from centroids")
+	}
+}